@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/database"
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/worker_repo"
+	masterUsecase "github.com/pace-noge/distributed-load-tester/internal/master/usecase"
+)
+
+// adminDateLayout is the date format accepted by admin command flags.
+const adminDateLayout = "2006-01-02"
+
+// NewAdminCommand creates the maintenance CLI command
+func NewAdminCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "admin",
+		Usage: "Maintenance commands",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "reaggregate",
+				Usage: "Recompute aggregated results for tests created in a date range",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "database-url",
+						Usage:    "Database connection URL",
+						EnvVars:  []string{"DATABASE_URL"},
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "since",
+						Usage:    "Only tests created on or after this date (YYYY-MM-DD)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "until",
+						Usage: "Only tests created before this date (YYYY-MM-DD); defaults to now",
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Value: 4,
+						Usage: "Number of tests aggregated at the same time",
+					},
+					&cli.Float64Flag{
+						Name:  "rate",
+						Value: 10,
+						Usage: "Maximum tests started per second, to limit database load (0 = unlimited)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "List the affected tests without changing anything",
+					},
+				},
+				Action: runReaggregate,
+			},
+		},
+	}
+}
+
+// runReaggregate recomputes the aggregated results of finished tests.
+func runReaggregate(c *cli.Context) error {
+	since, err := time.ParseInLocation(adminDateLayout, c.String("since"), time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", c.String("since"))
+	}
+	until := time.Now()
+	if c.IsSet("until") {
+		until, err = time.ParseInLocation(adminDateLayout, c.String("until"), time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --until date %q: expected YYYY-MM-DD", c.String("until"))
+		}
+		until = until.Add(-time.Nanosecond) // Exclusive end date
+	}
+	if !until.After(since) {
+		return fmt.Errorf("--until must be after --since")
+	}
+	concurrency := c.Int("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if c.Float64("rate") < 0 {
+		return fmt.Errorf("--rate must not be negative")
+	}
+
+	db, err := database.Open(c.String("database-url"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := db.CheckSchema(ctx); err != nil {
+		return err
+	}
+
+	tests, err := db.GetTestsInRange(ctx, since, until)
+	if err != nil {
+		return err
+	}
+
+	// Tests still running are aggregated by the master as results arrive
+	var affected []*domain.TestRequest
+	for _, test := range tests {
+		if !masterUsecase.IsTestActive(test.Status) {
+			affected = append(affected, test)
+		}
+	}
+
+	if c.Bool("dry-run") {
+		fmt.Printf("%d tests would be reaggregated:\n", len(affected))
+		for _, test := range affected {
+			fmt.Printf("  %s  %s  %-22s %s\n", test.ID, test.CreatedAt.Format("2006-01-02 15:04"), test.Status, test.Name)
+		}
+		return nil
+	}
+	if len(affected) == 0 {
+		fmt.Println("No tests to reaggregate.")
+		return nil
+	}
+
+	masterUC := masterUsecase.NewMasterUsecase(worker_repo.NewInMemoryWorkerRepository(), db, db, db, db, db, db)
+
+	// Feed tests to the workers no faster than --rate
+	jobs := make(chan *domain.TestRequest)
+	go func() {
+		defer close(jobs)
+		var throttle <-chan time.Time
+		if rate := c.Float64("rate"); rate > 0 {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+			defer ticker.Stop()
+			throttle = ticker.C
+		}
+		for _, test := range affected {
+			if throttle != nil {
+				select {
+				case <-throttle:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- test:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	progress := newReaggregateProgress(os.Stdout, len(affected), term.IsTerminal(int(os.Stdout.Fd())))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for test := range jobs {
+				result, err := masterUC.RecomputeAggregatedResult(ctx, test.ID)
+				progress.record(test.ID, result == nil && err == nil, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return progress.finish(ctx.Err() != nil)
+}
+
+// reaggregateProgress reports reaggregation progress, redrawing a progress bar
+// on a terminal and printing one line per test otherwise.
+type reaggregateProgress struct {
+	mu          sync.Mutex
+	out         io.Writer
+	interactive bool
+	started     time.Time
+	total       int
+	done        int
+	skipped     int
+	failures    []string
+}
+
+func newReaggregateProgress(out io.Writer, total int, interactive bool) *reaggregateProgress {
+	return &reaggregateProgress{out: out, interactive: interactive, started: time.Now(), total: total}
+}
+
+// record counts one processed test. Skipped tests have no raw results.
+func (p *reaggregateProgress) record(testID string, skipped bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	outcome := "ok"
+	switch {
+	case err != nil:
+		p.failures = append(p.failures, fmt.Sprintf("%s: %v", testID, err))
+		outcome = fmt.Sprintf("failed: %v", err)
+	case skipped:
+		p.skipped++
+		outcome = "skipped (no raw results)"
+	}
+
+	if !p.interactive {
+		fmt.Fprintf(p.out, "[%d/%d] %s %s\n", p.done, p.total, testID, outcome)
+		return
+	}
+	fmt.Fprintf(p.out, "\r%s %d/%d tests, %d skipped, %d failed, ETA %s\033[K",
+		progressBar(int64(p.done), int64(p.total), 30), p.done, p.total, p.skipped, len(p.failures), p.eta())
+}
+
+// eta estimates the time left from the average time per test so far.
+func (p *reaggregateProgress) eta() string {
+	remaining := p.total - p.done
+	perTest := time.Since(p.started) / time.Duration(p.done)
+	return (perTest * time.Duration(remaining)).Truncate(time.Second).String()
+}
+
+// finish prints the summary and returns an error if any test failed.
+func (p *reaggregateProgress) finish(interrupted bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.interactive {
+		fmt.Fprintln(p.out)
+	}
+	for _, failure := range p.failures {
+		fmt.Fprintf(p.out, "  failed %s\n", failure)
+	}
+	fmt.Fprintf(p.out, "Reaggregated %d of %d tests in %s (%d skipped, %d failed)\n",
+		p.done-p.skipped-len(p.failures), p.total, time.Since(p.started).Truncate(time.Millisecond), p.skipped, len(p.failures))
+
+	if interrupted {
+		return fmt.Errorf("interrupted after %d of %d tests", p.done, p.total)
+	}
+	if len(p.failures) > 0 {
+		return fmt.Errorf("%d tests failed to reaggregate", len(p.failures))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/sandbox"
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/vegeta"
+)
+
+// NewAttackExecCommand creates the hidden command used by workers to run a
+// single attack in an isolated subprocess. It reads a test assignment as JSON
+// on stdin and writes the result envelope to stdout; see sandbox.Serve.
+func NewAttackExecCommand() *cli.Command {
+	return &cli.Command{
+		Name:   sandbox.ExecCommand,
+		Usage:  "Runs one test assignment in an isolated subprocess (internal)",
+		Hidden: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "mode",
+				Value: sandbox.ModeVegeta,
+				Usage: "Executor to use: vegeta, scenario or script",
+			},
+			&cli.Uint64Flag{
+				Name:  "memory-limit",
+				Usage: "Address space limit in bytes (0 = unlimited)",
+			},
+			&cli.Uint64Flag{
+				Name:  "max-open-files",
+				Usage: "Open file descriptor limit (0 = unlimited)",
+			},
+		},
+		Action: runAttackExec,
+	}
+}
+
+func runAttackExec(c *cli.Context) error {
+	return sandbox.Serve(os.Stdin, os.Stdout, func(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+		if err := sandbox.ApplyLimits(sandbox.Limits{
+			MemoryBytes:  c.Uint64("memory-limit"),
+			MaxOpenFiles: c.Uint64("max-open-files"),
+		}); err != nil {
+			return nil, err
+		}
+
+		switch c.String("mode") {
+		case sandbox.ModeScenario:
+			return vegeta.NewScenarioAdapter().Run(ctx, assignment)
+		case sandbox.ModeVegeta:
+			return vegeta.NewVegetaAdapter().Attack(ctx, assignment)
+		case sandbox.ModeScript:
+			return vegeta.NewScriptAdapter().Execute(ctx, assignment)
+		default:
+			return nil, fmt.Errorf("unknown attack mode %q", c.String("mode"))
+		}
+	})
+}
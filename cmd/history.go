@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// NewHistoryCommand creates the history command, which lists, shows and
+// compares tests from a local cache of what was fetched from the master.
+func NewHistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "Lists, shows and compares tests, keeping a local copy for offline use",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List your most recent tests",
+				Flags: append(historyFlags(),
+					&cli.StringFlag{
+						Name:  "status",
+						Usage: "Only tests with this status (e.g., COMPLETED)",
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Value: 20,
+						Usage: "Number of tests listed",
+					},
+				),
+				Action: runHistoryList,
+			},
+			{
+				Name:      "show",
+				Usage:     "Show a test's aggregated result",
+				ArgsUsage: "<testID>",
+				Flags:     historyFlags(),
+				Action:    runHistoryShow,
+			},
+			{
+				Name:      "diff",
+				Usage:     "Compare a test's aggregated result against a baseline test",
+				ArgsUsage: "<testID> <baselineTestID>",
+				Flags:     historyFlags(),
+				Action:    runHistoryDiff,
+			},
+		},
+	}
+}
+
+// historyFlags returns the flags shared by the history subcommands.
+func historyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "master-address",
+			Aliases: []string{"ma"},
+			Value:   "localhost:50051",
+			Usage:   "Master service gRPC address (host:port)",
+			EnvVars: []string{"MASTER_ADDRESS"},
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Login token or API key to authenticate with; without one only the local history is used",
+			EnvVars: []string{"LOADTESTER_TOKEN"},
+		},
+		&cli.StringFlag{
+			Name:    "history-file",
+			Value:   defaultHistoryPath(),
+			Usage:   "SQLite file keeping the fetched tests and results",
+			EnvVars: []string{"LOADTESTER_HISTORY"},
+		},
+		&cli.BoolFlag{
+			Name:  "offline",
+			Usage: "Only use the local history, without contacting the master",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Value: 5 * time.Second,
+			Usage: "How long to wait for the master before falling back to the local history",
+		},
+	}
+}
+
+// historySession is the local history and, unless offline, a connection to the master.
+type historySession struct {
+	cache   *historyCache
+	conn    *grpc.ClientConn
+	client  pb.MasterServiceClient // Nil when offline
+	token   string
+	timeout time.Duration
+}
+
+func openHistorySession(c *cli.Context) (*historySession, error) {
+	cache, err := openHistoryCache(c.String("history-file"))
+	if err != nil {
+		return nil, err
+	}
+	s := &historySession{cache: cache, token: c.String("token"), timeout: c.Duration("timeout")}
+	if c.Bool("offline") || s.token == "" {
+		return s, nil
+	}
+
+	s.conn, err = grpc.Dial(c.String("master-address"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		cache.Close()
+		return nil, fmt.Errorf("failed to connect to master gRPC server %s: %w", c.String("master-address"), err)
+	}
+	s.client = pb.NewMasterServiceClient(s.conn)
+	return s, nil
+}
+
+func (s *historySession) Close() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.cache.Close()
+}
+
+// call returns a context for one call to the master.
+func (s *historySession) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.token)
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+// unreachable reports whether err means the master could not be reached, in
+// which case the local history is used instead.
+func unreachable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// result returns a test's aggregated result from the master, keeping a copy,
+// or from the local history when offline or the master is unreachable.
+func (s *historySession) result(ctx context.Context, testID string) (*pb.AggregatedResult, error) {
+	if s.client != nil {
+		callCtx, cancel := s.call(ctx)
+		result, err := s.client.GetAggregatedResult(callCtx, &pb.AggregatedResultRequest{TestId: testID})
+		cancel()
+		if err == nil {
+			if err := s.cache.saveResult(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			return result, nil
+		}
+		if !unreachable(err) {
+			return nil, fmt.Errorf("failed to get result of test %s: %w", testID, err)
+		}
+		fmt.Fprintf(os.Stderr, "Master unreachable (%v); using the local history\n", status.Convert(err).Message())
+	}
+
+	result, fetchedAt, err := s.cache.result(testID)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Result of test %s as fetched %s\n", testID, fetchedAt.Format(time.DateTime))
+	return result, nil
+}
+
+func runHistoryList(c *cli.Context) error {
+	s, err := openHistorySession(c)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	var tests []*pb.TestSummary
+	fetched := false
+	if s.client != nil {
+		ctx, cancel := s.call(c.Context)
+		resp, err := s.client.ListTests(ctx, &pb.ListTestsRequest{Status: c.String("status"), Limit: int32(c.Int("limit"))})
+		cancel()
+		switch {
+		case err == nil:
+			tests, fetched = resp.Tests, true
+			if err := s.cache.saveTests(tests); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		case unreachable(err):
+			fmt.Fprintf(os.Stderr, "Master unreachable (%v); listing the local history\n", status.Convert(err).Message())
+		default:
+			return fmt.Errorf("failed to list tests: %w", err)
+		}
+	}
+	if !fetched {
+		if tests, err = s.cache.listTests(c.String("status"), c.Int("limit")); err != nil {
+			return fmt.Errorf("failed to read the local history: %w", err)
+		}
+	}
+
+	fmt.Printf("%-36s  %-24s  %-10s  %-16s  %8s  %7s\n", "ID", "NAME", "STATUS", "CREATED", "RATE", "WORKERS")
+	for _, t := range tests {
+		fmt.Printf("%-36s  %-24s  %-10s  %-16s  %8d  %7d\n", t.Id, truncate(t.Name, 24), t.Status,
+			time.Unix(t.CreatedAt, 0).Format("2006-01-02 15:04"), t.RatePerSecond, t.WorkerCount)
+	}
+	if len(tests) == 0 {
+		fmt.Println("No tests")
+	}
+	return nil
+}
+
+func runHistoryShow(c *cli.Context) error {
+	testID := c.Args().First()
+	if testID == "" {
+		return fmt.Errorf("usage: %s history show <testID>", c.App.Name)
+	}
+	s, err := openHistorySession(c)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	r, err := s.result(c.Context, testID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Test %s  [%s]\n\n", r.TestId, r.OverallStatus)
+	fmt.Printf("  Requests    %d (%d successful, %d failed)\n", r.TotalRequests, r.SuccessfulRequests, r.FailedRequests)
+	fmt.Printf("  Throughput  %.1f req/s over %s\n", r.ThroughputRps, formatElapsed(r.DurationMs))
+	fmt.Printf("  Latency     %.1fms mean, %.1fms p95\n", r.AvgLatencyMs, r.P95LatencyMs)
+	if r.ApdexScore > 0 {
+		fmt.Printf("  Apdex       %.2f\n", r.ApdexScore)
+	}
+	if len(r.ErrorRates) > 0 {
+		fmt.Println("\n  Errors")
+		for _, code := range slices.Sorted(maps.Keys(r.ErrorRates)) {
+			fmt.Printf("    %-12s %d\n", code, r.ErrorRates[code])
+		}
+	}
+	return nil
+}
+
+func runHistoryDiff(c *cli.Context) error {
+	testID, baselineID := c.Args().Get(0), c.Args().Get(1)
+	if testID == "" || baselineID == "" {
+		return fmt.Errorf("usage: %s history diff <testID> <baselineTestID>", c.App.Name)
+	}
+	s, err := openHistorySession(c)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	result, err := s.result(c.Context, testID)
+	if err != nil {
+		return err
+	}
+	baseline, err := s.result(c.Context, baselineID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s  %14s  %14s  %9s\n", "METRIC", "BASELINE", "TEST", "CHANGE")
+	for _, row := range []struct {
+		name           string
+		baseline, test float64
+		format         string
+	}{
+		{"Requests", float64(baseline.TotalRequests), float64(result.TotalRequests), "%.0f"},
+		{"Error rate %", errorRatePercent(baseline), errorRatePercent(result), "%.2f"},
+		{"Throughput rps", baseline.ThroughputRps, result.ThroughputRps, "%.1f"},
+		{"Mean latency ms", baseline.AvgLatencyMs, result.AvgLatencyMs, "%.1f"},
+		{"P95 latency ms", baseline.P95LatencyMs, result.P95LatencyMs, "%.1f"},
+		{"Apdex", baseline.ApdexScore, result.ApdexScore, "%.2f"},
+	} {
+		fmt.Printf("%-16s  %14s  %14s  %9s\n", row.name,
+			fmt.Sprintf(row.format, row.baseline), fmt.Sprintf(row.format, row.test), percentChange(row.baseline, row.test))
+	}
+	return nil
+}
+
+func errorRatePercent(r *pb.AggregatedResult) float64 {
+	if r.TotalRequests == 0 {
+		return 0
+	}
+	return float64(r.FailedRequests) / float64(r.TotalRequests) * 100
+}
+
+// percentChange formats the relative change from baseline to value.
+func percentChange(baseline, value float64) string {
+	if baseline == 0 {
+		if value == 0 {
+			return "0%"
+		}
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (value-baseline)/baseline*100)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	_ "modernc.org/sqlite" // Registers the "sqlite" driver
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// historySchema creates the tables of the CLI's local history.
+const historySchema = `
+CREATE TABLE IF NOT EXISTS tests (
+	id           TEXT PRIMARY KEY,
+	created_at   INTEGER NOT NULL,
+	summary_json TEXT NOT NULL,
+	fetched_at   INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS results (
+	test_id     TEXT PRIMARY KEY,
+	result_json TEXT NOT NULL,
+	fetched_at  INTEGER NOT NULL
+);`
+
+// historyCache is the CLI's local SQLite copy of the tests and results it
+// fetched from the master, so they can be listed, shown and compared offline.
+type historyCache struct {
+	db *sql.DB
+}
+
+// defaultHistoryPath returns where the history is kept: in the user's home directory.
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "load-tester-history.db"
+	}
+	return filepath.Join(home, ".load-tester", "history.db")
+}
+
+// openHistoryCache opens the history at path, creating it if needed.
+func openHistoryCache(path string) (*historyCache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	if _, err := db.Exec(historySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize history %s: %w", path, err)
+	}
+	return &historyCache{db: db}, nil
+}
+
+func (c *historyCache) Close() error {
+	return c.db.Close()
+}
+
+// saveTests stores test summaries, replacing older copies of the same tests.
+func (c *historyCache) saveTests(tests []*pb.TestSummary) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+	for _, test := range tests {
+		data, err := protojson.Marshal(test)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO tests (id, created_at, summary_json, fetched_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET created_at = excluded.created_at, summary_json = excluded.summary_json, fetched_at = excluded.fetched_at`,
+			test.Id, test.CreatedAt, string(data), now); err != nil {
+			return fmt.Errorf("failed to save test %s: %w", test.Id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// listTests returns the newest stored tests, only those with status if set.
+func (c *historyCache) listTests(status string, limit int) ([]*pb.TestSummary, error) {
+	rows, err := c.db.Query(`SELECT summary_json FROM tests ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tests []*pb.TestSummary
+	for rows.Next() && (limit <= 0 || len(tests) < limit) {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		test := &pb.TestSummary{}
+		if err := protojson.Unmarshal([]byte(data), test); err != nil {
+			return nil, err
+		}
+		if status == "" || test.Status == status {
+			tests = append(tests, test)
+		}
+	}
+	return tests, rows.Err()
+}
+
+// saveResult stores a test's aggregated result, replacing an older copy.
+func (c *historyCache) saveResult(result *pb.AggregatedResult) error {
+	data, err := protojson.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.Exec(`INSERT INTO results (test_id, result_json, fetched_at) VALUES (?, ?, ?)
+		ON CONFLICT(test_id) DO UPDATE SET result_json = excluded.result_json, fetched_at = excluded.fetched_at`,
+		result.TestId, string(data), time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to save result of test %s: %w", result.TestId, err)
+	}
+	return nil
+}
+
+// result returns a stored aggregated result and when it was fetched.
+func (c *historyCache) result(testID string) (*pb.AggregatedResult, time.Time, error) {
+	var data string
+	var fetchedAt int64
+	err := c.db.QueryRow(`SELECT result_json, fetched_at FROM results WHERE test_id = ?`, testID).Scan(&data, &fetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, time.Time{}, fmt.Errorf("no result of test %s in the local history", testID)
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	result := &pb.AggregatedResult{}
+	if err := protojson.Unmarshal([]byte(data), result); err != nil {
+		return nil, time.Time{}, err
+	}
+	return result, time.Unix(fetchedAt, 0), nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/database"
+)
+
+// NewMigrateCommand creates the schema migration CLI command
+func NewMigrateCommand() *cli.Command {
+	databaseURLFlag := &cli.StringFlag{
+		Name:     "database-url",
+		Usage:    "Database connection URL",
+		EnvVars:  []string{"DATABASE_URL"},
+		Required: true,
+	}
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Database schema migrations",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "up",
+				Usage:  "Apply all pending migrations",
+				Flags:  []cli.Flag{databaseURLFlag},
+				Action: withStore(func(ctx context.Context, db database.Store) error { return db.Migrate(ctx) }),
+			},
+			{
+				Name:   "down",
+				Usage:  "Roll back the most recently applied migration",
+				Flags:  []cli.Flag{databaseURLFlag},
+				Action: withStore(func(ctx context.Context, db database.Store) error { return db.RollbackMigration(ctx) }),
+			},
+			{
+				Name:   "status",
+				Usage:  "List migrations and whether each has been applied",
+				Flags:  []cli.Flag{databaseURLFlag},
+				Action: withStore(printMigrationStatus),
+			},
+		},
+	}
+}
+
+// withStore wraps a migrate action with opening and closing the database.
+func withStore(action func(ctx context.Context, db database.Store) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		db, err := database.Open(c.String("database-url"))
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer db.Close()
+		return action(c.Context, db)
+	}
+}
+
+// printMigrationStatus prints each migration with when it was applied.
+func printMigrationStatus(ctx context.Context, db database.Store) error {
+	statuses, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		applied := "pending"
+		if s.Applied {
+			applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%5d  %-40s %s\n", s.Version, s.Name, applied)
+	}
+	return nil
+}
@@ -8,15 +8,25 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// Version is the build version, set with
+// -ldflags "-X github.com/pace-noge/distributed-load-tester/cmd.Version=v1.2.3".
+var Version = "dev"
+
 // NewRootApp creates the root CLI application
 func NewRootApp() *cli.App {
 	return &cli.App{
-		Name:  "load-tester-app",
-		Usage: "A distributed load testing application (master or worker).",
+		Name:    "load-tester-app",
+		Usage:   "A distributed load testing application (master or worker).",
+		Version: Version,
 		Commands: []*cli.Command{
 			NewMasterCommand(),
 			NewWorkerCommand(),
 			NewUserCommand(),
+			NewAdminCommand(),
+			NewMigrateCommand(),
+			NewWatchCommand(),
+			NewHistoryCommand(),
+			NewAttackExecCommand(),
 		},
 	}
 }
@@ -75,18 +75,19 @@ func resetDefaultPassword(c *cli.Context) error {
 	}
 
 	// Initialize database connection
-	db, err := database.NewPostgresDB(databaseURL)
+	db, err := database.Open(databaseURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer db.Close()
 
 	// Initialize repository and usecase
-	userRepo := database.NewUserRepository(db.GetDB())
+	userRepo := db.UserRepository()
 	userUsecase := usecase.NewUserUsecase(userRepo, "your-jwt-secret-key") // In production, use proper secret
+	userUsecase.SetAuditRepository(db)
 
-	// Reset password
-	ctx := context.Background()
+	// Reset password, recorded in the audit log as done from the CLI
+	ctx := domain.WithAuditActor(context.Background(), "", "cli")
 
 	// First ensure default user exists
 	err = userUsecase.EnsureDefaultUser(ctx)
@@ -133,14 +134,14 @@ func createDefaultAdmin(c *cli.Context) error {
 	databaseURL := c.String("database-url")
 
 	// Initialize database connection
-	db, err := database.NewPostgresDB(databaseURL)
+	db, err := database.Open(databaseURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer db.Close()
 
 	// Initialize repository and usecase
-	userRepo := database.NewUserRepository(db.GetDB())
+	userRepo := db.UserRepository()
 	userUsecase := usecase.NewUserUsecase(userRepo, "your-jwt-secret-key") // In production, use proper secret
 
 	// Ensure default user exists
@@ -152,6 +153,6 @@ func createDefaultAdmin(c *cli.Context) error {
 
 	log.Println("Default admin user ensured successfully")
 	log.Println("Default credentials: username=admin, password=admin123")
-	log.Println("Please change the default password using the 'user reset-password' command")
+	log.Println("The password must be changed at the first login, or beforehand using the 'user reset-password' command")
 	return nil
 }
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// sparklineWidth is how many samples the latency sparklines show.
+const sparklineWidth = 40
+
+// NewWatchCommand creates the watch command
+func NewWatchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "watch",
+		Usage:     "Watches a running test's live progress in an interactive terminal view",
+		ArgsUsage: "<testID>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "master-address",
+				Aliases: []string{"ma"},
+				Value:   "localhost:50051",
+				Usage:   "Master service gRPC address (host:port)",
+				EnvVars: []string{"MASTER_ADDRESS"},
+			},
+			&cli.StringFlag{
+				Name:     "token",
+				Usage:    "Login token or API key to authenticate with",
+				EnvVars:  []string{"LOADTESTER_TOKEN"},
+				Required: true,
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Value: time.Second,
+				Usage: "Refresh interval",
+			},
+		},
+		Action: runWatch,
+	}
+}
+
+func runWatch(c *cli.Context) error {
+	testID := c.Args().First()
+	if testID == "" {
+		return fmt.Errorf("usage: %s watch <testID>", c.App.Name)
+	}
+
+	conn, err := grpc.Dial(c.String("master-address"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to master gRPC server %s: %w", c.String("master-address"), err)
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.String("token"))
+
+	stream, err := pb.NewMasterServiceClient(conn).WatchTest(ctx, &pb.WatchTestRequest{
+		TestId:     testID,
+		IntervalMs: c.Duration("interval").Milliseconds(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch test %s: %w", testID, err)
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return printProgress(ctx, stream, os.Stdout)
+	}
+	model, err := tea.NewProgram(newWatchModel(stream), tea.WithContext(ctx), tea.WithAltScreen()).Run()
+	if errors.Is(err, tea.ErrProgramKilled) || errors.Is(err, tea.ErrInterrupted) {
+		return nil // Interrupted by a signal
+	}
+	if err != nil {
+		return err
+	}
+	return model.(*watchModel).err
+}
+
+// printProgress prints one summary line per update, for output that is not a terminal.
+func printProgress(ctx context.Context, stream pb.MasterService_WatchTestClient, out io.Writer) error {
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // Interrupted by the user
+			}
+			return fmt.Errorf("watch stream failed: %w", err)
+		}
+		fmt.Fprintf(out, "%s %s elapsed=%s requests=%d failures=%d rps=%.1f errors=%.1f%% latency=%.1fms\n",
+			time.Now().Format(time.TimeOnly), p.Status, formatElapsed(p.ElapsedMs), p.Requests, p.Failures,
+			p.Rps, p.ErrorRate*100, p.MeanLatencyMs)
+	}
+}
+
+// progressMsg carries a progress update from the watch stream.
+type progressMsg struct{ progress *pb.TestProgress }
+
+// streamEndMsg reports that the watch stream ended, with err nil once the test finished.
+type streamEndMsg struct{ err error }
+
+// recvProgress waits for the next progress update on the stream.
+func recvProgress(stream pb.MasterService_WatchTestClient) tea.Cmd {
+	return func() tea.Msg {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			return streamEndMsg{}
+		}
+		if err != nil {
+			return streamEndMsg{err: fmt.Errorf("watch stream failed: %w", err)}
+		}
+		return progressMsg{progress: p}
+	}
+}
+
+// watchHeaderLines and watchFooterLines are the lines drawn around the worker table.
+const (
+	watchHeaderLines = 6
+	watchFooterLines = 3
+)
+
+// watchModel is the bubbletea model of the watch command: the test's totals
+// and a scrollable table of its workers, one of which is selected and shown
+// in detail.
+type watchModel struct {
+	stream   pb.MasterService_WatchTestClient
+	progress *pb.TestProgress
+	latency  []float64            // Test-wide mean latency per update
+	workers  map[string][]float64 // Per-worker mean latency per update
+	selected int                  // Index of the selected worker
+	offset   int                  // Index of the first worker shown
+	height   int                  // Terminal height
+	finished bool                 // The stream ended; the last update stays on screen
+	err      error
+}
+
+func newWatchModel(stream pb.MasterService_WatchTestClient) *watchModel {
+	return &watchModel{stream: stream, workers: make(map[string][]float64), height: 24}
+}
+
+func (m *watchModel) Init() tea.Cmd {
+	return recvProgress(m.stream)
+}
+
+func (m *watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			m.selected--
+		case "down", "j":
+			m.selected++
+		case "pgup":
+			m.selected -= m.rows()
+		case "pgdown", " ":
+			m.selected += m.rows()
+		case "home", "g":
+			m.selected = 0
+		case "end", "G":
+			m.selected = len(m.progressWorkers()) - 1
+		}
+	case progressMsg:
+		m.progress = msg.progress
+		m.latency = appendSample(m.latency, msg.progress.MeanLatencyMs)
+		for _, w := range msg.progress.Workers {
+			m.workers[w.WorkerId] = appendSample(m.workers[w.WorkerId], w.MeanLatencyMs)
+		}
+		m.clampSelection()
+		return m, recvProgress(m.stream)
+	case streamEndMsg:
+		m.finished, m.err = true, msg.err
+		return m, nil
+	}
+	m.clampSelection()
+	return m, nil
+}
+
+// rows returns how many workers fit on screen.
+func (m *watchModel) rows() int {
+	return max(m.height-watchHeaderLines-watchFooterLines, 1)
+}
+
+func (m *watchModel) progressWorkers() []*pb.WorkerProgress {
+	if m.progress == nil {
+		return nil
+	}
+	return m.progress.Workers
+}
+
+// clampSelection keeps the selected worker valid and scrolls it into view.
+func (m *watchModel) clampSelection() {
+	n := len(m.progressWorkers())
+	m.selected = max(min(m.selected, n-1), 0)
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+m.rows() {
+		m.offset = m.selected - m.rows() + 1
+	}
+	m.offset = max(min(m.offset, n-m.rows()), 0)
+}
+
+func (m *watchModel) View() string {
+	p := m.progress
+	if p == nil {
+		if m.err != nil {
+			return fmt.Sprintf("\n  %v\n\n  q to quit\n", m.err)
+		}
+		return "\n  Waiting for the first update...  (q to quit)\n"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Test %s  [%s]  elapsed %s\n\n", p.TestId, p.Status, formatElapsed(p.ElapsedMs))
+	fmt.Fprintf(&b, "  Requests %-10d Failures %-8d RPS %-9.1f Errors %5.1f%%  Latency %.1fms\n",
+		p.Requests, p.Failures, p.Rps, p.ErrorRate*100, p.MeanLatencyMs)
+	fmt.Fprintf(&b, "  Latency  %s\n\n", sparkline(m.latency))
+
+	fmt.Fprintf(&b, "  %-28s %-10s %-24s %9s %8s %9s  %s\n", "WORKER", "STATUS", "PROGRESS", "RPS", "ERRORS", "LATENCY", "TREND")
+	workers := p.Workers
+	end := min(m.offset+m.rows(), len(workers))
+	for i := m.offset; i < end; i++ {
+		w := workers[i]
+		cursor := " "
+		if i == m.selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(&b, "%s %-28s %-10s %-24s %9.1f %8d %7.1fms  %s\n",
+			cursor, truncate(w.WorkerId, 28), w.Status, progressBar(w.Requests, w.Expected, 14), w.Rps, w.Failures,
+			w.MeanLatencyMs, sparkline(m.workers[w.WorkerId]))
+	}
+	if len(workers) == 0 {
+		b.WriteString("  Waiting for workers to report...\n")
+	}
+
+	b.WriteString("\n")
+	if len(workers) > 0 {
+		w := workers[m.selected]
+		fmt.Fprintf(&b, "  %s: %s, %d of %d requests, %d failures  (workers %d-%d of %d)\n",
+			w.WorkerId, w.Status, w.Requests, w.Expected, w.Failures, m.offset+1, end, len(workers))
+	} else {
+		b.WriteString("\n")
+	}
+	switch {
+	case m.err != nil:
+		fmt.Fprintf(&b, "  %v  (q to quit)\n", m.err)
+	case m.finished:
+		b.WriteString("  Test finished  (q to quit)\n")
+	default:
+		b.WriteString("  ↑/↓ select worker  PgUp/PgDn scroll  q quit (the test keeps running)\n")
+	}
+	return b.String()
+}
+
+func appendSample(samples []float64, v float64) []float64 {
+	samples = append(samples, v)
+	if len(samples) > sparklineWidth {
+		samples = samples[len(samples)-sparklineWidth:]
+	}
+	return samples
+}
+
+// sparkline draws samples as block characters scaled to their maximum.
+func sparkline(samples []float64) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	var maxVal float64
+	for _, s := range samples {
+		maxVal = max(maxVal, s)
+	}
+	var b strings.Builder
+	for _, s := range samples {
+		i := 0
+		if maxVal > 0 {
+			i = int(s / maxVal * float64(len(blocks)-1))
+		}
+		b.WriteRune(blocks[i])
+	}
+	return b.String()
+}
+
+func progressBar(done, total int64, width int) string {
+	if total <= 0 {
+		return fmt.Sprintf("%-*s", width+7, fmt.Sprintf("%d reqs", done))
+	}
+	ratio := min(float64(done)/float64(total), 1)
+	filled := int(ratio * float64(width))
+	return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("#", filled), strings.Repeat(".", width-filled), ratio*100)
+}
+
+func formatElapsed(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Truncate(time.Second).String()
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
@@ -8,6 +8,8 @@ import { AuthProvider, useAuth } from './src/contexts/AuthContext.jsx';
 
 // Pages
 import { LoginPage } from './src/pages/LoginPage.jsx';
+import { SignupPage } from './src/pages/SignupPage.jsx';
+import { PublicSharedTestPage } from './src/pages/PublicSharedTestPage.jsx';
 import { TestHistoryPage } from './src/pages/TestHistoryPage.jsx';
 import { DashboardPage } from './src/pages/DashboardPage.jsx';
 import NewTestPage from './src/pages/NewTestPage.jsx';
@@ -22,7 +24,7 @@ import { SharedTestPage } from './src/pages/SharedTestPage.jsx';
 import { MainLayout } from './src/components/layout/MainLayout.jsx';
 
 const AppContent = () => {
-    const { isLoggedIn } = useAuth();
+    const { isLoggedIn, user } = useAuth();
 
     // Handle auth errors by listening to custom events
     useEffect(() => {
@@ -35,10 +37,25 @@ const AppContent = () => {
         return () => window.removeEventListener('auth-error', handleAuthError);
     }, []);
 
+    // Public shared links are for people without an account, logged in or not
+    if (window.location.pathname.startsWith('/public/')) {
+        return <PublicSharedTestPage token={window.location.pathname.slice('/public/'.length)} />;
+    }
+
+    // Invited users sign up before they have an account to log in with
+    if (!isLoggedIn && window.location.pathname === '/signup') {
+        return <SignupPage />;
+    }
+
     if (!isLoggedIn) {
         return <LoginPage />;
     }
 
+    // The API refuses everything else until a required password change is done
+    if (user?.mustChangePassword) {
+        return <ProfilePage />;
+    }
+
     return (
         <MainLayout>
             <Routes>
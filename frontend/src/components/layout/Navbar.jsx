@@ -1,7 +1,7 @@
 import { Link, useLocation } from 'react-router-dom';
 import { Inbox, Zap } from 'lucide-react';
 import { useEffect, useState } from 'react';
-import { fetchInbox } from '../../utils/api';
+import { fetchInboxSummary } from '../../utils/api';
 
 const NAV_ITEMS = [
     { id: 'inbox', label: 'Inbox', icon: Inbox, path: '/inbox' },
@@ -9,32 +9,30 @@ const NAV_ITEMS = [
 
 export const Navbar = ({ inboxRight }) => {
     const location = useLocation();
-    const [hasUnread, setHasUnread] = useState(false);
+    const [unread, setUnread] = useState(0);
 
-    // Poll inbox for unread status
+    // Load the unread count, then follow inbox_update messages
     useEffect(() => {
         let ws;
         let pollInterval;
         const checkInbox = async () => {
             try {
-                const data = await fetchInbox();
-                const userId = localStorage.getItem('user_id');
-                setHasUnread(
-                    (data.inbox || []).some(item => !(item.readBy || []).includes(userId) && !item.isExpired)
-                );
+                const summary = await fetchInboxSummary();
+                setUnread(summary.unread);
             } catch {
-                setHasUnread(false);
+                setUnread(0);
             }
         };
         checkInbox();
-        if (window.WebSocket) {
+        const token = localStorage.getItem('jwt_token');
+        if (window.WebSocket && token) {
             const wsProtocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-            ws = new window.WebSocket(wsProtocol + '//' + window.location.host + '/ws');
+            ws = new window.WebSocket(`${wsProtocol}//${window.location.host}/ws?token=${encodeURIComponent(token)}`);
             ws.onmessage = (event) => {
                 try {
                     const msg = JSON.parse(event.data);
-                    if (msg.type === 'inbox') {
-                        checkInbox();
+                    if (msg.type === 'inbox_update') {
+                        setUnread(msg.data.unread);
                     }
                 } catch {
                     checkInbox();
@@ -76,7 +74,11 @@ export const Navbar = ({ inboxRight }) => {
                             >
                                 <Icon className="w-4 h-4" />
                                 <span>{item.label}</span>
-                                {item.id === 'inbox' && hasUnread && <span className="ml-2 inline-block w-2 h-2 bg-red-500 rounded-full" title="New" />}
+                                {item.id === 'inbox' && unread > 0 && (
+                                    <span className="ml-2 px-1.5 text-xs font-semibold bg-red-500 text-white rounded-full" title={`${unread} unread`}>
+                                        {unread}
+                                    </span>
+                                )}
                             </Link>
                         );
                     })}
@@ -101,12 +101,13 @@ export const TestDetailModal = ({ testId, isOpen, onClose }) => {
         }
     };
 
-    const handleCopyLink = async () => {
+    const handleCopyLink = async (isPublic = false) => {
         setShareLoading(true);
         setShareError('');
         try {
-            const res = await copyShareLink(testId);
-            const fullLink = window.location.origin + res.link;
+            const res = await copyShareLink(testId, isPublic);
+            // Public links open a read-only page that needs no account
+            const fullLink = window.location.origin + (isPublic ? res.publicUrl : res.link);
             setShareLink(fullLink);
             await navigator.clipboard.writeText(fullLink);
         } catch (err) {
@@ -169,11 +170,18 @@ export const TestDetailModal = ({ testId, isOpen, onClose }) => {
                                     </button>
                                     <button
                                         className="w-full bg-blue-600 text-white py-2 rounded"
-                                        onClick={handleCopyLink}
+                                        onClick={() => handleCopyLink()}
                                         disabled={shareLoading}
                                     >
                                         {shareLoading ? 'Copying...' : 'Copy Shareable Link'}
                                     </button>
+                                    <button
+                                        className="w-full bg-gray-700 text-white py-2 rounded"
+                                        onClick={() => handleCopyLink(true)}
+                                        disabled={shareLoading}
+                                    >
+                                        {shareLoading ? 'Copying...' : 'Copy Public Link (no login needed)'}
+                                    </button>
                                 </div>
                             )}
                             {shareMode === 'inbox' && (
@@ -364,6 +372,13 @@ const QuickResultsSummary = ({ aggregatedResult }) => (
             </div>
         </div>
 
+        {aggregatedResult.apdex && (
+            <div className="mt-3 text-center text-sm text-gray-700">
+                Apdex <span className="font-semibold">{aggregatedResult.apdex.score.toFixed(2)}</span>
+                {' '}({aggregatedResult.apdex.rating}, T = {aggregatedResult.apdex.thresholdMs}ms)
+            </div>
+        )}
+
         <div className="mt-3 text-center">
             <div className="text-sm text-gray-600">
                 View the dedicated page for detailed worker metrics, charts, and comprehensive analysis.
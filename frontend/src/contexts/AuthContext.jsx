@@ -52,8 +52,13 @@ export const AuthProvider = ({ children }) => {
 
     const getToken = () => token;
 
+    // Reloads the profile, e.g. once a required password change is done
+    const refreshUser = async () => {
+        setUser(await getUserProfile());
+    };
+
     return (
-        <AuthContext.Provider value={{ isLoggedIn, user, login, logout, getToken }}>
+        <AuthContext.Provider value={{ isLoggedIn, user, login, logout, getToken, refreshUser }}>
             {children}
         </AuthContext.Provider>
     );
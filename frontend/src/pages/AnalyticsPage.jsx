@@ -165,6 +165,21 @@ const AnalyticsPage = () => {
                             </div>
                         </div>
                     </div>
+
+                    {analyticsData.apdex && (
+                        <div className="bg-white rounded-lg shadow p-6">
+                            <div className="flex items-center">
+                                <div className="p-3 rounded-lg bg-amber-50 text-amber-600">
+                                    <Target className="h-6 w-6" />
+                                </div>
+                                <div className="ml-4">
+                                    <p className="text-sm font-medium text-gray-600">Apdex ({analyticsData.apdex.tests} tests)</p>
+                                    <p className="text-2xl font-bold text-gray-900">{analyticsData.apdex.score.toFixed(2)}</p>
+                                    <p className="text-xs text-gray-500 capitalize">{analyticsData.apdex.rating}</p>
+                                </div>
+                            </div>
+                        </div>
+                    )}
                 </div>
             )}
 
@@ -271,6 +271,7 @@ const WorkerStatusTable = ({ workers }) => (
                     <tr>
                         <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Worker ID</th>
                         <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Status</th>
+                        <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Version</th>
                         <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Current Test</th>
                         <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Progress</th>
                     </tr>
@@ -292,6 +293,15 @@ const WorkerRow = ({ worker }) => (
         </td>
         <td className="px-6 py-4 whitespace-nowrap">
             <StatusBadge status={worker.status_type} />
+            {worker.connection_state && worker.connection_state !== 'READY' && (
+                <div className="text-xs text-gray-500 mt-1">Connection: {worker.connection_state}</div>
+            )}
+            {worker.draining && (
+                <div className="text-xs text-yellow-700 mt-1">Draining for upgrade</div>
+            )}
+        </td>
+        <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
+            {worker.version || 'Unknown'}
         </td>
         <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
             {worker.current_test_id || 'None'}
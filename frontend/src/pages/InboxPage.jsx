@@ -35,12 +35,12 @@ export const InboxPage = () => {
         <ul className="space-y-4">
           {inbox.map((item) => {
             const isExpired = item.isExpired;
-            const isRead = (item.readBy || []).includes(item.userId);
+            const isRead = item.read;
             return (
               <li key={item.id} className={`p-4 rounded shadow flex items-center justify-between ${isExpired ? 'bg-gray-100' : 'bg-white'}`}>
                 <div>
                   <div className="font-semibold">Shared Test: {item.testId}</div>
-                  <div className="text-xs text-gray-500">Shared by: {item.sharedBy}</div>
+                  <div className="text-xs text-gray-500">Shared by: {item.sharedByName || item.sharedBy}</div>
                   <div className="text-xs text-gray-500">Expires: {new Date(item.expiresAt).toLocaleString()}</div>
                   {isExpired && <span className="text-red-500 text-xs">Expired</span>}
                 </div>
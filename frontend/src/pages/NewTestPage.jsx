@@ -23,6 +23,7 @@ const NewTestPage = () => {
         http2: false,
         insecure: false,
         connections: 10000,
+        apdexThreshold: '',
         vegetaPayloadJson: '{}'
     });
 
@@ -117,6 +118,10 @@ const NewTestPage = () => {
                 if (formData.rampSteps) payload.ramp_steps = parseInt(formData.rampSteps);
             }
 
+            if (formData.apdexThreshold.trim()) {
+                payload.apdex_threshold = formData.apdexThreshold.trim();
+            }
+
             await authenticatedFetch(`${API_BASE_URL}/test/submit`, {
                 method: 'POST',
                 body: JSON.stringify(payload)
@@ -337,6 +342,18 @@ const NewTestPage = () => {
                                 min="1"
                             />
                         </div>
+
+                        <div>
+                            <label className="block text-sm font-medium text-gray-700 mb-2">Apdex Threshold</label>
+                            <input
+                                type="text"
+                                name="apdexThreshold"
+                                value={formData.apdexThreshold}
+                                onChange={handleInputChange}
+                                className="w-full px-4 py-2 border border-gray-300 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent"
+                                placeholder="e.g., 300ms (server default if empty)"
+                            />
+                        </div>
                     </div>
 
                     <div className="grid grid-cols-1 md:grid-cols-3 gap-6 mb-6">
@@ -1,6 +1,7 @@
 import { useState, useEffect } from 'react';
 import { User, Mail, Calendar, Shield, Lock, Save } from 'lucide-react';
 import { LoadingSpinner } from '../components/common/UIComponents.jsx';
+import { useAuth } from '../contexts/AuthContext.jsx';
 import { getUserProfile, updateUserProfile, changePassword } from '../utils/api.js';
 
 export const ProfilePage = () => {
@@ -10,6 +11,7 @@ export const ProfilePage = () => {
     const [success, setSuccess] = useState('');
     const [editMode, setEditMode] = useState(false);
     const [showPasswordForm, setShowPasswordForm] = useState(false);
+    const { user, refreshUser } = useAuth();
 
     const [profileForm, setProfileForm] = useState({
         firstName: '',
@@ -71,6 +73,9 @@ export const ProfilePage = () => {
 
         try {
             await changePassword(passwordForm.currentPassword, passwordForm.newPassword);
+            if (user?.mustChangePassword) {
+                await refreshUser();
+            }
             setSuccess('Password changed successfully');
             setShowPasswordForm(false);
             setPasswordForm({
@@ -125,6 +130,11 @@ export const ProfilePage = () => {
             </div>
 
             {/* Messages */}
+            {user?.mustChangePassword && (
+                <div className="bg-yellow-50 border border-yellow-200 text-yellow-800 px-4 py-3 rounded-lg">
+                    You must change your password before continuing.
+                </div>
+            )}
             {error && (
                 <div className="bg-red-50 border border-red-200 text-red-700 px-4 py-3 rounded-lg">
                     {error}
@@ -0,0 +1,96 @@
+import { useEffect, useState } from 'react';
+import { Zap } from 'lucide-react';
+import { LoadingSpinner } from '../components/common/UIComponents.jsx';
+import { fetchPublicSharedTest } from '../utils/api.js';
+
+const Stat = ({ label, value }) => (
+    <div className="bg-gray-50 rounded-lg p-4">
+        <div className="text-sm text-gray-500">{label}</div>
+        <div className="text-xl font-semibold text-gray-900">{value}</div>
+    </div>
+);
+
+// Read-only result page for public shared links, shown without logging in
+export const PublicSharedTestPage = ({ token }) => {
+    const [view, setView] = useState(null);
+    const [error, setError] = useState('');
+    const [loading, setLoading] = useState(true);
+
+    useEffect(() => {
+        fetchPublicSharedTest(token)
+            .then(setView)
+            .catch((err) => setError(err.message))
+            .finally(() => setLoading(false));
+    }, [token]);
+
+    const result = view?.result;
+    const errors = Object.entries(result?.error_rates || {});
+
+    return (
+        <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 p-4">
+            <div className="bg-white p-8 rounded-2xl shadow-2xl max-w-4xl mx-auto">
+                <div className="flex items-center mb-6">
+                    <div className="h-10 w-10 bg-blue-600 rounded-xl flex items-center justify-center mr-3">
+                        <Zap className="h-5 w-5 text-white" />
+                    </div>
+                    <div>
+                        <h2 className="text-2xl font-bold text-gray-900">{view ? view.name : 'Shared Load Test'}</h2>
+                        {view && (
+                            <p className="text-gray-600 text-sm">
+                                {view.status} · created {new Date(view.createdAt).toLocaleString()} · link expires {new Date(view.linkExpiresAt).toLocaleString()}
+                            </p>
+                        )}
+                    </div>
+                </div>
+
+                {loading && <div className="flex justify-center"><LoadingSpinner /></div>}
+                {error && (
+                    <div className="bg-red-50 border border-red-200 text-red-800 px-4 py-3 rounded-lg">{error}</div>
+                )}
+
+                {view && (
+                    <div className="space-y-6">
+                        <div className="grid grid-cols-2 md:grid-cols-4 gap-4">
+                            <Stat label="Rate" value={`${view.ratePerSecond} req/s`} />
+                            <Stat label="Duration" value={view.durationSeconds} />
+                            <Stat label="Workers" value={view.workerCount} />
+                            <Stat label="Tags" value={(view.tags || []).join(', ') || '—'} />
+                        </div>
+
+                        {view.targetUrls?.length > 0 && (
+                            <div>
+                                <h3 className="font-semibold text-gray-900 mb-2">Targets</h3>
+                                <ul className="text-sm text-gray-700 font-mono space-y-1">
+                                    {view.targetUrls.map((target) => <li key={target} className="break-all">{target}</li>)}
+                                </ul>
+                            </div>
+                        )}
+
+                        {result ? (
+                            <div>
+                                <h3 className="font-semibold text-gray-900 mb-2">Result: {result.overall_status}</h3>
+                                {view.snapshotAt && (
+                                    <p className="text-sm text-gray-500 mb-2">As of {new Date(view.snapshotAt).toLocaleString()}, when the link was shared</p>
+                                )}
+                                <div className="grid grid-cols-2 md:grid-cols-4 gap-4">
+                                    <Stat label="Requests" value={result.total_requests.toLocaleString()} />
+                                    <Stat label="Failed" value={result.failed_requests.toLocaleString()} />
+                                    <Stat label="Avg latency" value={`${result.avg_latency_ms.toFixed(1)} ms`} />
+                                    <Stat label="P95 latency" value={`${result.p95_latency_ms.toFixed(1)} ms`} />
+                                    <Stat label="Throughput" value={`${result.throughput_rps.toFixed(1)} req/s`} />
+                                </div>
+                                {errors.length > 0 && (
+                                    <ul className="mt-4 text-sm text-gray-700 space-y-1">
+                                        {errors.map(([message, count]) => <li key={message}>{count} × {message}</li>)}
+                                    </ul>
+                                )}
+                            </div>
+                        ) : (
+                            <p className="text-gray-600">The test has no result yet.</p>
+                        )}
+                    </div>
+                )}
+            </div>
+        </div>
+    );
+};
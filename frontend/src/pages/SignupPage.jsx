@@ -0,0 +1,125 @@
+import { useState, useEffect } from 'react';
+import { UserPlus } from 'lucide-react';
+import { useAuth } from '../contexts/AuthContext.jsx';
+import { LoadingSpinner } from '../components/common/UIComponents.jsx';
+import { getInvite, signupUser } from '../utils/api.js';
+
+const inputClassName = 'w-full px-4 py-3 border border-gray-300 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent transition-all';
+
+export const SignupPage = () => {
+    const token = new URLSearchParams(window.location.search).get('token') || '';
+    const [invite, setInvite] = useState(null);
+    const [form, setForm] = useState({ username: '', firstName: '', lastName: '', password: '', confirmPassword: '' });
+    const [error, setError] = useState('');
+    const [loading, setLoading] = useState(true);
+    const { login } = useAuth();
+
+    useEffect(() => {
+        getInvite(token)
+            .then(setInvite)
+            .catch((err) => setError(err.message))
+            .finally(() => setLoading(false));
+    }, [token]);
+
+    const handleChange = (field) => (e) => setForm({ ...form, [field]: e.target.value });
+
+    const handleSignup = async (e) => {
+        e.preventDefault();
+        setError('');
+        if (form.password !== form.confirmPassword) {
+            setError('Passwords do not match');
+            return;
+        }
+        setLoading(true);
+
+        try {
+            const data = await signupUser({
+                token,
+                username: form.username,
+                password: form.password,
+                firstName: form.firstName,
+                lastName: form.lastName,
+            });
+            window.history.replaceState(null, '', '/');
+            login(data.token);
+        } catch (err) {
+            setError(err.message);
+            setLoading(false);
+        }
+    };
+
+    return (
+        <div className="min-h-screen flex items-center justify-center bg-gradient-to-br from-blue-50 to-indigo-100 p-4">
+            <div className="bg-white p-8 rounded-2xl shadow-2xl w-full max-w-md">
+                <div className="text-center mb-8">
+                    <div className="mx-auto h-12 w-12 bg-blue-600 rounded-xl flex items-center justify-center mb-4">
+                        <UserPlus className="h-6 w-6 text-white" />
+                    </div>
+                    <h2 className="text-3xl font-bold text-gray-900">Create Your Account</h2>
+                    {invite && (
+                        <p className="text-gray-600 mt-2">
+                            You were invited as {invite.role} with {invite.email}
+                        </p>
+                    )}
+                </div>
+
+                {!invite ? (
+                    loading ? (
+                        <div className="flex justify-center"><LoadingSpinner /></div>
+                    ) : (
+                        <div className="bg-red-50 border border-red-200 text-red-800 px-4 py-3 rounded-lg">
+                            {error} — ask an administrator to send you a new one.
+                        </div>
+                    )
+                ) : (
+                    <form onSubmit={handleSignup} className="space-y-6">
+                        <div>
+                            <label className="block text-sm font-medium text-gray-700 mb-2">Username</label>
+                            <input type="text" value={form.username} onChange={handleChange('username')}
+                                className={inputClassName} required minLength={3} maxLength={50} disabled={loading} />
+                        </div>
+
+                        <div className="grid grid-cols-2 gap-4">
+                            <div>
+                                <label className="block text-sm font-medium text-gray-700 mb-2">First Name</label>
+                                <input type="text" value={form.firstName} onChange={handleChange('firstName')}
+                                    className={inputClassName} disabled={loading} />
+                            </div>
+                            <div>
+                                <label className="block text-sm font-medium text-gray-700 mb-2">Last Name</label>
+                                <input type="text" value={form.lastName} onChange={handleChange('lastName')}
+                                    className={inputClassName} disabled={loading} />
+                            </div>
+                        </div>
+
+                        <div>
+                            <label className="block text-sm font-medium text-gray-700 mb-2">Password</label>
+                            <input type="password" value={form.password} onChange={handleChange('password')}
+                                className={inputClassName} required disabled={loading} />
+                        </div>
+
+                        <div>
+                            <label className="block text-sm font-medium text-gray-700 mb-2">Confirm Password</label>
+                            <input type="password" value={form.confirmPassword} onChange={handleChange('confirmPassword')}
+                                className={inputClassName} required disabled={loading} />
+                        </div>
+
+                        {error && (
+                            <div className="bg-red-50 border border-red-200 text-red-800 px-4 py-3 rounded-lg">
+                                {error}
+                            </div>
+                        )}
+
+                        <button
+                            type="submit"
+                            className="w-full bg-blue-600 text-white py-3 px-4 rounded-lg hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2 flex items-center justify-center transition-all font-medium"
+                            disabled={loading}
+                        >
+                            {loading ? <LoadingSpinner size="small" /> : 'Sign Up'}
+                        </button>
+                    </form>
+                )}
+            </div>
+        </div>
+    );
+};
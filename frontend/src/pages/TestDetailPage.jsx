@@ -403,6 +403,14 @@ const ResultsTab = ({ testDetail }) => (
                     bgColor="bg-yellow-50"
                     textColor="text-yellow-900"
                 />
+                {testDetail.aggregated_result.apdex && (
+                    <InfoCard
+                        value={`${testDetail.aggregated_result.apdex.score.toFixed(2)} (${testDetail.aggregated_result.apdex.rating})`}
+                        label={`Apdex (T = ${testDetail.aggregated_result.apdex.thresholdMs}ms)`}
+                        bgColor="bg-purple-50"
+                        textColor="text-purple-900"
+                    />
+                )}
             </div>
         ) : (
             <div className="bg-white p-8 rounded-xl shadow-sm border border-gray-200">
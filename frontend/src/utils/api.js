@@ -149,6 +149,39 @@ export const loginUser = async (username, password) => {
     return response.json();
 };
 
+/**
+ * Get the invite a signup link is for
+ * @param {string} token - Signup token from the invite link
+ * @returns {Promise} - Invited email address, role and expiry
+ */
+export const getInvite = async (token) => {
+    const response = await fetch(`${API_BASE_URL}/auth/invites/${encodeURIComponent(token)}`);
+    if (!response.ok) {
+        throw new Error('This invite link is invalid or has expired');
+    }
+    return response.json();
+};
+
+/**
+ * Sign up with an invite
+ * @param {object} signupData - Token, username, password, first and last name
+ * @returns {Promise} - Login response with token
+ */
+export const signupUser = async (signupData) => {
+    const response = await fetch(`${API_BASE_URL}/auth/signup`, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(signupData),
+    });
+
+    if (!response.ok) {
+        const message = await response.text().catch(() => '');
+        throw new Error(message.trim() || 'Signup failed');
+    }
+
+    return response.json();
+};
+
 /**
  * Get current user profile
  * @returns {Promise} - User profile data
@@ -282,11 +315,13 @@ export const getTargetAnalytics = async (options = {}) => {
 /**
  * Share a test and get a shareable link
  */
-export const shareTest = async (testId, userId = null) => {
+export const shareTest = async (testId, userId = null, isPublic = false) => {
     let url = `${API_BASE_URL}/tests/${testId}/share`;
     const options = { method: 'POST' };
     if (userId) {
         url += `?userId=${encodeURIComponent(userId)}`;
+    } else if (isPublic) {
+        url += '?public=true';
     }
     return authenticatedFetch(url, options);
 };
@@ -303,6 +338,19 @@ export const fetchSharedTest = async (linkId, noAuth = false) => {
     return authenticatedFetch(url);
 };
 
+/**
+ * Open a public shared link, without logging in
+ * @param {string} token - Signed token of the public link
+ * @returns {Promise} - Read-only view of the test and its result
+ */
+export const fetchPublicSharedTest = async (token) => {
+    const response = await fetch(`${API_BASE_URL}/public/shared/${encodeURIComponent(token)}`);
+    if (!response.ok) {
+        throw new Error('This link is invalid, expired or was revoked');
+    }
+    return response.json();
+};
+
 /**
  * Fetch inbox (shared tests)
  */
@@ -311,6 +359,14 @@ export const fetchInbox = async () => {
     return authenticatedFetch(url);
 };
 
+/**
+ * Fetch the unread count and newest items of the inbox
+ */
+export const fetchInboxSummary = async (limit) => {
+    const url = `${API_BASE_URL}/inbox/summary${limit ? `?limit=${limit}` : ''}`;
+    return authenticatedFetch(url);
+};
+
 /**
  * Mark inbox item as read
  */
@@ -330,7 +386,7 @@ export const sendTestToInbox = async (testId, userId) => {
 /**
  * Copy share link for a test
  */
-export const copyShareLink = async (testId) => {
+export const copyShareLink = async (testId, isPublic = false) => {
     // Just get the share link, do not send to inbox
-    return shareTest(testId);
+    return shareTest(testId, null, isPublic);
 };
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AggregateSummary names the fixed aggregate every test gets, the
+// TestResultAggregated row. It can't be disabled and isn't a strategy.
+const AggregateSummary = "summary"
+
+// aggregateNamePattern is what an aggregate strategy name may look like.
+var aggregateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+// ValidateAggregateName checks that name can name an aggregate strategy.
+func ValidateAggregateName(name string) error {
+	if !aggregateNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid aggregate name %q: must be lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	if name == AggregateSummary {
+		return fmt.Errorf("aggregate name %q is reserved", name)
+	}
+	return nil
+}
+
+// AggregateInput is what an aggregate strategy computes its document from.
+type AggregateInput struct {
+	Test    *TestRequest
+	Results []*TestResult         // The raw result of each worker that reported
+	Summary *TestResultAggregated // The fixed aggregate of Results
+
+	// TimeSeries returns the test's progress metrics in buckets of interval,
+	// as GET /api/tests/{id}/timeseries does.
+	TimeSeries func(ctx context.Context, interval time.Duration) ([]TimeSeriesPoint, error)
+}
+
+// AggregateStrategy computes one named aggregate of a test's results. It
+// runs each time the test is aggregated, so as each worker reports.
+type AggregateStrategy interface {
+	// Aggregate returns the aggregate as a value encoded to JSON, or nil when
+	// the results don't allow one yet.
+	Aggregate(ctx context.Context, input *AggregateInput) (interface{}, error)
+}
+
+// AggregateStrategyFunc lets a function be an AggregateStrategy.
+type AggregateStrategyFunc func(ctx context.Context, input *AggregateInput) (interface{}, error)
+
+// Aggregate calls f.
+func (f AggregateStrategyFunc) Aggregate(ctx context.Context, input *AggregateInput) (interface{}, error) {
+	return f(ctx, input)
+}
+
+// AggregateStrategyRegistry holds the aggregate strategies the master knows, by name.
+type AggregateStrategyRegistry struct {
+	mu         sync.RWMutex
+	strategies map[string]AggregateStrategy
+}
+
+// NewAggregateStrategyRegistry creates an empty registry.
+func NewAggregateStrategyRegistry() *AggregateStrategyRegistry {
+	return &AggregateStrategyRegistry{strategies: make(map[string]AggregateStrategy)}
+}
+
+// Register makes strategy compute the aggregate called name, replacing any
+// strategy already registered under it.
+func (r *AggregateStrategyRegistry) Register(name string, strategy AggregateStrategy) error {
+	if err := ValidateAggregateName(name); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strategies[name] = strategy
+	return nil
+}
+
+// Get returns the strategy registered under name.
+func (r *AggregateStrategyRegistry) Get(name string) (AggregateStrategy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	strategy, ok := r.strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("no aggregate strategy registered as %q", name)
+	}
+	return strategy, nil
+}
+
+// Names lists the registered strategies in order.
+func (r *AggregateStrategyRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.strategies))
+	for name := range r.strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AggregateDocument is the latest aggregate a strategy computed for a test.
+type AggregateDocument struct {
+	TestID     string          `json:"testId"`
+	Name       string          `json:"name"`
+	Document   json.RawMessage `json:"document"`
+	ComputedAt time.Time       `json:"computedAt"`
+}
+
+// AggregateDocumentRepository stores the named aggregates of tests.
+type AggregateDocumentRepository interface {
+	// SaveAggregateDocument replaces the test's aggregate of the same name.
+	SaveAggregateDocument(ctx context.Context, doc *AggregateDocument) error
+	// GetAggregateDocuments returns every aggregate of a test, by name.
+	GetAggregateDocuments(ctx context.Context, testID string) ([]*AggregateDocument, error)
+}
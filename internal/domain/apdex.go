@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultApdexThreshold is the Apdex threshold of tests that set none, unless
+// the master is given another.
+const DefaultApdexThreshold = 500 * time.Millisecond
+
+// MaxApdexTargets caps the targets a test can give thresholds of their own.
+const MaxApdexTargets = 32
+
+// ApdexConfig sets the thresholds a test's requests are scored against. A
+// request is satisfying within the threshold T, tolerable within 4T, and
+// frustrating when slower or failed.
+type ApdexConfig struct {
+	Threshold string            `json:"threshold,omitempty"` // e.g. "300ms"; empty = the master's default
+	Targets   map[string]string `json:"targets,omitempty"`   // Thresholds of the targets whose URL starts with each key; the longest match wins
+}
+
+// Validate checks that the thresholds parse and are positive.
+func (c *ApdexConfig) Validate() error {
+	if c.Threshold != "" {
+		if _, err := parseApdexThreshold(c.Threshold); err != nil {
+			return err
+		}
+	}
+	if len(c.Targets) > MaxApdexTargets {
+		return fmt.Errorf("at most %d apdex targets are allowed, got %d", MaxApdexTargets, len(c.Targets))
+	}
+	for prefix, threshold := range c.Targets {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("apdex target URL prefixes cannot be empty")
+		}
+		if _, err := parseApdexThreshold(threshold); err != nil {
+			return fmt.Errorf("apdex target %q: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+func parseApdexThreshold(s string) (time.Duration, error) {
+	threshold, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid apdex threshold %q: %w", s, err)
+	}
+	if threshold <= 0 {
+		return 0, fmt.Errorf("invalid apdex threshold %q: must be positive", s)
+	}
+	return threshold, nil
+}
+
+// ApdexCounts are requests classified against an Apdex threshold.
+type ApdexCounts struct {
+	Satisfied  int64 `json:"satisfied"`
+	Tolerating int64 `json:"tolerating"`
+	Frustrated int64 `json:"frustrated"`
+}
+
+// Score is (satisfied + tolerating/2) / requests, or 0 without requests.
+func (c ApdexCounts) Score() float64 {
+	total := c.Satisfied + c.Tolerating + c.Frustrated
+	if total == 0 {
+		return 0
+	}
+	return (float64(c.Satisfied) + float64(c.Tolerating)/2) / float64(total)
+}
+
+func (c *ApdexCounts) add(other ApdexCounts) {
+	c.Satisfied += other.Satisfied
+	c.Tolerating += other.Tolerating
+	c.Frustrated += other.Frustrated
+}
+
+// ApdexRating is the customary name of an Apdex score's band: excellent,
+// good, fair, poor or unacceptable.
+func ApdexRating(score float64) string {
+	switch {
+	case score >= 0.94:
+		return "excellent"
+	case score >= 0.85:
+		return "good"
+	case score >= 0.70:
+		return "fair"
+	case score >= 0.50:
+		return "poor"
+	default:
+		return "unacceptable"
+	}
+}
+
+// Apdex is the Apdex score of a test, or of one worker's share of it.
+type Apdex struct {
+	ThresholdMs float64 `json:"thresholdMs"` // The test's threshold; targets may have their own
+	ApdexCounts
+	Score   float64       `json:"score"`
+	Rating  string        `json:"rating"`
+	Targets []TargetApdex `json:"targets,omitempty"` // Targets with a threshold of their own, by prefix
+}
+
+// TargetApdex is the Apdex score of the requests to the targets under one
+// URL prefix. They count towards the test's score too.
+type TargetApdex struct {
+	Target      string  `json:"target"` // URL prefix
+	ThresholdMs float64 `json:"thresholdMs"`
+	ApdexCounts
+	Score  float64 `json:"score"`
+	Rating string  `json:"rating"`
+}
+
+// rate sets the scores and ratings from the counts.
+func (a *Apdex) rate() {
+	a.Score, a.Rating = roundedApdexScore(a.ApdexCounts)
+	for i := range a.Targets {
+		a.Targets[i].Score, a.Targets[i].Rating = roundedApdexScore(a.Targets[i].ApdexCounts)
+	}
+}
+
+func roundedApdexScore(counts ApdexCounts) (float64, string) {
+	score := counts.Score()
+	return math.Round(score*1000) / 1000, ApdexRating(score)
+}
+
+// MergeApdex rolls the Apdex of each worker up into the test's. Workers
+// without one are left out; nil is returned when none has one.
+func MergeApdex(parts []*Apdex) *Apdex {
+	var merged *Apdex
+	targets := make(map[string]*TargetApdex)
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &Apdex{ThresholdMs: part.ThresholdMs}
+		}
+		merged.ApdexCounts.add(part.ApdexCounts)
+		for _, t := range part.Targets {
+			if targets[t.Target] == nil {
+				targets[t.Target] = &TargetApdex{Target: t.Target, ThresholdMs: t.ThresholdMs}
+			}
+			targets[t.Target].ApdexCounts.add(t.ApdexCounts)
+		}
+	}
+	if merged == nil {
+		return nil
+	}
+	for _, t := range targets {
+		merged.Targets = append(merged.Targets, *t)
+	}
+	sort.Slice(merged.Targets, func(i, j int) bool { return merged.Targets[i].Target < merged.Targets[j].Target })
+	merged.rate()
+	return merged
+}
+
+// ApdexCollector classifies a test's requests as they complete. It is not
+// safe for concurrent use.
+type ApdexCollector struct {
+	threshold time.Duration
+	prefixes  []string // Target prefixes, longest first
+	limits    map[string]time.Duration
+	overall   ApdexCounts
+	targets   map[string]*ApdexCounts
+}
+
+// NewApdexCollector scores requests against config, whose thresholds must be
+// valid. Without a threshold of its own the test's is DefaultApdexThreshold.
+func NewApdexCollector(config *ApdexConfig) (*ApdexCollector, error) {
+	c := &ApdexCollector{
+		threshold: DefaultApdexThreshold,
+		limits:    make(map[string]time.Duration),
+		targets:   make(map[string]*ApdexCounts),
+	}
+	if config == nil {
+		return c, nil
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Threshold != "" {
+		c.threshold, _ = parseApdexThreshold(config.Threshold)
+	}
+	for prefix, threshold := range config.Targets {
+		c.limits[prefix], _ = parseApdexThreshold(threshold)
+		c.prefixes = append(c.prefixes, prefix)
+	}
+	sort.Slice(c.prefixes, func(i, j int) bool { return len(c.prefixes[i]) > len(c.prefixes[j]) })
+	return c, nil
+}
+
+// Add classifies one request to url. Failed requests always frustrate.
+func (c *ApdexCollector) Add(url string, latency time.Duration, ok bool) {
+	threshold := c.threshold
+	var target *ApdexCounts
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(url, prefix) {
+			threshold = c.limits[prefix]
+			if target = c.targets[prefix]; target == nil {
+				target = &ApdexCounts{}
+				c.targets[prefix] = target
+			}
+			break
+		}
+	}
+
+	var counts ApdexCounts
+	switch {
+	case !ok || latency > 4*threshold:
+		counts.Frustrated = 1
+	case latency > threshold:
+		counts.Tolerating = 1
+	default:
+		counts.Satisfied = 1
+	}
+	c.overall.add(counts)
+	if target != nil {
+		target.add(counts)
+	}
+}
+
+// Result returns the score of the requests added so far, or nil if there
+// were none.
+func (c *ApdexCollector) Result() *Apdex {
+	if c.overall == (ApdexCounts{}) {
+		return nil
+	}
+	apdex := &Apdex{ThresholdMs: durationMs(c.threshold), ApdexCounts: c.overall}
+	for _, prefix := range c.prefixes {
+		if counts := c.targets[prefix]; counts != nil {
+			apdex.Targets = append(apdex.Targets, TargetApdex{Target: prefix, ThresholdMs: durationMs(c.limits[prefix]), ApdexCounts: *counts})
+		}
+	}
+	sort.Slice(apdex.Targets, func(i, j int) bool { return apdex.Targets[i].Target < apdex.Targets[j].Target })
+	apdex.rate()
+	return apdex
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, which tells keys from login tokens.
+const APIKeyPrefix = "ldt_"
+
+// MaxAPIKeysPerUser is how many API keys a user can hold at once.
+const MaxAPIKeysPerUser = 20
+
+// APIKey is a long-lived credential for CLIs and CI, used like a login
+// token of its user. Only a hash of the key is stored; the key itself is
+// shown once, when it is created.
+type APIKey struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"userId"`
+	Name       string     `json:"name"`
+	Hint       string     `json:"hint"` // Start of the key, to tell keys apart
+	KeyHash    string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// APIKeyRepository stores users' API keys.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	// GetAPIKeyByHash returns the key with hash, or nil if there is none.
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	// GetAPIKeysByUser lists a user's keys, newest first.
+	GetAPIKeysByUser(ctx context.Context, userID string) ([]*APIKey, error)
+	// DeleteAPIKey removes one of a user's keys.
+	DeleteAPIKey(ctx context.Context, keyID, userID string) error
+	TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error
+}
+
+type callerKey struct{}
+
+// WithCaller returns a context carrying the authenticated user making the
+// current request.
+func WithCaller(ctx context.Context, user *UserProfile) context.Context {
+	return context.WithValue(ctx, callerKey{}, user)
+}
+
+// CallerFrom returns the authenticated user stored in ctx, if any.
+func CallerFrom(ctx context.Context) (*UserProfile, bool) {
+	user, ok := ctx.Value(callerKey{}).(*UserProfile)
+	return user, ok && user != nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// MaxWaitForWorkersLimit is the longest a test may wait for its workers
+// before its partial assignment policy applies. The master assigns no other
+// test meanwhile.
+const MaxWaitForWorkersLimit = time.Hour
+
+// Partial assignment policies: what the master does with a test when fewer
+// workers than it asked for became available in time.
+const (
+	PartialBestEffort = "bestEffort" // Run on the workers available, each at its planned rate
+	PartialScaleRate  = "scaleRate"  // Run on the workers available, spreading the full rate over them
+	PartialRequireAll = "requireAll" // Wait for every worker, failing if the fleet is too small
+)
+
+// PartialPolicies lists the partial assignment policies.
+var PartialPolicies = []string{PartialBestEffort, PartialScaleRate, PartialRequireAll}
+
+// Worker failure policies: what the master does with a running test when one
+// of its workers goes offline while others are still running it.
+const (
+	WorkerFailureContinue     = "continue"     // Let the remaining workers finish at their planned rates
+	WorkerFailureRedistribute = "redistribute" // Spread the lost worker's rate over the remaining workers
+	WorkerFailureAbort        = "abort"        // Stop the whole test
+)
+
+// WorkerFailurePolicies lists the worker failure policies.
+var WorkerFailurePolicies = []string{WorkerFailureContinue, WorkerFailureRedistribute, WorkerFailureAbort}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// TargetAttachments are the attachment references a JSON target may carry in
+// addition to vegeta's own fields. BodyAttachment sends an attachment as the
+// raw request body; Multipart builds a multipart/form-data body.
+type TargetAttachments struct {
+	BodyAttachment string         `json:"bodyAttachment,omitempty"`
+	Multipart      *MultipartBody `json:"multipart,omitempty"`
+}
+
+// MultipartBody describes a multipart/form-data request body.
+type MultipartBody struct {
+	Fields map[string]string `json:"fields,omitempty"`
+	Files  []MultipartFile   `json:"files,omitempty"`
+}
+
+// MultipartFile is a file part whose content comes from an attachment.
+type MultipartFile struct {
+	Field       string `json:"field"`
+	Attachment  string `json:"attachment"`
+	Filename    string `json:"filename,omitempty"`    // Defaults to the attachment name
+	ContentType string `json:"contentType,omitempty"` // Defaults to the attachment content type
+}
+
+// AttachmentIDs returns the attachments referenced by base64-encoded JSON
+// targets. Plain-text targets cannot reference attachments.
+func AttachmentIDs(targetsBase64 string) ([]string, error) {
+	refs, err := decodeTargetAttachments(targetsBase64)
+	if err != nil || refs == nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for i, ref := range refs {
+		if ref.BodyAttachment != "" && ref.Multipart != nil {
+			return nil, fmt.Errorf("target %d: bodyAttachment and multipart are mutually exclusive", i)
+		}
+		add(ref.BodyAttachment)
+		if ref.Multipart != nil {
+			for _, f := range ref.Multipart.Files {
+				if f.Field == "" || f.Attachment == "" {
+					return nil, fmt.Errorf("target %d: multipart files need a field and an attachment", i)
+				}
+				add(f.Attachment)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// ResolveTargetAttachments inlines attachment references into the targets'
+// base64 bodies, setting Content-Type as needed, and returns the rewritten
+// targets. Targets without references are left untouched.
+func ResolveTargetAttachments(targetsBase64 string, fetch func(id string) (*Attachment, []byte, error)) (string, error) {
+	refs, err := decodeTargetAttachments(targetsBase64)
+	if err != nil || refs == nil {
+		return targetsBase64, err
+	}
+	decoded, _ := base64.StdEncoding.DecodeString(targetsBase64) // Already validated above
+	var targets []map[string]json.RawMessage
+	if err := json.Unmarshal(decoded, &targets); err != nil {
+		return "", fmt.Errorf("failed to decode targets: %w", err)
+	}
+
+	changed := false
+	for i, ref := range refs {
+		var body []byte
+		var contentType string
+		switch {
+		case ref.BodyAttachment != "":
+			att, data, err := fetch(ref.BodyAttachment)
+			if err != nil {
+				return "", fmt.Errorf("target %d: %w", i, err)
+			}
+			body, contentType = data, att.ContentType
+		case ref.Multipart != nil:
+			if body, contentType, err = buildMultipartBody(ref.Multipart, fetch); err != nil {
+				return "", fmt.Errorf("target %d: %w", i, err)
+			}
+		default:
+			continue
+		}
+
+		delete(targets[i], "bodyAttachment")
+		delete(targets[i], "multipart")
+		if targets[i]["body"], err = json.Marshal(body); err != nil {
+			return "", err
+		}
+		if err := setTargetContentType(targets[i], contentType); err != nil {
+			return "", fmt.Errorf("target %d: %w", i, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return targetsBase64, nil
+	}
+
+	encoded, err := json.Marshal(targets)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode targets: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+func decodeTargetAttachments(targetsBase64 string) ([]TargetAttachments, error) {
+	decoded, err := base64.StdEncoding.DecodeString(targetsBase64)
+	if err != nil {
+		return nil, nil // Reported by the executor like any other malformed targets
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(decoded), []byte("[")) {
+		return nil, nil
+	}
+	var refs []TargetAttachments
+	if err := json.Unmarshal(decoded, &refs); err != nil {
+		return nil, nil
+	}
+	return refs, nil
+}
+
+func buildMultipartBody(mp *MultipartBody, fetch func(id string) (*Attachment, []byte, error)) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for name, value := range mp.Fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, f := range mp.Files {
+		att, data, err := fetch(f.Attachment)
+		if err != nil {
+			return nil, "", err
+		}
+		filename, contentType := f.Filename, f.ContentType
+		if filename == "" {
+			filename = att.Name
+		}
+		if contentType == "" {
+			contentType = att.ContentType
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, f.Field, filename))
+		h.Set("Content-Type", contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// setTargetContentType sets Content-Type unless the target already has one,
+// except for multipart bodies whose boundary must always match.
+func setTargetContentType(target map[string]json.RawMessage, contentType string) error {
+	header := map[string][]string{}
+	if raw, ok := target["header"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return fmt.Errorf("invalid header: %w", err)
+		}
+	}
+	for k := range header {
+		if strings.EqualFold(k, "Content-Type") {
+			if !strings.HasPrefix(contentType, "multipart/") {
+				return nil
+			}
+			delete(header, k)
+		}
+	}
+	header["Content-Type"] = []string{contentType}
+	raw, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	target["header"] = raw
+	return nil
+}
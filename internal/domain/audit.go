@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Audited actions.
+const (
+	AuditLogin          = "auth.login"
+	AuditLoginFailed    = "auth.login_failed"
+	AuditLoginLockout   = "auth.lockout"
+	AuditPasswordChange = "auth.password_change"
+	AuditPasswordReset  = "user.password_reset"
+	AuditUserCreate     = "user.create"
+	AuditUserUpdate     = "user.update"
+	AuditUserActivate   = "user.activate"
+	AuditUserDeactivate = "user.deactivate"
+	AuditUserUnlock     = "user.unlock"
+	AuditInviteCreate   = "invite.create"
+	AuditInviteResend   = "invite.resend"
+	AuditInviteRevoke   = "invite.revoke"
+	AuditInviteAccept   = "invite.accept"
+	AuditAPIKeyCreate   = "api_key.create"
+	AuditAPIKeyRevoke   = "api_key.revoke"
+	AuditTestSubmit     = "test.submit"
+	AuditTestApprove    = "test.approve"
+	AuditTestReject     = "test.reject"
+	AuditTestCancel     = "test.cancel"
+	AuditTestPromote    = "test.promote"
+	AuditTestTransfer   = "test.transfer"
+	AuditShareCreate    = "share.create"
+	AuditShareRevoke    = "share.revoke"
+	AuditTeamCreate     = "team.create"
+	AuditTeamUpdate     = "team.update"
+	AuditTeamDelete     = "team.delete"
+	AuditRolloutStart   = "rollout.start"
+	AuditRolloutCancel  = "rollout.cancel"
+	AuditWorkerOffline  = "worker.offline"
+	AuditWorkerRemove   = "worker.remove"
+)
+
+// AuditEntry records one action taken by a user or an administrator.
+type AuditEntry struct {
+	ID            int64     `json:"id"`
+	Time          time.Time `json:"time"`
+	ActorID       string    `json:"actorId"`
+	ActorName     string    `json:"actorName"`
+	IP            string    `json:"ip"`                     // Address the request came from
+	ForwardedFor  string    `json:"forwardedFor,omitempty"` // X-Forwarded-For as sent; may be spoofed
+	Action        string    `json:"action"`
+	Target        string    `json:"target,omitempty"`        // ID of the test, user or link acted on
+	PayloadDigest string    `json:"payloadDigest,omitempty"` // SHA-256 of the action's JSON payload
+}
+
+// AuditFilter selects audit entries. Zero fields match everything.
+type AuditFilter struct {
+	ActorID string
+	Action  string
+	Target  string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+	Offset  int
+}
+
+// AuditRepository stores the audit log. Entries are never changed or deleted.
+type AuditRepository interface {
+	SaveAuditEntry(ctx context.Context, entry *AuditEntry) error
+	// GetAuditEntries returns matching entries, newest first, and how many match in total.
+	GetAuditEntries(ctx context.Context, filter AuditFilter) ([]*AuditEntry, int, error)
+}
+
+// AuditSource identifies who made a request and from where.
+type AuditSource struct {
+	ActorID      string
+	ActorName    string
+	IP           string
+	ForwardedFor string
+}
+
+type auditSourceKey struct{}
+
+// WithAuditSource returns a context carrying the source of the current request.
+func WithAuditSource(ctx context.Context, source AuditSource) context.Context {
+	return context.WithValue(ctx, auditSourceKey{}, source)
+}
+
+// WithAuditActor returns a context whose audit source names the
+// authenticated user, keeping the address already recorded.
+func WithAuditActor(ctx context.Context, actorID, actorName string) context.Context {
+	source := AuditSourceFrom(ctx)
+	source.ActorID, source.ActorName = actorID, actorName
+	return WithAuditSource(ctx, source)
+}
+
+// AuditSourceFrom returns the request source stored in ctx, if any.
+func AuditSourceFrom(ctx context.Context) AuditSource {
+	source, _ := ctx.Value(auditSourceKey{}).(AuditSource)
+	return source
+}
+
+// NewAuditEntry builds an entry for action on target by the source in ctx.
+// Payload is digested rather than stored, so callers must leave secrets such
+// as passwords out of it. A nil payload has no digest.
+func NewAuditEntry(ctx context.Context, action, target string, payload interface{}) *AuditEntry {
+	source := AuditSourceFrom(ctx)
+	entry := &AuditEntry{
+		Time:         time.Now(),
+		ActorID:      source.ActorID,
+		ActorName:    source.ActorName,
+		IP:           source.IP,
+		ForwardedFor: source.ForwardedFor,
+		Action:       action,
+		Target:       target,
+	}
+	if payload != nil {
+		if data, err := json.Marshal(payload); err == nil {
+			sum := sha256.Sum256(data)
+			entry.PayloadDigest = hex.EncodeToString(sum[:])
+		}
+	}
+	return entry
+}
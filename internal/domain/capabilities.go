@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Protocols a worker's executor may be able to send.
+const (
+	ProtocolHTTP      = "http" // HTTP and HTTPS
+	ProtocolGRPC      = "grpc"
+	ProtocolWebSocket = "ws" // WebSocket, plain or over TLS
+)
+
+// Executor features a test may depend on beyond sending plain requests.
+const (
+	FeatureScenario     = "scenario"      // Multi-step scenarios (ScenarioJSON)
+	FeatureTemplates    = "templates"     // {{seq}}, {{uuid}} and {{timestamp}} placeholders
+	FeaturePreflight    = "preflight"     // Probing targets before the attack
+	FeatureThinkTime    = "think-time"    // Pauses between requests
+	FeaturePacingJitter = "pacing-jitter" // Randomized request intervals
+	FeatureRequestID    = "request-id"    // Injected X-Request-ID headers
+	FeatureSmoke        = "smoke"         // Per-target response reports of smoke runs
+	FeatureCapture      = "capture"       // Per-request results kept for download
+	FeatureLabels       = "labels"        // Label headers added to every request
+)
+
+// WorkerCapabilities is what a worker reports it can run when it registers.
+// Workers that report no protocols predate capability reporting and are not
+// checked.
+type WorkerCapabilities struct {
+	Version         string   `json:"version"`
+	Protocols       []string `json:"protocols"`
+	Features        []string `json:"features"`
+	Executors       []string `json:"executors"`       // Registered executors; empty = only ExecutorVegetaHTTP
+	MaxPayloadBytes uint64   `json:"maxPayloadBytes"` // Largest test assignment the worker accepts; 0 = no limit
+}
+
+// MissingCapabilities describes what a worker lacks to run a test whose
+// assignment is payloadBytes long, or returns nil if it can run it.
+func (c WorkerCapabilities) MissingCapabilities(test *TestRequest, payloadBytes int) []string {
+	if len(c.Protocols) == 0 {
+		return nil
+	}
+	var missing []string
+	executors := c.Executors
+	if len(executors) == 0 {
+		executors = []string{ExecutorVegetaHTTP}
+	}
+	if executor := ExecutorName(test.Protocol); !slices.Contains(executors, executor) {
+		missing = append(missing, "executor "+executor)
+	}
+	for _, protocol := range test.RequiredProtocols() {
+		if !slices.Contains(c.Protocols, protocol) {
+			missing = append(missing, "protocol "+protocol)
+		}
+	}
+	for _, feature := range test.RequiredFeatures() {
+		if !slices.Contains(c.Features, feature) {
+			missing = append(missing, "feature "+feature)
+		}
+	}
+	if c.MaxPayloadBytes > 0 && uint64(payloadBytes) > c.MaxPayloadBytes {
+		missing = append(missing, fmt.Sprintf("room for a %d-byte assignment (max %d)", payloadBytes, c.MaxPayloadBytes))
+	}
+	return missing
+}
+
+// RequiredProtocols returns the protocols of the test's targets.
+func (t *TestRequest) RequiredProtocols() []string {
+	var protocols []string
+	for _, target := range t.TargetURLs {
+		fields := strings.Fields(target)
+		if len(fields) == 0 {
+			continue
+		}
+		u, err := url.Parse(fields[len(fields)-1])
+		if err != nil {
+			continue
+		}
+		var protocol string
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https":
+			protocol = ProtocolHTTP
+		case "grpc", "grpcs":
+			protocol = ProtocolGRPC
+		case "ws", "wss":
+			protocol = ProtocolWebSocket
+		default:
+			continue
+		}
+		if !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols
+}
+
+// RequiredFeatures returns the executor features the test uses.
+func (t *TestRequest) RequiredFeatures() []string {
+	var features []string
+	if t.ScenarioJSON != "" {
+		features = append(features, FeatureScenario)
+	}
+	if t.TemplateTargets {
+		features = append(features, FeatureTemplates)
+	}
+	if t.Preflight {
+		features = append(features, FeaturePreflight)
+	}
+	if t.ThinkTime != "" {
+		features = append(features, FeatureThinkTime)
+	}
+	if t.PacingJitter > 0 {
+		features = append(features, FeaturePacingJitter)
+	}
+	if t.InjectRequestID {
+		features = append(features, FeatureRequestID)
+	}
+	if t.Smoke {
+		features = append(features, FeatureSmoke)
+	}
+	if t.CaptureRequests {
+		features = append(features, FeatureCapture)
+	}
+	if len(t.LabelHeaders) > 0 {
+		features = append(features, FeatureLabels)
+	}
+	return features
+}
@@ -0,0 +1,27 @@
+package domain
+
+// CapacitySLO is the service level a capacity estimate is measured against.
+type CapacitySLO struct {
+	MaxLatencyMs float64 `json:"maxLatencyMs"` // Highest acceptable mean latency
+	MaxErrorRate float64 `json:"maxErrorRate"` // Highest acceptable fraction of failed requests (0.0-1.0)
+}
+
+// CapacityPoint is the mean latency and error rate observed while a test ran
+// at one level of throughput.
+type CapacityPoint struct {
+	RPS           float64 `json:"rps"`
+	MeanLatencyMs float64 `json:"meanLatencyMs"`
+	ErrorRate     float64 `json:"errorRate"` // Failures / requests (0.0-1.0)
+	Seconds       int     `json:"seconds"`   // Seconds of the test spent at this throughput
+}
+
+// CapacityEstimate is how much load a test's target sustained, estimated from
+// the throughput it reached over the test and the latency and errors at each level.
+type CapacityEstimate struct {
+	TestID            string          `json:"testId"`
+	SLO               CapacitySLO     `json:"slo"`
+	Curve             []CapacityPoint `json:"curve"`             // Throughput levels, lowest first
+	MaxSustainableRPS float64         `json:"maxSustainableRps"` // Highest level that met the SLO, as did every level below it; 0 if none did
+	KneeRPS           float64         `json:"kneeRps,omitempty"` // Where latency starts climbing steeply; omitted when the curve has no clear knee
+	Note              string          `json:"note,omitempty"`    // Why the estimate is limited, if it is
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxRequestCaptureBytes caps the uncompressed size of the requests one worker
+// captures in a test. Requests sent after the cap is reached are not captured.
+const MaxRequestCaptureBytes = 512 << 20
+
+// RequestCapture describes the requests one worker captured in a test, kept
+// gzip-compressed as vegeta JSON results, one per line and without response
+// bodies or headers, so they can be fed to `vegeta plot` and `vegeta report`.
+type RequestCapture struct {
+	TestID    string    `json:"testId"`
+	WorkerID  string    `json:"workerId"`
+	Requests  int64     `json:"requests"`
+	Size      int64     `json:"size"`      // Compressed bytes stored
+	Truncated bool      `json:"truncated"` // The worker reached MaxRequestCaptureBytes
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RequestCaptureRepository stores the requests workers captured in tests.
+type RequestCaptureRepository interface {
+	SaveRequestCapture(ctx context.Context, capture *RequestCapture, data []byte) error
+	// GetRequestCaptures lists a test's captures by worker, without their content.
+	GetRequestCaptures(ctx context.Context, testID string) ([]*RequestCapture, error)
+	GetRequestCaptureData(ctx context.Context, testID, workerID string) ([]byte, error)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrConfirmationRequired is returned, as a *ConfirmationError, for a test
+// above the master's confirmation thresholds that was submitted without a
+// valid confirmation token.
+var ErrConfirmationRequired = errors.New("test needs confirmation")
+
+// ConfirmationError asks the submitter to confirm a test by submitting it
+// again, unchanged, with Token as its confirmation token before ExpiresAt.
+type ConfirmationError struct {
+	Token     string    `json:"confirmationToken"`
+	Reasons   []string  `json:"reasons"` // Thresholds the test exceeds
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (e *ConfirmationError) Error() string {
+	return fmt.Sprintf("%v: %s; submit it again with confirmation_token %q before %s to run it",
+		ErrConfirmationRequired, strings.Join(e.Reasons, "; "), e.Token, e.ExpiresAt.Format(time.RFC3339))
+}
+
+func (e *ConfirmationError) Unwrap() error {
+	return ErrConfirmationRequired
+}
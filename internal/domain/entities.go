@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"errors"
+	"io"
+	"math"
 	"time"
 )
 
@@ -17,6 +20,9 @@ type User struct {
 	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
 	LastLoginAt *time.Time `json:"lastLoginAt" db:"last_login_at"`
+
+	MustChangePassword bool       `json:"mustChangePassword" db:"must_change_password"`
+	PasswordChangedAt  *time.Time `json:"passwordChangedAt" db:"password_changed_at"` // nil until first changed
 }
 
 // UserProfile represents user profile information (without sensitive data)
@@ -31,6 +37,10 @@ type UserProfile struct {
 	CreatedAt   time.Time  `json:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt"`
 	LastLoginAt *time.Time `json:"lastLoginAt"`
+
+	// MustChangePassword is set until the user changes a password that was
+	// set for them or has expired; until then only the password can be changed.
+	MustChangePassword bool `json:"mustChangePassword"`
 }
 
 // AuthResponse represents authentication response
@@ -48,6 +58,8 @@ type CreateUserRequest struct {
 	FirstName string `json:"firstName" validate:"required,min=1,max=100"`
 	LastName  string `json:"lastName" validate:"required,min=1,max=100"`
 	Role      string `json:"role" validate:"required,oneof=admin user"`
+
+	MustChangePassword bool `json:"mustChangePassword"` // Make the user choose their own password at first login
 }
 
 // UpdateUserRequest represents request to update user information
@@ -70,23 +82,72 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// RateDistributions lists the ways a test's rate can be distributed among
+// its workers.
+var RateDistributions = []string{"shared", "same", "weighted", "ramped", "burst"}
+
 // TestRequest represents a user-submitted load test configuration.
 type TestRequest struct {
-	ID                 string    `json:"id"`
-	Name               string    `json:"name"`
-	VegetaPayloadJSON  string    `json:"vegetaPayloadJson"` // Raw JSON for Vegeta attack options
-	DurationSeconds    string    `json:"durationSeconds"`   // e.g., "10s"
-	RatePerSecond      uint64    `json:"ratePerSecond"`     // e.g., 50 for 50 req/s
-	TargetsBase64      string    `json:"targetsBase64"`     // Base64 encoded targets content
-	RequesterID        string    `json:"requesterId"`
-	WorkerCount        uint32    `json:"workerCount"`           // Number of workers to use for this test
-	RateDistribution   string    `json:"rateDistribution"`      // "shared", "same", "weighted", "ramped", or "burst" - how to distribute rate among workers
-	RateWeights        []float64 `json:"rateWeights,omitempty"` // For "weighted" distribution: weight for each worker (optional)
-	CreatedAt          time.Time `json:"createdAt"`
-	Status             string    `json:"status"` // e.g., "PENDING", "RUNNING", "COMPLETED", "FAILED"
-	AssignedWorkersIDs []string  `json:"assignedWorkersIds"`
-	CompletedWorkers   []string  `json:"completedWorkers"`
-	FailedWorkers      []string  `json:"failedWorkers"`
+	ID                 string        `json:"id"`
+	Name               string        `json:"name"`
+	VegetaPayloadJSON  string        `json:"vegetaPayloadJson"` // Raw JSON for Vegeta attack options
+	DurationSeconds    string        `json:"durationSeconds"`   // e.g., "10s"
+	RatePerSecond      uint64        `json:"ratePerSecond"`     // e.g., 50 for 50 req/s
+	TargetsBase64      string        `json:"targetsBase64"`     // Base64 encoded targets content
+	RequesterID        string        `json:"requesterId"`
+	WorkerCount        uint32        `json:"workerCount"`                // Number of workers to use for this test
+	RateDistribution   string        `json:"rateDistribution"`           // "shared", "same", "weighted", "ramped", or "burst" - how to distribute rate among workers
+	RateWeights        []float64     `json:"rateWeights,omitempty"`      // For "weighted" distribution: weight for each worker (optional)
+	ThinkTime          string        `json:"thinkTime,omitempty"`        // Pause between requests: fixed ("200ms") or range ("100ms-500ms")
+	PacingJitter       float64       `json:"pacingJitter,omitempty"`     // Random pacer jitter as a fraction of the request interval (0.0-1.0)
+	InjectRequestID    bool          `json:"injectRequestId,omitempty"`  // Add an X-Request-ID header to every generated request
+	RequestIDPrefix    string        `json:"requestIdPrefix,omitempty"`  // Prefix of the injected X-Request-ID values (the test ID)
+	TemplateTargets    bool          `json:"templateTargets,omitempty"`  // Expand {{seq}}, {{uuid}} and {{timestamp}} placeholders in targets
+	SequenceStart      uint64        `json:"sequenceStart,omitempty"`    // First {{seq}} value; ranges are partitioned across workers from here
+	ScenarioJSON       string        `json:"scenarioJson,omitempty"`     // Multi-step scenario definition; replaces targets when set
+	Script             string        `json:"script,omitempty"`           // Lua program run by the ExecutorLua executor; replaces targets when set
+	GraphQLJSON        string        `json:"graphqlJson,omitempty"`      // GraphQL endpoint and operations; expanded into TargetsBase64 on submit
+	Preflight          bool          `json:"preflight,omitempty"`        // Probe every target once before the attack and fail fast if any is unhealthy
+	InterimInterval    string        `json:"interimInterval,omitempty"`  // How often workers report their results so far, e.g. "5m"; empty = only at the end
+	Priority           string        `json:"priority"`                   // Scheduling priority: PriorityHigh, PriorityNormal or PriorityLow
+	PartialPolicy      string        `json:"partialPolicy"`              // What to do when fewer workers than WorkerCount are available; see PartialPolicies
+	OnWorkerFailure    string        `json:"onWorkerFailure"`            // What to do when a worker is lost mid-test; see WorkerFailurePolicies
+	OverrideGuardrails bool          `json:"overrideGuardrails"`         // Start regardless of the concurrent test limit and target cooldown; set by admins only
+	ConfirmationToken  string        `json:"-"`                          // Token confirming a test above the confirmation thresholds; not stored
+	Smoke              bool          `json:"smoke,omitempty"`            // Run at 1 RPS for 10 seconds on one worker and report each target's responses
+	CaptureRequests    bool          `json:"captureRequests,omitempty"`  // Keep every request for download in vegeta's JSON format
+	Protocol           string        `json:"protocol,omitempty"`         // Executor that runs the test, e.g. ExecutorGRPC; empty = ExecutorVegetaHTTP
+	TargetBuild        string        `json:"targetBuild,omitempty"`      // Git SHA or build of the system under test, as given by the submitter
+	Tags               []string      `json:"tags,omitempty"`             // Labels for finding the test in listings; see ValidateTags
+	Regions            []RegionShare `json:"regions,omitempty"`          // Split of the test across worker regions; nil = any workers
+	ProbeResults       []ProbeResult `json:"probeResults,omitempty"`     // Results of a failed pre-flight check
+	TargetURLs         []string      `json:"-"`                          // Target URLs indexed for search; set on submit
+	DistributionPlan   []WorkerRate  `json:"distributionPlan,omitempty"` // Rate given to each worker when the test was assigned
+	MissingResults     []string      `json:"missingResults,omitempty"`   // Workers that finished the test but whose result never arrived
+	CapacityWarning    string        `json:"capacityWarning,omitempty"`  // Set on submit when the rate exceeded the fleet's capacity: the shortfall, or how the worker count was raised
+	CreatedAt          time.Time     `json:"createdAt"`
+	Status             string        `json:"status"` // e.g., "PENDING", "RUNNING", "COMPLETED", "FAILED"
+	AssignedWorkersIDs []string      `json:"assignedWorkersIds"`
+	CompletedWorkers   []string      `json:"completedWorkers"`
+	FailedWorkers      []string      `json:"failedWorkers"`
+
+	SmokeReport  []TargetResponse  `json:"smokeReport,omitempty"`  // Responses of each target in a smoke run
+	LabelHeaders map[string]string `json:"labelHeaders,omitempty"` // Headers added to every request, e.g. "X-Load-Test-Id": LabelTestID
+	Apdex        *ApdexConfig      `json:"apdex,omitempty"`        // Apdex thresholds; nil = the master's default threshold
+
+	MaxWaitForWorkers string `json:"maxWaitForWorkers,omitempty"` // How long to wait for free workers before PartialPolicy applies, e.g. "10m"; empty = the master's default
+}
+
+// WorkerRate is one worker's share of a test in its distribution plan.
+type WorkerRate struct {
+	WorkerID      string `json:"workerId"`
+	RatePerSecond uint64 `json:"ratePerSecond"`
+	SequenceStart uint64 `json:"sequenceStart,omitempty"` // {{seq}} range of templated tests
+	SequenceEnd   uint64 `json:"sequenceEnd,omitempty"`
+	Accepted      bool   `json:"accepted"`          // Whether the worker accepted the assignment
+	MaxRate       uint64 `json:"maxRate,omitempty"` // Worker's calibrated maximum rate when assigned
+	SpareOf       string `json:"spareOf,omitempty"` // Worker whose share this spare took over
+	Region        string `json:"region,omitempty"`  // Worker's region in multi-region tests
 }
 
 // TestResult represents the aggregated result of a single worker's test run.
@@ -103,6 +164,27 @@ type TestResult struct {
 	AverageLatencyMs  float64        `json:"averageLatencyMs"`
 	P95LatencyMs      float64        `json:"p95LatencyMs"`
 	StatusCodes       map[string]int `json:"statusCodes"` // Map of status code counts
+	// Explicit outcome counts; zero on results stored before they were recorded
+	SuccessfulRequests int64 `json:"successfulRequests"`
+	FailedRequests     int64 `json:"failedRequests"`
+
+	ResourceUsage   *ResourceUsage   `json:"resourceUsage,omitempty"`   // The worker's own resource use; nil if it was not sampled
+	TargetResponses []TargetResponse `json:"targetResponses,omitempty"` // Responses of each target; set by smoke runs only
+	Region          string           `json:"region,omitempty"`          // Worker's region in multi-region tests; set by the master
+
+	GeneratorOverhead *GeneratorOverhead `json:"generatorOverhead,omitempty"` // The worker's own setup time and scheduling drift; nil if not measured
+	Environment       *RunEnvironment    `json:"environment,omitempty"`       // What the worker ran the test with; nil on results stored before it was recorded
+	Apdex             *Apdex             `json:"apdex,omitempty"`             // The worker's requests scored against the test's thresholds; nil if it didn't score them
+}
+
+// SuccessCounts returns the result's successful and failed request counts.
+// Older results only carry a success rate, so their counts are derived from it.
+func (r *TestResult) SuccessCounts() (successful, failed int64) {
+	if r.SuccessfulRequests+r.FailedRequests > 0 || r.TotalRequests == 0 {
+		return r.SuccessfulRequests, r.FailedRequests
+	}
+	successful = int64(math.Round(r.SuccessRate * float64(r.TotalRequests)))
+	return successful, r.TotalRequests - successful
 }
 
 // Worker represents a registered load testing worker.
@@ -115,8 +197,33 @@ type Worker struct {
 	LastProgressMessage string    `json:"lastProgressMessage"` // Last progress message from worker
 	CompletedRequests   int64     `json:"completedRequests"`
 	TotalRequests       int64     `json:"totalRequests"`
+	MaxRate             uint64    `json:"maxRate"` // Calibrated maximum req/s the worker can generate; 0 = not calibrated
+	Region              string    `json:"region"`  // Where the worker runs, e.g. "eu-west"; empty = no region
+	WorkerCapabilities
+}
+
+// WorkerDetail is a worker as shown to admins, with the master's view of it.
+type WorkerDetail struct {
+	Worker
+	ConnectionState   string `json:"connectionState"`   // Master's gRPC connection to the worker, as in WorkerSummary
+	Draining          bool   `json:"draining"`          // A rollout keeps it from taking new tests
+	HeartbeatInterval string `json:"heartbeatInterval"` // Interval negotiated at registration
+	MissedHeartbeats  int    `json:"missedHeartbeats"`  // Heartbeats missed since the worker was last heard from
+	ClockOffset       string `json:"clockOffset"`       // Master's clock minus the worker's, as the worker measured it; empty until measured
+}
+
+// WorkerPing is a worker's answer to a ping from the master.
+type WorkerPing struct {
+	WorkerID      string  `json:"workerId"`
+	Version       string  `json:"version"`
+	CurrentTestID string  `json:"currentTestId"` // Empty while the worker is idle
+	LatencyMs     float64 `json:"latencyMs"`     // Round trip of the ping
 }
 
+// ErrExceedsFleetCapacity is returned when a test asks for more than the
+// registered workers can generate and the master refuses such tests.
+var ErrExceedsFleetCapacity = errors.New("requested rate exceeds fleet capacity")
+
 // DashboardStatus provides a summary for the UI dashboard.
 type DashboardStatus struct {
 	TotalWorkers     uint32              `json:"total_workers"`
@@ -124,6 +231,7 @@ type DashboardStatus struct {
 	BusyWorkers      uint32              `json:"busy_workers"`
 	ActiveTests      []ActiveTestSummary `json:"active_tests"`
 	WorkerSummaries  []WorkerSummary     `json:"worker_summaries"`
+	WorkerVersions   map[string]int      `json:"worker_versions"` // Number of workers not offline per version, to follow fleet upgrades
 }
 
 // ActiveTestSummary provides a summary of an ongoing or recently completed test.
@@ -148,6 +256,11 @@ type WorkerSummary struct {
 	CurrentTestID     string `json:"current_test_id"`
 	CompletedRequests int64  `json:"completed_requests"`
 	TotalRequests     int64  `json:"total_requests"`
+	MaxRate           uint64 `json:"max_rate"`         // Calibrated maximum req/s; 0 = not calibrated
+	ConnectionState   string `json:"connection_state"` // Master's gRPC connection to the worker: READY, CONNECTING, TRANSIENT_FAILURE, IDLE or DISCONNECTED
+	Version           string `json:"version"`          // Empty for workers that predate version reporting
+	Draining          bool   `json:"draining"`         // A rollout keeps it from taking new tests
+	Region            string `json:"region"`           // Empty for workers started without --region
 }
 
 // TestResultAggregated represents a high-level aggregated view of a test result, for dashboard/reports
@@ -158,10 +271,20 @@ type TestResultAggregated struct {
 	FailedRequests     int64          `json:"failed_requests"`
 	AvgLatencyMs       float64        `json:"avg_latency_ms"`
 	P95LatencyMs       float64        `json:"p95_latency_ms"`
-	ErrorRates         map[string]int `json:"error_rates"` // Map of error types and counts
-	DurationMs         int64          `json:"duration_ms"`
+	ErrorRates         map[string]int `json:"error_rates"`    // Map of error types and counts
+	DurationMs         int64          `json:"duration_ms"`    // Wall-clock time from the first worker's start to the last worker's end
+	ThroughputRPS      float64        `json:"throughput_rps"` // Total requests per second of wall-clock duration
 	OverallStatus      string         `json:"overall_status"` // "Success", "Partial Failure", "Failure"
 	CompletedAt        time.Time      `json:"completed_at"`
+	Apdex              *Apdex         `json:"apdex,omitempty"` // Rolled up from the workers' scores; nil if none scored its requests
+
+	RateAttainment *RateAttainmentReport `json:"rate_attainment,omitempty"` // Requested vs achieved rate; nil for results aggregated before it was recorded
+
+	Capacity         *CapacityEstimate `json:"capacity,omitempty"`          // Computed for ramped tests when served, not stored
+	ResourceWarnings []string          `json:"resource_warnings,omitempty"` // Workers that were saturated, from their results when served
+	Regions          []RegionBreakdown `json:"regions,omitempty"`           // Results by worker region for multi-region tests, when served
+	TargetBuild      string            `json:"target_build,omitempty"`      // Build of the system under test, from the test when served
+	Environments     []RunEnvironment  `json:"environments,omitempty"`      // Distinct environments of the workers' results, when served
 }
 
 type TestAssignment struct {
@@ -170,6 +293,30 @@ type TestAssignment struct {
 	DurationSeconds   string
 	RatePerSecond     uint64
 	TargetsBase64     string
+	ThinkTime         string
+	PacingJitter      float64
+	RequestIDPrefix   string // Empty disables X-Request-ID injection
+	TemplateTargets   bool
+	SequenceStart     uint64 // This worker's {{seq}} range is [SequenceStart, SequenceEnd)
+	SequenceEnd       uint64
+	ScenarioJSON      string // Multi-step scenario; executed instead of targets when set
+	Script            string // Lua program; executed instead of targets by the ExecutorLua executor
+	Preflight         bool   // Probe every target before the attack starts
+	Smoke             bool   // Collect the responses of each target into the result
+	InterimInterval   string // How often to pass the cumulative result to Interim (e.g., "5m"); empty = never
+	CaptureRequests   bool   // Encode every request into Capture
+	Protocol          string // Name of the executor that runs the assignment; empty = ExecutorVegetaHTTP
+
+	LabelHeaders map[string]string // Headers added to every request; the test's placeholders are filled in, LabelWorkerID by the worker
+	Apdex        *ApdexConfig      // Thresholds the requests are scored against; the threshold is always set by the master
+
+	StartAt time.Time // When to start the attack, on the master's clock; zero = at once
+
+	Progress *ProgressCounter `json:"-"` // Live request counts, updated by the executor when set
+	Recorder RequestRecorder  `json:"-"` // Receives every request when request records are enabled
+	Interim  InterimReporter  `json:"-"` // Receives the cumulative result every InterimInterval
+	Rates    <-chan uint64    `json:"-"` // Receives the new rate per second when the master changes it mid-test
+	Capture  io.Writer        `json:"-"` // Receives every request as a vegeta JSON line, one Write each, when CaptureRequests is set
 }
 
 // Analytics domain models
@@ -181,11 +328,39 @@ type AnalyticsOverview struct {
 	SuccessRate         float64          `json:"successRate"`
 	AverageResponseTime float64          `json:"averageResponseTime"`
 	MedianResponseTime  float64          `json:"medianResponseTime"`
+	P75ResponseTime     float64          `json:"p75ResponseTime"`
+	P90ResponseTime     float64          `json:"p90ResponseTime"`
 	P95ResponseTime     float64          `json:"p95ResponseTime"`
 	P99ResponseTime     float64          `json:"p99ResponseTime"`
+	P999ResponseTime    float64          `json:"p999ResponseTime"`
+	PercentileSource    string           `json:"percentileSource"` // "requests" (exact) or "timeseries" (estimated)
 	TopErrorCodes       []ErrorCodeStats `json:"topErrorCodes"`
 	TestsPerDay         []TestsByDay     `json:"testsPerDay"`
 	RequestsPerDay      []RequestsByDay  `json:"requestsPerDay"`
+
+	PerformancePerDay []PerformancePoint `json:"performancePerDay"` // Days with results only
+	Anomalies         []AnalyticsAnomaly `json:"anomalies"`         // Days whose latency or error rate jumped
+	Apdex             *AnalyticsApdex    `json:"apdex,omitempty"`   // Nil when no test has an Apdex score
+}
+
+// AnalyticsApdex rolls up the Apdex counts of the tests in range, each
+// classified against its own test's threshold.
+type AnalyticsApdex struct {
+	Tests int64 `json:"tests"` // Tests with an Apdex score
+	ApdexCounts
+	Score  float64 `json:"score"`
+	Rating string  `json:"rating"`
+}
+
+// AnalyticsAnomaly flags a day whose latency or error rate rose well above
+// the trend of the days before it.
+type AnalyticsAnomaly struct {
+	Date     string   `json:"date"`
+	Metric   string   `json:"metric"` // "latency" (ms) or "errorRate" (%)
+	Value    float64  `json:"value"`
+	Baseline float64  `json:"baseline"` // Moving average of the preceding days
+	ZScore   float64  `json:"zScore"`
+	TestIDs  []string `json:"testIds"` // Tests that day above the baseline, worst first
 }
 
 // TargetAnalytics provides analytics for specific targets/URLs
@@ -196,8 +371,12 @@ type TargetAnalytics struct {
 	SuccessRate         float64            `json:"successRate"`
 	AverageResponseTime float64            `json:"averageResponseTime"`
 	MedianResponseTime  float64            `json:"medianResponseTime"`
+	P75ResponseTime     float64            `json:"p75ResponseTime"`
+	P90ResponseTime     float64            `json:"p90ResponseTime"`
 	P95ResponseTime     float64            `json:"p95ResponseTime"`
 	P99ResponseTime     float64            `json:"p99ResponseTime"`
+	P999ResponseTime    float64            `json:"p999ResponseTime"`
+	PercentileSource    string             `json:"percentileSource"` // "requests" (exact) or "timeseries" (estimated)
 	ErrorBreakdown      []ErrorCodeStats   `json:"errorBreakdown"`
 	PerformanceTrend    []PerformancePoint `json:"performanceTrend"`
 }
@@ -241,6 +420,7 @@ type AnalyticsRequest struct {
 	TargetURL   string              `json:"targetUrl,omitempty"`
 	Granularity string              `json:"granularity,omitempty"` // "day", "hour", "week"
 	UserID      string              `json:"userId,omitempty"`
+	TargetBuild string              `json:"targetBuild,omitempty"` // Only tests against this build of the target
 }
 
 // SharedLink represents a link for sharing a test result between users.
@@ -251,5 +431,37 @@ type SharedLink struct {
 	CreatedAt time.Time `json:"createdAt" db:"created_at"`
 	ExpiresAt time.Time `json:"expiresAt" db:"expires_at"`
 	UsedBy    []string  `json:"usedBy" db:"used_by"` // User IDs who accessed this link
+	ReadBy    []string  `json:"-" db:"read_by"`      // User IDs who marked the link read in their inbox
 	IsExpired bool      `json:"isExpired" db:"-"`    // Computed, not stored
+
+	AccessCount    int64      `json:"accessCount" db:"access_count"` // Times the link was opened, counting repeat visits
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty" db:"last_accessed_at"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty" db:"revoked_at"` // Revoked links can no longer be opened
+	TeamID         string     `json:"teamId,omitempty" db:"team_id"`       // Team whose members received the link
+
+	// Public links can also be opened without an account, read-only, with
+	// the signed PublicToken instead of the ID.
+	Public      bool   `json:"public" db:"public"`
+	PublicToken string `json:"publicToken,omitempty" db:"-"` // Computed, not stored
+}
+
+// SharedTestView is what the recipient of a shared link sees: the test and
+// its aggregated result as it was when the link was created, so later
+// re-aggregation does not change what they are shown.
+type SharedTestView struct {
+	*TestRequest
+	Result     *TestResultAggregated `json:"result,omitempty"`     // nil until the test has a result
+	SnapshotAt *time.Time            `json:"snapshotAt,omitempty"` // When Result was captured; nil if the test had no result then and Result is the current one
+}
+
+// Attachment is a file uploaded separately and referenced by targets as a
+// request body or multipart file. Its content is fetched by workers at run time.
+type Attachment struct {
+	ID          string    `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	ContentType string    `json:"contentType" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	SHA256      string    `json:"sha256" db:"sha256"`
+	UploadedBy  string    `json:"uploadedBy" db:"uploaded_by"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
 }
@@ -0,0 +1,29 @@
+package domain
+
+import "runtime"
+
+// MaxTargetBuildLength caps the length of a test's TargetBuild.
+const MaxTargetBuildLength = 128
+
+// RunEnvironment is what a worker produced a result with, so results can be
+// audited and compared across worker and library upgrades.
+type RunEnvironment struct {
+	WorkerVersion string `json:"workerVersion"`
+	Executor      string `json:"executor"`                // Executor that ran the test, e.g. ExecutorVegetaHTTP
+	VegetaVersion string `json:"vegetaVersion,omitempty"` // Version of the vegeta library the worker was built with
+	GoVersion     string `json:"goVersion"`
+	OS            string `json:"os"`
+	Arch          string `json:"arch"`
+}
+
+// NewRunEnvironment describes the running worker, built with the given
+// version of vegeta. Executor is left for the caller to set per test.
+func NewRunEnvironment(workerVersion, vegetaVersion string) RunEnvironment {
+	return RunEnvironment{
+		WorkerVersion: workerVersion,
+		VegetaVersion: vegetaVersion,
+		GoVersion:     runtime.Version(),
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Event types published on the master's event bus.
+const (
+	EventTestSubmitted  = "test.submitted"
+	EventTestAssigned   = "test.assigned"
+	EventTestUpdated    = "test.updated"   // A worker finished, or the test was approved or rejected
+	EventTestCompleted  = "test.completed" // Any final status, including FAILED and aborted tests
+	EventWorkerOffline  = "worker.offline"
+	EventSLABreached    = "sla.breached"        // A test exceeded its error budget
+	EventResultMissing  = "test.result_missing" // A worker finished a test but its result never arrived
+	EventTestMetrics    = "test.metrics"        // A worker reported progress; about once a second per worker
+	EventTestAggregated = "test.aggregated"     // The aggregated result of a test was (re)computed
+	EventTestInterim    = "test.interim"        // A worker flushed an interim result of a running test
+	EventInboxUpdated   = "inbox.updated"       // A link was shared into, or read in, the inboxes of UserIDs
+)
+
+// Event describes something that happened to a test or worker. Subscribers
+// receive events in the order they were published.
+type Event struct {
+	Type     string          `json:"type"`
+	TestID   string          `json:"testId,omitempty"`
+	WorkerID string          `json:"workerId,omitempty"`
+	Status   string          `json:"status,omitempty"` // Test status after the event
+	Message  string          `json:"message,omitempty"`
+	Time     time.Time       `json:"time"`
+	Test     *TestRequest    `json:"-"`                  // The submitted test, on test.submitted
+	Progress *WorkerProgress `json:"progress,omitempty"` // The worker's latest report, on test.metrics
+	UserIDs  []string        `json:"-"`                  // The users whose inbox changed, on inbox.updated
+}
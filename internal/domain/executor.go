@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// Names of the executors a test's Protocol can select. Workers may register
+// further custom executors under their own names.
+const (
+	ExecutorVegetaHTTP = "vegeta-http" // HTTP(S) attacks and scenarios through vegeta; the default
+	ExecutorGRPC       = "grpc"
+	ExecutorWebSocket  = "websocket"
+)
+
+// executorNamePattern is what an executor name may look like.
+var executorNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+// ValidateExecutorName checks that name can name an executor.
+func ValidateExecutorName(name string) error {
+	if !executorNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid protocol %q: must be lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// ExecutorName returns the executor a test's protocol selects.
+func ExecutorName(protocol string) string {
+	if protocol == "" {
+		return ExecutorVegetaHTTP
+	}
+	return protocol
+}
+
+// ExecutorRegistry holds the executors a worker can run, by name.
+type ExecutorRegistry struct {
+	mu        sync.RWMutex
+	executors map[string]Executor
+}
+
+// NewExecutorRegistry creates an empty registry.
+func NewExecutorRegistry() *ExecutorRegistry {
+	return &ExecutorRegistry{executors: make(map[string]Executor)}
+}
+
+// Register makes executor run the tests whose protocol is name, replacing
+// any executor already registered under it.
+func (r *ExecutorRegistry) Register(name string, executor Executor) error {
+	if err := ValidateExecutorName(name); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executors[name] = executor
+	return nil
+}
+
+// Get returns the executor registered for protocol; an empty protocol selects
+// ExecutorVegetaHTTP.
+func (r *ExecutorRegistry) Get(protocol string) (Executor, error) {
+	name := ExecutorName(protocol)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	executor, ok := r.executors[name]
+	if !ok {
+		return nil, fmt.Errorf("no executor registered for protocol %q", name)
+	}
+	return executor, nil
+}
+
+// Names lists the registered executors in order.
+func (r *ExecutorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.executors))
+	for name := range r.executors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// GraphQLTarget describes a GraphQL endpoint and the operations to send to
+// it. Each operation becomes one vegeta target with a JSON POST body.
+type GraphQLTarget struct {
+	URL        string              `json:"url"`
+	Header     map[string][]string `json:"header,omitempty"`
+	Operations []GraphQLOperation  `json:"operations"`
+}
+
+// GraphQLOperation is a single query or mutation. OperationName defaults to
+// the name declared in the query document.
+type GraphQLOperation struct {
+	OperationName string                 `json:"operationName,omitempty"`
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+var graphQLOperationName = regexp.MustCompile(`^\s*(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// ParseGraphQLTarget decodes and validates a GraphQL target definition.
+func ParseGraphQLTarget(graphQLJSON string) (*GraphQLTarget, error) {
+	var target GraphQLTarget
+	dec := json.NewDecoder(strings.NewReader(graphQLJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&target); err != nil {
+		return nil, fmt.Errorf("invalid graphql JSON: %w", err)
+	}
+	if target.URL == "" {
+		return nil, fmt.Errorf("invalid graphql target: url is required")
+	}
+	if len(target.Operations) == 0 {
+		return nil, fmt.Errorf("invalid graphql target: at least one operation is required")
+	}
+	for i := range target.Operations {
+		op := &target.Operations[i]
+		if strings.TrimSpace(op.Query) == "" {
+			return nil, fmt.Errorf("invalid graphql target: operation %d has no query", i+1)
+		}
+		if op.OperationName == "" {
+			if m := graphQLOperationName.FindStringSubmatch(op.Query); m != nil {
+				op.OperationName = m[1]
+			}
+		}
+	}
+	return &target, nil
+}
+
+// TargetsBase64 renders the operations as base64-encoded vegeta JSON targets,
+// the same format accepted in a test's targets_base64.
+func (g *GraphQLTarget) TargetsBase64() (string, error) {
+	type vegetaTarget struct {
+		Method string              `json:"method"`
+		URL    string              `json:"url"`
+		Body   []byte              `json:"body"` // Encoded as base64, as vegeta expects
+		Header map[string][]string `json:"header"`
+	}
+
+	header := make(map[string][]string, len(g.Header)+1)
+	for k, v := range g.Header {
+		header[k] = v
+	}
+	if _, ok := http.Header(header)["Content-Type"]; !ok {
+		header["Content-Type"] = []string{"application/json"}
+	}
+
+	targets := make([]vegetaTarget, 0, len(g.Operations))
+	for _, op := range g.Operations {
+		body, err := json.Marshal(op)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode graphql operation %q: %w", op.OperationName, err)
+		}
+		targets = append(targets, vegetaTarget{Method: http.MethodPost, URL: g.URL, Body: body, Header: header})
+	}
+
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode graphql targets: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// GraphQLOperationName returns the operation name of a GraphQL request body,
+// or "" if the body is not a GraphQL request.
+func GraphQLOperationName(body []byte) string {
+	var op GraphQLOperation
+	if err := json.Unmarshal(body, &op); err != nil || op.Query == "" {
+		return ""
+	}
+	if op.OperationName != "" {
+		return op.OperationName
+	}
+	if m := graphQLOperationName.FindStringSubmatch(op.Query); m != nil {
+		return m[1]
+	}
+	return "anonymous"
+}
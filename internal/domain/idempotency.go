@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxIdempotencyKeyLength caps the Idempotency-Key a client may send.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey records the test a user's submission with a given
+// Idempotency-Key created, so retries of it return that test.
+type IdempotencyKey struct {
+	UserID    string
+	Key       string
+	TestID    string
+	CreatedAt time.Time
+}
+
+// IdempotencyRepository stores the idempotency keys of recent submissions.
+type IdempotencyRepository interface {
+	// GetIdempotencyKey returns the user's key if it was saved after since, or nil.
+	GetIdempotencyKey(ctx context.Context, userID, key string, since time.Time) (*IdempotencyKey, error)
+	// SaveIdempotencyKey stores a key, replacing an older one from the same user,
+	// and deletes every key created before expiredBefore.
+	SaveIdempotencyKey(ctx context.Context, key *IdempotencyKey, expiredBefore time.Time) error
+}
@@ -0,0 +1,31 @@
+package domain
+
+// InboxItem is a shared link in a user's inbox, as that user sees it.
+type InboxItem struct {
+	*SharedLink
+	Read         bool   `json:"read"`                   // The user marked the item read
+	SharedByName string `json:"sharedByName,omitempty"` // Display name of the sender; empty if they no longer exist
+}
+
+// InboxSummary is what a notification badge shows of a user's inbox.
+type InboxSummary struct {
+	Total  int          `json:"total"`
+	Unread int          `json:"unread"` // Unread items whose links have not expired
+	Latest []*InboxItem `json:"latest"` // The newest items, newest first
+}
+
+// DisplayName is the name shown for a user: their full name, or their
+// username if they have not given one.
+func (u *User) DisplayName() string {
+	name := u.FirstName
+	if u.LastName != "" {
+		if name != "" {
+			name += " "
+		}
+		name += u.LastName
+	}
+	if name == "" {
+		return u.Username
+	}
+	return name
+}
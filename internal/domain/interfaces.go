@@ -12,8 +12,14 @@ type UserRepository interface {
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	UpdateUser(ctx context.Context, userID string, updates *UpdateUserRequest) (*User, error)
-	UpdateUserPassword(ctx context.Context, userID string, hashedPassword string) error
+	// UpdateUserPassword also records the replaced hash in the password
+	// history and sets whether the user must change the new password.
+	UpdateUserPassword(ctx context.Context, userID string, hashedPassword string, mustChange bool) error
+	// GetPasswordHistory returns up to limit replaced password hashes, newest first.
+	GetPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error)
+	SetMustChangePassword(ctx context.Context, userID string, mustChange bool) error
 	GetAllUsers(ctx context.Context) ([]*User, error)
+	SearchUsers(ctx context.Context, query string, limit int) ([]*User, error)
 	ActivateUser(ctx context.Context, userID string) error
 	DeactivateUser(ctx context.Context, userID string) error
 	UpdateLastLogin(ctx context.Context, userID string) error
@@ -27,29 +33,40 @@ type WorkerRepository interface {
 	GetAvailableWorkers(ctx context.Context) ([]*Worker, error)
 	GetAllWorkers(ctx context.Context) ([]*Worker, error)
 	MarkWorkerOffline(ctx context.Context, workerID string) error
+	DeleteWorker(ctx context.Context, workerID string) error
 }
 
 // TestRepository defines operations for managing test requests and their states.
 type TestRepository interface {
 	SaveTestRequest(ctx context.Context, test *TestRequest) error
+	// UpdateTestStatus merges the given workers into the stored lists rather than
+	// replacing them, and returns ErrInvalidStatusTransition for disallowed moves.
 	UpdateTestStatus(ctx context.Context, testID string, status string, completedWorkers, failedWorkers []string) error
 	GetTestRequestByID(ctx context.Context, testID string) (*TestRequest, error)
 	GetAllTestRequests(ctx context.Context) ([]*TestRequest, error)
 	GetTestRequestsPaginated(ctx context.Context, limit, offset int) ([]*TestRequest, int, error)
 	GetTestsInRange(ctx context.Context, startDate, endDate time.Time) ([]*TestRequest, error)
 	GetTestRequestsByUser(ctx context.Context, userID string) ([]*TestRequest, error)
-	GetTestRequestsPaginatedByUser(ctx context.Context, userID string, limit, offset int) ([]*TestRequest, int, error)
+	SearchTests(ctx context.Context, query, userID string, limit int) ([]*TestRequest, error) // Empty userID searches all users' tests
+	ListTests(ctx context.Context, filter TestListFilter) ([]*TestRequest, int, error)        // Returns a page of tests and how many match in total
 	GetTestsInRangeByUser(ctx context.Context, userID string, startDate, endDate time.Time) ([]*TestRequest, error)
 	// Add paginated per-user test history
 	IncrementTestAssignedWorkers(ctx context.Context, testID string, workerID string) error
 	AddCompletedWorkerToTest(ctx context.Context, testID string, workerID string) error
 	AddFailedWorkerToTest(ctx context.Context, testID string, workerID string) error
+	SaveProbeResults(ctx context.Context, testID string, results []ProbeResult) error
+	SaveSmokeReport(ctx context.Context, testID string, report []TargetResponse) error
+	SaveDistributionPlan(ctx context.Context, testID string, plan []WorkerRate) error
+	SetMissingResults(ctx context.Context, testID string, workerIDs []string) error
+	SetTestPriority(ctx context.Context, testID string, priority string) error
+	SetTestRequester(ctx context.Context, testID string, requesterID string) error
 }
 
 // TestResultRepository defines operations for storing and retrieving raw test results.
 type TestResultRepository interface {
 	SaveTestResult(ctx context.Context, result *TestResult) error
 	GetResultsByTestID(ctx context.Context, testID string) ([]*TestResult, error)
+	GetResultsPage(ctx context.Context, testID string, page ResultPage) ([]*TestResult, int, error) // Returns a page of results and how many the test has
 	DeleteResultsByTestID(ctx context.Context, testID string) error
 }
 
@@ -61,9 +78,15 @@ type AggregatedResultRepository interface {
 	GetAllAggregatedResults(ctx context.Context) ([]*TestResultAggregated, error)
 }
 
-// VegetaExecutor defines operations for executing Vegeta load tests.
-type VegetaExecutor interface {
-	Attack(ctx context.Context, vegetaPayloadJSON, durationStr string, rate uint64, targetsBase64 string) (*TestResult, error)
+// Executor runs test assignments with one protocol engine. Executors are
+// registered by name in an ExecutorRegistry and picked by the test's Protocol.
+type Executor interface {
+	Execute(ctx context.Context, assignment *TestAssignment) (*TestResult, error)
+}
+
+// TargetProber defines operations for checking targets before an attack.
+type TargetProber interface {
+	Probe(ctx context.Context, assignment *TestAssignment) ([]ProbeResult, error)
 }
 
 // SharedLinkRepository defines operations for managing shared test links.
@@ -73,4 +96,27 @@ type SharedLinkRepository interface {
 	AddUsedBy(ctx context.Context, linkID, userID string) error
 	GetInboxForUser(ctx context.Context, userID string) ([]*SharedLink, error)
 	MarkInboxItemRead(ctx context.Context, linkID, userID string) error
+	// GetSharedLinksBySharer lists the links a user created, newest first, including revoked ones.
+	GetSharedLinksBySharer(ctx context.Context, userID string) ([]*SharedLink, error)
+	RecordSharedLinkAccess(ctx context.Context, linkID string) error
+	RevokeSharedLink(ctx context.Context, linkID string) error
+	// SetSharedLinkTeam records the team a link was shared with.
+	SetSharedLinkTeam(ctx context.Context, linkID, teamID string) error
+	// SetSharedLinkPublic lets a link be opened without logging in.
+	SetSharedLinkPublic(ctx context.Context, linkID string) error
+	// SetSharedLinkSnapshot stores the result a link's recipients are shown,
+	// as it was when the link was created.
+	SetSharedLinkSnapshot(ctx context.Context, linkID string, result *TestResultAggregated) error
+	// GetSharedLinkSnapshot returns the result stored with a link, or nil if
+	// it has none.
+	GetSharedLinkSnapshot(ctx context.Context, linkID string) (*TestResultAggregated, error)
+}
+
+// AttachmentRepository defines operations for storing uploaded request bodies.
+type AttachmentRepository interface {
+	SaveAttachment(ctx context.Context, attachment *Attachment, data []byte) error
+	GetAttachment(ctx context.Context, attachmentID string) (*Attachment, error)
+	GetAttachmentData(ctx context.Context, attachmentID string) ([]byte, error)
+	GetAttachmentsByUser(ctx context.Context, userID string) ([]*Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Invite lets someone sign up with an email address and role chosen by an
+// admin, setting their own username and password. The signup link carries a
+// one-time token of which only a hash is stored.
+type Invite struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	TokenHash  string     `json:"-"`
+	InvitedBy  string     `json:"invitedBy"` // User ID of the admin
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	SentAt     time.Time  `json:"sentAt"`               // Last time the link was issued
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"` // Set once the invite is used
+	UserID     string     `json:"userId,omitempty"`     // User who signed up with it
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Usable reports whether the invite can still be used to sign up at now.
+func (i *Invite) Usable(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// CreateInviteRequest is an admin's invitation of a new user.
+type CreateInviteRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // "user" if empty
+}
+
+// SignupRequest accepts an invite.
+type SignupRequest struct {
+	Token     string `json:"token"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// InviteRepository stores user invitations.
+type InviteRepository interface {
+	CreateInvite(ctx context.Context, invite *Invite) error
+	// GetInvite returns the invite with id, or nil if there is none.
+	GetInvite(ctx context.Context, id string) (*Invite, error)
+	// GetInviteByTokenHash returns the invite whose current token has hash, or nil if there is none.
+	GetInviteByTokenHash(ctx context.Context, tokenHash string) (*Invite, error)
+	// GetInvites lists every invite, newest first.
+	GetInvites(ctx context.Context) ([]*Invite, error)
+	// ReissueInvite replaces the token of an invite that has not been used.
+	ReissueInvite(ctx context.Context, id, tokenHash string, sentAt, expiresAt time.Time) error
+	// AcceptInvite marks an unused invite as used by userID, failing if it was already used.
+	AcceptInvite(ctx context.Context, id, userID string, acceptedAt time.Time) error
+	RevokeInvite(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// Mailer sends plain text email.
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+}
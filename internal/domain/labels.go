@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Placeholders a label header's value may contain. The master fills in the
+// test's and each worker its own.
+const (
+	LabelTestID   = "{{test_id}}"
+	LabelTestName = "{{test_name}}"
+	LabelWorkerID = "{{worker_id}}"
+)
+
+// Limits on a test's label headers.
+const (
+	MaxLabelHeaders     = 16
+	MaxLabelValueLength = 256
+)
+
+var (
+	// headerNamePattern is an HTTP header field name (an RFC 9110 token).
+	headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// labelPlaceholderPattern finds the placeholders in a label value.
+	labelPlaceholderPattern = regexp.MustCompile(`{{[^{}]*}}`)
+	// reservedLabelHeaders are set by the HTTP client and cannot be labels.
+	reservedLabelHeaders = []string{"Host", "Content-Length", "Transfer-Encoding", "Connection"}
+)
+
+// ValidateLabelHeaders checks a test's label headers: names must be valid
+// and not set by the HTTP client, and values may only hold known placeholders.
+func ValidateLabelHeaders(headers map[string]string) error {
+	if len(headers) > MaxLabelHeaders {
+		return fmt.Errorf("at most %d label headers are allowed, got %d", MaxLabelHeaders, len(headers))
+	}
+	seen := make(map[string]bool, len(headers))
+	for name, value := range headers {
+		if !headerNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid label header name %q", name)
+		}
+		canonical := http.CanonicalHeaderKey(name)
+		if slices.Contains(reservedLabelHeaders, canonical) {
+			return fmt.Errorf("label header %q is set by the HTTP client and cannot be overridden", name)
+		}
+		if seen[canonical] {
+			return fmt.Errorf("label header %q is given more than once", canonical)
+		}
+		seen[canonical] = true
+		if len(value) > MaxLabelValueLength {
+			return fmt.Errorf("label header %q: value exceeds %d characters", name, MaxLabelValueLength)
+		}
+		if strings.ContainsFunc(value, isControl) {
+			return fmt.Errorf("label header %q: value must not contain control characters", name)
+		}
+		for _, placeholder := range labelPlaceholderPattern.FindAllString(value, -1) {
+			if placeholder != LabelTestID && placeholder != LabelTestName && placeholder != LabelWorkerID {
+				return fmt.Errorf("label header %q: unknown placeholder %s (use %s, %s or %s)",
+					name, placeholder, LabelTestID, LabelTestName, LabelWorkerID)
+			}
+		}
+	}
+	return nil
+}
+
+// ExpandLabelHeaders returns a copy of headers with each placeholder in
+// oldnew, given as placeholder and value pairs, replaced by its value.
+// Control characters are dropped from the values so the headers stay valid.
+func ExpandLabelHeaders(headers map[string]string, oldnew ...string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	pairs := make([]string, len(oldnew))
+	for i, s := range oldnew {
+		if i%2 == 1 {
+			s = strings.Map(func(r rune) rune {
+				if isControl(r) {
+					return -1
+				}
+				return r
+			}, s)
+		}
+		pairs[i] = s
+	}
+	replacer := strings.NewReplacer(pairs...)
+	expanded := make(map[string]string, len(headers))
+	for name, value := range headers {
+		expanded[name] = replacer.Replace(value)
+	}
+	return expanded
+}
+
+// isControl reports whether r is a control character, which header values
+// may not hold.
+func isControl(r rune) bool {
+	return r < 0x20 && r != '\t' || r == 0x7f
+}
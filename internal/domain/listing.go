@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Limits on a test's tags.
+const (
+	MaxTestTags  = 16
+	MaxTagLength = 64
+)
+
+// tagPattern is a tag: lowercase letters, digits and . _ : / -, starting
+// with a letter or digit. Commas are excluded because tags are stored as a
+// comma-separated list.
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:/-]*$`)
+
+// ValidateTags checks a test's tags, which must already be lowercase.
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTestTags {
+		return fmt.Errorf("at most %d tags are allowed, got %d", MaxTestTags, len(tags))
+	}
+	for i, tag := range tags {
+		if len(tag) > MaxTagLength {
+			return fmt.Errorf("tag %q exceeds %d characters", tag, MaxTagLength)
+		}
+		if !tagPattern.MatchString(tag) {
+			return fmt.Errorf("invalid tag %q: use lowercase letters, digits and . _ : / -", tag)
+		}
+		if slices.Contains(tags[:i], tag) {
+			return fmt.Errorf("tag %q is given more than once", tag)
+		}
+	}
+	return nil
+}
+
+// Fields a test listing can be sorted by.
+const (
+	TestSortCreated = "created_at"
+	TestSortName    = "name"
+	TestSortStatus  = "status"
+	TestSortRate    = "rate"
+	TestSortWorkers = "workers"
+)
+
+// TestSortFields lists the valid TestListFilter.SortBy values.
+var TestSortFields = []string{TestSortCreated, TestSortName, TestSortStatus, TestSortRate, TestSortWorkers}
+
+// TestListFilter selects and orders one page of tests. Zero fields do not
+// filter.
+type TestListFilter struct {
+	UserID        string    // Only this user's tests; empty = every user's
+	Status        string    // Only tests in this status
+	Priority      string    // Only tests with this priority
+	CreatedAfter  time.Time // Only tests created at or after this time
+	CreatedBefore time.Time // Only tests created before this time
+	NameContains  string    // Only tests whose name contains this, ignoring case
+	Tag           string    // Only tests with this tag
+	TargetHost    string    // Only tests with a target on this host, ignoring case
+	SortBy        string    // One of TestSortFields; empty = TestSortCreated
+	Ascending     bool      // Sort ascending instead of descending
+	Limit         int
+	Offset        int
+}
+
+// ResultPage selects one page of a test's raw worker results.
+type ResultPage struct {
+	Limit         int // 0 = every result from Offset on
+	Offset        int
+	WithoutMetric bool // Leave each result's raw Metric out
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLoginLocked is returned, as a *LoginLockedError, for a login attempt
+// refused because of too many recent failures.
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// LoginLockedError refuses a login until RetryAfter has passed. It does not
+// say whether the username or the client address is locked out.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%v: try again in %v", ErrLoginLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
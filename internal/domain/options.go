@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseThinkTime parses a think time specification. It accepts either a fixed
+// duration ("200ms") or an inclusive range ("100ms-500ms"). An empty string
+// means no think time.
+func ParseThinkTime(spec string) (min, max time.Duration, err error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return 0, 0, nil
+	}
+
+	lower, upper, isRange := strings.Cut(spec, "-")
+	min, err = time.ParseDuration(strings.TrimSpace(lower))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid think time %q: %w", spec, err)
+	}
+	max = min
+	if isRange {
+		max, err = time.ParseDuration(strings.TrimSpace(upper))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid think time %q: %w", spec, err)
+		}
+	}
+
+	if min < 0 || max < min {
+		return 0, 0, fmt.Errorf("invalid think time %q: range must be non-negative and ascending", spec)
+	}
+	return min, max, nil
+}
+
+// AttackOptions holds the HTTP client and connection tuning options accepted
+// in a test's vegeta payload JSON. Pointer fields distinguish "not set" from
+// an explicit zero value so vegeta's own defaults are kept when omitted.
+type AttackOptions struct {
+	Timeout        *OptionDuration `json:"timeout,omitempty"`        // Per-request timeout: seconds (number) or duration string ("30s")
+	Redirects      *int            `json:"redirects,omitempty"`      // Max redirects to follow (-1 disables following)
+	KeepAlive      *bool           `json:"keepalive,omitempty"`      // Reuse TCP connections between requests
+	HTTP2          *bool           `json:"http2,omitempty"`          // Enable HTTP/2 over TLS
+	H2C            bool            `json:"h2c,omitempty"`            // Send HTTP/2 requests without TLS
+	Insecure       bool            `json:"insecure,omitempty"`       // Skip TLS certificate verification
+	Connections    *int            `json:"connections,omitempty"`    // Max idle connections per host
+	MaxConnections *int            `json:"maxConnections,omitempty"` // Max open connections per host (0 = unlimited)
+	Workers        *uint64         `json:"workers,omitempty"`        // Initial number of attack workers
+	MaxWorkers     *uint64         `json:"maxWorkers,omitempty"`     // Upper bound on attack workers
+	MaxBody        *int64          `json:"maxBody,omitempty"`        // Max bytes of each response body to read (-1 = unlimited)
+	LocalAddr      string          `json:"localAddr,omitempty"`      // Local IP address to bind outgoing connections to
+	Proxy          string          `json:"proxy,omitempty"`          // Outbound proxy URL (http, https or socks5)
+	Resolve        []string        `json:"resolve,omitempty"`        // DNS overrides in curl --resolve form: "host:port:addr"
+	Cookies        bool            `json:"cookies,omitempty"`        // Keep a cookie jar per virtual user (scenario tests only)
+	VirtualUsers   *int            `json:"virtualUsers,omitempty"`   // Sessions reused across iterations; unset = fresh session per iteration
+	ErrorBudget    *ErrorBudget    `json:"errorBudget,omitempty"`    // Abort the test when too many requests fail (enforced by the master)
+}
+
+// Error budget defaults applied when a budget omits them.
+const (
+	DefaultErrorBudgetWindow      = 10 * time.Second
+	DefaultErrorBudgetMinRequests = 100
+)
+
+// ErrorBudget is a circuit breaker for a test: once the error rate over the
+// trailing window exceeds MaxErrorRate, the master aborts the test.
+type ErrorBudget struct {
+	MaxErrorRate float64         `json:"maxErrorRate"`          // Fraction of failed requests (0-1] that trips the breaker
+	Window       *OptionDuration `json:"window,omitempty"`      // Trailing window the error rate is measured over (default 10s)
+	MinRequests  int64           `json:"minRequests,omitempty"` // Requests needed in the window before it is evaluated (default 100)
+}
+
+// WindowDuration returns the budget's window, or the default when unset.
+func (b *ErrorBudget) WindowDuration() time.Duration {
+	if b.Window == nil {
+		return DefaultErrorBudgetWindow
+	}
+	return time.Duration(*b.Window)
+}
+
+// MinWindowRequests returns the budget's minimum sample size, or the default when unset.
+func (b *ErrorBudget) MinWindowRequests() int64 {
+	if b.MinRequests == 0 {
+		return DefaultErrorBudgetMinRequests
+	}
+	return b.MinRequests
+}
+
+// OptionDuration is a duration that can be given in JSON either as a number
+// of seconds or as a Go duration string.
+type OptionDuration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *OptionDuration) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		*d = OptionDuration(time.Duration(seconds * float64(time.Second)))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a number of seconds or a duration string")
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = OptionDuration(parsed)
+	return nil
+}
+
+// ParseAttackOptions decodes and validates a vegeta payload JSON document.
+// Unknown keys are rejected so typos don't silently fall back to defaults.
+func ParseAttackOptions(payloadJSON string) (*AttackOptions, error) {
+	opts := &AttackOptions{}
+	if strings.TrimSpace(payloadJSON) == "" {
+		return opts, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(payloadJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(opts); err != nil {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: %w", err)
+	}
+
+	if opts.Timeout != nil && *opts.Timeout < 0 {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: timeout must not be negative")
+	}
+	if opts.Redirects != nil && *opts.Redirects < -1 {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: redirects must be -1 or greater")
+	}
+	if opts.Connections != nil && *opts.Connections < 0 {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: connections must not be negative")
+	}
+	if opts.MaxConnections != nil && *opts.MaxConnections < 0 {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: maxConnections must not be negative")
+	}
+	if opts.Workers != nil && opts.MaxWorkers != nil && *opts.Workers > *opts.MaxWorkers {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: workers must not exceed maxWorkers")
+	}
+	if opts.LocalAddr != "" && net.ParseIP(opts.LocalAddr) == nil {
+		return nil, fmt.Errorf("invalid vegeta payload JSON: localAddr %q is not an IP address", opts.LocalAddr)
+	}
+	if opts.Proxy != "" {
+		u, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: proxy: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid vegeta payload JSON: proxy scheme must be http, https or socks5, got %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: proxy %q has no host", opts.Proxy)
+		}
+	}
+	for _, entry := range opts.Resolve {
+		if _, _, err := ParseResolveOverride(entry); err != nil {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: %w", err)
+		}
+	}
+	if opts.VirtualUsers != nil {
+		if !opts.Cookies {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: virtualUsers requires cookies")
+		}
+		if *opts.VirtualUsers < 1 {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: virtualUsers must be at least 1")
+		}
+	}
+	if b := opts.ErrorBudget; b != nil {
+		if b.MaxErrorRate <= 0 || b.MaxErrorRate > 1 {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: errorBudget.maxErrorRate must be greater than 0 and at most 1")
+		}
+		if b.Window != nil && time.Duration(*b.Window) < time.Second {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: errorBudget.window must be at least 1s")
+		}
+		if b.MinRequests < 0 {
+			return nil, fmt.Errorf("invalid vegeta payload JSON: errorBudget.minRequests must not be negative")
+		}
+	}
+	return opts, nil
+}
+
+// ParseResolveOverride parses a curl --resolve style entry ("host:port:addr")
+// into the dial address it overrides and its replacement, both as "host:port".
+// IPv6 addresses may be given in brackets ("example.com:443:[::1]").
+func ParseResolveOverride(entry string) (from, to string, err error) {
+	host, rest, ok := strings.Cut(entry, ":")
+	if !ok {
+		return "", "", fmt.Errorf("resolve entry %q must be in host:port:addr form", entry)
+	}
+	port, addr, ok := strings.Cut(rest, ":")
+	if !ok || host == "" || port == "" || addr == "" {
+		return "", "", fmt.Errorf("resolve entry %q must be in host:port:addr form", entry)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", "", fmt.Errorf("resolve entry %q has an invalid port", entry)
+	}
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if net.ParseIP(addr) == nil {
+		return "", "", fmt.Errorf("resolve entry %q must map to an IP address", entry)
+	}
+	return net.JoinHostPort(host, port), net.JoinHostPort(addr, port), nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "fmt"
+
+// Levels past which a worker's own overhead counts as significant. Beyond
+// them the load generator, not the target, may be what skews a test.
+const (
+	SignificantDriftMs = 10.0   // 95th percentile of how late requests went out
+	SignificantSetupMs = 1000.0 // Setup and DNS warm-up before the first request
+)
+
+// GeneratorOverhead is the time a worker spent on its own work during a test
+// rather than waiting on the target. Latencies are measured from when each
+// request actually went out, so scheduling drift does not inflate them; it
+// shows as requests sent later, and so fewer of them, than the rate asked for.
+type GeneratorOverhead struct {
+	SetupMs     float64 `json:"setupMs"`     // Parsing targets and building the targeter
+	DNSWarmupMs float64 `json:"dnsWarmupMs"` // Resolving the targets' hosts before the first request
+	MeanDriftMs float64 `json:"meanDriftMs"` // How late requests went out compared to their schedule
+	P95DriftMs  float64 `json:"p95DriftMs"`
+	MaxDriftMs  float64 `json:"maxDriftMs"`
+}
+
+// Warnings describes each overhead large enough to skew the test.
+func (o *GeneratorOverhead) Warnings() []string {
+	var warnings []string
+	if o.P95DriftMs >= SignificantDriftMs {
+		warnings = append(warnings, fmt.Sprintf("requests went out up to %.1f ms behind schedule (p95, max %.1f ms)", o.P95DriftMs, o.MaxDriftMs))
+	}
+	if setup := o.SetupMs + o.DNSWarmupMs; setup >= SignificantSetupMs {
+		warnings = append(warnings, fmt.Sprintf("setup and DNS warm-up took %.0f ms before the first request", setup))
+	}
+	return warnings
+}
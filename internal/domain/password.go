@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrWeakPassword is returned, wrapped, for a new password the password
+// policy refuses.
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+// ErrPasswordChangeRequired refuses the requests of a user who must change
+// their password before doing anything else.
+var ErrPasswordChangeRequired = errors.New("password change required")
+
+// PasswordPolicy is what new passwords must satisfy.
+type PasswordPolicy struct {
+	MinLength  int           // Characters
+	MinClasses int           // Of lowercase letters, uppercase letters, digits and other characters
+	History    int           // Most recent passwords, the current one included, a new one may not repeat; 0 = reuse allowed
+	MaxAge     time.Duration // After which a password must be changed; 0 = never
+}
+
+// Check returns why password does not satisfy the length and complexity
+// rules of the policy, or nil. History is checked against stored hashes.
+func (p PasswordPolicy) Check(password string) error {
+	if length := utf8.RuneCountInString(password); length < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, p.MinLength)
+	}
+	if classes := passwordClasses(password); classes < p.MinClasses {
+		return fmt.Errorf("%w: must mix at least %d of lowercase letters, uppercase letters, digits and other characters", ErrWeakPassword, p.MinClasses)
+	}
+	return nil
+}
+
+// Expired reports whether a password last changed at changedAt must be
+// changed at now.
+func (p PasswordPolicy) Expired(changedAt, now time.Time) bool {
+	return p.MaxAge > 0 && now.Sub(changedAt) > p.MaxAge
+}
+
+// passwordClasses counts the character classes password uses.
+func passwordClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	classes := 0
+	for _, used := range []bool{lower, upper, digit, other} {
+		if used {
+			classes++
+		}
+	}
+	return classes
+}
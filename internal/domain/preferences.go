@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	_ "time/tzdata" // Time zones validate on hosts without a zoneinfo database
+)
+
+// MaxDefaultWorkerCount caps the worker count a user can set as default.
+const MaxDefaultWorkerCount = 1000
+
+// UserPreferences are a user's defaults for the tests they submit and how
+// the dashboard presents things to them. Tests take the defaults for the
+// fields they omit.
+type UserPreferences struct {
+	DefaultWorkerCount      uint32 `json:"defaultWorkerCount,omitempty"`
+	DefaultRateDistribution string `json:"defaultRateDistribution,omitempty"` // One of RateDistributions, except "weighted"
+	DefaultDuration         string `json:"defaultDuration,omitempty"`         // e.g. "1m"
+	Timezone                string `json:"timezone,omitempty"`                // IANA name such as "Europe/Berlin"; the browser's if empty
+
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences choose which of their tests' outcomes the
+// dashboard notifies a user of.
+type NotificationPreferences struct {
+	TestCompleted bool `json:"testCompleted"`
+	TestFailed    bool `json:"testFailed"`
+}
+
+// Validate checks that the preferences could be applied to a test.
+func (p *UserPreferences) Validate() error {
+	if p.DefaultWorkerCount > MaxDefaultWorkerCount {
+		return fmt.Errorf("defaultWorkerCount must be at most %d", MaxDefaultWorkerCount)
+	}
+	if p.DefaultRateDistribution != "" {
+		if p.DefaultRateDistribution == "weighted" {
+			return errors.New("defaultRateDistribution cannot be weighted: weights are given per test")
+		}
+		if !slices.Contains(RateDistributions, p.DefaultRateDistribution) {
+			return fmt.Errorf("invalid defaultRateDistribution %q: must be one of %v", p.DefaultRateDistribution, RateDistributions)
+		}
+	}
+	if p.DefaultDuration != "" {
+		if duration, err := time.ParseDuration(p.DefaultDuration); err != nil || duration <= 0 {
+			return fmt.Errorf("invalid defaultDuration %q: must be a positive duration such as \"30s\" or \"5m\"", p.DefaultDuration)
+		}
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: must be an IANA time zone such as \"Europe/Berlin\"", p.Timezone)
+		}
+	}
+	return nil
+}
+
+// PreferencesRepository stores user preferences.
+type PreferencesRepository interface {
+	// GetUserPreferences returns the preferences of a user, empty if they
+	// have saved none.
+	GetUserPreferences(ctx context.Context, userID string) (*UserPreferences, error)
+	SaveUserPreferences(ctx context.Context, userID string, prefs *UserPreferences) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProbeResult is the outcome of one pre-flight probe request to a target.
+type ProbeResult struct {
+	Method     string  `json:"method"`
+	URL        string  `json:"url"`
+	StatusCode uint16  `json:"statusCode"`
+	LatencyMs  float64 `json:"latencyMs"`
+	Error      string  `json:"error,omitempty"`
+	Healthy    bool    `json:"healthy"`
+}
+
+// PreflightError reports targets that failed the pre-flight check.
+type PreflightError struct {
+	Results []ProbeResult
+}
+
+func (e *PreflightError) Error() string {
+	var failed []string
+	for _, r := range e.Results {
+		if r.Healthy {
+			continue
+		}
+		reason := r.Error
+		if reason == "" {
+			reason = fmt.Sprintf("HTTP %d", r.StatusCode)
+		}
+		failed = append(failed, fmt.Sprintf("%s %s: %s", r.Method, r.URL, reason))
+	}
+	return fmt.Sprintf("pre-flight check failed for %d of %d targets: %s", len(failed), len(e.Results), strings.Join(failed, "; "))
+}
+
+// CheckProbeResults returns a *PreflightError if any probed target is unhealthy.
+func CheckProbeResults(results []ProbeResult) error {
+	for _, r := range results {
+		if !r.Healthy {
+			return &PreflightError{Results: results}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Test priorities. Waiting tests get workers highest priority first.
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+// Priorities lists the test priorities, highest first.
+var Priorities = []string{PriorityHigh, PriorityNormal, PriorityLow}
+
+// PriorityRank orders a priority among Priorities, 0 being the highest. An
+// unknown priority ranks as PriorityNormal.
+func PriorityRank(priority string) int {
+	for rank, p := range Priorities {
+		if p == priority {
+			return rank
+		}
+	}
+	return 1
+}
+
+// QueuedTest is a test waiting for workers. Only the test's owner and admins
+// see its ID, name and requester.
+type QueuedTest struct {
+	Position          int       `json:"position"` // 1 = the next test to get workers
+	TestID            string    `json:"testId,omitempty"`
+	Name              string    `json:"name,omitempty"`
+	RequesterID       string    `json:"requesterId,omitempty"`
+	Priority          string    `json:"priority"`
+	EffectivePriority string    `json:"effectivePriority"` // Priority after aging while queued
+	WorkerCount       uint32    `json:"workerCount"`
+	RatePerSecond     uint64    `json:"ratePerSecond"`
+	SubmittedAt       time.Time `json:"submittedAt"`
+	Assigning         bool      `json:"assigning"`        // Already gathering workers; it can no longer be removed or promoted
+	HeldBy            string    `json:"heldBy,omitempty"` // Guardrail keeping the test from starting, if any
+	Overflow          bool      `json:"overflow"`         // Waiting in the database for room in the queue window
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressCounter accumulates request outcomes while an attack runs so the
+// worker can report live progress. It is safe for concurrent use.
+type ProgressCounter struct {
+	requests      atomic.Int64
+	failures      atomic.Int64
+	latencyMicros atomic.Int64
+
+	codesMu sync.Mutex
+	codes   map[uint16]int64 // Requests by status code; 0 = transport error
+}
+
+// ProgressSnapshot is a point-in-time copy of a ProgressCounter.
+type ProgressSnapshot struct {
+	Requests      int64            `json:"requests"`
+	Failures      int64            `json:"failures"`
+	LatencyMicros int64            `json:"latencyMicros"`         // Sum of all request latencies
+	StatusCodes   map[string]int64 `json:"statusCodes,omitempty"` // Requests by status code; "0" = transport error
+}
+
+// Record adds one completed request. A request fails on a transport error or
+// a status outside 2xx/3xx, matching vegeta's success ratio.
+func (p *ProgressCounter) Record(latency time.Duration, code uint16, errMsg string) {
+	p.requests.Add(1)
+	p.latencyMicros.Add(latency.Microseconds())
+	if errMsg != "" || code < 200 || code >= 400 {
+		p.failures.Add(1)
+	}
+	p.codesMu.Lock()
+	if p.codes == nil {
+		p.codes = make(map[uint16]int64)
+	}
+	p.codes[code]++
+	p.codesMu.Unlock()
+}
+
+// Snapshot returns the current totals.
+func (p *ProgressCounter) Snapshot() ProgressSnapshot {
+	snapshot := ProgressSnapshot{
+		Requests:      p.requests.Load(),
+		Failures:      p.failures.Load(),
+		LatencyMicros: p.latencyMicros.Load(),
+	}
+	p.codesMu.Lock()
+	if len(p.codes) > 0 {
+		snapshot.StatusCodes = make(map[string]int64, len(p.codes))
+		for code, count := range p.codes {
+			snapshot.StatusCodes[strconv.Itoa(int(code))] = count
+		}
+	}
+	p.codesMu.Unlock()
+	return snapshot
+}
+
+// Set overwrites the totals, for counters mirroring progress reported by
+// another process.
+func (p *ProgressCounter) Set(s ProgressSnapshot) {
+	p.requests.Store(s.Requests)
+	p.failures.Store(s.Failures)
+	p.latencyMicros.Store(s.LatencyMicros)
+	codes := make(map[uint16]int64, len(s.StatusCodes))
+	for key, count := range s.StatusCodes {
+		if code, err := strconv.ParseUint(key, 10, 16); err == nil {
+			codes[uint16(code)] = count
+		}
+	}
+	p.codesMu.Lock()
+	p.codes = codes
+	p.codesMu.Unlock()
+}
+
+// TestProgress is a live view of a test, built from worker status updates.
+// Rates and latencies cover the most recent reporting interval.
+type TestProgress struct {
+	TestID        string           `json:"testId"`
+	Status        string           `json:"status"`
+	ElapsedMs     int64            `json:"elapsedMs"`
+	Requests      int64            `json:"requests"`
+	Failures      int64            `json:"failures"`
+	RPS           float64          `json:"rps"`
+	ErrorRate     float64          `json:"errorRate"` // Failures / requests in the last interval (0.0-1.0)
+	MeanLatencyMs float64          `json:"meanLatencyMs"`
+	Workers       []WorkerProgress `json:"workers"`
+}
+
+// WorkerProgress is one worker's share of a TestProgress.
+type WorkerProgress struct {
+	WorkerID      string          `json:"workerId"`
+	Status        string          `json:"status"`
+	Requests      int64           `json:"requests"`
+	Expected      int64           `json:"expected"` // Requests the worker will send at its rate over the full duration
+	Failures      int64           `json:"failures"`
+	RPS           float64         `json:"rps"`
+	MeanLatencyMs float64         `json:"meanLatencyMs"`
+	Resources     *ResourceSample `json:"resources,omitempty"` // Latest resource sample of the worker and its host
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PublicTestView is what a public shared link shows to someone without an
+// account: the test's settings and aggregated result, but not its targets'
+// headers and bodies, its requester or the workers that ran it.
+type PublicTestView struct {
+	Name             string                `json:"name"`
+	Status           string                `json:"status"`
+	CreatedAt        time.Time             `json:"createdAt"`
+	DurationSeconds  string                `json:"durationSeconds"`
+	RatePerSecond    uint64                `json:"ratePerSecond"`
+	WorkerCount      uint32                `json:"workerCount"`
+	RateDistribution string                `json:"rateDistribution,omitempty"`
+	TargetURLs       []string              `json:"targetUrls,omitempty"` // Without query strings, which often carry credentials
+	Tags             []string              `json:"tags,omitempty"`
+	Result           *TestResultAggregated `json:"result,omitempty"`     // As when the link was created; nil until the test has finished
+	SnapshotAt       *time.Time            `json:"snapshotAt,omitempty"` // When Result was captured; nil if it is the current result
+	LinkExpiresAt    time.Time             `json:"linkExpiresAt"`
+}
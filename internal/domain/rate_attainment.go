@@ -0,0 +1,33 @@
+package domain
+
+// DefaultRateAttainmentWarning is the rate attainment, in percent, below which
+// a test or worker is flagged, unless the master is given another.
+const DefaultRateAttainmentWarning = 90.0
+
+// Rate attainment statuses.
+const (
+	RateAttainmentOK      = "OK"
+	RateAttainmentWarning = "WARNING" // The generator likely couldn't keep up, e.g. it was saturated
+)
+
+// RateComparison compares the rate a test or worker asked for with the rate
+// it actually sent requests at.
+type RateComparison struct {
+	RequestedRate  float64 `json:"requestedRate"`  // Requests per second
+	AchievedRate   float64 `json:"achievedRate"`   // Requests per second sent over the attack
+	RateAttainment float64 `json:"rateAttainment"` // AchievedRate / RequestedRate, in percent
+	Status         string  `json:"status"`         // RateAttainmentOK or RateAttainmentWarning
+}
+
+// WorkerRateComparison is one worker's RateComparison.
+type WorkerRateComparison struct {
+	WorkerID string `json:"workerId"`
+	RateComparison
+}
+
+// RateAttainmentReport compares the requested and achieved rates of a test
+// overall and for each worker that reported a result.
+type RateAttainmentReport struct {
+	RateComparison
+	Workers []WorkerRateComparison `json:"workers"`
+}
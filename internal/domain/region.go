@@ -0,0 +1,32 @@
+package domain
+
+// RegionShare is the part of a multi-region test run by the workers of one
+// region: its rate is split among them as the test's RateDistribution says.
+type RegionShare struct {
+	Region        string `json:"region"`
+	RatePerSecond uint64 `json:"ratePerSecond"`
+	WorkerCount   uint32 `json:"workerCount"`
+}
+
+// RegionBreakdown aggregates the results of a test's workers in one region,
+// so latency can be compared by geography.
+type RegionBreakdown struct {
+	Region         string  `json:"region"`
+	Workers        int     `json:"workers"`
+	TotalRequests  int64   `json:"total_requests"`
+	FailedRequests int64   `json:"failed_requests"`
+	AvgLatencyMs   float64 `json:"avg_latency_ms"`
+	P95LatencyMs   float64 `json:"p95_latency_ms"`
+	ThroughputRPS  float64 `json:"throughput_rps"`
+}
+
+// RegionAnalytics compares the latency of one region's workers across the
+// multi-region tests in an analytics time range.
+type RegionAnalytics struct {
+	Region              string  `json:"region"`
+	TestCount           int64   `json:"testCount"`
+	TotalRequests       int64   `json:"totalRequests"`
+	SuccessRate         float64 `json:"successRate"`
+	AverageResponseTime float64 `json:"averageResponseTime"` // Weighted by each test's requests
+	P95ResponseTime     float64 `json:"p95ResponseTime"`     // Highest of the tests' p95 latencies
+}
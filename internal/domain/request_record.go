@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RequestRecord is the outcome of a single request sent during a test. Records
+// are kept outside Postgres for analysis beyond the per-worker summaries.
+type RequestRecord struct {
+	TestID    string
+	WorkerID  string
+	Target    string // Request URL
+	Code      uint16 // 0 when the request failed before a response
+	Latency   time.Duration
+	Timestamp time.Time // When the request was sent
+}
+
+// RequestRecorder receives every request an attack sends. Record is called
+// from the attack's hot path, so it must be safe for concurrent use and must
+// not block.
+type RequestRecorder interface {
+	Record(record RequestRecord)
+}
+
+// RequestRecordStore answers queries over stored request records.
+type RequestRecordStore interface {
+	LatencyHeatmap(ctx context.Context, testID string, interval time.Duration) ([]HeatmapCell, error)
+	LatencyPercentiles(ctx context.Context, testID, target string) (*LatencyPercentiles, error) // Empty target covers the whole test
+	// LatencyDistribution computes percentiles over the requests of several tests; an empty target covers all of them.
+	LatencyDistribution(ctx context.Context, testIDs []string, target string) (*LatencyDistribution, error)
+}
+
+// HeatmapCell counts the requests of one time bucket whose latency fell in one
+// latency bucket. Latency buckets double in width: [1, 2) µs, [2, 4) µs, ...
+type HeatmapCell struct {
+	Time         time.Time `json:"time"` // Start of the time bucket
+	LatencyMinMs float64   `json:"latencyMinMs"`
+	LatencyMaxMs float64   `json:"latencyMaxMs"`
+	Count        uint64    `json:"count"`
+}
+
+// LatencyPercentiles are exact latency percentiles computed over every
+// request of a test, rather than merged from per-worker summaries.
+type LatencyPercentiles struct {
+	TestID   string  `json:"testId"`
+	Target   string  `json:"target,omitempty"`
+	Requests uint64  `json:"requests"`
+	P50Ms    float64 `json:"p50Ms"`
+	P90Ms    float64 `json:"p90Ms"`
+	P95Ms    float64 `json:"p95Ms"`
+	P99Ms    float64 `json:"p99Ms"`
+	MaxMs    float64 `json:"maxMs"`
+}
+
+// Sources of a LatencyDistribution.
+const (
+	LatencySourceRequests   = "requests"   // Exact, over every request record
+	LatencySourceTimeSeries = "timeseries" // Estimated from per-second mean latencies
+)
+
+// LatencyDistribution is a set of latency percentiles over the requests of
+// one or more tests.
+type LatencyDistribution struct {
+	Requests uint64  `json:"requests"`
+	P50Ms    float64 `json:"p50Ms"`
+	P75Ms    float64 `json:"p75Ms"`
+	P90Ms    float64 `json:"p90Ms"`
+	P95Ms    float64 `json:"p95Ms"`
+	P99Ms    float64 `json:"p99Ms"`
+	P999Ms   float64 `json:"p999Ms"`
+	Source   string  `json:"source"` // LatencySourceRequests or LatencySourceTimeSeries
+}
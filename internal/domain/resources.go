@@ -0,0 +1,78 @@
+package domain
+
+import "fmt"
+
+// Levels past which a load generator counts as saturated. Beyond them the
+// worker, not the target, may be what limits throughput and latency.
+const (
+	SaturatedCPUPercent     = 90.0 // Mean host CPU busy over the test
+	SaturatedMemoryPercent  = 90.0 // Peak host memory in use
+	SaturatedOpenFilesRatio = 0.9  // Peak open files as a share of the limit
+)
+
+// ResourceSample is the resource use of a worker and its host at one moment
+// during a test.
+type ResourceSample struct {
+	CPUPercent     float64 `json:"cpuPercent"`     // Host CPU busy since the previous sample (0-100)
+	MemoryBytes    int64   `json:"memoryBytes"`    // Resident memory of the worker process
+	MemoryPercent  float64 `json:"memoryPercent"`  // Host memory in use (0-100)
+	NetRxBytes     int64   `json:"netRxBytes"`     // Bytes the host received since the previous sample
+	NetTxBytes     int64   `json:"netTxBytes"`     // Bytes the host sent since the previous sample
+	OpenFiles      int64   `json:"openFiles"`      // Open file descriptors, sockets included, of the worker process
+	OpenFilesLimit int64   `json:"openFilesLimit"` // Soft limit on OpenFiles; 0 = unknown
+}
+
+// Saturated reports whether any resource in the sample is past its saturation level.
+func (s ResourceSample) Saturated() bool {
+	return s.CPUPercent >= SaturatedCPUPercent || s.MemoryPercent >= SaturatedMemoryPercent ||
+		(s.OpenFilesLimit > 0 && float64(s.OpenFiles) >= SaturatedOpenFilesRatio*float64(s.OpenFilesLimit))
+}
+
+// ResourceSampler measures the resources of the machine a worker runs on.
+type ResourceSampler interface {
+	// Sample returns the use since the previous call, or false where
+	// sampling is not supported.
+	Sample() (ResourceSample, bool)
+}
+
+// ResourceUsage summarizes the samples a worker took while running a test.
+type ResourceUsage struct {
+	Samples          int     `json:"samples"`
+	MeanCPUPercent   float64 `json:"meanCpuPercent"`
+	MaxCPUPercent    float64 `json:"maxCpuPercent"`
+	MaxMemoryBytes   int64   `json:"maxMemoryBytes"`
+	MaxMemoryPercent float64 `json:"maxMemoryPercent"`
+	NetRxBytes       int64   `json:"netRxBytes"`
+	NetTxBytes       int64   `json:"netTxBytes"`
+	MaxOpenFiles     int64   `json:"maxOpenFiles"`
+	OpenFilesLimit   int64   `json:"openFilesLimit"`
+}
+
+// Add folds a sample into the summary.
+func (u *ResourceUsage) Add(s ResourceSample) {
+	u.Samples++
+	u.MeanCPUPercent += (s.CPUPercent - u.MeanCPUPercent) / float64(u.Samples)
+	u.MaxCPUPercent = max(u.MaxCPUPercent, s.CPUPercent)
+	u.MaxMemoryBytes = max(u.MaxMemoryBytes, s.MemoryBytes)
+	u.MaxMemoryPercent = max(u.MaxMemoryPercent, s.MemoryPercent)
+	u.NetRxBytes += s.NetRxBytes
+	u.NetTxBytes += s.NetTxBytes
+	u.MaxOpenFiles = max(u.MaxOpenFiles, s.OpenFiles)
+	u.OpenFilesLimit = s.OpenFilesLimit
+}
+
+// SaturationWarnings describes each resource the worker ran short of. CPU
+// must stay high for the whole test to count; brief spikes are normal.
+func (u *ResourceUsage) SaturationWarnings() []string {
+	var warnings []string
+	if u.MeanCPUPercent >= SaturatedCPUPercent {
+		warnings = append(warnings, fmt.Sprintf("host CPU averaged %.0f%% busy", u.MeanCPUPercent))
+	}
+	if u.MaxMemoryPercent >= SaturatedMemoryPercent {
+		warnings = append(warnings, fmt.Sprintf("host memory peaked at %.0f%% in use", u.MaxMemoryPercent))
+	}
+	if u.OpenFilesLimit > 0 && float64(u.MaxOpenFiles) >= SaturatedOpenFilesRatio*float64(u.OpenFilesLimit) {
+		warnings = append(warnings, fmt.Sprintf("open files peaked at %d of the %d allowed", u.MaxOpenFiles, u.OpenFilesLimit))
+	}
+	return warnings
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Rollout states.
+const (
+	RolloutRunning   = "running"
+	RolloutCompleted = "completed"
+	RolloutFailed    = "failed"
+	RolloutCancelled = "cancelled"
+)
+
+// Phases a worker goes through in a rollout.
+const (
+	RolloutWorkerPending   = "pending"   // Waiting for its batch
+	RolloutWorkerDraining  = "draining"  // Takes no new tests and is finishing the one it runs
+	RolloutWorkerUpgrading = "upgrading" // Idle, waiting to re-register with the target version
+	RolloutWorkerUpgraded  = "upgraded"  // Re-registered with the target version and takes tests again
+	RolloutWorkerFailed    = "failed"    // Did not re-register with the target version in time
+)
+
+// ErrRolloutInProgress is returned when a rollout is started while another
+// one is running.
+var ErrRolloutInProgress = errors.New("a rollout is already in progress")
+
+// RolloutOptions configures a rolling upgrade of the workers.
+type RolloutOptions struct {
+	TargetVersion  string
+	BatchSize      int           // Workers drained at once; 0 = 1
+	BatchTimeout   time.Duration // How long a batch may take to drain and re-register; 0 = 10 minutes
+	RestartWorkers bool          // Ask drained workers to exit so their supervisor restarts them on the new version
+}
+
+// Rollout reports a rolling upgrade of the workers. Workers are drained a
+// batch at a time, and the next batch starts once every worker of the
+// current one re-registered with the target version.
+type Rollout struct {
+	State          string          `json:"state"`
+	TargetVersion  string          `json:"targetVersion"`
+	BatchSize      int             `json:"batchSize"`
+	BatchTimeout   string          `json:"batchTimeout"`
+	RestartWorkers bool            `json:"restartWorkers"`
+	Batch          int             `json:"batch"` // Batch being upgraded, from 1; 0 before the first one
+	Batches        int             `json:"batches"`
+	StartedBy      string          `json:"startedBy"`
+	StartedAt      time.Time       `json:"startedAt"`
+	FinishedAt     *time.Time      `json:"finishedAt,omitempty"`
+	Message        string          `json:"message,omitempty"` // Why the rollout failed or was cancelled
+	Workers        []RolloutWorker `json:"workers"`
+}
+
+// RolloutWorker is the progress of one worker in a rollout.
+type RolloutWorker struct {
+	WorkerID    string `json:"workerId"`
+	Batch       int    `json:"batch"`
+	Phase       string `json:"phase"`
+	FromVersion string `json:"fromVersion"`
+	Version     string `json:"version"` // Version it last registered with
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// RuntimeStats shows the master's in-memory state, so leaked goroutines,
+// connections or test bookkeeping can be spotted before they exhaust it.
+type RuntimeStats struct {
+	Goroutines        int        `json:"goroutines"`
+	PeakGoroutines    int        `json:"peakGoroutines"` // Highest count seen by the lifecycle sweeps
+	WorkerConnections int        `json:"workerConnections"`
+	TestAssignments   int        `json:"testAssignments"` // Tests whose worker assignments are tracked
+	LiveTests         int        `json:"liveTests"`       // Tests with live progress held in memory
+	ClosedConnections int64      `json:"closedConnections"`
+	ReleasedTests     int64      `json:"releasedTests"` // Assignment entries removed after their tests finished
+	LastSweepAt       *time.Time `json:"lastSweepAt,omitempty"`
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Scenario is a chain of requests executed in order by each virtual-user
+// iteration. Values extracted from one response can be referenced in later
+// steps of the same iteration as ${name}.
+type Scenario struct {
+	Name  string         `json:"name"`
+	Steps []ScenarioStep `json:"steps"`
+}
+
+// ScenarioStep is a single request in a scenario.
+type ScenarioStep struct {
+	Name         string              `json:"name"`
+	Method       string              `json:"method"`
+	URL          string              `json:"url"`
+	Header       map[string][]string `json:"header,omitempty"`
+	Body         string              `json:"body,omitempty"`
+	Extract      []ScenarioExtract   `json:"extract,omitempty"`
+	ExpectStatus []int               `json:"expectStatus,omitempty"` // Accepted status codes; defaults to any 2xx/3xx
+}
+
+// ScenarioExtract captures a value from a step's response into a variable.
+// Exactly one of JSONPath or Regex must be set. Regex matches against the
+// response body, or the named response header when Header is set, and yields
+// the first capture group (or the whole match if it has none).
+type ScenarioExtract struct {
+	Var      string `json:"var"`
+	JSONPath string `json:"jsonPath,omitempty"` // e.g. "$.data.token" or "$.items[0].id"
+	Regex    string `json:"regex,omitempty"`
+	Header   string `json:"header,omitempty"`
+}
+
+var scenarioVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseScenario decodes and validates a scenario definition.
+func ParseScenario(scenarioJSON string) (*Scenario, error) {
+	var scenario Scenario
+	dec := json.NewDecoder(strings.NewReader(scenarioJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("invalid scenario JSON: %w", err)
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("invalid scenario: at least one step is required")
+	}
+
+	seen := make(map[string]bool, len(scenario.Steps))
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step-%d", i+1)
+		}
+		if seen[step.Name] {
+			return nil, fmt.Errorf("invalid scenario: duplicate step name %q", step.Name)
+		}
+		seen[step.Name] = true
+		if step.Method == "" {
+			step.Method = http.MethodGet
+		}
+		if step.URL == "" {
+			return nil, fmt.Errorf("invalid scenario: step %q has no url", step.Name)
+		}
+		for _, ex := range step.Extract {
+			if !scenarioVarName.MatchString(ex.Var) {
+				return nil, fmt.Errorf("invalid scenario: step %q has an invalid variable name %q", step.Name, ex.Var)
+			}
+			if (ex.JSONPath == "") == (ex.Regex == "") {
+				return nil, fmt.Errorf("invalid scenario: extract %q in step %q needs exactly one of jsonPath or regex", ex.Var, step.Name)
+			}
+			if ex.JSONPath != "" && !strings.HasPrefix(ex.JSONPath, "$") {
+				return nil, fmt.Errorf("invalid scenario: jsonPath %q in step %q must start with $", ex.JSONPath, step.Name)
+			}
+			if ex.Regex != "" {
+				if _, err := regexp.Compile(ex.Regex); err != nil {
+					return nil, fmt.Errorf("invalid scenario: regex for %q in step %q: %w", ex.Var, step.Name, err)
+				}
+			}
+		}
+	}
+	return &scenario, nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExecutorLua runs tests whose Script is a Lua program instead of targets.
+const ExecutorLua = "lua"
+
+// MaxScriptBytes caps the size of a test's script.
+const MaxScriptBytes = 256 << 10
+
+// Functions a test script defines. Only ScriptIteration is required.
+const (
+	ScriptSetup     = "setup"     // Runs once per worker before the test; its return value is passed to the others
+	ScriptIteration = "iteration" // Runs at the test's rate with the setup data and the iteration number
+	ScriptTeardown  = "teardown"  // Runs once per worker after the test with the setup data
+)
+
+// ValidateScript checks a test script's size. Syntax errors are found by the
+// worker, which compiles the script before the test starts.
+func ValidateScript(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return fmt.Errorf("invalid script: it is empty")
+	}
+	if len(script) > MaxScriptBytes {
+		return fmt.Errorf("invalid script: %d bytes exceeds the limit of %d", len(script), MaxScriptBytes)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Search result types.
+const (
+	SearchResultTest = "test"
+	SearchResultUser = "user"
+)
+
+// SearchResult is one match of a global search, typed so clients can render
+// and link each kind of entity differently.
+type SearchResult struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Subtitle  string    `json:"subtitle,omitempty"`
+	Matched   string    `json:"matched"` // Which field matched, e.g. "name" or "target"
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// A smoke run sends the exact test configuration at a low rate, so a user can
+// check the targets and their authentication before the full-rate run.
+const (
+	SmokeRatePerSecond = 1
+	SmokeDuration      = "10s"
+	SmokeWorkerCount   = 1
+)
+
+// MaxSampleBodyBytes caps the response body kept as a target's sample.
+const MaxSampleBodyBytes = 512
+
+// TargetResponse summarizes the responses one target gave in a smoke run.
+type TargetResponse struct {
+	Method       string           `json:"method"`
+	URL          string           `json:"url"`
+	Requests     int64            `json:"requests"`
+	StatusCodes  map[string]int64 `json:"statusCodes"`
+	AvgLatencyMs float64          `json:"avgLatencyMs"`
+	Error        string           `json:"error,omitempty"`      // First error the target returned
+	SampleBody   string           `json:"sampleBody,omitempty"` // Body of the first response, truncated to MaxSampleBodyBytes
+}
+
+// ApplySmoke overrides the rate, duration and workers of a smoke test with
+// the smoke run's. Everything else about the test is kept as submitted.
+func (t *TestRequest) ApplySmoke() {
+	if !t.Smoke {
+		return
+	}
+	t.RatePerSecond = SmokeRatePerSecond
+	t.DurationSeconds = SmokeDuration
+	t.WorkerCount = SmokeWorkerCount
+	t.RateDistribution = "shared"
+	t.RateWeights = nil
+	t.Regions = nil
+}
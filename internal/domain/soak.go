@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MinInterimInterval is the shortest interval at which workers may flush
+// interim results of a test.
+const MinInterimInterval = time.Minute
+
+// InterimReporter receives a worker's cumulative result of a running test
+// every TestAssignment.InterimInterval. ReportInterim is called from the
+// attack loop, so it must not block.
+type InterimReporter interface {
+	ReportInterim(result *TestResult)
+}
+
+// InterimResult is one worker's cumulative result of a running test at its
+// Sequence-th interim flush.
+type InterimResult struct {
+	Sequence int `json:"sequence"` // From 1
+	TestResult
+}
+
+// InterimSnapshot summarizes every worker's result at one interim flush.
+type InterimSnapshot struct {
+	Sequence       int       `json:"sequence"`
+	Time           time.Time `json:"time"` // When the last worker flushed it
+	Workers        int       `json:"workers"`
+	TotalRequests  int64     `json:"totalRequests"`
+	FailedRequests int64     `json:"failedRequests"`
+	AvgLatencyMs   float64   `json:"avgLatencyMs"`
+	P95LatencyMs   float64   `json:"p95LatencyMs"`
+	ThroughputRPS  float64   `json:"throughputRps"`
+}
+
+// InterimReport is the view of a long-running test built from the interim
+// results of its workers: the rolling aggregate of each worker's latest
+// flush, and the aggregate at every flush so far.
+type InterimReport struct {
+	TestID    string                `json:"testId"`
+	Rolling   *TestResultAggregated `json:"rolling"` // Nil until the first flush
+	Snapshots []InterimSnapshot     `json:"snapshots"`
+}
+
+// InterimResultRepository stores the interim results of running tests.
+type InterimResultRepository interface {
+	SaveInterimResult(ctx context.Context, result *InterimResult) error
+	// GetInterimResults returns a test's interim results by sequence, without their raw metrics.
+	GetInterimResults(ctx context.Context, testID string) ([]*InterimResult, error)
+	// GetLatestInterimResults returns the last interim result of each of a test's workers.
+	GetLatestInterimResults(ctx context.Context, testID string) ([]*InterimResult, error)
+}
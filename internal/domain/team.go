@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Team is a named group of users that tests can be shared with at once.
+type Team struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MemberIDs   []string  `json:"memberIds"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// TeamRequest is the body of requests creating or updating a team. Members
+// are replaced wholesale on update.
+type TeamRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	MemberIDs   []string `json:"memberIds"`
+}
+
+// TeamRepository stores teams and their members.
+type TeamRepository interface {
+	// CreateTeam stores a team with its members. Unknown member IDs are an error.
+	CreateTeam(ctx context.Context, team *Team) error
+	GetTeamByID(ctx context.Context, teamID string) (*Team, error)
+	GetAllTeams(ctx context.Context) ([]*Team, error)
+	// UpdateTeam replaces a team's name, description and members.
+	UpdateTeam(ctx context.Context, team *Team) error
+	DeleteTeam(ctx context.Context, teamID string) error
+}
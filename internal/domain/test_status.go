@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+// Test statuses stored on test requests.
+const (
+	TestStatusPending            = "PENDING"
+	TestStatusAwaitingApproval   = "AWAITING_APPROVAL"
+	TestStatusRunning            = "RUNNING"
+	TestStatusCompleted          = "COMPLETED"
+	TestStatusPartiallyFailed    = "PARTIALLY_FAILED"
+	TestStatusFailed             = "FAILED"
+	TestStatusRejected           = "REJECTED"
+	TestStatusAbortedErrorBudget = "ABORTED_ERROR_BUDGET"
+	TestStatusAbortedWorkerLost  = "ABORTED_WORKER_LOST"
+	TestStatusCancelled          = "CANCELLED"
+)
+
+// ErrInvalidStatusTransition is returned when a test cannot move from its
+// current status to the requested one, usually because another update won.
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// testStatusTransitions lists, for each status, the statuses a test may be in
+// before moving to it. Final statuses are never left or re-entered, so only
+// one of several concurrent updates can finish a test.
+var testStatusTransitions = map[string][]string{
+	TestStatusPending:            {TestStatusAwaitingApproval},
+	TestStatusRejected:           {TestStatusAwaitingApproval},
+	TestStatusRunning:            {TestStatusPending, TestStatusRunning},
+	TestStatusCompleted:          {TestStatusRunning},
+	TestStatusPartiallyFailed:    {TestStatusPending, TestStatusRunning},
+	TestStatusFailed:             {TestStatusPending, TestStatusRunning},
+	TestStatusAbortedErrorBudget: {TestStatusPending, TestStatusRunning},
+	TestStatusAbortedWorkerLost:  {TestStatusRunning},
+	TestStatusCancelled:          {TestStatusPending},
+}
+
+// TestStatusesBefore returns the statuses a test may move to status from.
+func TestStatusesBefore(status string) []string {
+	return testStatusTransitions[status]
+}
+
+// CanTransitionTestStatus reports whether a test may move from one status to another.
+func CanTransitionTestStatus(from, to string) bool {
+	for _, allowed := range testStatusTransitions[to] {
+		if allowed == from {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinalTestStatus reports whether a test in status is done: no other
+// status can follow it.
+func IsFinalTestStatus(status string) bool {
+	for _, before := range testStatusTransitions {
+		if slices.Contains(before, status) {
+			return false
+		}
+	}
+	return true
+}
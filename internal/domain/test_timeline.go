@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Steps of a test recorded in its timeline besides the events published on
+// the event bus, which are recorded too except for metrics.
+const (
+	TimelineTestQueued          = "test.queued"
+	TimelineWorkersCollected    = "test.workers_collected"
+	TimelineWorkerAssigned      = "worker.assigned"
+	TimelineWorkerRejected      = "worker.rejected" // The worker refused the assignment or could not be reached
+	TimelineWorkerFailed        = "worker.failed"   // The worker reported an execution error
+	TimelineWorkerResult        = "worker.result"   // The worker delivered its result
+	TimelineAggregationStarted  = "aggregation.started"
+	TimelineAggregationFinished = EventTestAggregated
+)
+
+// TestTimelineEntry is one step in the life of a test, for post-mortems of
+// runs that went wrong.
+type TestTimelineEntry struct {
+	ID       int64     `json:"id"`
+	TestID   string    `json:"testId"`
+	Type     string    `json:"type"` // An event type or one of the Timeline constants
+	WorkerID string    `json:"workerId,omitempty"`
+	Status   string    `json:"status,omitempty"` // Test status after the step, when known
+	Message  string    `json:"message,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// TimelineRepository stores the timelines of tests.
+type TimelineRepository interface {
+	SaveTimelineEntry(ctx context.Context, entry *TestTimelineEntry) error
+	// GetTestTimeline returns a test's timeline, oldest first.
+	GetTestTimeline(ctx context.Context, testID string) ([]*TestTimelineEntry, error)
+}
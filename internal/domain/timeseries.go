@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MetricSample is one worker's request counts over one progress reporting
+// interval, ending at Time.
+type MetricSample struct {
+	TestID         string
+	WorkerID       string
+	Time           time.Time
+	Requests       int64
+	Failures       int64
+	LatencyTotalUs int64            // Sum of the latencies of Requests
+	StatusCodes    map[string]int64 // Requests by status code; "0" = transport error
+}
+
+// TimeSeriesPoint summarizes all workers of a test over one time bucket.
+type TimeSeriesPoint struct {
+	Time          time.Time `json:"time"` // Start of the bucket
+	Requests      int64     `json:"requests"`
+	Failures      int64     `json:"failures"`
+	RPS           float64   `json:"rps"`
+	ErrorRate     float64   `json:"errorRate"` // Failures / requests (0.0-1.0)
+	MeanLatencyMs float64   `json:"meanLatencyMs"`
+}
+
+// TestTimeSeries is a test's metrics over time. Buckets without requests are omitted.
+type TestTimeSeries struct {
+	TestID     string            `json:"testId"`
+	IntervalMs int64             `json:"intervalMs"`
+	Points     []TimeSeriesPoint `json:"points"`
+}
+
+// StatusCodePoint counts a test's responses by status code over one time bucket.
+type StatusCodePoint struct {
+	Time     time.Time        `json:"time"` // Start of the bucket
+	Requests int64            `json:"requests"`
+	Codes    map[string]int64 `json:"codes"` // Requests by status code; "0" = transport error
+}
+
+// TestStatusTimeline is a test's status codes over time. Buckets without
+// requests are omitted.
+type TestStatusTimeline struct {
+	TestID             string            `json:"testId"`
+	IntervalMs         int64             `json:"intervalMs"`
+	Points             []StatusCodePoint `json:"points"`
+	Totals             map[string]int64  `json:"totals"`
+	FirstServerErrorAt *time.Time        `json:"firstServerErrorAt,omitempty"` // Start of the first bucket with a 5xx response
+}
+
+// ThroughputPoint is the rate a test sent and completed requests at over one
+// time bucket.
+type ThroughputPoint struct {
+	Time          time.Time `json:"time"` // Start of the bucket
+	Sent          float64   `json:"sent"` // Estimated from the completions and their latency
+	Completed     int64     `json:"completed"`
+	SendRPS       float64   `json:"sendRps"`
+	CompletionRPS float64   `json:"completionRps"`
+	Achieved      float64   `json:"achieved"` // SendRPS / the requested rate (0 without one)
+}
+
+// TestThroughput is a test's send and completion rates over time against the
+// rate it asked for. Buckets between the first and the last with requests are
+// all included, so stalls show as zero.
+type TestThroughput struct {
+	TestID       string            `json:"testId"`
+	IntervalMs   int64             `json:"intervalMs"`
+	RequestedRPS float64           `json:"requestedRps"` // Sum of the rates of the workers that accepted the test, or the submitted rate
+	Points       []ThroughputPoint `json:"points"`
+}
+
+// MetricsRepository stores per-second test metrics.
+type MetricsRepository interface {
+	SaveMetricSamples(ctx context.Context, samples []MetricSample) error
+	GetTimeSeries(ctx context.Context, testID string, interval time.Duration) ([]TimeSeriesPoint, error)
+	GetStatusTimeline(ctx context.Context, testID string, interval time.Duration) ([]StatusCodePoint, error)
+}
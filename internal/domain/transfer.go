@@ -0,0 +1,16 @@
+package domain
+
+// TestTransferRequest moves tests to another owner: either the listed tests,
+// or every test of FromUserID.
+type TestTransferRequest struct {
+	FromUserID string   `json:"fromUserId,omitempty"`
+	TestIDs    []string `json:"testIds,omitempty"`
+	ToUserID   string   `json:"toUserId"`
+}
+
+// TestTransferResult lists the tests that changed owner, and why others
+// did not.
+type TestTransferResult struct {
+	Transferred []string          `json:"transferred"`
+	Skipped     map[string]string `json:"skipped"` // Test ID -> reason
+}
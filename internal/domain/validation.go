@@ -0,0 +1,41 @@
+package domain
+
+// ValidationError describes one problem with a test definition. Field is the
+// request field at fault, as named in the submission JSON.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// CostEstimate predicts the load a test will generate, so users can see its
+// size before submitting. Byte figures are extrapolated from earlier runs of
+// the same user against the same targets; without any, bytes sent are taken
+// from the request bodies and bytes received are zero.
+type CostEstimate struct {
+	TotalRequests       uint64  `json:"totalRequests"`
+	RequestsPerSecond   uint64  `json:"requestsPerSecond"`
+	WorkerSeconds       float64 `json:"workerSeconds"`
+	WorkerHours         float64 `json:"workerHours"`
+	AvgResponseBytes    float64 `json:"avgResponseBytes"`
+	AvgRequestBytes     float64 `json:"avgRequestBytes"`
+	AvgRequestBodyBytes float64 `json:"avgRequestBodyBytes"` // Average body of the targets or scenario steps, as sent in turn
+	EstimatedBytesIn    uint64  `json:"estimatedBytesIn"`
+	EstimatedBytesOut   uint64  `json:"estimatedBytesOut"`
+	SampleTests         int     `json:"sampleTests"` // Earlier tests the byte averages were taken from
+
+	Storage StorageEstimate `json:"storage"`
+}
+
+// StorageEstimate predicts the space a test's results will take up once it
+// has run. The figures are approximate, from typical row sizes.
+type StorageEstimate struct {
+	ResultBytes        uint64 `json:"resultBytes"`        // Worker, aggregated and interim results
+	TimeSeriesBytes    uint64 `json:"timeSeriesBytes"`    // Per-second progress samples of each worker
+	CaptureBytes       uint64 `json:"captureBytes"`       // Captured requests before compression; zero unless captured
+	RequestRecordBytes uint64 `json:"requestRecordBytes"` // ClickHouse request records; zero without a ClickHouse sink
+	TotalBytes         uint64 `json:"totalBytes"`
+}
@@ -0,0 +1,264 @@
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Records are written in batches of up to recordBatchSize, at least every
+// recordFlushInterval. Up to recordBufferSize records wait in memory; beyond
+// that new records are dropped so a slow ClickHouse never slows an attack.
+const (
+	recordBatchSize     = 10000
+	recordFlushInterval = time.Second
+	recordBufferSize    = 100000
+)
+
+// recordRow is a request_records row in JSONEachRow format.
+type recordRow struct {
+	TestID    string `json:"test_id"`
+	WorkerID  string `json:"worker_id"`
+	Target    string `json:"target"`
+	Code      uint16 `json:"code"`
+	LatencyUs int64  `json:"latency_us"`
+	Timestamp string `json:"ts"`
+}
+
+// RecordWriter implements domain.RequestRecorder by batching records into
+// ClickHouse in the background.
+type RecordWriter struct {
+	client  *Client
+	records chan domain.RequestRecord
+	dropped atomic.Int64
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewRecordWriter starts a writer that inserts records through client.
+func NewRecordWriter(client *Client) *RecordWriter {
+	w := &RecordWriter{
+		client:  client,
+		records: make(chan domain.RequestRecord, recordBufferSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Record queues a record for writing, dropping it if the buffer is full.
+func (w *RecordWriter) Record(record domain.RequestRecord) {
+	select {
+	case w.records <- record:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Close writes the records still queued and stops the writer.
+func (w *RecordWriter) Close() {
+	w.once.Do(func() { close(w.records) })
+	<-w.done
+}
+
+// run collects records into batches and writes each one.
+func (w *RecordWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(recordFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.RequestRecord, 0, recordBatchSize)
+	for {
+		select {
+		case record, ok := <-w.records:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) < recordBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		w.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+// flush inserts a batch, logging rather than retrying on failure: records
+// are an analysis aid and the worker's result summary does not depend on them.
+func (w *RecordWriter) flush(batch []domain.RequestRecord) {
+	if dropped := w.dropped.Swap(0); dropped > 0 {
+		log.Printf("Warning: Dropped %d request records: ClickHouse writes are falling behind", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := w.client.InsertRecords(ctx, batch); err != nil {
+		log.Printf("Warning: Failed to write %d request records to ClickHouse: %v", len(batch), err)
+	}
+}
+
+// InsertRecords writes request records to the request_records table.
+func (c *Client) InsertRecords(ctx context.Context, records []domain.RequestRecord) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		enc.Encode(recordRow{
+			TestID:    record.TestID,
+			WorkerID:  record.WorkerID,
+			Target:    record.Target,
+			Code:      record.Code,
+			LatencyUs: record.Latency.Microseconds(),
+			Timestamp: record.Timestamp.UTC().Format(time.RFC3339Nano),
+		})
+	}
+
+	body, err := c.exec(ctx, `INSERT INTO request_records FORMAT JSONEachRow`, nil, &buf)
+	if err != nil {
+		return fmt.Errorf("failed to insert request records: %w", err)
+	}
+	body.Close()
+	return nil
+}
+
+// LatencyHeatmap counts a test's requests per time bucket and latency bucket.
+func (c *Client) LatencyHeatmap(ctx context.Context, testID string, interval time.Duration) ([]domain.HeatmapCell, error) {
+	query := `SELECT toStartOfInterval(ts, toIntervalMillisecond({interval_ms:UInt32})) AS t,
+                     toUInt8(floor(log2(greatest(latency_us, 1)))) AS bucket,
+                     count() AS requests
+              FROM request_records
+              WHERE test_id = {test_id:String}
+              GROUP BY t, bucket
+              ORDER BY t, bucket
+              FORMAT JSONEachRow`
+	params := map[string]string{"test_id": testID, "interval_ms": fmt.Sprint(interval.Milliseconds())}
+	body, err := c.exec(ctx, query, params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency heatmap for test %s: %w", testID, err)
+	}
+	defer body.Close()
+
+	var cells []domain.HeatmapCell
+	dec := json.NewDecoder(body)
+	for dec.More() {
+		var row struct {
+			T        time.Time `json:"t"`
+			Bucket   int       `json:"bucket"`
+			Requests uint64    `json:"requests"`
+		}
+		if err := dec.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to decode latency heatmap: %w", err)
+		}
+		cells = append(cells, domain.HeatmapCell{
+			Time:         row.T,
+			LatencyMinMs: math.Ldexp(1, row.Bucket) / 1000,
+			LatencyMaxMs: math.Ldexp(1, row.Bucket+1) / 1000,
+			Count:        row.Requests,
+		})
+	}
+	return cells, nil
+}
+
+// LatencyPercentiles computes exact latency percentiles over a test's requests,
+// optionally restricted to one target.
+func (c *Client) LatencyPercentiles(ctx context.Context, testID, target string) (*domain.LatencyPercentiles, error) {
+	query := `SELECT count() AS requests,
+                     quantilesExact(0.5, 0.9, 0.95, 0.99)(latency_us) AS quantiles,
+                     max(latency_us) AS max
+              FROM request_records
+              WHERE test_id = {test_id:String} AND ({target:String} = '' OR target = {target:String})
+              FORMAT JSONEachRow`
+	body, err := c.exec(ctx, query, map[string]string{"test_id": testID, "target": target}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency percentiles for test %s: %w", testID, err)
+	}
+	defer body.Close()
+
+	var row struct {
+		Requests  uint64    `json:"requests"`
+		Quantiles []float64 `json:"quantiles"`
+		Max       float64   `json:"max"`
+	}
+	if err := json.NewDecoder(body).Decode(&row); err != nil {
+		return nil, fmt.Errorf("failed to decode latency percentiles: %w", err)
+	}
+
+	percentiles := &domain.LatencyPercentiles{TestID: testID, Target: target, Requests: row.Requests}
+	if row.Requests == 0 {
+		return percentiles, nil
+	}
+	if len(row.Quantiles) != 4 {
+		return nil, fmt.Errorf("unexpected percentiles from ClickHouse: %v", row.Quantiles)
+	}
+	percentiles.P50Ms = row.Quantiles[0] / 1000
+	percentiles.P90Ms = row.Quantiles[1] / 1000
+	percentiles.P95Ms = row.Quantiles[2] / 1000
+	percentiles.P99Ms = row.Quantiles[3] / 1000
+	percentiles.MaxMs = row.Max / 1000
+	return percentiles, nil
+}
+
+// LatencyDistribution computes exact latency percentiles over the requests of
+// several tests, optionally restricted to one target.
+func (c *Client) LatencyDistribution(ctx context.Context, testIDs []string, target string) (*domain.LatencyDistribution, error) {
+	query := `SELECT count() AS requests,
+                     quantilesExact(0.5, 0.75, 0.9, 0.95, 0.99, 0.999)(latency_us) AS quantiles
+              FROM request_records
+              WHERE test_id IN {test_ids:Array(String)} AND ({target:String} = '' OR target = {target:String})
+              FORMAT JSONEachRow`
+	params := map[string]string{"test_ids": arrayParam(testIDs), "target": target}
+	body, err := c.exec(ctx, query, params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency distribution: %w", err)
+	}
+	defer body.Close()
+
+	var row struct {
+		Requests  uint64    `json:"requests"`
+		Quantiles []float64 `json:"quantiles"`
+	}
+	if err := json.NewDecoder(body).Decode(&row); err != nil {
+		return nil, fmt.Errorf("failed to decode latency distribution: %w", err)
+	}
+
+	distribution := &domain.LatencyDistribution{Requests: row.Requests, Source: domain.LatencySourceRequests}
+	if row.Requests == 0 {
+		return distribution, nil
+	}
+	if len(row.Quantiles) != 6 {
+		return nil, fmt.Errorf("unexpected percentiles from ClickHouse: %v", row.Quantiles)
+	}
+	distribution.P50Ms = row.Quantiles[0] / 1000
+	distribution.P75Ms = row.Quantiles[1] / 1000
+	distribution.P90Ms = row.Quantiles[2] / 1000
+	distribution.P95Ms = row.Quantiles[3] / 1000
+	distribution.P99Ms = row.Quantiles[4] / 1000
+	distribution.P999Ms = row.Quantiles[5] / 1000
+	return distribution, nil
+}
+
+// stringEscaper escapes a value inside a quoted ClickHouse string literal.
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// arrayParam formats strings as a ClickHouse Array(String) query parameter.
+func arrayParam(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + stringEscaper.Replace(v) + "'"
+	}
+	return "[" + strings.Join(quoted, ",") + "]"
+}
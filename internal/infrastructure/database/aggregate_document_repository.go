@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SaveAggregateDocument replaces the test's aggregate of the same name.
+func (p *PostgresDB) SaveAggregateDocument(ctx context.Context, doc *domain.AggregateDocument) error {
+	_, err := p.db.ExecContext(ctx, `INSERT INTO aggregate_documents (test_id, name, document, computed_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (test_id, name) DO UPDATE SET document = EXCLUDED.document, computed_at = EXCLUDED.computed_at;`,
+		doc.TestID, doc.Name, string(doc.Document), doc.ComputedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save aggregate %s: %w", doc.Name, err)
+	}
+	return nil
+}
+
+// GetAggregateDocuments returns every aggregate of a test, by name.
+func (p *PostgresDB) GetAggregateDocuments(ctx context.Context, testID string) ([]*domain.AggregateDocument, error) {
+	return getAggregateDocuments(ctx, p.db, testID)
+}
+
+// SaveAggregateDocument replaces the test's aggregate of the same name.
+func (p *PortableDB) SaveAggregateDocument(ctx context.Context, doc *domain.AggregateDocument) error {
+	err := p.inTx(ctx, func(tx rebound) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM aggregate_documents WHERE test_id = $1 AND name = $2;`, doc.TestID, doc.Name); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO aggregate_documents (test_id, name, document, computed_at) VALUES ($1, $2, $3, $4);`,
+			doc.TestID, doc.Name, string(doc.Document), doc.ComputedAt.UTC())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save aggregate %s: %w", doc.Name, err)
+	}
+	return nil
+}
+
+// GetAggregateDocuments returns every aggregate of a test, by name.
+func (p *PortableDB) GetAggregateDocuments(ctx context.Context, testID string) ([]*domain.AggregateDocument, error) {
+	return getAggregateDocuments(ctx, p.db, testID)
+}
+
+func getAggregateDocuments(ctx context.Context, q queryer, testID string) ([]*domain.AggregateDocument, error) {
+	rows, err := q.QueryContext(ctx, `SELECT test_id, name, document, computed_at FROM aggregate_documents WHERE test_id = $1 ORDER BY name;`, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*domain.AggregateDocument{}
+	for rows.Next() {
+		var doc domain.AggregateDocument
+		var document string
+		if err := rows.Scan(&doc.TestID, &doc.Name, &document, &doc.ComputedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+		}
+		doc.Document = []byte(document)
+		docs = append(docs, &doc)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const apiKeyColumns = `id, user_id, name, hint, key_hash, created_at, last_used_at`
+
+// The API key queries are plain SQL shared by every database; PortableDB
+// runs them through rebound.
+
+// CreateAPIKey stores a new API key.
+func (p *PostgresDB) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	return createAPIKey(ctx, p.db, key)
+}
+
+// GetAPIKeyByHash returns the key with hash, or nil if there is none.
+func (p *PostgresDB) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	return getAPIKeyByHash(ctx, p.db, keyHash)
+}
+
+// GetAPIKeysByUser lists a user's keys, newest first.
+func (p *PostgresDB) GetAPIKeysByUser(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	return getAPIKeysByUser(ctx, p.db, userID)
+}
+
+// DeleteAPIKey removes one of a user's keys.
+func (p *PostgresDB) DeleteAPIKey(ctx context.Context, keyID, userID string) error {
+	return deleteAPIKey(ctx, p.db, keyID, userID)
+}
+
+// TouchAPIKey records when a key was last used.
+func (p *PostgresDB) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	return touchAPIKey(ctx, p.db, keyID, usedAt)
+}
+
+// CreateAPIKey stores a new API key.
+func (p *PortableDB) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	return createAPIKey(ctx, p.db, key)
+}
+
+// GetAPIKeyByHash returns the key with hash, or nil if there is none.
+func (p *PortableDB) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	return getAPIKeyByHash(ctx, p.db, keyHash)
+}
+
+// GetAPIKeysByUser lists a user's keys, newest first.
+func (p *PortableDB) GetAPIKeysByUser(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	return getAPIKeysByUser(ctx, p.db, userID)
+}
+
+// DeleteAPIKey removes one of a user's keys.
+func (p *PortableDB) DeleteAPIKey(ctx context.Context, keyID, userID string) error {
+	return deleteAPIKey(ctx, p.db, keyID, userID)
+}
+
+// TouchAPIKey records when a key was last used.
+func (p *PortableDB) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	return touchAPIKey(ctx, p.db, keyID, usedAt)
+}
+
+func createAPIKey(ctx context.Context, q queryer, key *domain.APIKey) error {
+	query := `INSERT INTO api_keys (` + apiKeyColumns + `) VALUES ($1, $2, $3, $4, $5, $6, NULL);`
+	if _, err := q.ExecContext(ctx, query, key.ID, key.UserID, key.Name, key.Hint, key.KeyHash, key.CreatedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+func getAPIKeyByHash(ctx context.Context, q queryer, keyHash string) (*domain.APIKey, error) {
+	keys, err := queryAPIKeys(ctx, q, ` WHERE key_hash = $1`, keyHash)
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+func getAPIKeysByUser(ctx context.Context, q queryer, userID string) ([]*domain.APIKey, error) {
+	return queryAPIKeys(ctx, q, ` WHERE user_id = $1`, userID)
+}
+
+func queryAPIKeys(ctx context.Context, q queryer, where string, args ...interface{}) ([]*domain.APIKey, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys`+where+` ORDER BY created_at DESC;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key := &domain.APIKey{}
+		var lastUsedAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Hint, &key.KeyHash, &key.CreatedAt, &lastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func deleteAPIKey(ctx context.Context, q queryer, keyID, userID string) error {
+	res, err := q.ExecContext(ctx, `DELETE FROM api_keys WHERE id = $1 AND user_id = $2;`, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.New("API key not found")
+	}
+	return nil
+}
+
+func touchAPIKey(ctx context.Context, q queryer, keyID string, usedAt time.Time) error {
+	if _, err := q.ExecContext(ctx, `UPDATE api_keys SET last_used_at = $1 WHERE id = $2;`, usedAt.UTC(), keyID); err != nil {
+		return fmt.Errorf("failed to record API key use: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const attachmentColumns = `id, name, content_type, size, sha256, uploaded_by, created_at`
+
+func NewAttachmentRepository(db *PostgresDB) domain.AttachmentRepository {
+	return db
+}
+
+func scanAttachment(row rowScanner) (*domain.Attachment, error) {
+	var a domain.Attachment
+	if err := row.Scan(&a.ID, &a.Name, &a.ContentType, &a.Size, &a.SHA256, &a.UploadedBy, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SaveAttachment stores an attachment and its content.
+func (p *PostgresDB) SaveAttachment(ctx context.Context, attachment *domain.Attachment, data []byte) error {
+	query := `INSERT INTO attachments (` + attachmentColumns + `, data) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`
+	_, err := p.db.ExecContext(ctx, query, attachment.ID, attachment.Name, attachment.ContentType,
+		attachment.Size, attachment.SHA256, attachment.UploadedBy, attachment.CreatedAt, data)
+	if err != nil {
+		return fmt.Errorf("failed to save attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment retrieves an attachment's metadata.
+func (p *PostgresDB) GetAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1;`
+	attachment, err := scanAttachment(p.db.QueryRowContext(ctx, query, attachmentID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("attachment not found: %s", attachmentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// GetAttachmentData retrieves an attachment's content.
+func (p *PostgresDB) GetAttachmentData(ctx context.Context, attachmentID string) ([]byte, error) {
+	var data []byte
+	err := p.db.QueryRowContext(ctx, `SELECT data FROM attachments WHERE id = $1;`, attachmentID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("attachment not found: %s", attachmentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment data: %w", err)
+	}
+	return data, nil
+}
+
+// GetAttachmentsByUser lists the attachments uploaded by a user, newest first.
+func (p *PostgresDB) GetAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE uploaded_by = $1 ORDER BY created_at DESC;`
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*domain.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// DeleteAttachment removes an attachment.
+func (p *PostgresDB) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	res, err := p.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1;`, attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("attachment not found: %s", attachmentID)
+	}
+	return nil
+}
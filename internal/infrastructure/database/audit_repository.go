@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const auditColumns = `created_at, actor_id, actor_name, ip, forwarded_for, action, target, payload_digest`
+
+// defaultAuditLimit is the page size when the filter sets none.
+const defaultAuditLimit = 100
+
+// SaveAuditEntry appends an entry to the audit log.
+func (p *PostgresDB) SaveAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	query := `INSERT INTO audit_log (` + auditColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
+	err := p.db.QueryRowContext(ctx, query, entry.Time, entry.ActorID, entry.ActorName, entry.IP,
+		entry.ForwardedFor, entry.Action, entry.Target, entry.PayloadDigest).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditEntries returns matching audit entries, newest first.
+func (p *PostgresDB) GetAuditEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, int, error) {
+	return getAuditEntries(ctx, p.db, filter)
+}
+
+// getAuditEntries runs the audit log query shared by every database.
+func getAuditEntries(ctx context.Context, q queryer, filter domain.AuditFilter) ([]*domain.AuditEntry, int, error) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ActorID != "" {
+		where("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		where("action = $%d", filter.Action)
+	}
+	if filter.Target != "" {
+		where("target = $%d", filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		where("created_at >= $%d", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		where("created_at < $%d", filter.Until.UTC())
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log`+whereClause+`;`, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	query := fmt.Sprintf(`SELECT id, %s FROM audit_log%s ORDER BY created_at DESC, id DESC LIMIT %d OFFSET %d;`,
+		auditColumns, whereClause, limit, filter.Offset)
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.Time, &e.ActorID, &e.ActorName, &e.IP, &e.ForwardedFor,
+			&e.Action, &e.Target, &e.PayloadDigest); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, total, rows.Err()
+}
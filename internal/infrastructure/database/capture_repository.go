@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// The request capture queries are plain SQL shared by every database, apart
+// from how an upload that arrives twice is ignored; PortableDB runs them
+// through rebound.
+
+// SaveRequestCapture stores the requests a worker captured in a test. An
+// upload the worker retries is stored once.
+func (p *PostgresDB) SaveRequestCapture(ctx context.Context, capture *domain.RequestCapture, data []byte) error {
+	return saveRequestCapture(ctx, p.db, "INSERT", " ON CONFLICT DO NOTHING", capture, data)
+}
+
+// GetRequestCaptures lists a test's captures by worker, without their content.
+func (p *PostgresDB) GetRequestCaptures(ctx context.Context, testID string) ([]*domain.RequestCapture, error) {
+	return getRequestCaptures(ctx, p.db, testID)
+}
+
+// GetRequestCaptureData returns the gzipped requests a worker captured in a test.
+func (p *PostgresDB) GetRequestCaptureData(ctx context.Context, testID, workerID string) ([]byte, error) {
+	return getRequestCaptureData(ctx, p.db, testID, workerID)
+}
+
+// SaveRequestCapture stores the requests a worker captured in a test. An
+// upload the worker retries is stored once.
+func (p *PortableDB) SaveRequestCapture(ctx context.Context, capture *domain.RequestCapture, data []byte) error {
+	return saveRequestCapture(ctx, p.db, p.dialect.insertIgnore, "", capture, data)
+}
+
+// GetRequestCaptures lists a test's captures by worker, without their content.
+func (p *PortableDB) GetRequestCaptures(ctx context.Context, testID string) ([]*domain.RequestCapture, error) {
+	return getRequestCaptures(ctx, p.db, testID)
+}
+
+// GetRequestCaptureData returns the gzipped requests a worker captured in a test.
+func (p *PortableDB) GetRequestCaptureData(ctx context.Context, testID, workerID string) ([]byte, error) {
+	return getRequestCaptureData(ctx, p.db, testID, workerID)
+}
+
+func saveRequestCapture(ctx context.Context, q queryer, insert, onConflict string, capture *domain.RequestCapture, data []byte) error {
+	query := insert + ` INTO request_captures (test_id, worker_id, requests, size, truncated, created_at, data)
+              VALUES ($1, $2, $3, $4, $5, $6, $7)` + onConflict + `;`
+	_, err := q.ExecContext(ctx, query, capture.TestID, capture.WorkerID, capture.Requests, capture.Size,
+		capture.Truncated, capture.CreatedAt.UTC(), data)
+	if err != nil {
+		return fmt.Errorf("failed to save request capture of worker %s for test %s: %w", capture.WorkerID, capture.TestID, err)
+	}
+	return nil
+}
+
+func getRequestCaptures(ctx context.Context, q queryer, testID string) ([]*domain.RequestCapture, error) {
+	query := `SELECT worker_id, requests, size, truncated, created_at FROM request_captures WHERE test_id = $1 ORDER BY worker_id;`
+	rows, err := q.QueryContext(ctx, query, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request captures for test %s: %w", testID, err)
+	}
+	defer rows.Close()
+
+	var captures []*domain.RequestCapture
+	for rows.Next() {
+		capture := &domain.RequestCapture{TestID: testID}
+		if err := rows.Scan(&capture.WorkerID, &capture.Requests, &capture.Size, &capture.Truncated, &capture.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan request capture row: %w", err)
+		}
+		captures = append(captures, capture)
+	}
+	return captures, rows.Err()
+}
+
+func getRequestCaptureData(ctx context.Context, q queryer, testID, workerID string) ([]byte, error) {
+	var data []byte
+	err := q.QueryRowContext(ctx, `SELECT data FROM request_captures WHERE test_id = $1 AND worker_id = $2;`, testID, workerID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("request capture not found: worker %s of test %s", workerID, testID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request capture data: %w", err)
+	}
+	return data, nil
+}
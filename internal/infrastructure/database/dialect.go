@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// dialect holds what differs between the databases PortableDB runs on.
+type dialect struct {
+	name         string
+	migrations   goose.Dialect // Also names the directory under migrations/
+	insertIgnore string        // INSERT that skips rows whose key already exists
+	forUpdate    string        // Suffix locking rows selected for a read-modify-write
+}
+
+var sqliteDialect = dialect{
+	name:         "SQLite",
+	migrations:   goose.DialectSQLite3,
+	insertIgnore: "INSERT OR IGNORE",
+	// SQLite serializes writers, and PortableDB uses a single connection
+	forUpdate: "",
+}
+
+var mysqlDialect = dialect{
+	name:         "MySQL",
+	migrations:   goose.DialectMySQL,
+	insertIgnore: "INSERT IGNORE",
+	forUpdate:    " FOR UPDATE",
+}
+
+// queryer is satisfied by *sql.DB, *sql.Tx and rebound.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// rebound runs queries written with PostgreSQL's $N placeholders on databases
+// that only accept ?, so the same query text works on every dialect.
+type rebound struct {
+	q queryer
+}
+
+func (r rebound) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query, args = rebind(query, args)
+	return r.q.ExecContext(ctx, query, args...)
+}
+
+func (r rebound) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query, args = rebind(query, args)
+	return r.q.QueryContext(ctx, query, args...)
+}
+
+func (r rebound) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	query, args = rebind(query, args)
+	return r.q.QueryRowContext(ctx, query, args...)
+}
+
+// rebind replaces each $N placeholder in query with ? and returns the
+// arguments in placeholder order, repeating any placeholder used twice.
+// Queries must not contain $ inside string literals.
+func rebind(query string, args []interface{}) (string, []interface{}) {
+	var b strings.Builder
+	var ordered []interface{}
+	for {
+		i := strings.IndexByte(query, '$')
+		if i < 0 {
+			b.WriteString(query)
+			return b.String(), ordered
+		}
+		j := i + 1
+		for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+			j++
+		}
+		n, err := strconv.Atoi(query[i+1 : j])
+		if err != nil || n < 1 || n > len(args) {
+			b.WriteString(query[:j])
+			query = query[j:]
+			continue
+		}
+		b.WriteString(query[:i])
+		b.WriteByte('?')
+		ordered = append(ordered, args[n-1])
+		query = query[j:]
+	}
+}
+
+// jsonList stores a string list as JSON text in databases without an array
+// type. It scans into list and is written from it.
+type jsonList struct {
+	list *[]string
+}
+
+func (j jsonList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*j.list = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into a string list", src)
+	}
+	if len(data) == 0 {
+		*j.list = nil
+		return nil
+	}
+	return json.Unmarshal(data, j.list)
+}
+
+func (j jsonList) Value() (driver.Value, error) {
+	if *j.list == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(*j.list)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
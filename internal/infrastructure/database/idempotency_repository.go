@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// The idempotency key queries are plain SQL shared by every database;
+// PortableDB runs them through rebound.
+
+// GetIdempotencyKey returns the user's key if it was saved after since.
+func (p *PostgresDB) GetIdempotencyKey(ctx context.Context, userID, key string, since time.Time) (*domain.IdempotencyKey, error) {
+	return getIdempotencyKey(ctx, p.db, userID, key, since)
+}
+
+// SaveIdempotencyKey stores a key and deletes expired ones.
+func (p *PostgresDB) SaveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, expiredBefore time.Time) error {
+	return p.inTx(ctx, func(tx queryer) error { return saveIdempotencyKey(ctx, tx, key, expiredBefore) })
+}
+
+// GetIdempotencyKey returns the user's key if it was saved after since.
+func (p *PortableDB) GetIdempotencyKey(ctx context.Context, userID, key string, since time.Time) (*domain.IdempotencyKey, error) {
+	return getIdempotencyKey(ctx, p.db, userID, key, since)
+}
+
+// SaveIdempotencyKey stores a key and deletes expired ones.
+func (p *PortableDB) SaveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, expiredBefore time.Time) error {
+	return p.inTx(ctx, func(tx rebound) error { return saveIdempotencyKey(ctx, tx, key, expiredBefore) })
+}
+
+func getIdempotencyKey(ctx context.Context, q queryer, userID, key string, since time.Time) (*domain.IdempotencyKey, error) {
+	k := domain.IdempotencyKey{UserID: userID, Key: key}
+	err := q.QueryRowContext(ctx, `SELECT test_id, created_at FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2 AND created_at > $3;`, userID, key, since.UTC()).Scan(&k.TestID, &k.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &k, nil
+}
+
+func saveIdempotencyKey(ctx context.Context, q queryer, key *domain.IdempotencyKey, expiredBefore time.Time) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at < $1 OR (user_id = $2 AND idempotency_key = $3);`,
+		expiredBefore.UTC(), key.UserID, key.Key); err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	if _, err := q.ExecContext(ctx, `INSERT INTO idempotency_keys (user_id, idempotency_key, test_id, created_at) VALUES ($1, $2, $3, $4);`,
+		key.UserID, key.Key, key.TestID, key.CreatedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// The interim result queries are plain SQL shared by every database, apart
+// from how a flush that arrives twice is ignored; PortableDB runs them
+// through rebound.
+
+// SaveInterimResult stores one interim flush of a worker. A flush the worker
+// retries is stored once.
+func (p *PostgresDB) SaveInterimResult(ctx context.Context, result *domain.InterimResult) error {
+	return saveInterimResult(ctx, p.db, "INSERT", " ON CONFLICT DO NOTHING", result)
+}
+
+// GetInterimResults returns a test's interim results by sequence, without their raw metrics.
+func (p *PostgresDB) GetInterimResults(ctx context.Context, testID string) ([]*domain.InterimResult, error) {
+	return getInterimResults(ctx, p.db, testID, false)
+}
+
+// GetLatestInterimResults returns the last interim result of each of a test's workers.
+func (p *PostgresDB) GetLatestInterimResults(ctx context.Context, testID string) ([]*domain.InterimResult, error) {
+	return getInterimResults(ctx, p.db, testID, true)
+}
+
+// SaveInterimResult stores one interim flush of a worker. A flush the worker
+// retries is stored once.
+func (p *PortableDB) SaveInterimResult(ctx context.Context, result *domain.InterimResult) error {
+	return saveInterimResult(ctx, p.db, p.dialect.insertIgnore, "", result)
+}
+
+// GetInterimResults returns a test's interim results by sequence, without their raw metrics.
+func (p *PortableDB) GetInterimResults(ctx context.Context, testID string) ([]*domain.InterimResult, error) {
+	return getInterimResults(ctx, p.db, testID, false)
+}
+
+// GetLatestInterimResults returns the last interim result of each of a test's workers.
+func (p *PortableDB) GetLatestInterimResults(ctx context.Context, testID string) ([]*domain.InterimResult, error) {
+	return getInterimResults(ctx, p.db, testID, true)
+}
+
+func saveInterimResult(ctx context.Context, q queryer, insert, onConflict string, result *domain.InterimResult) error {
+	statusCodeJSON, err := json.Marshal(result.StatusCodes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status codes: %w", err)
+	}
+	query := insert + ` INTO interim_results (test_id, worker_id, sequence, metric, timestamp, total_requests, duration_ms, success_rate, average_latency_ms, p95_latency_ms, status_codes, successful_requests, failed_requests)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)` + onConflict + `;`
+	_, err = q.ExecContext(ctx, query, result.TestID, result.WorkerID, result.Sequence, result.Metric, result.Timestamp.UTC(),
+		result.TotalRequests, result.DurationMs, result.SuccessRate, result.AverageLatencyMs, result.P95LatencyMs,
+		string(statusCodeJSON), result.SuccessfulRequests, result.FailedRequests)
+	if err != nil {
+		return fmt.Errorf("failed to save interim result %d of worker %s for test %s: %w", result.Sequence, result.WorkerID, result.TestID, err)
+	}
+	return nil
+}
+
+// getInterimResults reads a test's interim results. The raw metrics of a
+// long test add up, so they are only read for the latest flush of each worker.
+func getInterimResults(ctx context.Context, q queryer, testID string, latest bool) ([]*domain.InterimResult, error) {
+	columns := `r.worker_id, r.sequence, r.timestamp, r.total_requests, r.duration_ms, r.success_rate, r.average_latency_ms, r.p95_latency_ms, r.status_codes, r.successful_requests, r.failed_requests`
+	query := `SELECT ` + columns + ` FROM interim_results r WHERE r.test_id = $1 ORDER BY r.sequence, r.worker_id;`
+	if latest {
+		query = `SELECT ` + columns + `, r.metric FROM interim_results r
+              JOIN (SELECT worker_id, MAX(sequence) AS sequence FROM interim_results WHERE test_id = $1 GROUP BY worker_id) l
+                ON r.worker_id = l.worker_id AND r.sequence = l.sequence
+              WHERE r.test_id = $1 ORDER BY r.worker_id;`
+	}
+	rows, err := q.QueryContext(ctx, query, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interim results for test %s: %w", testID, err)
+	}
+	defer rows.Close()
+
+	var results []*domain.InterimResult
+	for rows.Next() {
+		result := &domain.InterimResult{}
+		result.TestID = testID
+		var statusCodeJSON []byte
+		dest := []interface{}{
+			&result.WorkerID, &result.Sequence, &result.Timestamp, &result.TotalRequests, &result.DurationMs,
+			&result.SuccessRate, &result.AverageLatencyMs, &result.P95LatencyMs, &statusCodeJSON,
+			&result.SuccessfulRequests, &result.FailedRequests,
+		}
+		if latest {
+			dest = append(dest, &result.Metric)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan interim result row: %w", err)
+		}
+		if err := json.Unmarshal(statusCodeJSON, &result.StatusCodes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status codes: %w", err)
+		}
+		result.CompletedRequests = result.TotalRequests
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
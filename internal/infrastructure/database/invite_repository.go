@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const inviteColumns = `id, email, role, token_hash, invited_by, created_at, expires_at, sent_at, accepted_at, user_id, revoked_at`
+
+// The invite queries are plain SQL shared by every database; PortableDB
+// runs them through rebound.
+
+// CreateInvite stores a new invite.
+func (p *PostgresDB) CreateInvite(ctx context.Context, invite *domain.Invite) error {
+	return createInvite(ctx, p.db, invite)
+}
+
+// GetInvite returns the invite with id, or nil if there is none.
+func (p *PostgresDB) GetInvite(ctx context.Context, id string) (*domain.Invite, error) {
+	return getInvite(ctx, p.db, ` WHERE id = $1`, id)
+}
+
+// GetInviteByTokenHash returns the invite whose current token has hash, or nil if there is none.
+func (p *PostgresDB) GetInviteByTokenHash(ctx context.Context, tokenHash string) (*domain.Invite, error) {
+	return getInvite(ctx, p.db, ` WHERE token_hash = $1`, tokenHash)
+}
+
+// GetInvites lists every invite, newest first.
+func (p *PostgresDB) GetInvites(ctx context.Context) ([]*domain.Invite, error) {
+	return queryInvites(ctx, p.db, ``)
+}
+
+// ReissueInvite replaces the token of an invite that has not been used.
+func (p *PostgresDB) ReissueInvite(ctx context.Context, id, tokenHash string, sentAt, expiresAt time.Time) error {
+	return reissueInvite(ctx, p.db, id, tokenHash, sentAt, expiresAt)
+}
+
+// AcceptInvite marks an unused invite as used by userID.
+func (p *PostgresDB) AcceptInvite(ctx context.Context, id, userID string, acceptedAt time.Time) error {
+	return acceptInvite(ctx, p.db, id, userID, acceptedAt)
+}
+
+// RevokeInvite stops an unused invite from being used.
+func (p *PostgresDB) RevokeInvite(ctx context.Context, id string, revokedAt time.Time) error {
+	return revokeInvite(ctx, p.db, id, revokedAt)
+}
+
+// CreateInvite stores a new invite.
+func (p *PortableDB) CreateInvite(ctx context.Context, invite *domain.Invite) error {
+	return createInvite(ctx, p.db, invite)
+}
+
+// GetInvite returns the invite with id, or nil if there is none.
+func (p *PortableDB) GetInvite(ctx context.Context, id string) (*domain.Invite, error) {
+	return getInvite(ctx, p.db, ` WHERE id = $1`, id)
+}
+
+// GetInviteByTokenHash returns the invite whose current token has hash, or nil if there is none.
+func (p *PortableDB) GetInviteByTokenHash(ctx context.Context, tokenHash string) (*domain.Invite, error) {
+	return getInvite(ctx, p.db, ` WHERE token_hash = $1`, tokenHash)
+}
+
+// GetInvites lists every invite, newest first.
+func (p *PortableDB) GetInvites(ctx context.Context) ([]*domain.Invite, error) {
+	return queryInvites(ctx, p.db, ``)
+}
+
+// ReissueInvite replaces the token of an invite that has not been used.
+func (p *PortableDB) ReissueInvite(ctx context.Context, id, tokenHash string, sentAt, expiresAt time.Time) error {
+	return reissueInvite(ctx, p.db, id, tokenHash, sentAt, expiresAt)
+}
+
+// AcceptInvite marks an unused invite as used by userID.
+func (p *PortableDB) AcceptInvite(ctx context.Context, id, userID string, acceptedAt time.Time) error {
+	return acceptInvite(ctx, p.db, id, userID, acceptedAt)
+}
+
+// RevokeInvite stops an unused invite from being used.
+func (p *PortableDB) RevokeInvite(ctx context.Context, id string, revokedAt time.Time) error {
+	return revokeInvite(ctx, p.db, id, revokedAt)
+}
+
+func createInvite(ctx context.Context, q queryer, invite *domain.Invite) error {
+	query := `INSERT INTO user_invites (` + inviteColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL, '', NULL);`
+	_, err := q.ExecContext(ctx, query, invite.ID, invite.Email, invite.Role, invite.TokenHash, invite.InvitedBy,
+		invite.CreatedAt.UTC(), invite.ExpiresAt.UTC(), invite.SentAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+	return nil
+}
+
+func getInvite(ctx context.Context, q queryer, where string, args ...interface{}) (*domain.Invite, error) {
+	invites, err := queryInvites(ctx, q, where, args...)
+	if err != nil || len(invites) == 0 {
+		return nil, err
+	}
+	return invites[0], nil
+}
+
+func queryInvites(ctx context.Context, q queryer, where string, args ...interface{}) ([]*domain.Invite, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+inviteColumns+` FROM user_invites`+where+` ORDER BY created_at DESC;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invites: %w", err)
+	}
+	defer rows.Close()
+
+	invites := []*domain.Invite{}
+	for rows.Next() {
+		invite := &domain.Invite{}
+		var acceptedAt, revokedAt sql.NullTime
+		err := rows.Scan(&invite.ID, &invite.Email, &invite.Role, &invite.TokenHash, &invite.InvitedBy,
+			&invite.CreatedAt, &invite.ExpiresAt, &invite.SentAt, &acceptedAt, &invite.UserID, &revokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		if acceptedAt.Valid {
+			invite.AcceptedAt = &acceptedAt.Time
+		}
+		if revokedAt.Valid {
+			invite.RevokedAt = &revokedAt.Time
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+func reissueInvite(ctx context.Context, q queryer, id, tokenHash string, sentAt, expiresAt time.Time) error {
+	res, err := q.ExecContext(ctx, `UPDATE user_invites SET token_hash = $1, sent_at = $2, expires_at = $3
+		WHERE id = $4 AND accepted_at IS NULL AND revoked_at IS NULL;`, tokenHash, sentAt.UTC(), expiresAt.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to reissue invite: %w", err)
+	}
+	return inviteUpdated(res)
+}
+
+func acceptInvite(ctx context.Context, q queryer, id, userID string, acceptedAt time.Time) error {
+	res, err := q.ExecContext(ctx, `UPDATE user_invites SET accepted_at = $1, user_id = $2
+		WHERE id = $3 AND accepted_at IS NULL AND revoked_at IS NULL;`, acceptedAt.UTC(), userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to accept invite: %w", err)
+	}
+	return inviteUpdated(res)
+}
+
+func revokeInvite(ctx context.Context, q queryer, id string, revokedAt time.Time) error {
+	res, err := q.ExecContext(ctx, `UPDATE user_invites SET revoked_at = $1
+		WHERE id = $2 AND accepted_at IS NULL AND revoked_at IS NULL;`, revokedAt.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invite: %w", err)
+	}
+	return inviteUpdated(res)
+}
+
+// inviteUpdated fails if an update matched no invite that is still open.
+func inviteUpdated(res sql.Result) error {
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.New("invite not found or already used")
+	}
+	return nil
+}
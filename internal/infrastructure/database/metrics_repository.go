@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+func NewMetricsRepository(db *PostgresDB) domain.MetricsRepository {
+	return db
+}
+
+// initTimeSeries turns test_metrics into a TimescaleDB hypertable with a
+// per-minute continuous aggregate. Without the timescaledb extension the
+// plain table is used and time series are bucketed at query time.
+func (p *PostgresDB) initTimeSeries(ctx context.Context) {
+	if _, err := p.db.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS timescaledb;`); err != nil {
+		log.Printf("Info: timescaledb extension unavailable, test metrics use a plain table: %v", err)
+		return
+	}
+	queries := []string{
+		`SELECT create_hypertable('test_metrics', 'ts', if_not_exists => TRUE, migrate_data => TRUE);`,
+		`CREATE MATERIALIZED VIEW IF NOT EXISTS test_metrics_1m
+            WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+            SELECT test_id, time_bucket(INTERVAL '1 minute', ts) AS bucket,
+                   sum(requests) AS requests, sum(failures) AS failures, sum(latency_total_us) AS latency_total_us
+            FROM test_metrics
+            GROUP BY test_id, bucket
+            WITH NO DATA;`,
+		`SELECT add_continuous_aggregate_policy('test_metrics_1m',
+            start_offset => INTERVAL '1 day', end_offset => INTERVAL '1 minute',
+            schedule_interval => INTERVAL '1 minute', if_not_exists => TRUE);`,
+	}
+	for _, q := range queries {
+		if _, err := p.db.ExecContext(ctx, q); err != nil {
+			log.Printf("Warning: failed to set up TimescaleDB for test metrics: %v", err)
+			return
+		}
+	}
+}
+
+// SaveMetricSamples stores a batch of worker metric samples.
+func (p *PostgresDB) SaveMetricSamples(ctx context.Context, samples []domain.MetricSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(samples))
+	args := make([]interface{}, 0, len(samples)*6)
+	for i, s := range samples {
+		n := i * 6
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+		args = append(args, s.TestID, s.WorkerID, s.Time, s.Requests, s.Failures, s.LatencyTotalUs)
+	}
+
+	query := `INSERT INTO test_metrics (test_id, worker_id, ts, requests, failures, latency_total_us) VALUES ` +
+		strings.Join(placeholders, ", ") + `;`
+	err := p.inTx(ctx, func(tx queryer) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+		return saveStatusCodes(ctx, tx, samples)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save metric samples: %w", err)
+	}
+	return nil
+}
+
+// saveStatusCodes stores the status code counts of a batch of metric samples,
+// one row per sample and code.
+func saveStatusCodes(ctx context.Context, q queryer, samples []domain.MetricSample) error {
+	var placeholders []string
+	var args []interface{}
+	flush := func() error {
+		if len(placeholders) == 0 {
+			return nil
+		}
+		query := `INSERT INTO test_status_codes (test_id, worker_id, ts, code, requests) VALUES ` +
+			strings.Join(placeholders, ", ") + `;`
+		_, err := q.ExecContext(ctx, query, args...)
+		placeholders, args = placeholders[:0], args[:0]
+		return err
+	}
+	for _, s := range samples {
+		for code, count := range s.StatusCodes {
+			if count <= 0 {
+				continue
+			}
+			n := len(args)
+			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5))
+			args = append(args, s.TestID, s.WorkerID, s.Time.UTC(), code, count)
+			if len(placeholders) == metricSampleBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// GetTimeSeries sums a test's metric samples into buckets of interval. Whole
+// minute intervals read the continuous aggregate when TimescaleDB provides one.
+func (p *PostgresDB) GetTimeSeries(ctx context.Context, testID string, interval time.Duration) ([]domain.TimeSeriesPoint, error) {
+	var query string
+	var bucket interface{}
+	if interval%time.Minute == 0 && p.hasMetricsAggregate(ctx) {
+		query = `SELECT time_bucket($2::interval, bucket) AS t, sum(requests), sum(failures), sum(latency_total_us)
+                 FROM test_metrics_1m WHERE test_id = $1 GROUP BY t ORDER BY t;`
+		bucket = fmt.Sprintf("%d seconds", int64(interval.Seconds()))
+	} else {
+		query = `SELECT to_timestamp(floor(extract(epoch FROM ts)::float8 / $2::float8) * $2::float8) AS t, sum(requests), sum(failures), sum(latency_total_us)
+                 FROM test_metrics WHERE test_id = $1 GROUP BY t ORDER BY t;`
+		bucket = interval.Seconds()
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, testID, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time series for test %s: %w", testID, err)
+	}
+	defer rows.Close()
+
+	var points []domain.TimeSeriesPoint
+	for rows.Next() {
+		var point domain.TimeSeriesPoint
+		var latencyTotalUs int64
+		if err := rows.Scan(&point.Time, &point.Requests, &point.Failures, &latencyTotalUs); err != nil {
+			return nil, fmt.Errorf("failed to scan time series point: %w", err)
+		}
+		point.RPS = float64(point.Requests) / interval.Seconds()
+		if point.Requests > 0 {
+			point.ErrorRate = float64(point.Failures) / float64(point.Requests)
+			point.MeanLatencyMs = float64(latencyTotalUs) / float64(point.Requests) / 1000
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// GetStatusTimeline sums a test's status code counts into buckets of interval.
+func (p *PostgresDB) GetStatusTimeline(ctx context.Context, testID string, interval time.Duration) ([]domain.StatusCodePoint, error) {
+	return getStatusTimeline(ctx, p.db, testID, interval)
+}
+
+// getStatusTimeline runs the status timeline query shared by every database.
+// The buckets are computed here rather than with each database's date functions.
+func getStatusTimeline(ctx context.Context, q queryer, testID string, interval time.Duration) ([]domain.StatusCodePoint, error) {
+	rows, err := q.QueryContext(ctx, `SELECT ts, code, requests FROM test_status_codes WHERE test_id = $1 ORDER BY ts;`, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status timeline for test %s: %w", testID, err)
+	}
+	defer rows.Close()
+
+	var points []domain.StatusCodePoint
+	for rows.Next() {
+		var ts time.Time
+		var code string
+		var count int64
+		if err := rows.Scan(&ts, &code, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan status timeline point: %w", err)
+		}
+		bucket := time.Unix(0, ts.UnixNano()/int64(interval)*int64(interval)).UTC()
+		if len(points) == 0 || !points[len(points)-1].Time.Equal(bucket) {
+			points = append(points, domain.StatusCodePoint{Time: bucket, Codes: make(map[string]int64)})
+		}
+		points[len(points)-1].Requests += count
+		points[len(points)-1].Codes[code] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status timeline for test %s: %w", testID, err)
+	}
+	return points, nil
+}
+
+// hasMetricsAggregate reports whether the test_metrics_1m continuous
+// aggregate exists. It is checked on each call, so a master started in
+// read-only mode notices when the primary creates it.
+func (p *PostgresDB) hasMetricsAggregate(ctx context.Context) bool {
+	var exists bool
+	if err := p.db.QueryRowContext(ctx, `SELECT to_regclass('test_metrics_1m') IS NOT NULL;`).Scan(&exists); err != nil {
+		return false
+	}
+	return exists
+}
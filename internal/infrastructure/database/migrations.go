@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// migrationFiles holds the versioned schema migrations, one directory per
+// dialect. Add a migration with the next number to every directory.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// ErrSchemaOutOfDate is returned by CheckSchema when migrations are pending.
+var ErrSchemaOutOfDate = errors.New("database schema is out of date")
+
+// MigrationStatus describes one schema migration.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// migrator applies the embedded migrations of one dialect to a database.
+type migrator struct {
+	db      *sql.DB
+	dialect goose.Dialect
+}
+
+func (m migrator) provider() (*goose.Provider, error) {
+	fsys, err := fs.Sub(migrationFiles, "migrations/"+string(m.dialect))
+	if err != nil {
+		return nil, err
+	}
+	var opts []goose.ProviderOption
+	if m.dialect == goose.DialectPostgres {
+		// Masters started together with --auto-migrate take turns
+		locker, err := lock.NewPostgresSessionLocker()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, goose.WithSessionLocker(locker))
+	}
+	provider, err := goose.NewProvider(m.dialect, m.db, fsys, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// up applies all pending migrations.
+func (m migrator) up(ctx context.Context) error {
+	provider, err := m.provider()
+	if err != nil {
+		return err
+	}
+	results, err := provider.Up(ctx)
+	for _, result := range results {
+		log.Printf("Migration %s", result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// down rolls back the most recently applied migration.
+func (m migrator) down(ctx context.Context) error {
+	provider, err := m.provider()
+	if err != nil {
+		return err
+	}
+	result, err := provider.Down(ctx)
+	if result != nil {
+		log.Printf("Migration %s", result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	return nil
+}
+
+// status lists every migration, oldest first.
+func (m migrator) status(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := m.provider()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+	var out []MigrationStatus
+	for _, s := range statuses {
+		out = append(out, MigrationStatus{
+			Version:   s.Source.Version,
+			Name:      filepath.Base(s.Source.Path),
+			Applied:   s.State == goose.StateApplied,
+			AppliedAt: s.AppliedAt,
+		})
+	}
+	return out, nil
+}
+
+// check returns ErrSchemaOutOfDate unless every migration has been applied.
+func (m migrator) check(ctx context.Context) error {
+	provider, err := m.provider()
+	if err != nil {
+		return err
+	}
+	current, target, err := provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if current < target {
+		return fmt.Errorf("%w: at version %d, need %d (run `migrate up`)", ErrSchemaOutOfDate, current, target)
+	}
+	return nil
+}
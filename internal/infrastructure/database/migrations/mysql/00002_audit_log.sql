@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE audit_log (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor_name VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(255) NOT NULL DEFAULT '',
+    forwarded_for VARCHAR(1024) NOT NULL DEFAULT '',
+    action VARCHAR(100) NOT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    payload_digest VARCHAR(64) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id, created_at);
+CREATE INDEX idx_audit_log_action ON audit_log(action, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE shared_links ADD COLUMN revoked_at DATETIME(6) NULL;
+ALTER TABLE shared_links ADD COLUMN access_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE shared_links ADD COLUMN last_accessed_at DATETIME(6) NULL;
+
+CREATE INDEX idx_shared_links_shared_by ON shared_links(shared_by);
+
+-- +goose Down
+DROP INDEX idx_shared_links_shared_by ON shared_links;
+ALTER TABLE shared_links DROP COLUMN last_accessed_at;
+ALTER TABLE shared_links DROP COLUMN access_count;
+ALTER TABLE shared_links DROP COLUMN revoked_at;
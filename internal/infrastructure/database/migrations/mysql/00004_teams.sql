@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE teams (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    updated_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE team_members (
+    team_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (team_id, user_id),
+    INDEX idx_team_members_user_id (user_id),
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE shared_links ADD COLUMN team_id VARCHAR(255) NULL;
+
+-- +goose Down
+ALTER TABLE shared_links DROP COLUMN team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
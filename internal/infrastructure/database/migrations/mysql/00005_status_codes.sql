@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE test_status_codes (
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    ts DATETIME(6) NOT NULL,
+    code VARCHAR(8) NOT NULL,
+    requests BIGINT NOT NULL,
+    INDEX idx_test_status_codes_test_id_ts (test_id, ts)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS test_status_codes;
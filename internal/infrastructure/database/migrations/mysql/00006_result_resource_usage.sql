@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_results ADD COLUMN resource_usage_json TEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE test_results DROP COLUMN resource_usage_json;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN capacity_warning TEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN capacity_warning;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN partial_policy VARCHAR(16) NOT NULL DEFAULT 'bestEffort';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN partial_policy;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN override_guardrails BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN override_guardrails;
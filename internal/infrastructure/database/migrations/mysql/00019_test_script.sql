@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN script LONGTEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN script;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN target_build VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE test_results ADD COLUMN environment_json TEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE test_results DROP COLUMN environment_json;
+ALTER TABLE test_requests DROP COLUMN target_build;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN label_headers_json TEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN label_headers_json;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE user_invites (
+    id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    sent_at DATETIME(6) NOT NULL,
+    accepted_at DATETIME(6) NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    revoked_at DATETIME(6) NULL,
+    INDEX idx_user_invites_created_at (created_at)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_invites;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    preferences_json TEXT NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_preferences;
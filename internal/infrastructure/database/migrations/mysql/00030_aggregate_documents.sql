@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE aggregate_documents (
+    test_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    document LONGTEXT NOT NULL,
+    computed_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (test_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS aggregate_documents;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN apdex_json TEXT NOT NULL;
+ALTER TABLE test_results ADD COLUMN apdex_json TEXT NOT NULL;
+ALTER TABLE aggregated_test_results ADD COLUMN apdex_json TEXT NOT NULL;
+
+-- +goose Down
+ALTER TABLE aggregated_test_results DROP COLUMN apdex_json;
+ALTER TABLE test_results DROP COLUMN apdex_json;
+ALTER TABLE test_requests DROP COLUMN apdex_json;
@@ -0,0 +1,160 @@
+-- The schema as created by InitSchema before versioned migrations. Every
+-- statement is idempotent so databases created that way adopt it unchanged.
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) UNIQUE NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL DEFAULT 'user',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS workers (
+    id VARCHAR(255) PRIMARY KEY,
+    address VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    last_seen TIMESTAMP WITH TIME ZONE NOT NULL,
+    current_test_id VARCHAR(255) DEFAULT '',
+    last_progress_message TEXT DEFAULT '',
+    completed_requests BIGINT DEFAULT 0,
+    total_requests BIGINT DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS test_requests (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    vegeta_payload_json TEXT NOT NULL,
+    duration_seconds VARCHAR(50) NOT NULL,
+    rate_per_second BIGINT NOT NULL,
+    targets_base64 TEXT NOT NULL,
+    requester_id VARCHAR(255) NOT NULL,
+    worker_count INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    assigned_workers_ids TEXT[],
+    completed_workers TEXT[],
+    failed_workers TEXT[],
+    request_id_prefix VARCHAR(255) NOT NULL DEFAULT '',
+    scenario_json TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS test_results (
+    id VARCHAR(255) PRIMARY KEY,
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    metric JSONB NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_requests BIGINT NOT NULL,
+    completed_requests BIGINT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    success_rate DOUBLE PRECISION NOT NULL,
+    average_latency_ms DOUBLE PRECISION NOT NULL,
+    p95_latency_ms DOUBLE PRECISION NOT NULL,
+    status_codes JSONB NOT NULL,
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS aggregated_test_results (
+    test_id VARCHAR(255) PRIMARY KEY,
+    total_requests BIGINT NOT NULL,
+    successful_requests BIGINT NOT NULL,
+    failed_requests BIGINT NOT NULL,
+    avg_latency_ms DOUBLE PRECISION NOT NULL,
+    p95_latency_ms DOUBLE PRECISION NOT NULL,
+    error_rates JSONB NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    overall_status VARCHAR(50) NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS shared_links (
+    id VARCHAR(255) PRIMARY KEY,
+    test_id VARCHAR(255) NOT NULL,
+    shared_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_by TEXT[],
+    read_by TEXT[],
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    uploaded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    data BYTEA NOT NULL
+);
+
+-- Add worker_count column to existing test_requests table if it doesn't exist
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS worker_count INTEGER NOT NULL DEFAULT 1;
+
+-- Add request_id_prefix column for X-Request-ID correlation
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS request_id_prefix VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Add scenario_json column for multi-step scenario tests
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS scenario_json TEXT NOT NULL DEFAULT '';
+
+-- Add pre-flight check columns
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS preflight BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS probe_results_json TEXT NOT NULL DEFAULT '';
+
+-- Add explicit success/failure counts to worker results
+ALTER TABLE test_results ADD COLUMN IF NOT EXISTS successful_requests BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE test_results ADD COLUMN IF NOT EXISTS failed_requests BIGINT NOT NULL DEFAULT 0;
+
+-- Add target_urls column so tests can be searched by target
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS target_urls TEXT NOT NULL DEFAULT '';
+
+-- Add whole-test throughput to aggregated results
+ALTER TABLE aggregated_test_results ADD COLUMN IF NOT EXISTS throughput_rps DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Add distribution_plan_json column recording each worker's assigned rate
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS distribution_plan_json TEXT NOT NULL DEFAULT '';
+
+-- Add missing_results column flagging workers whose results never arrived
+ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS missing_results TEXT[];
+
+-- Per-second test metrics from worker progress reports
+CREATE TABLE IF NOT EXISTS test_metrics (
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    ts TIMESTAMP WITH TIME ZONE NOT NULL,
+    requests BIGINT NOT NULL,
+    failures BIGINT NOT NULL,
+    latency_total_us BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_test_metrics_test_id_ts ON test_metrics(test_id, ts);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
+
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_uploaded_by ON attachments(uploaded_by);
+
+-- +goose Down
+DROP TABLE IF EXISTS test_metrics;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS shared_links;
+DROP TABLE IF EXISTS aggregated_test_results;
+DROP TABLE IF EXISTS test_results;
+DROP TABLE IF EXISTS test_requests;
+DROP TABLE IF EXISTS workers;
+DROP TABLE IF EXISTS users;
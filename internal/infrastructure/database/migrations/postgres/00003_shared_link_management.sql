@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE shared_links ADD COLUMN revoked_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE shared_links ADD COLUMN access_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE shared_links ADD COLUMN last_accessed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_shared_links_shared_by ON shared_links(shared_by);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_shared_links_shared_by;
+ALTER TABLE shared_links DROP COLUMN last_accessed_at;
+ALTER TABLE shared_links DROP COLUMN access_count;
+ALTER TABLE shared_links DROP COLUMN revoked_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE teams (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE team_members (
+    team_id VARCHAR(255) NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+ALTER TABLE shared_links ADD COLUMN team_id VARCHAR(255);
+
+-- +goose Down
+ALTER TABLE shared_links DROP COLUMN team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN priority VARCHAR(16) NOT NULL DEFAULT 'normal';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN priority;
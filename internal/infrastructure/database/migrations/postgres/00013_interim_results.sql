@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN interim_interval VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE interim_results (
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    sequence INTEGER NOT NULL,
+    metric JSONB NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_requests BIGINT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    success_rate DOUBLE PRECISION NOT NULL,
+    average_latency_ms DOUBLE PRECISION NOT NULL,
+    p95_latency_ms DOUBLE PRECISION NOT NULL,
+    status_codes JSONB NOT NULL,
+    successful_requests BIGINT NOT NULL,
+    failed_requests BIGINT NOT NULL,
+    PRIMARY KEY (test_id, worker_id, sequence),
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS interim_results;
+ALTER TABLE test_requests DROP COLUMN interim_interval;
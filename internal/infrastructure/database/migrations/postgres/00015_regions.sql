@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN regions_json TEXT NOT NULL DEFAULT '';
+ALTER TABLE test_results ADD COLUMN region VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_results DROP COLUMN region;
+ALTER TABLE test_requests DROP COLUMN regions_json;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN protocol VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN protocol;
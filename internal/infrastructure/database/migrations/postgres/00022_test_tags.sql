@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_test_requests_status_created_at ON test_requests(status, created_at);
+
+-- +goose Down
+DROP INDEX idx_test_requests_status_created_at;
+ALTER TABLE test_requests DROP COLUMN tags;
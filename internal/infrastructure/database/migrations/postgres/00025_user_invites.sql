@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE user_invites (
+    id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_user_invites_created_at ON user_invites(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_invites;
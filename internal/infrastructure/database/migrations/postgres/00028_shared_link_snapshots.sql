@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE shared_links ADD COLUMN result_snapshot TEXT;
+
+-- +goose Down
+ALTER TABLE shared_links DROP COLUMN result_snapshot;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE aggregated_test_results ADD COLUMN rate_attainment_json TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE aggregated_test_results DROP COLUMN rate_attainment_json;
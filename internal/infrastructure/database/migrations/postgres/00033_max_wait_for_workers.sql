@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN max_wait_for_workers VARCHAR(50) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN max_wait_for_workers;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN template_targets BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE test_requests ADD COLUMN sequence_start BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN sequence_start;
+ALTER TABLE test_requests DROP COLUMN template_targets;
@@ -0,0 +1,120 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) UNIQUE NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL DEFAULT 'user',
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    last_login_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS test_requests (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    vegeta_payload_json TEXT NOT NULL,
+    duration_seconds VARCHAR(50) NOT NULL,
+    rate_per_second BIGINT NOT NULL,
+    targets_base64 TEXT NOT NULL,
+    requester_id VARCHAR(255) NOT NULL,
+    worker_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    assigned_workers_ids TEXT NOT NULL,
+    completed_workers TEXT NOT NULL,
+    failed_workers TEXT NOT NULL,
+    request_id_prefix VARCHAR(255) NOT NULL,
+    scenario_json TEXT NOT NULL,
+    preflight BOOLEAN NOT NULL,
+    probe_results_json TEXT NOT NULL,
+    target_urls TEXT NOT NULL,
+    distribution_plan_json TEXT NOT NULL,
+    missing_results TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS test_results (
+    id VARCHAR(255) PRIMARY KEY,
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    metric BLOB NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    total_requests BIGINT NOT NULL,
+    completed_requests BIGINT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    success_rate DOUBLE PRECISION NOT NULL,
+    average_latency_ms DOUBLE PRECISION NOT NULL,
+    p95_latency_ms DOUBLE PRECISION NOT NULL,
+    status_codes TEXT NOT NULL,
+    successful_requests BIGINT NOT NULL,
+    failed_requests BIGINT NOT NULL,
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS aggregated_test_results (
+    test_id VARCHAR(255) PRIMARY KEY,
+    total_requests BIGINT NOT NULL,
+    successful_requests BIGINT NOT NULL,
+    failed_requests BIGINT NOT NULL,
+    avg_latency_ms DOUBLE PRECISION NOT NULL,
+    p95_latency_ms DOUBLE PRECISION NOT NULL,
+    error_rates TEXT NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    throughput_rps DOUBLE PRECISION NOT NULL,
+    overall_status VARCHAR(50) NOT NULL,
+    completed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS shared_links (
+    id VARCHAR(255) PRIMARY KEY,
+    test_id VARCHAR(255) NOT NULL,
+    shared_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_by TEXT NOT NULL,
+    read_by TEXT NOT NULL,
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    uploaded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    data BLOB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS test_metrics (
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    ts TIMESTAMP NOT NULL,
+    requests BIGINT NOT NULL,
+    failures BIGINT NOT NULL,
+    latency_total_us BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_test_requests_requester_id ON test_requests(requester_id);
+
+CREATE INDEX IF NOT EXISTS idx_test_results_test_id ON test_results(test_id);
+
+CREATE INDEX IF NOT EXISTS idx_test_metrics_test_id_ts ON test_metrics(test_id, ts);
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_uploaded_by ON attachments(uploaded_by);
+
+-- +goose Down
+DROP TABLE IF EXISTS test_metrics;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS shared_links;
+DROP TABLE IF EXISTS aggregated_test_results;
+DROP TABLE IF EXISTS test_results;
+DROP TABLE IF EXISTS test_requests;
+DROP TABLE IF EXISTS users;
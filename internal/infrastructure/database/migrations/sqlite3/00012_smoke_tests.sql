@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN smoke BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE test_requests ADD COLUMN smoke_report_json TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN smoke_report_json;
+ALTER TABLE test_requests DROP COLUMN smoke;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN on_worker_failure VARCHAR(16) NOT NULL DEFAULT 'continue';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN on_worker_failure;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE test_results ADD COLUMN generator_overhead_json TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_results DROP COLUMN generator_overhead_json;
@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN capture_requests BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE request_captures (
+    test_id VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    requests BIGINT NOT NULL,
+    size BIGINT NOT NULL,
+    truncated BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    data BLOB NOT NULL,
+    PRIMARY KEY (test_id, worker_id),
+    FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS request_captures;
+ALTER TABLE test_requests DROP COLUMN capture_requests;
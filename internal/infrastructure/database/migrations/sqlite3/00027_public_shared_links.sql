@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE shared_links ADD COLUMN public BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE shared_links DROP COLUMN public;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE test_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    test_id VARCHAR(255) NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_test_events_test_id ON test_events(test_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS test_events;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN think_time VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE test_requests ADD COLUMN pacing_jitter DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN pacing_jitter;
+ALTER TABLE test_requests DROP COLUMN think_time;
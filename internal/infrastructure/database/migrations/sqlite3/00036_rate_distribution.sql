@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE test_requests ADD COLUMN rate_distribution VARCHAR(16) NOT NULL DEFAULT 'shared';
+ALTER TABLE test_requests ADD COLUMN rate_weights_json TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE test_requests DROP COLUMN rate_weights_json;
+ALTER TABLE test_requests DROP COLUMN rate_distribution;
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/pressly/goose/v3"
 )
 
 // PostgresDB implements TestRepository, TestResultRepository, AggregatedResultRepository and WorkerRepository.
@@ -42,101 +44,67 @@ func NewPostgresDB(databaseURL string) (*PostgresDB, error) {
 	return &PostgresDB{db: db}, nil
 }
 
-// InitSchema creates the necessary tables if they don't exist.
-func (p *PostgresDB) InitSchema(ctx context.Context) error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS users (
-            id VARCHAR(255) PRIMARY KEY,
-            username VARCHAR(255) UNIQUE NOT NULL,
-            email VARCHAR(255) UNIQUE NOT NULL,
-            password_hash VARCHAR(255) NOT NULL,
-            first_name VARCHAR(255) NOT NULL,
-            last_name VARCHAR(255) NOT NULL,
-            role VARCHAR(50) NOT NULL DEFAULT 'user',
-            is_active BOOLEAN NOT NULL DEFAULT true,
-            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-            last_login_at TIMESTAMP WITH TIME ZONE
-        );`,
-		`CREATE TABLE IF NOT EXISTS workers (
-            id VARCHAR(255) PRIMARY KEY,
-            address VARCHAR(255) NOT NULL,
-            status VARCHAR(50) NOT NULL,
-            last_seen TIMESTAMP WITH TIME ZONE NOT NULL,
-            current_test_id VARCHAR(255) DEFAULT '',
-            last_progress_message TEXT DEFAULT '',
-            completed_requests BIGINT DEFAULT 0,
-            total_requests BIGINT DEFAULT 0
-        );`,
-		`CREATE TABLE IF NOT EXISTS test_requests (
-            id VARCHAR(255) PRIMARY KEY,
-            name VARCHAR(255) NOT NULL,
-            vegeta_payload_json TEXT NOT NULL,
-            duration_seconds VARCHAR(50) NOT NULL,
-            rate_per_second BIGINT NOT NULL,
-            targets_base64 TEXT NOT NULL,
-            requester_id VARCHAR(255) NOT NULL,
-            worker_count INTEGER NOT NULL DEFAULT 1,
-            created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-            status VARCHAR(50) NOT NULL,
-            assigned_workers_ids TEXT[],
-            completed_workers TEXT[],
-            failed_workers TEXT[]
-        );`,
-		`CREATE TABLE IF NOT EXISTS test_results (
-            id VARCHAR(255) PRIMARY KEY,
-            test_id VARCHAR(255) NOT NULL,
-            worker_id VARCHAR(255) NOT NULL,
-            metric JSONB NOT NULL,
-            timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
-            total_requests BIGINT NOT NULL,
-            completed_requests BIGINT NOT NULL,
-            duration_ms BIGINT NOT NULL,
-            success_rate DOUBLE PRECISION NOT NULL,
-            average_latency_ms DOUBLE PRECISION NOT NULL,
-            p95_latency_ms DOUBLE PRECISION NOT NULL,
-            status_codes JSONB NOT NULL,
-            FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
-        );`,
-		`CREATE TABLE IF NOT EXISTS aggregated_test_results (
-            test_id VARCHAR(255) PRIMARY KEY,
-            total_requests BIGINT NOT NULL,
-            successful_requests BIGINT NOT NULL,
-            failed_requests BIGINT NOT NULL,
-            avg_latency_ms DOUBLE PRECISION NOT NULL,
-            p95_latency_ms DOUBLE PRECISION NOT NULL,
-            error_rates JSONB NOT NULL,
-            duration_ms BIGINT NOT NULL,
-            overall_status VARCHAR(50) NOT NULL,
-            completed_at TIMESTAMP WITH TIME ZONE NOT NULL,
-            FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
-        );`,
-		`CREATE TABLE IF NOT EXISTS shared_links (
-			id VARCHAR(255) PRIMARY KEY,
-			test_id VARCHAR(255) NOT NULL,
-			shared_by VARCHAR(255) NOT NULL,
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
-			used_by TEXT[],
-			read_by TEXT[],
-			FOREIGN KEY (test_id) REFERENCES test_requests(id) ON DELETE CASCADE
-		);`,
-		// Add worker_count column to existing test_requests table if it doesn't exist
-		`ALTER TABLE test_requests ADD COLUMN IF NOT EXISTS worker_count INTEGER NOT NULL DEFAULT 1;`,
-		// Create indexes for better performance
-		`CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);`,
-		`CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);`,
-		`CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);`,
+// Migrate applies pending schema migrations, then sets up the optional
+// search indexes and TimescaleDB features where the extensions are available.
+func (p *PostgresDB) Migrate(ctx context.Context) error {
+	if err := p.migrator().up(ctx); err != nil {
+		return err
 	}
+	p.initSearchIndexes(ctx)
+	p.initTimeSeries(ctx)
+	log.Println("PostgreSQL schema is up to date.")
+	return nil
+}
+
+// RollbackMigration reverts the most recently applied schema migration.
+func (p *PostgresDB) RollbackMigration(ctx context.Context) error {
+	return p.migrator().down(ctx)
+}
+
+// MigrationStatus lists the schema migrations and whether each is applied.
+func (p *PostgresDB) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	return p.migrator().status(ctx)
+}
+
+// CheckSchema returns ErrSchemaOutOfDate if any migration is pending.
+func (p *PostgresDB) CheckSchema(ctx context.Context) error {
+	return p.migrator().check(ctx)
+}
 
+func (p *PostgresDB) migrator() migrator {
+	return migrator{db: p.db, dialect: goose.DialectPostgres}
+}
+
+// initSearchIndexes creates trigram indexes that let the substring matches of
+// global search and test listing filters use an index. They need the pg_trgm extension; without it
+// search still works, with sequential scans.
+func (p *PostgresDB) initSearchIndexes(ctx context.Context) {
+	if _, err := p.db.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS pg_trgm;`); err != nil {
+		log.Printf("Warning: pg_trgm extension unavailable, search will not be indexed: %v", err)
+		return
+	}
+	queries := []string{
+		`CREATE INDEX IF NOT EXISTS idx_test_requests_name_trgm ON test_requests USING GIN (lower(name) gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_test_requests_target_urls_trgm ON test_requests USING GIN (lower(target_urls) gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_test_requests_tags_trgm ON test_requests USING GIN (tags gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (lower(username) gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (lower(email) gin_trgm_ops);`,
+	}
 	for _, q := range queries {
-		_, err := p.db.ExecContext(ctx, q)
-		if err != nil {
-			return fmt.Errorf("failed to execute schema query: %w", err)
+		if _, err := p.db.ExecContext(ctx, q); err != nil {
+			log.Printf("Warning: failed to create search index: %v", err)
 		}
 	}
-	log.Println("PostgreSQL schema initialized successfully.")
-	return nil
+}
+
+// likeEscaper escapes LIKE wildcards with '!', which every supported database
+// accepts as an ESCAPE character (MySQL treats a backslash in '\' specially).
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+// likePattern builds a case-insensitive LIKE pattern matching query anywhere
+// in a value, for use with ESCAPE '!'.
+func likePattern(query string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
 }
 
 // Close closes the database connection.
@@ -144,11 +112,32 @@ func (p *PostgresDB) Close() error {
 	return p.db.Close()
 }
 
+// inTx runs fn in a transaction, committing if it returns nil.
+func (p *PostgresDB) inTx(ctx context.Context, fn func(tx queryer) error) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // GetDB returns the underlying sql.DB instance
 func (p *PostgresDB) GetDB() *sql.DB {
 	return p.db
 }
 
+// UserRepository returns a user repository sharing this connection.
+func (p *PostgresDB) UserRepository() domain.UserRepository {
+	return NewUserRepository(p.db)
+}
+
 // --- WorkerRepository Implementations ---
 
 // RegisterWorker registers or updates a worker's initial status.
@@ -169,7 +158,7 @@ func (p *PostgresDB) UpdateWorkerStatus(ctx context.Context, workerID string, st
 	query := `UPDATE workers SET status = $1, last_seen = $2, current_test_id = $3, last_progress_message = $4, completed_requests = $5, total_requests = $6 WHERE id = $7;`
 	_, err := p.db.ExecContext(ctx, query, status, time.Now(), currentTestID, progressMsg, completedReqs, totalReqs, workerID)
 	if err != nil {
-		return fmt.Errorf("failed to update worker status: $w", err)
+		return fmt.Errorf("failed to update worker status: %w", err)
 	}
 	return nil
 }
@@ -249,8 +238,91 @@ func (p *PostgresDB) MarkWorkerOffline(ctx context.Context, workerID string) err
 	return nil
 }
 
+// DeleteWorker removes a worker's registration.
+func (p *PostgresDB) DeleteWorker(ctx context.Context, workerID string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM workers WHERE id = $1;`, workerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete worker: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("worker not found: %s", workerID)
+	}
+	return nil
+}
+
 // --- TestRepository Implementations ---
 
+// testRequestColumns is the column list shared by all test_requests queries; keep it in sync with scanTestRequest.
+const testRequestColumns = `id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers, request_id_prefix, scenario_json, preflight, probe_results_json, target_urls, distribution_plan_json, missing_results, capacity_warning, priority, partial_policy, override_guardrails, smoke, smoke_report_json, interim_interval, on_worker_failure, regions_json, capture_requests, protocol, script, target_build, label_headers_json, tags, apdex_json, max_wait_for_workers, think_time, pacing_jitter, template_targets, sequence_start, rate_distribution, rate_weights_json`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTestRequest scans a row selected with testRequestColumns into a TestRequest.
+func scanTestRequest(row rowScanner) (*domain.TestRequest, error) {
+	return scanTestRequestLists(row, func(list *[]string) interface{} { return pq.Array(list) })
+}
+
+// scanTestRequestLists is scanTestRequest with the scanner used for the
+// worker list columns, which are stored differently by each database.
+func scanTestRequestLists(row rowScanner, listScanner func(*[]string) interface{}) (*domain.TestRequest, error) {
+	test := &domain.TestRequest{}
+	var probeResultsJSON, targetURLs, distributionPlanJSON, smokeReportJSON, regionsJSON, labelHeadersJSON, tags, apdexJSON, rateWeightsJSON string
+	err := row.Scan(
+		&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
+		&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, listScanner(&test.AssignedWorkersIDs), listScanner(&test.CompletedWorkers), listScanner(&test.FailedWorkers),
+		&test.RequestIDPrefix, &test.ScenarioJSON, &test.Preflight, &probeResultsJSON, &targetURLs, &distributionPlanJSON,
+		listScanner(&test.MissingResults), &test.CapacityWarning, &test.Priority, &test.PartialPolicy, &test.OverrideGuardrails,
+		&test.Smoke, &smokeReportJSON, &test.InterimInterval, &test.OnWorkerFailure, &regionsJSON, &test.CaptureRequests, &test.Protocol, &test.Script, &test.TargetBuild, &labelHeadersJSON, &tags, &apdexJSON, &test.MaxWaitForWorkers, &test.ThinkTime, &test.PacingJitter, &test.TemplateTargets, &test.SequenceStart, &test.RateDistribution, &rateWeightsJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+	test.InjectRequestID = test.RequestIDPrefix != ""
+	if distributionPlanJSON != "" {
+		if err := json.Unmarshal([]byte(distributionPlanJSON), &test.DistributionPlan); err != nil {
+			return nil, fmt.Errorf("failed to decode distribution plan: %w", err)
+		}
+	}
+	if targetURLs != "" {
+		test.TargetURLs = strings.Split(targetURLs, "\n")
+	}
+	test.Tags = splitTags(tags)
+	if probeResultsJSON != "" {
+		if err := json.Unmarshal([]byte(probeResultsJSON), &test.ProbeResults); err != nil {
+			return nil, fmt.Errorf("failed to decode probe results: %w", err)
+		}
+	}
+	if smokeReportJSON != "" {
+		if err := json.Unmarshal([]byte(smokeReportJSON), &test.SmokeReport); err != nil {
+			return nil, fmt.Errorf("failed to decode smoke report: %w", err)
+		}
+	}
+	if regionsJSON != "" {
+		if err := json.Unmarshal([]byte(regionsJSON), &test.Regions); err != nil {
+			return nil, fmt.Errorf("failed to decode regions: %w", err)
+		}
+	}
+	if labelHeadersJSON != "" {
+		if err := json.Unmarshal([]byte(labelHeadersJSON), &test.LabelHeaders); err != nil {
+			return nil, fmt.Errorf("failed to decode label headers: %w", err)
+		}
+	}
+	if apdexJSON != "" {
+		if err := json.Unmarshal([]byte(apdexJSON), &test.Apdex); err != nil {
+			return nil, fmt.Errorf("failed to decode apdex settings: %w", err)
+		}
+	}
+	if rateWeightsJSON != "" {
+		if err := json.Unmarshal([]byte(rateWeightsJSON), &test.RateWeights); err != nil {
+			return nil, fmt.Errorf("failed to decode rate weights: %w", err)
+		}
+	}
+	return test, nil
+}
+
 // SaveTestRequest saves a new test request.
 func (p *PostgresDB) SaveTestRequest(ctx context.Context, test *domain.TestRequest) error {
 	if test.ID == "" {
@@ -262,36 +334,87 @@ func (p *PostgresDB) SaveTestRequest(ctx context.Context, test *domain.TestReque
 	if test.Status == "" {
 		test.Status = "PENDING"
 	}
+	regionsJSON, err := marshalRegions(test.Regions)
+	if err != nil {
+		return err
+	}
+	labelHeadersJSON, err := marshalLabelHeaders(test.LabelHeaders)
+	if err != nil {
+		return err
+	}
+	apdexJSON, err := marshalApdexConfig(test.Apdex)
+	if err != nil {
+		return err
+	}
+	rateWeightsJSON, err := marshalRateWeights(test.RateWeights)
+	if err != nil {
+		return err
+	}
 
-	query := `INSERT INTO test_requests (id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);`
-	_, err := p.db.ExecContext(ctx, query, test.ID, test.Name, test.VegetaPayloadJSON, test.DurationSeconds,
+	query := `INSERT INTO test_requests (` + testRequestColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, '', $17, '', NULL, $18, $19, $20, $21, $22, '', $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39);`
+	_, err = p.db.ExecContext(ctx, query, test.ID, test.Name, test.VegetaPayloadJSON, test.DurationSeconds,
 		test.RatePerSecond, test.TargetsBase64, test.RequesterID, test.WorkerCount, test.CreatedAt, test.Status,
-		pq.Array(test.AssignedWorkersIDs), pq.Array(test.CompletedWorkers), pq.Array(test.FailedWorkers))
+		pq.Array(test.AssignedWorkersIDs), pq.Array(test.CompletedWorkers), pq.Array(test.FailedWorkers), test.RequestIDPrefix, test.ScenarioJSON,
+		test.Preflight, strings.Join(test.TargetURLs, "\n"), test.CapacityWarning, test.Priority, test.PartialPolicy, test.OverrideGuardrails, test.Smoke, test.InterimInterval, test.OnWorkerFailure, regionsJSON,
+		test.CaptureRequests, test.Protocol, test.Script, test.TargetBuild, labelHeadersJSON, joinTags(test.Tags), apdexJSON, test.MaxWaitForWorkers, test.ThinkTime, test.PacingJitter, test.TemplateTargets, test.SequenceStart, test.RateDistribution, rateWeightsJSON)
 	if err != nil {
 		return fmt.Errorf("failed to save test request: %w", err)
 	}
 	return nil
 }
 
-// UpdateTestStatus updates the status of a test request.
+// UpdateTestStatus moves a test to a new status and merges the given workers
+// into its completed and failed lists. The status check and the update run as
+// one statement, so a transition that is not allowed from the test's current
+// status (see domain.TestStatusesBefore) fails with ErrInvalidStatusTransition
+// and leaves the test untouched.
 func (p *PostgresDB) UpdateTestStatus(ctx context.Context, testID string, status string, completedWorkers, failedWorkers []string) error {
-	query := `UPDATE test_requests SET status = $1, completed_workers = $2, failed_workers = $3 WHERE id = $4;`
-	_, err := p.db.ExecContext(ctx, query, status, pq.Array(completedWorkers), pq.Array(failedWorkers), testID)
+	query := `UPDATE test_requests SET status = $1,
+                     completed_workers = ` + mergeWorkersSQL("completed_workers", "$2") + `,
+                     failed_workers = ` + mergeWorkersSQL("failed_workers", "$3") + `
+              WHERE id = $4 AND status = ANY($5);`
+	result, err := p.db.ExecContext(ctx, query, status, pq.Array(completedWorkers), pq.Array(failedWorkers), testID,
+		pq.Array(domain.TestStatusesBefore(status)))
 	if err != nil {
 		return fmt.Errorf("failed to update test status: %w", err)
 	}
-	return nil
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update test status: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	var current string
+	err = p.db.QueryRowContext(ctx, `SELECT status FROM test_requests WHERE id = $1;`, testID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("test request not found: %s", testID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update test status: %w", err)
+	}
+	return fmt.Errorf("%w: test %s cannot move from %s to %s", domain.ErrInvalidStatusTransition, testID, current, status)
+}
+
+// mergeWorkersSQL returns an expression that adds the workers in the array
+// parameter param to column, skipping any already present and keeping order.
+func mergeWorkersSQL(column, param string) string {
+	return `ARRAY(SELECT w FROM unnest(COALESCE(` + column + `, '{}') || COALESCE(` + param + `::text[], '{}'))
+                           WITH ORDINALITY AS t(w, n) GROUP BY w ORDER BY min(n))`
+}
+
+// appendWorkerSQL returns an expression that appends the worker in param to
+// column unless it is already there.
+func appendWorkerSQL(column, param string) string {
+	return `CASE WHEN ` + param + ` = ANY(` + column + `) THEN ` + column + ` ELSE array_append(` + column + `, ` + param + `) END`
 }
 
 // GetTestRequestByID retrieves a test request by its ID.
 func (p *PostgresDB) GetTestRequestByID(ctx context.Context, testID string) (*domain.TestRequest, error) {
-	test := &domain.TestRequest{}
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers FROM test_requests WHERE id = $1;`
-	err := p.db.QueryRowContext(ctx, query, testID).Scan(
-		&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-		&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-	)
+	query := `SELECT ` + testRequestColumns + ` FROM test_requests WHERE id = $1;`
+	test, err := scanTestRequest(p.db.QueryRowContext(ctx, query, testID))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("test request not found: %s", testID)
 	}
@@ -303,7 +426,7 @@ func (p *PostgresDB) GetTestRequestByID(ctx context.Context, testID string) (*do
 
 // GetAllTestRequests retrieves all test requests.
 func (p *PostgresDB) GetAllTestRequests(ctx context.Context) ([]*domain.TestRequest, error) {
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers FROM test_requests ORDER BY created_at DESC;`
+	query := `SELECT ` + testRequestColumns + ` FROM test_requests ORDER BY created_at DESC;`
 	rows, err := p.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all test requests: %w", err)
@@ -312,11 +435,7 @@ func (p *PostgresDB) GetAllTestRequests(ctx context.Context) ([]*domain.TestRequ
 
 	var tests []*domain.TestRequest
 	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
+		test, err := scanTestRequest(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan test request row: %w", err)
 		}
@@ -336,7 +455,7 @@ func (p *PostgresDB) GetTestRequestsPaginated(ctx context.Context, limit, offset
 	}
 
 	// Get paginated results
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers
+	query := `SELECT ` + testRequestColumns + `
 		FROM test_requests
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2`
@@ -349,11 +468,7 @@ func (p *PostgresDB) GetTestRequestsPaginated(ctx context.Context, limit, offset
 
 	var tests []*domain.TestRequest
 	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
+		test, err := scanTestRequest(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan test request row: %w", err)
 		}
@@ -363,48 +478,10 @@ func (p *PostgresDB) GetTestRequestsPaginated(ctx context.Context, limit, offset
 	return tests, totalCount, nil
 }
 
-// GetTestRequestsPaginatedByUser retrieves test requests for a specific user with pagination.
-func (p *PostgresDB) GetTestRequestsPaginatedByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.TestRequest, int, error) {
-	// Get total count for this user
-	var totalCount int
-	countQuery := `SELECT COUNT(*) FROM test_requests WHERE requester_id = $1`
-	err := p.db.QueryRowContext(ctx, countQuery, userID).Scan(&totalCount)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get total count for user: %w", err)
-	}
-
-	// Get paginated results for this user
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers
-		FROM test_requests
-		WHERE requester_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`
-
-	rows, err := p.db.QueryContext(ctx, query, userID, limit, offset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get paginated test requests by user: %w", err)
-	}
-	defer rows.Close()
-
-	var tests []*domain.TestRequest
-	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan test request row: %w", err)
-		}
-		tests = append(tests, test)
-	}
-
-	return tests, totalCount, nil
-}
-
-// IncrementTestAssignedWorkers appends a worker ID to the assigned_workers_ids array.
+// IncrementTestAssignedWorkers appends a worker ID to the assigned_workers_ids array
+// unless it is already there.
 func (p *PostgresDB) IncrementTestAssignedWorkers(ctx context.Context, testID string, workerID string) error {
-	query := `UPDATE test_requests SET assigned_workers_ids = array_append(assigned_workers_ids, $1) WHERE id = $2;`
+	query := `UPDATE test_requests SET assigned_workers_ids = ` + appendWorkerSQL("assigned_workers_ids", "$1") + ` WHERE id = $2;`
 	_, err := p.db.ExecContext(ctx, query, workerID, testID)
 	if err != nil {
 		return fmt.Errorf("failed to increment assigned workers for test %s: %w", testID, err)
@@ -412,9 +489,10 @@ func (p *PostgresDB) IncrementTestAssignedWorkers(ctx context.Context, testID st
 	return nil
 }
 
-// AddCompletedWorkerToTest adds a worker ID to the completed_workers array.
+// AddCompletedWorkerToTest adds a worker ID to the completed_workers array
+// unless it is already there.
 func (p *PostgresDB) AddCompletedWorkerToTest(ctx context.Context, testID string, workerID string) error {
-	query := `UPDATE test_requests SET completed_workers = array_append(completed_workers, $1) WHERE id = $2;`
+	query := `UPDATE test_requests SET completed_workers = ` + appendWorkerSQL("completed_workers", "$1") + ` WHERE id = $2;`
 	_, err := p.db.ExecContext(ctx, query, workerID, testID)
 	if err != nil {
 		return fmt.Errorf("failed to add completed worker to test %s: %w", testID, err)
@@ -422,9 +500,10 @@ func (p *PostgresDB) AddCompletedWorkerToTest(ctx context.Context, testID string
 	return nil
 }
 
-// AddFailedWorkerToTest adds a worker ID to the failed_workers array.
+// AddFailedWorkerToTest adds a worker ID to the failed_workers array unless it
+// is already there.
 func (p *PostgresDB) AddFailedWorkerToTest(ctx context.Context, testID string, workerID string) error {
-	query := `UPDATE test_requests SET failed_workers = array_append(failed_workers, $1) WHERE id = $2;`
+	query := `UPDATE test_requests SET failed_workers = ` + appendWorkerSQL("failed_workers", "$1") + ` WHERE id = $2;`
 	_, err := p.db.ExecContext(ctx, query, workerID, testID)
 	if err != nil {
 		return fmt.Errorf("failed to add failed worker to test %s: %w", testID, err)
@@ -432,6 +511,80 @@ func (p *PostgresDB) AddFailedWorkerToTest(ctx context.Context, testID string, w
 	return nil
 }
 
+// SaveProbeResults stores the results of a failed pre-flight check on a test.
+func (p *PostgresDB) SaveProbeResults(ctx context.Context, testID string, results []domain.ProbeResult) error {
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode probe results: %w", err)
+	}
+	query := `UPDATE test_requests SET probe_results_json = $1 WHERE id = $2;`
+	if _, err := p.db.ExecContext(ctx, query, string(resultsJSON), testID); err != nil {
+		return fmt.Errorf("failed to save probe results for test %s: %w", testID, err)
+	}
+	return nil
+}
+
+// SaveSmokeReport stores the responses each target gave in a smoke run.
+func (p *PostgresDB) SaveSmokeReport(ctx context.Context, testID string, report []domain.TargetResponse) error {
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode smoke report: %w", err)
+	}
+	query := `UPDATE test_requests SET smoke_report_json = $1 WHERE id = $2;`
+	if _, err := p.db.ExecContext(ctx, query, string(reportJSON), testID); err != nil {
+		return fmt.Errorf("failed to save smoke report for test %s: %w", testID, err)
+	}
+	return nil
+}
+
+// SaveDistributionPlan stores the rate each worker was given for a test.
+func (p *PostgresDB) SaveDistributionPlan(ctx context.Context, testID string, plan []domain.WorkerRate) error {
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("failed to encode distribution plan: %w", err)
+	}
+	query := `UPDATE test_requests SET distribution_plan_json = $1 WHERE id = $2;`
+	if _, err := p.db.ExecContext(ctx, query, string(planJSON), testID); err != nil {
+		return fmt.Errorf("failed to save distribution plan for test %s: %w", testID, err)
+	}
+	return nil
+}
+
+// SetMissingResults replaces the list of workers whose results are missing for a test.
+func (p *PostgresDB) SetMissingResults(ctx context.Context, testID string, workerIDs []string) error {
+	query := `UPDATE test_requests SET missing_results = $1 WHERE id = $2;`
+	if _, err := p.db.ExecContext(ctx, query, pq.Array(workerIDs), testID); err != nil {
+		return fmt.Errorf("failed to set missing results for test %s: %w", testID, err)
+	}
+	return nil
+}
+
+// SetTestPriority changes the scheduling priority of a test.
+func (p *PostgresDB) SetTestPriority(ctx context.Context, testID string, priority string) error {
+	return setTestPriority(ctx, p.db, testID, priority)
+}
+
+// SetTestRequester makes another user the owner of a test.
+func (p *PostgresDB) SetTestRequester(ctx context.Context, testID string, requesterID string) error {
+	return setTestRequester(ctx, p.db, testID, requesterID)
+}
+
+// setTestRequester runs the owner update shared by every database.
+func setTestRequester(ctx context.Context, q queryer, testID string, requesterID string) error {
+	if _, err := q.ExecContext(ctx, `UPDATE test_requests SET requester_id = $1 WHERE id = $2;`, requesterID, testID); err != nil {
+		return fmt.Errorf("failed to set requester of test %s: %w", testID, err)
+	}
+	return nil
+}
+
+// setTestPriority runs the priority update shared by every database.
+func setTestPriority(ctx context.Context, q queryer, testID string, priority string) error {
+	if _, err := q.ExecContext(ctx, `UPDATE test_requests SET priority = $1 WHERE id = $2;`, priority, testID); err != nil {
+		return fmt.Errorf("failed to set priority of test %s: %w", testID, err)
+	}
+	return nil
+}
+
 // --- TestResultRepository Implementations ---
 
 // SaveTestResult saves a single worker's test result.
@@ -447,12 +600,30 @@ func (p *PostgresDB) SaveTestResult(ctx context.Context, result *domain.TestResu
 	if err != nil {
 		return fmt.Errorf("failed to marshal status codes: %w", err)
 	}
+	resourceUsageJSON, err := marshalResourceUsage(result.ResourceUsage)
+	if err != nil {
+		return err
+	}
+	generatorOverheadJSON, err := marshalGeneratorOverhead(result.GeneratorOverhead)
+	if err != nil {
+		return err
+	}
+	environmentJSON, err := marshalRunEnvironment(result.Environment)
+	if err != nil {
+		return err
+	}
+	apdexJSON, err := marshalApdex(result.Apdex)
+	if err != nil {
+		return err
+	}
 
-	query := `INSERT INTO test_results (id, test_id, worker_id, metric, timestamp, total_requests, completed_requests, duration_ms, success_rate, average_latency_ms, p95_latency_ms, status_codes)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`
+	query := `INSERT INTO test_results (id, test_id, worker_id, metric, timestamp, total_requests, completed_requests, duration_ms, success_rate, average_latency_ms, p95_latency_ms, status_codes, successful_requests, failed_requests, resource_usage_json, region, generator_overhead_json, environment_json, apdex_json)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+              ON CONFLICT (id) DO NOTHING;` // Workers retry spooled results, so a result may arrive twice
 	_, err = p.db.ExecContext(ctx, query, result.ID, result.TestID, result.WorkerID, result.Metric, result.Timestamp,
 		result.TotalRequests, result.CompletedRequests, result.DurationMs, result.SuccessRate, result.AverageLatencyMs,
-		result.P95LatencyMs, statusCodeJSON)
+		result.P95LatencyMs, statusCodeJSON, result.SuccessfulRequests, result.FailedRequests, resourceUsageJSON, result.Region,
+		generatorOverheadJSON, environmentJSON, apdexJSON)
 	if err != nil {
 		return fmt.Errorf("failed to save test result: %w", err)
 	}
@@ -461,35 +632,240 @@ func (p *PostgresDB) SaveTestResult(ctx context.Context, result *domain.TestResu
 
 // GetResultsByTestID retrieves all raw test results for a given test ID.
 func (p *PostgresDB) GetResultsByTestID(ctx context.Context, testID string) ([]*domain.TestResult, error) {
-	query := `SELECT id, test_id, worker_id, metric, timestamp, total_requests, completed_requests, duration_ms, success_rate, average_latency_ms, p95_latency_ms, status_codes FROM test_results WHERE test_id = $1 ORDER BY timestamp ASC;`
-	rows, err := p.db.QueryContext(ctx, query, testID)
+	results, _, err := getResultsPage(ctx, p.db, testID, domain.ResultPage{})
+	return results, err
+}
+
+// GetResultsPage retrieves one page of a test's raw results, oldest first,
+// and how many results the test has.
+func (p *PostgresDB) GetResultsPage(ctx context.Context, testID string, page domain.ResultPage) ([]*domain.TestResult, int, error) {
+	return getResultsPage(ctx, p.db, testID, page)
+}
+
+// getResultsPage runs the result queries shared by every database. A page
+// without a limit holds all results from its offset.
+func getResultsPage(ctx context.Context, q queryer, testID string, page domain.ResultPage) ([]*domain.TestResult, int, error) {
+	var totalCount int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM test_results WHERE test_id = $1;`, testID).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count results by test ID: %w", err)
+	}
+	if totalCount == 0 {
+		return nil, 0, nil
+	}
+
+	metric := "metric"
+	if page.WithoutMetric {
+		metric = "NULL" // Leaves the largest column unread
+	}
+	limit := page.Limit
+	if limit <= 0 {
+		limit = totalCount
+	}
+	query := `SELECT id, test_id, worker_id, ` + metric + `, timestamp, total_requests, completed_requests, duration_ms, success_rate, average_latency_ms, p95_latency_ms, status_codes, successful_requests, failed_requests, resource_usage_json, region, generator_overhead_json, environment_json, apdex_json
+              FROM test_results WHERE test_id = $1 ORDER BY timestamp ASC, id ASC LIMIT $2 OFFSET $3;`
+	rows, err := q.QueryContext(ctx, query, testID, limit, page.Offset)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get results by test ID: %w", err)
+		return nil, 0, fmt.Errorf("failed to get results by test ID: %w", err)
 	}
 	defer rows.Close()
 
 	var results []*domain.TestResult
 	for rows.Next() {
 		result := &domain.TestResult{}
-		var metricJSON, statusCodeJSON []byte
+		var statusCodeJSON []byte
+		var resourceUsageJSON, generatorOverheadJSON, environmentJSON, apdexJSON string
 		err := rows.Scan(
-			&result.ID, &result.TestID, &result.WorkerID, &metricJSON, &result.Timestamp,
+			&result.ID, &result.TestID, &result.WorkerID, &result.Metric, &result.Timestamp,
 			&result.TotalRequests, &result.CompletedRequests, &result.DurationMs, &result.SuccessRate,
 			&result.AverageLatencyMs, &result.P95LatencyMs, &statusCodeJSON,
+			&result.SuccessfulRequests, &result.FailedRequests, &resourceUsageJSON, &result.Region, &generatorOverheadJSON, &environmentJSON, &apdexJSON,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan test result row: %w", err)
+			return nil, 0, fmt.Errorf("failed to scan test result row: %w", err)
 		}
-		result.Metric = metricJSON
-
-		err = json.Unmarshal(statusCodeJSON, &result.StatusCodes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal status codes: %w", err)
+		if err := json.Unmarshal(statusCodeJSON, &result.StatusCodes); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal status codes: %w", err)
+		}
+		if result.ResourceUsage, err = unmarshalResourceUsage(resourceUsageJSON); err != nil {
+			return nil, 0, err
+		}
+		if result.GeneratorOverhead, err = unmarshalGeneratorOverhead(generatorOverheadJSON); err != nil {
+			return nil, 0, err
+		}
+		if result.Environment, err = unmarshalRunEnvironment(environmentJSON); err != nil {
+			return nil, 0, err
+		}
+		if result.Apdex, err = unmarshalApdex(apdexJSON); err != nil {
+			return nil, 0, err
 		}
-
 		results = append(results, result)
 	}
-	return results, nil
+	return results, totalCount, rows.Err()
+}
+
+// marshalResourceUsage encodes a result's resource usage for the
+// resource_usage_json column; results without one store an empty string.
+func marshalResourceUsage(usage *domain.ResourceUsage) (string, error) {
+	if usage == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource usage: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalGeneratorOverhead encodes a result's generator overhead for the
+// generator_overhead_json column; results without one store an empty string.
+func marshalGeneratorOverhead(overhead *domain.GeneratorOverhead) (string, error) {
+	if overhead == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(overhead)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal generator overhead: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalRunEnvironment encodes a result's run environment for the
+// environment_json column; results without one store an empty string.
+func marshalRunEnvironment(environment *domain.RunEnvironment) (string, error) {
+	if environment == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(environment)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal run environment: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalRegions encodes a test's regions for the regions_json column; tests
+// that are not multi-region store an empty string.
+func marshalRegions(regions []domain.RegionShare) (string, error) {
+	if len(regions) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(regions)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal regions: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalLabelHeaders encodes a test's label headers for the
+// label_headers_json column; tests without any store an empty string.
+func marshalLabelHeaders(headers map[string]string) (string, error) {
+	if len(headers) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal label headers: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalResourceUsage decodes the resource_usage_json column.
+func unmarshalResourceUsage(data string) (*domain.ResourceUsage, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var usage domain.ResourceUsage
+	if err := json.Unmarshal([]byte(data), &usage); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource usage: %w", err)
+	}
+	return &usage, nil
+}
+
+// unmarshalGeneratorOverhead decodes the generator_overhead_json column.
+func unmarshalGeneratorOverhead(data string) (*domain.GeneratorOverhead, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var overhead domain.GeneratorOverhead
+	if err := json.Unmarshal([]byte(data), &overhead); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal generator overhead: %w", err)
+	}
+	return &overhead, nil
+}
+
+// marshalApdexConfig encodes a test's Apdex thresholds for the apdex_json
+// column; tests without any store an empty string.
+func marshalApdexConfig(config *domain.ApdexConfig) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal apdex settings: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalRateWeights encodes a test's rate weights for the rate_weights_json
+// column; tests without weights store an empty string.
+func marshalRateWeights(weights []float64) (string, error) {
+	if len(weights) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal rate weights: %w", err)
+	}
+	return string(data), nil
+}
+
+// marshalApdex encodes the Apdex score of a result or aggregate for the
+// apdex_json column; those without one store an empty string.
+func marshalApdex(apdex *domain.Apdex) (string, error) {
+	if apdex == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(apdex)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal apdex score: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalApdex decodes an apdex_json column of results and aggregates.
+func unmarshalApdex(data string) (*domain.Apdex, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var apdex domain.Apdex
+	if err := json.Unmarshal([]byte(data), &apdex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal apdex score: %w", err)
+	}
+	return &apdex, nil
+}
+
+// marshalRateAttainment encodes an aggregate's requested and achieved rates
+// for the rate_attainment_json column; those without any store an empty string.
+func marshalRateAttainment(report *domain.RateAttainmentReport) (string, error) {
+	if report == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal rate attainment: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalRunEnvironment decodes the environment_json column.
+func unmarshalRunEnvironment(data string) (*domain.RunEnvironment, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var environment domain.RunEnvironment
+	if err := json.Unmarshal([]byte(data), &environment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal run environment: %w", err)
+	}
+	return &environment, nil
 }
 
 // DeleteResultsByTestID deletes all raw test results for a given test ID.
@@ -515,8 +891,17 @@ func (p *PostgresDB) SaveAggregatedResult(ctx context.Context, result *domain.Te
 		return fmt.Errorf("failed to marshal error rates: %w", err)
 	}
 
-	query := `INSERT INTO aggregated_test_results (test_id, total_requests, successful_requests, failed_requests, avg_latency_ms, p95_latency_ms, error_rates, duration_ms, overall_status, completed_at)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	apdexJSON, err := marshalApdex(result.Apdex)
+	if err != nil {
+		return err
+	}
+	rateJSON, err := marshalRateAttainment(result.RateAttainment)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO aggregated_test_results (` + aggregatedResultColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
               ON CONFLICT (test_id) DO UPDATE SET
               total_requests = EXCLUDED.total_requests,
               successful_requests = EXCLUDED.successful_requests,
@@ -525,11 +910,14 @@ func (p *PostgresDB) SaveAggregatedResult(ctx context.Context, result *domain.Te
               p95_latency_ms = EXCLUDED.p95_latency_ms,
               error_rates = EXCLUDED.error_rates,
               duration_ms = EXCLUDED.duration_ms,
+              throughput_rps = EXCLUDED.throughput_rps,
               overall_status = EXCLUDED.overall_status,
-              completed_at = EXCLUDED.completed_at;` // Update on conflict to handle re-aggregation
+              completed_at = EXCLUDED.completed_at,
+              apdex_json = EXCLUDED.apdex_json,
+              rate_attainment_json = EXCLUDED.rate_attainment_json;` // Update on conflict to handle re-aggregation
 	_, err = p.db.ExecContext(ctx, query, result.TestID, result.TotalRequests, result.SuccessfulRequests,
 		result.FailedRequests, result.AvgLatencyMs, result.P95LatencyMs, errorRatesJSON,
-		result.DurationMs, result.OverallStatus, result.CompletedAt)
+		result.DurationMs, result.ThroughputRPS, result.OverallStatus, result.CompletedAt, apdexJSON, rateJSON)
 	if err != nil {
 		return fmt.Errorf("failed to save aggregated test result: %w", err)
 	}
@@ -542,32 +930,20 @@ func (p *PostgresDB) GetAggregatedResultByTestID(ctx context.Context, testID str
 		return nil, fmt.Errorf("test ID cannot be empty")
 	}
 
-	result := &domain.TestResultAggregated{}
-	var errorRatesJSON []byte
-	query := `SELECT test_id, total_requests, successful_requests, failed_requests, avg_latency_ms, p95_latency_ms, error_rates, duration_ms, overall_status, completed_at FROM aggregated_test_results WHERE test_id = $1;`
-	err := p.db.QueryRowContext(ctx, query, testID).Scan(
-		&result.TestID, &result.TotalRequests, &result.SuccessfulRequests, &result.FailedRequests,
-		&result.AvgLatencyMs, &result.P95LatencyMs, &errorRatesJSON, &result.DurationMs,
-		&result.OverallStatus, &result.CompletedAt,
-	)
+	query := `SELECT ` + aggregatedResultColumns + ` FROM aggregated_test_results WHERE test_id = $1;`
+	result, err := scanAggregatedResult(p.db.QueryRowContext(ctx, query, testID))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("aggregated test result not found for test ID: %s", testID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get aggregated test result by ID: %w", err)
 	}
-
-	err = json.Unmarshal(errorRatesJSON, &result.ErrorRates)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal error rates: %w", err)
-	}
-
 	return result, nil
 }
 
 // GetAllAggregatedResults retrieves all aggregated test results.
 func (p *PostgresDB) GetAllAggregatedResults(ctx context.Context) ([]*domain.TestResultAggregated, error) {
-	query := `SELECT test_id, total_requests, successful_requests, failed_requests, avg_latency_ms, p95_latency_ms, error_rates, duration_ms, overall_status, completed_at FROM aggregated_test_results ORDER BY completed_at DESC;`
+	query := `SELECT ` + aggregatedResultColumns + ` FROM aggregated_test_results ORDER BY completed_at DESC;`
 	rows, err := p.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all aggregated test results: %w", err)
@@ -576,22 +952,10 @@ func (p *PostgresDB) GetAllAggregatedResults(ctx context.Context) ([]*domain.Tes
 
 	var results []*domain.TestResultAggregated
 	for rows.Next() {
-		result := &domain.TestResultAggregated{}
-		var errorRatesJSON []byte
-		err := rows.Scan(
-			&result.TestID, &result.TotalRequests, &result.SuccessfulRequests, &result.FailedRequests,
-			&result.AvgLatencyMs, &result.P95LatencyMs, &errorRatesJSON, &result.DurationMs,
-			&result.OverallStatus, &result.CompletedAt,
-		)
+		result, err := scanAggregatedResult(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan aggregated test result row: %w", err)
 		}
-
-		err = json.Unmarshal(errorRatesJSON, &result.ErrorRates)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal error rates: %w", err)
-		}
-
 		results = append(results, result)
 	}
 	return results, nil
@@ -599,7 +963,7 @@ func (p *PostgresDB) GetAllAggregatedResults(ctx context.Context) ([]*domain.Tes
 
 // GetTestsInRange retrieves test requests within a date range
 func (p *PostgresDB) GetTestsInRange(ctx context.Context, startDate, endDate time.Time) ([]*domain.TestRequest, error) {
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers
+	query := `SELECT ` + testRequestColumns + `
               FROM test_requests
               WHERE created_at >= $1 AND created_at <= $2
               ORDER BY created_at DESC;`
@@ -612,11 +976,7 @@ func (p *PostgresDB) GetTestsInRange(ctx context.Context, startDate, endDate tim
 
 	var tests []*domain.TestRequest
 	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
+		test, err := scanTestRequest(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan test request row: %w", err)
 		}
@@ -627,7 +987,7 @@ func (p *PostgresDB) GetTestsInRange(ctx context.Context, startDate, endDate tim
 
 // GetTestRequestsByUser retrieves all test requests for a specific user.
 func (p *PostgresDB) GetTestRequestsByUser(ctx context.Context, userID string) ([]*domain.TestRequest, error) {
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers FROM test_requests WHERE requester_id = $1 ORDER BY created_at DESC;`
+	query := `SELECT ` + testRequestColumns + ` FROM test_requests WHERE requester_id = $1 ORDER BY created_at DESC;`
 	rows, err := p.db.QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get test requests by user: %w", err)
@@ -636,11 +996,30 @@ func (p *PostgresDB) GetTestRequestsByUser(ctx context.Context, userID string) (
 
 	var tests []*domain.TestRequest
 	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
+		test, err := scanTestRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan test request row: %w", err)
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// SearchTests finds tests whose name or target URLs contain query, ignoring
+// case, newest first. An empty userID searches every user's tests.
+func (p *PostgresDB) SearchTests(ctx context.Context, query, userID string, limit int) ([]*domain.TestRequest, error) {
+	q := `SELECT ` + testRequestColumns + ` FROM test_requests
+          WHERE (lower(name) LIKE $1 ESCAPE '!' OR lower(target_urls) LIKE $1 ESCAPE '!') AND ($2 = '' OR requester_id = $2)
+          ORDER BY created_at DESC LIMIT $3;`
+	rows, err := p.db.QueryContext(ctx, q, likePattern(query), userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search test requests: %w", err)
+	}
+	defer rows.Close()
+
+	var tests []*domain.TestRequest
+	for rows.Next() {
+		test, err := scanTestRequest(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan test request row: %w", err)
 		}
@@ -651,7 +1030,7 @@ func (p *PostgresDB) GetTestRequestsByUser(ctx context.Context, userID string) (
 
 // GetTestsInRangeByUser retrieves test requests for a user in a date range.
 func (p *PostgresDB) GetTestsInRangeByUser(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.TestRequest, error) {
-	query := `SELECT id, name, vegeta_payload_json, duration_seconds, rate_per_second, targets_base64, requester_id, worker_count, created_at, status, assigned_workers_ids, completed_workers, failed_workers FROM test_requests WHERE requester_id = $1 AND created_at >= $2 AND created_at <= $3 ORDER BY created_at DESC;`
+	query := `SELECT ` + testRequestColumns + ` FROM test_requests WHERE requester_id = $1 AND created_at >= $2 AND created_at <= $3 ORDER BY created_at DESC;`
 	rows, err := p.db.QueryContext(ctx, query, userID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get test requests by user in range: %w", err)
@@ -660,11 +1039,7 @@ func (p *PostgresDB) GetTestsInRangeByUser(ctx context.Context, userID string, s
 
 	var tests []*domain.TestRequest
 	for rows.Next() {
-		test := &domain.TestRequest{}
-		err := rows.Scan(
-			&test.ID, &test.Name, &test.VegetaPayloadJSON, &test.DurationSeconds, &test.RatePerSecond, &test.TargetsBase64,
-			&test.RequesterID, &test.WorkerCount, &test.CreatedAt, &test.Status, pq.Array(&test.AssignedWorkersIDs), pq.Array(&test.CompletedWorkers), pq.Array(&test.FailedWorkers),
-		)
+		test, err := scanTestRequest(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan test request row: %w", err)
 		}
@@ -697,15 +1072,15 @@ func (p *PostgresDB) CreateSharedLink(ctx context.Context, testID, sharedBy stri
 }
 
 func (p *PostgresDB) GetSharedLinkByID(ctx context.Context, linkID string) (*domain.SharedLink, error) {
-	row := p.db.QueryRowContext(ctx, `SELECT id, test_id, shared_by, created_at, expires_at, used_by FROM shared_links WHERE id = $1`, linkID)
-	var link domain.SharedLink
-	var usedBy []string
-	if err := row.Scan(&link.ID, &link.TestID, &link.SharedBy, &link.CreatedAt, &link.ExpiresAt, pq.Array(&usedBy)); err != nil {
+	row := p.db.QueryRowContext(ctx, `SELECT `+sharedLinkColumns+` FROM shared_links WHERE id = $1`, linkID)
+	link, err := scanPostgresSharedLink(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("shared link not found: %s", linkID)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to get shared link: %w", err)
 	}
-	link.UsedBy = usedBy
-	link.IsExpired = time.Now().After(link.ExpiresAt)
-	return &link, nil
+	return link, nil
 }
 
 func (p *PostgresDB) AddUsedBy(ctx context.Context, linkID, userID string) error {
@@ -713,23 +1088,20 @@ func (p *PostgresDB) AddUsedBy(ctx context.Context, linkID, userID string) error
 	return err
 }
 
+// GetInboxForUser lists the shared links a user received or opened, newest first.
 func (p *PostgresDB) GetInboxForUser(ctx context.Context, userID string) ([]*domain.SharedLink, error) {
-	rows, err := p.db.QueryContext(ctx, `SELECT id, test_id, shared_by, created_at, expires_at, used_by, read_by FROM shared_links WHERE used_by @> ARRAY[$1]`, userID)
+	rows, err := p.db.QueryContext(ctx, `SELECT `+sharedLinkColumns+` FROM shared_links WHERE used_by @> ARRAY[$1] AND revoked_at IS NULL ORDER BY created_at DESC`, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	var inbox []*domain.SharedLink
 	for rows.Next() {
-		var link domain.SharedLink
-		var usedBy, readBy []string
-		err := rows.Scan(&link.ID, &link.TestID, &link.SharedBy, &link.CreatedAt, &link.ExpiresAt, pq.Array(&usedBy), pq.Array(&readBy))
+		link, err := scanPostgresSharedLink(rows)
 		if err != nil {
 			return nil, err
 		}
-		link.UsedBy = usedBy
-		link.IsExpired = time.Now().After(link.ExpiresAt)
-		inbox = append(inbox, &link)
+		inbox = append(inbox, link)
 	}
 	return inbox, nil
 }
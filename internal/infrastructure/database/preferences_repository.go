@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Preferences are stored as one JSON document per user, so new settings need
+// no migration. Reading them is plain SQL shared by every database; saving
+// them replaces the document the way each database upserts best.
+
+// GetUserPreferences returns the preferences of a user, empty if they have saved none.
+func (p *PostgresDB) GetUserPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	return getUserPreferences(ctx, p.db, userID)
+}
+
+// SaveUserPreferences replaces the preferences of a user.
+func (p *PostgresDB) SaveUserPreferences(ctx context.Context, userID string, prefs *domain.UserPreferences) error {
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preferences: %w", err)
+	}
+	_, err = p.db.ExecContext(ctx, `INSERT INTO user_preferences (user_id, preferences_json, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET preferences_json = EXCLUDED.preferences_json, updated_at = EXCLUDED.updated_at;`,
+		userID, string(data), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
+
+// GetUserPreferences returns the preferences of a user, empty if they have saved none.
+func (p *PortableDB) GetUserPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	return getUserPreferences(ctx, p.db, userID)
+}
+
+// SaveUserPreferences replaces the preferences of a user.
+func (p *PortableDB) SaveUserPreferences(ctx context.Context, userID string, prefs *domain.UserPreferences) error {
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preferences: %w", err)
+	}
+	err = p.inTx(ctx, func(tx rebound) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM user_preferences WHERE user_id = $1;`, userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_preferences (user_id, preferences_json, updated_at) VALUES ($1, $2, $3);`,
+			userID, string(data), time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
+
+func getUserPreferences(ctx context.Context, q queryer, userID string) (*domain.UserPreferences, error) {
+	var data string
+	err := q.QueryRowContext(ctx, `SELECT preferences_json FROM user_preferences WHERE user_id = $1;`, userID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &domain.UserPreferences{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	prefs := &domain.UserPreferences{}
+	if err := json.Unmarshal([]byte(data), prefs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preferences: %w", err)
+	}
+	return prefs, nil
+}
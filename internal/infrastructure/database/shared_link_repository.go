@@ -1,12 +1,168 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
 )
 
-// SharedLinkRepository implementation for PostgresDB is already in postgres.go
-// This file is a placeholder for future custom logic if needed.
+// sharedLinkColumns is the column list read by every shared_links query; keep it in sync with scanSharedLink.
+const sharedLinkColumns = `id, test_id, shared_by, created_at, expires_at, used_by, read_by, access_count, last_accessed_at, revoked_at, team_id, public`
 
 func NewSharedLinkRepository(db *PostgresDB) domain.SharedLinkRepository {
 	return db
 }
+
+// scanSharedLink reads a row of sharedLinkColumns, using listScanner for the
+// used_by and read_by lists, which are stored differently by each database.
+func scanSharedLink(row rowScanner, listScanner func(*[]string) interface{}) (*domain.SharedLink, error) {
+	var link domain.SharedLink
+	var lastAccessedAt, revokedAt sql.NullTime
+	var teamID sql.NullString
+	if err := row.Scan(&link.ID, &link.TestID, &link.SharedBy, &link.CreatedAt, &link.ExpiresAt, listScanner(&link.UsedBy), listScanner(&link.ReadBy),
+		&link.AccessCount, &lastAccessedAt, &revokedAt, &teamID, &link.Public); err != nil {
+		return nil, err
+	}
+	link.TeamID = teamID.String
+	if lastAccessedAt.Valid {
+		link.LastAccessedAt = &lastAccessedAt.Time
+	}
+	if revokedAt.Valid {
+		link.RevokedAt = &revokedAt.Time
+	}
+	link.IsExpired = time.Now().After(link.ExpiresAt)
+	return &link, nil
+}
+
+func scanPostgresSharedLink(row rowScanner) (*domain.SharedLink, error) {
+	return scanSharedLink(row, func(list *[]string) interface{} { return pq.Array(list) })
+}
+
+// GetSharedLinksBySharer lists the links a user created, newest first.
+func (p *PostgresDB) GetSharedLinksBySharer(ctx context.Context, userID string) ([]*domain.SharedLink, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+sharedLinkColumns+` FROM shared_links WHERE shared_by = $1 ORDER BY created_at DESC;`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*domain.SharedLink{}
+	for rows.Next() {
+		link, err := scanPostgresSharedLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared link: %w", err)
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RecordSharedLinkAccess counts one visit to a shared link.
+func (p *PostgresDB) RecordSharedLinkAccess(ctx context.Context, linkID string) error {
+	return recordSharedLinkAccess(ctx, p.db, linkID)
+}
+
+// RevokeSharedLink stops a shared link from being opened.
+func (p *PostgresDB) RevokeSharedLink(ctx context.Context, linkID string) error {
+	return revokeSharedLink(ctx, p.db, linkID)
+}
+
+// SetSharedLinkTeam records the team a link was shared with.
+func (p *PostgresDB) SetSharedLinkTeam(ctx context.Context, linkID, teamID string) error {
+	return setSharedLinkTeam(ctx, p.db, linkID, teamID)
+}
+
+// SetSharedLinkPublic lets a link be opened without logging in.
+func (p *PostgresDB) SetSharedLinkPublic(ctx context.Context, linkID string) error {
+	return setSharedLinkPublic(ctx, p.db, linkID)
+}
+
+// SetSharedLinkSnapshot stores the result a link's recipients are shown.
+func (p *PostgresDB) SetSharedLinkSnapshot(ctx context.Context, linkID string, result *domain.TestResultAggregated) error {
+	return setSharedLinkSnapshot(ctx, p.db, linkID, result)
+}
+
+// GetSharedLinkSnapshot returns the result stored with a link, or nil if it has none.
+func (p *PostgresDB) GetSharedLinkSnapshot(ctx context.Context, linkID string) (*domain.TestResultAggregated, error) {
+	return getSharedLinkSnapshot(ctx, p.db, linkID)
+}
+
+// recordSharedLinkAccess is RecordSharedLinkAccess for every database.
+func recordSharedLinkAccess(ctx context.Context, q queryer, linkID string) error {
+	_, err := q.ExecContext(ctx, `UPDATE shared_links SET access_count = access_count + 1, last_accessed_at = $1 WHERE id = $2;`,
+		time.Now().UTC(), linkID)
+	if err != nil {
+		return fmt.Errorf("failed to record shared link access: %w", err)
+	}
+	return nil
+}
+
+// revokeSharedLink is RevokeSharedLink for every database. Revoking a
+// revoked link keeps its original revocation time.
+func revokeSharedLink(ctx context.Context, q queryer, linkID string) error {
+	res, err := q.ExecContext(ctx, `UPDATE shared_links SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL;`, time.Now().UTC(), linkID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke shared link: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		var exists int
+		if err := q.QueryRowContext(ctx, `SELECT 1 FROM shared_links WHERE id = $1;`, linkID).Scan(&exists); err == sql.ErrNoRows {
+			return fmt.Errorf("shared link not found: %s", linkID)
+		}
+	}
+	return nil
+}
+
+// setSharedLinkTeam is SetSharedLinkTeam for every database.
+func setSharedLinkTeam(ctx context.Context, q queryer, linkID, teamID string) error {
+	if _, err := q.ExecContext(ctx, `UPDATE shared_links SET team_id = $1 WHERE id = $2;`, teamID, linkID); err != nil {
+		return fmt.Errorf("failed to set shared link team: %w", err)
+	}
+	return nil
+}
+
+// setSharedLinkPublic is SetSharedLinkPublic for every database.
+func setSharedLinkPublic(ctx context.Context, q queryer, linkID string) error {
+	if _, err := q.ExecContext(ctx, `UPDATE shared_links SET public = $1 WHERE id = $2;`, true, linkID); err != nil {
+		return fmt.Errorf("failed to make shared link public: %w", err)
+	}
+	return nil
+}
+
+// setSharedLinkSnapshot is SetSharedLinkSnapshot for every database.
+func setSharedLinkSnapshot(ctx context.Context, q queryer, linkID string, result *domain.TestResultAggregated) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode shared link snapshot: %w", err)
+	}
+	if _, err := q.ExecContext(ctx, `UPDATE shared_links SET result_snapshot = $1 WHERE id = $2;`, string(data), linkID); err != nil {
+		return fmt.Errorf("failed to save shared link snapshot: %w", err)
+	}
+	return nil
+}
+
+// getSharedLinkSnapshot is GetSharedLinkSnapshot for every database.
+func getSharedLinkSnapshot(ctx context.Context, q queryer, linkID string) (*domain.TestResultAggregated, error) {
+	var data sql.NullString
+	err := q.QueryRowContext(ctx, `SELECT result_snapshot FROM shared_links WHERE id = $1;`, linkID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("shared link not found: %s", linkID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared link snapshot: %w", err)
+	}
+	if !data.Valid || data.String == "" {
+		return nil, nil
+	}
+	var result domain.TestResultAggregated
+	if err := json.Unmarshal([]byte(data.String), &result); err != nil {
+		return nil, fmt.Errorf("failed to decode shared link snapshot: %w", err)
+	}
+	return &result, nil
+}
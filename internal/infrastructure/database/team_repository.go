@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const teamColumns = `id, name, description, created_by, created_at, updated_at`
+
+// The team queries are plain SQL shared by every database; PortableDB runs
+// them through rebound.
+
+// CreateTeam stores a team with its members.
+func (p *PostgresDB) CreateTeam(ctx context.Context, team *domain.Team) error {
+	return p.inTx(ctx, func(tx queryer) error { return createTeam(ctx, tx, team) })
+}
+
+// GetTeamByID retrieves a team with its members.
+func (p *PostgresDB) GetTeamByID(ctx context.Context, teamID string) (*domain.Team, error) {
+	return getTeamByID(ctx, p.db, teamID)
+}
+
+// GetAllTeams lists every team with its members, by name.
+func (p *PostgresDB) GetAllTeams(ctx context.Context) ([]*domain.Team, error) {
+	return getAllTeams(ctx, p.db)
+}
+
+// UpdateTeam replaces a team's details and members.
+func (p *PostgresDB) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	return p.inTx(ctx, func(tx queryer) error { return updateTeam(ctx, tx, team) })
+}
+
+// DeleteTeam removes a team. Links already shared with it stay in members' inboxes.
+func (p *PostgresDB) DeleteTeam(ctx context.Context, teamID string) error {
+	return deleteTeam(ctx, p.db, teamID)
+}
+
+// CreateTeam stores a team with its members.
+func (p *PortableDB) CreateTeam(ctx context.Context, team *domain.Team) error {
+	return p.inTx(ctx, func(tx rebound) error { return createTeam(ctx, tx, team) })
+}
+
+// GetTeamByID retrieves a team with its members.
+func (p *PortableDB) GetTeamByID(ctx context.Context, teamID string) (*domain.Team, error) {
+	return getTeamByID(ctx, p.db, teamID)
+}
+
+// GetAllTeams lists every team with its members, by name.
+func (p *PortableDB) GetAllTeams(ctx context.Context) ([]*domain.Team, error) {
+	return getAllTeams(ctx, p.db)
+}
+
+// UpdateTeam replaces a team's details and members.
+func (p *PortableDB) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	return p.inTx(ctx, func(tx rebound) error { return updateTeam(ctx, tx, team) })
+}
+
+// DeleteTeam removes a team. Links already shared with it stay in members' inboxes.
+func (p *PortableDB) DeleteTeam(ctx context.Context, teamID string) error {
+	return deleteTeam(ctx, p.db, teamID)
+}
+
+func createTeam(ctx context.Context, q queryer, team *domain.Team) error {
+	query := `INSERT INTO teams (` + teamColumns + `) VALUES ($1, $2, $3, $4, $5, $6);`
+	_, err := q.ExecContext(ctx, query, team.ID, team.Name, team.Description, team.CreatedBy,
+		team.CreatedAt.UTC(), team.UpdatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return addTeamMembers(ctx, q, team.ID, team.MemberIDs)
+}
+
+func updateTeam(ctx context.Context, q queryer, team *domain.Team) error {
+	res, err := q.ExecContext(ctx, `UPDATE teams SET name = $1, description = $2, updated_at = $3 WHERE id = $4;`,
+		team.Name, team.Description, team.UpdatedAt.UTC(), team.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("team not found: %s", team.ID)
+	}
+	if _, err := q.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1;`, team.ID); err != nil {
+		return fmt.Errorf("failed to update team members: %w", err)
+	}
+	return addTeamMembers(ctx, q, team.ID, team.MemberIDs)
+}
+
+// addTeamMembers inserts the members of a team, checking first that each
+// user exists so that a bad ID is reported rather than failing a foreign key.
+func addTeamMembers(ctx context.Context, q queryer, teamID string, memberIDs []string) error {
+	for _, userID := range memberIDs {
+		var exists int
+		err := q.QueryRowContext(ctx, `SELECT 1 FROM users WHERE id = $1;`, userID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unknown team member: %s", userID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check team member: %w", err)
+		}
+		if _, err := q.ExecContext(ctx, `INSERT INTO team_members (team_id, user_id) VALUES ($1, $2);`, teamID, userID); err != nil {
+			return fmt.Errorf("failed to add team member: %w", err)
+		}
+	}
+	return nil
+}
+
+func getTeamByID(ctx context.Context, q queryer, teamID string) (*domain.Team, error) {
+	teams, err := queryTeams(ctx, q, ` WHERE id = $1`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, fmt.Errorf("team not found: %s", teamID)
+	}
+	return teams[0], nil
+}
+
+func getAllTeams(ctx context.Context, q queryer) ([]*domain.Team, error) {
+	return queryTeams(ctx, q, ``)
+}
+
+// queryTeams loads the teams matching where, then their members.
+func queryTeams(ctx context.Context, q queryer, where string, args ...interface{}) ([]*domain.Team, error) {
+	rows, err := q.QueryContext(ctx, `SELECT `+teamColumns+` FROM teams`+where+` ORDER BY name;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []*domain.Team{}
+	byID := make(map[string]*domain.Team)
+	for rows.Next() {
+		team := &domain.Team{MemberIDs: []string{}}
+		if err := rows.Scan(&team.ID, &team.Name, &team.Description, &team.CreatedBy, &team.CreatedAt, &team.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+		byID[team.ID] = team
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return teams, nil
+	}
+
+	memberWhere := ``
+	if where != `` {
+		memberWhere = ` WHERE team_id = $1`
+	}
+	members, err := q.QueryContext(ctx, `SELECT team_id, user_id FROM team_members`+memberWhere+` ORDER BY user_id;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	defer members.Close()
+	for members.Next() {
+		var teamID, userID string
+		if err := members.Scan(&teamID, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		if team, ok := byID[teamID]; ok {
+			team.MemberIDs = append(team.MemberIDs, userID)
+		}
+	}
+	return teams, members.Err()
+}
+
+func deleteTeam(ctx context.Context, q queryer, teamID string) error {
+	res, err := q.ExecContext(ctx, `DELETE FROM teams WHERE id = $1;`, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("team not found: %s", teamID)
+	}
+	return nil
+}
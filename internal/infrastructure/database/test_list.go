@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// joinTags stores a test's tags as ",a,b,", so a tag can be matched with
+// LIKE '%,tag,%'. Tests without tags store an empty string.
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+// splitTags reverses joinTags.
+func splitTags(stored string) []string {
+	stored = strings.Trim(stored, ",")
+	if stored == "" {
+		return nil
+	}
+	return strings.Split(stored, ",")
+}
+
+// testSortColumns maps each domain.TestSortFields value to what it orders by.
+var testSortColumns = map[string]string{
+	domain.TestSortCreated: "created_at",
+	domain.TestSortName:    "lower(name)",
+	domain.TestSortStatus:  "status",
+	domain.TestSortRate:    "rate_per_second",
+	domain.TestSortWorkers: "worker_count",
+}
+
+// testListQueries builds the queries counting and selecting the page of
+// tests chosen by filter, which share the returned arguments. The page query
+// takes two more: its limit and offset.
+func testListQueries(filter domain.TestListFilter) (countQuery, pageQuery string, args []interface{}) {
+	var conds []string
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if filter.UserID != "" {
+		conds = append(conds, "requester_id = "+arg(filter.UserID))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(filter.Status))
+	}
+	if filter.Priority != "" {
+		conds = append(conds, "priority = "+arg(filter.Priority))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= "+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, "created_at < "+arg(filter.CreatedBefore))
+	}
+	if filter.NameContains != "" {
+		conds = append(conds, "lower(name) LIKE "+arg(likePattern(filter.NameContains))+" ESCAPE '!'")
+	}
+	if filter.Tag != "" {
+		conds = append(conds, "tags LIKE "+arg("%,"+likeEscaper.Replace(filter.Tag)+",%")+" ESCAPE '!'")
+	}
+	if filter.TargetHost != "" {
+		// Target URLs are stored one per line; the host ends at a path, port,
+		// query, the next URL or the end of the list.
+		prefix := "%://" + likeEscaper.Replace(strings.ToLower(filter.TargetHost))
+		var hosts []string
+		for _, pattern := range []string{prefix + "/%", prefix + ":%", prefix + "?%", prefix + "\n%", prefix} {
+			hosts = append(hosts, "lower(target_urls) LIKE "+arg(pattern)+" ESCAPE '!'")
+		}
+		conds = append(conds, "("+strings.Join(hosts, " OR ")+")")
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	column, ok := testSortColumns[filter.SortBy]
+	if !ok {
+		column = testSortColumns[domain.TestSortCreated]
+	}
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+	countQuery = `SELECT COUNT(*) FROM test_requests` + where + `;`
+	pageQuery = `SELECT ` + testRequestColumns + ` FROM test_requests` + where +
+		` ORDER BY ` + column + ` ` + direction + `, id ` + direction +
+		` LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2) + `;`
+	return countQuery, pageQuery, args
+}
+
+// ListTests retrieves the page of tests selected by filter and how many tests
+// match it in total.
+func (p *PostgresDB) ListTests(ctx context.Context, filter domain.TestListFilter) ([]*domain.TestRequest, int, error) {
+	countQuery, pageQuery, args := testListQueries(filter)
+	var totalCount int
+	if err := p.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count tests: %w", err)
+	}
+
+	rows, err := p.db.QueryContext(ctx, pageQuery, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tests: %w", err)
+	}
+	defer rows.Close()
+
+	var tests []*domain.TestRequest
+	for rows.Next() {
+		test, err := scanTestRequest(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan test request row: %w", err)
+		}
+		tests = append(tests, test)
+	}
+	return tests, totalCount, rows.Err()
+}
+
+// ListTests retrieves the page of tests selected by filter and how many tests
+// match it in total.
+func (p *PortableDB) ListTests(ctx context.Context, filter domain.TestListFilter) ([]*domain.TestRequest, int, error) {
+	// Times are stored in UTC
+	filter.CreatedAfter, filter.CreatedBefore = filter.CreatedAfter.UTC(), filter.CreatedBefore.UTC()
+	countQuery, pageQuery, args := testListQueries(filter)
+	var totalCount int
+	if err := p.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count tests: %w", err)
+	}
+
+	tests, err := p.queryTestRequests(ctx, pageQuery, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tests: %w", err)
+	}
+	return tests, totalCount, nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const timelineColumns = `test_id, type, worker_id, status, message, created_at`
+
+// SaveTimelineEntry appends a step to a test's timeline.
+func (p *PostgresDB) SaveTimelineEntry(ctx context.Context, entry *domain.TestTimelineEntry) error {
+	query := `INSERT INTO test_events (` + timelineColumns + `) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
+	err := p.db.QueryRowContext(ctx, query, entry.TestID, entry.Type, entry.WorkerID, entry.Status,
+		entry.Message, entry.Time).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save timeline entry: %w", err)
+	}
+	return nil
+}
+
+// GetTestTimeline returns a test's timeline, oldest first.
+func (p *PostgresDB) GetTestTimeline(ctx context.Context, testID string) ([]*domain.TestTimelineEntry, error) {
+	return getTestTimeline(ctx, p.db, testID)
+}
+
+// SaveTimelineEntry appends a step to a test's timeline.
+func (p *PortableDB) SaveTimelineEntry(ctx context.Context, entry *domain.TestTimelineEntry) error {
+	query := `INSERT INTO test_events (` + timelineColumns + `) VALUES ($1, $2, $3, $4, $5, $6);`
+	res, err := p.db.ExecContext(ctx, query, entry.TestID, entry.Type, entry.WorkerID, entry.Status,
+		entry.Message, entry.Time.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save timeline entry: %w", err)
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		entry.ID = id
+	}
+	return nil
+}
+
+// GetTestTimeline returns a test's timeline, oldest first.
+func (p *PortableDB) GetTestTimeline(ctx context.Context, testID string) ([]*domain.TestTimelineEntry, error) {
+	return getTestTimeline(ctx, p.db, testID)
+}
+
+// getTestTimeline is GetTestTimeline for every database. Steps recorded in
+// the same instant keep the order they were saved in.
+func getTestTimeline(ctx context.Context, q queryer, testID string) ([]*domain.TestTimelineEntry, error) {
+	rows, err := q.QueryContext(ctx, `SELECT id, `+timelineColumns+` FROM test_events WHERE test_id = $1 ORDER BY created_at, id;`, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test timeline: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.TestTimelineEntry{}
+	for rows.Next() {
+		var e domain.TestTimelineEntry
+		if err := rows.Scan(&e.ID, &e.TestID, &e.Type, &e.WorkerID, &e.Status, &e.Message, &e.Time); err != nil {
+			return nil, fmt.Errorf("failed to scan timeline entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
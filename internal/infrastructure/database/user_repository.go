@@ -15,7 +15,21 @@ import (
 
 // UserRepository implements domain.UserRepository
 type UserRepository struct {
-	db *sql.DB
+	db queryer
+}
+
+// userColumns is the column list shared by all users queries; keep it in sync with scanUser.
+const userColumns = `id, username, email, password_hash, first_name, last_name, role, is_active,
+		       created_at, updated_at, last_login_at, must_change_password, password_changed_at`
+
+// scanUser scans a row selected with userColumns into a User.
+func scanUser(row rowScanner) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(
+		&user.ID, &user.Username, &user.Email, &user.Password,
+		&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.MustChangePassword, &user.PasswordChangedAt)
+	return user, err
 }
 
 // NewUserRepository creates a new user repository
@@ -26,13 +40,14 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 // CreateUser creates a new user in the database
 func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, username, email, password_hash, first_name, last_name, role, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO users (id, username, email, password_hash, first_name, last_name, role, is_active, created_at, updated_at, must_change_password, password_changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID, user.Username, user.Email, user.Password,
-		user.FirstName, user.LastName, user.Role, user.IsActive, user.CreatedAt, user.UpdatedAt)
+		user.FirstName, user.LastName, user.Role, user.IsActive, user.CreatedAt, user.UpdatedAt,
+		user.MustChangePassword, user.PasswordChangedAt)
 
 	return err
 }
@@ -40,16 +55,11 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) erro
 // GetUserByID retrieves a user by ID
 func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, is_active,
-		       created_at, updated_at, last_login_at
+		SELECT ` + userColumns + `
 		FROM users WHERE id = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Password,
-		&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -61,16 +71,11 @@ func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.Us
 // GetUserByUsername retrieves a user by username
 func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, is_active,
-		       created_at, updated_at, last_login_at
+		SELECT ` + userColumns + `
 		FROM users WHERE username = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Password,
-		&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, username))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -82,16 +87,11 @@ func (r *UserRepository) GetUserByUsername(ctx context.Context, username string)
 // GetUserByEmail retrieves a user by email
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, is_active,
-		       created_at, updated_at, last_login_at
+		SELECT ` + userColumns + `
 		FROM users WHERE email = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Password,
-		&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -166,15 +166,82 @@ func (r *UserRepository) UpdateUser(ctx context.Context, id string, updates *dom
 	return r.GetUserByID(ctx, id)
 }
 
-// UpdateUserPassword updates user password
-func (r *UserRepository) UpdateUserPassword(ctx context.Context, id string, passwordHash string) error {
+// UpdateUserPassword updates user password, keeping the replaced one in the
+// password history
+func (r *UserRepository) UpdateUserPassword(ctx context.Context, id string, passwordHash string, mustChange bool) error {
+	var replaced string
+	err := r.db.QueryRowContext(ctx, `SELECT password_hash FROM users WHERE id = $1`, id).Scan(&replaced)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, $3)
+	`, id, replaced, now)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE users
-		SET password_hash = $1, updated_at = $2
-		WHERE id = $3
+		SET password_hash = $1, must_change_password = $2, password_changed_at = $3, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, passwordHash, mustChange, now, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// GetPasswordHistory returns up to limit replaced password hashes, newest first
+func (r *UserRepository) GetPasswordHistory(ctx context.Context, id string, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
 	`
 
-	result, err := r.db.ExecContext(ctx, query, passwordHash, time.Now(), id)
+	rows, err := r.db.QueryContext(ctx, query, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
+// SetMustChangePassword sets whether a user must change their password
+func (r *UserRepository) SetMustChangePassword(ctx context.Context, id string, mustChange bool) error {
+	query := `UPDATE users SET must_change_password = $1 WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, mustChange, id)
 	if err != nil {
 		return err
 	}
@@ -236,8 +303,7 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*d
 
 	// Get users
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, is_active,
-		       created_at, updated_at, last_login_at
+		SELECT ` + userColumns + `
 		FROM users
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -251,11 +317,7 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*d
 
 	var users []*domain.User
 	for rows.Next() {
-		user := &domain.User{}
-		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.Password,
-			&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
-			&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -291,6 +353,8 @@ func (r *UserRepository) EnsureDefaultUser(ctx context.Context) error {
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+
+		MustChangePassword: true,
 	}
 
 	return r.CreateUser(ctx, defaultUser)
@@ -324,8 +388,7 @@ func (r *UserRepository) ResetDefaultUserPassword(ctx context.Context, newPasswo
 // GetAllUsers retrieves all users from the database
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, is_active,
-		       created_at, updated_at, last_login_at
+		SELECT ` + userColumns + `
 		FROM users ORDER BY created_at DESC
 	`
 
@@ -337,11 +400,35 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*domain.User, error
 
 	var users []*domain.User
 	for rows.Next() {
-		user := &domain.User{}
-		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.Password,
-			&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
-			&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SearchUsers finds users whose username, email or full name contains query,
+// ignoring case, newest first
+func (r *UserRepository) SearchUsers(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	q := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE lower(username) LIKE $1 ESCAPE '!' OR lower(email) LIKE $1 ESCAPE '!' OR lower(concat(first_name, ' ', last_name)) LIKE $1 ESCAPE '!'
+		ORDER BY created_at DESC LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, likePattern(query), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, err
 		}
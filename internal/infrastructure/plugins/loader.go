@@ -0,0 +1,79 @@
+// internal/infrastructure/plugins/loader.go
+package plugins
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Symbols an executor plugin exports. A plugin is a main package built with
+// `go build -buildmode=plugin` against the same version of this module, and
+// with the same Go toolchain, as the worker that loads it.
+const (
+	// RegisterSymbol is the plugin's
+	//	func RegisterExecutors(registry *domain.ExecutorRegistry) error
+	// which registers its executors under the protocols tests select them by.
+	RegisterSymbol = "RegisterExecutors"
+	// ProtocolsSymbol is an optional
+	//	var Protocols []string
+	// naming the target URL protocols the plugin's executors send, such as
+	// domain.ProtocolGRPC, so tests with such targets are assigned to the worker.
+	ProtocolsSymbol = "Protocols"
+)
+
+// AggregateRegisterSymbol is what an aggregate strategy plugin for the master
+// exports instead of RegisterSymbol:
+//
+//	func RegisterAggregateStrategies(registry *domain.AggregateStrategyRegistry) error
+//
+// which registers its strategies under the names the master enables them by.
+const AggregateRegisterSymbol = "RegisterAggregateStrategies"
+
+// Load opens every .so file in dir, in name order, and lets it register its
+// executors. Executors registered under a name already taken replace the
+// earlier one. It returns the protocols the plugins declare.
+func Load(dir string, registry *domain.ExecutorRegistry) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+	var protocols []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".so" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		declared, err := open(path, registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load executor plugin %s: %w", path, err)
+		}
+		protocols = append(protocols, declared...)
+		log.Printf("Loaded executor plugin %s (protocols: %v)", entry.Name(), declared)
+	}
+	return protocols, nil
+}
+
+// LoadAggregateStrategies opens every .so file in dir, in name order, and
+// lets it register its aggregate strategies. Strategies registered under a
+// name already taken replace the earlier one.
+func LoadAggregateStrategies(dir string, registry *domain.AggregateStrategyRegistry) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".so" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := openAggregateStrategies(path, registry); err != nil {
+			return fmt.Errorf("failed to load aggregate strategy plugin %s: %w", path, err)
+		}
+		log.Printf("Loaded aggregate strategy plugin %s", entry.Name())
+	}
+	return nil
+}
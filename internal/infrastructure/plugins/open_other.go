@@ -0,0 +1,18 @@
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Go plugins need cgo on Linux, macOS or FreeBSD.
+func open(path string, registry *domain.ExecutorRegistry) ([]string, error) {
+	return nil, fmt.Errorf("executor plugins are not supported by this build")
+}
+
+func openAggregateStrategies(path string, registry *domain.AggregateStrategyRegistry) error {
+	return fmt.Errorf("aggregate strategy plugins are not supported by this build")
+}
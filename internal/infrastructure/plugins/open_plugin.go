@@ -0,0 +1,54 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+func open(path string, registry *domain.ExecutorRegistry) ([]string, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(RegisterSymbol)
+	if err != nil {
+		return nil, err
+	}
+	register, ok := sym.(func(*domain.ExecutorRegistry) error)
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, not a func(*domain.ExecutorRegistry) error", RegisterSymbol, sym)
+	}
+	if err := register(registry); err != nil {
+		return nil, err
+	}
+
+	sym, err = p.Lookup(ProtocolsSymbol)
+	if err != nil {
+		return nil, nil // Protocols is optional
+	}
+	protocols, ok := sym.(*[]string)
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, not a []string", ProtocolsSymbol, sym)
+	}
+	return *protocols, nil
+}
+
+func openAggregateStrategies(path string, registry *domain.AggregateStrategyRegistry) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+	sym, err := p.Lookup(AggregateRegisterSymbol)
+	if err != nil {
+		return err
+	}
+	register, ok := sym.(func(*domain.AggregateStrategyRegistry) error)
+	if !ok {
+		return fmt.Errorf("%s is a %T, not a func(*domain.AggregateStrategyRegistry) error", AggregateRegisterSymbol, sym)
+	}
+	return register(registry)
+}
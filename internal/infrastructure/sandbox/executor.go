@@ -0,0 +1,257 @@
+// internal/infrastructure/sandbox/executor.go
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// ExecCommand is the hidden CLI command that runs a single attack inside the
+// subprocess. It must match the command registered in cmd.
+const ExecCommand = "attack-exec"
+
+// Execution modes passed to the subprocess.
+const (
+	ModeVegeta   = "vegeta"
+	ModeScenario = "scenario"
+	ModeScript   = "script"
+)
+
+// killGracePeriod is how long a cancelled subprocess gets to exit after
+// SIGTERM before it is killed.
+const killGracePeriod = 10 * time.Second
+
+// Limits bound the resources available to an attack subprocess. Zero values
+// leave the corresponding resource unlimited.
+type Limits struct {
+	MemoryBytes  uint64 // Hard limit via the cgroup's memory.max when CgroupPath is set; always the Go soft limit
+	MaxOpenFiles uint64 // Open file descriptor limit (RLIMIT_NOFILE), which caps connections
+	CgroupPath   string // Existing cgroup v2 directory the subprocess is moved into
+}
+
+// ApplyLimits restricts the current process. It is called by the attack
+// subprocess before the attack starts. Address space rlimits are avoided on
+// purpose: the Go runtime and resolver threads reserve far more virtual
+// memory than they use, so a cgroup is the reliable hard memory limit.
+func ApplyLimits(limits Limits) error {
+	if limits.MemoryBytes > 0 {
+		debug.SetMemoryLimit(int64(limits.MemoryBytes / 10 * 9)) // Collect harder before the hard limit
+	}
+	if limits.MaxOpenFiles > 0 {
+		return setOpenFilesLimit(limits.MaxOpenFiles)
+	}
+	return nil
+}
+
+// Envelope is a line of JSON written by the subprocess to stdout. Progress,
+// interim and capture envelopes are written while the attack runs; the last
+// one carries the result or error.
+type Envelope struct {
+	Progress *domain.ProgressSnapshot `json:"progress,omitempty"`
+	Interim  *domain.TestResult       `json:"interim,omitempty"` // Cumulative result for the assignment's Interim
+	Capture  []byte                   `json:"capture,omitempty"` // One Write to the assignment's Capture
+	Result   *domain.TestResult       `json:"result,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// Control is a line of JSON written by the worker to the subprocess's stdin
+// after the assignment, while the attack runs.
+type Control struct {
+	Rate uint64 `json:"rate,omitempty"` // New rate per second, from the assignment's Rates
+}
+
+// ProgressInterval is how often the subprocess reports progress.
+const ProgressInterval = time.Second
+
+// SubprocessExecutor implements domain.Executor for the vegeta HTTP and Lua
+// executors by running each attack in a child process of the current binary. A runaway attack can then be killed, or die from its
+// resource limits, without taking down the worker's gRPC and status loops;
+// the next assignment simply starts a fresh subprocess.
+type SubprocessExecutor struct {
+	executable string
+	limits     Limits
+}
+
+// NewSubprocessExecutor creates an executor that re-invokes the running binary.
+func NewSubprocessExecutor(limits Limits) (*SubprocessExecutor, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate worker executable: %w", err)
+	}
+	if limits.CgroupPath != "" {
+		if _, err := os.Stat(filepath.Join(limits.CgroupPath, "cgroup.procs")); err != nil {
+			return nil, fmt.Errorf("invalid attack cgroup %s: %w", limits.CgroupPath, err)
+		}
+		if limits.MemoryBytes > 0 {
+			memoryMax := filepath.Join(limits.CgroupPath, "memory.max")
+			if err := os.WriteFile(memoryMax, []byte(strconv.FormatUint(limits.MemoryBytes, 10)), 0644); err != nil {
+				return nil, fmt.Errorf("failed to set attack cgroup memory limit: %w", err)
+			}
+		}
+	}
+	return &SubprocessExecutor{executable: executable, limits: limits}, nil
+}
+
+// Execute runs the assignment's script, multi-step scenario or vegeta attack
+// in a subprocess.
+func (e *SubprocessExecutor) Execute(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	switch {
+	case assignment.Protocol == domain.ExecutorLua:
+		return e.run(ctx, ModeScript, assignment)
+	case assignment.ScenarioJSON != "":
+		return e.run(ctx, ModeScenario, assignment)
+	default:
+		return e.run(ctx, ModeVegeta, assignment)
+	}
+}
+
+func (e *SubprocessExecutor) run(ctx context.Context, mode string, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	cmd := exec.CommandContext(ctx, e.executable, ExecCommand,
+		"--mode", mode,
+		"--memory-limit", strconv.FormatUint(e.limits.MemoryBytes, 10),
+		"--max-open-files", strconv.FormatUint(e.limits.MaxOpenFiles, 10),
+	)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start attack subprocess: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start attack subprocess: %w", err)
+	}
+	cmd.Stderr = &prefixWriter{prefix: fmt.Sprintf("[attack %s] ", assignment.TestID)}
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+	cmd.WaitDelay = killGracePeriod
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start attack subprocess: %w", err)
+	}
+	log.Printf("Started attack subprocess %d for test %s (mode=%s)", cmd.Process.Pid, assignment.TestID, mode)
+
+	if e.limits.CgroupPath != "" {
+		procs := filepath.Join(e.limits.CgroupPath, "cgroup.procs")
+		if err := os.WriteFile(procs, []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return nil, fmt.Errorf("failed to move attack subprocess into cgroup: %w", err)
+		}
+	}
+
+	// Send the assignment, then its rate changes until the subprocess exits
+	exited := make(chan struct{})
+	go sendControl(stdin, assignment, exited)
+
+	// Mirror progress, interim and capture lines until the final envelope;
+	// stdout must be drained before Wait
+	var envelope Envelope
+	capture := assignment.Capture
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var env Envelope
+		if len(bytes.TrimSpace(line)) > 0 && json.Unmarshal(line, &env) == nil {
+			if env.Progress != nil && assignment.Progress != nil {
+				assignment.Progress.Set(*env.Progress)
+			}
+			if env.Interim != nil && assignment.Interim != nil {
+				assignment.Interim.ReportInterim(env.Interim)
+			}
+			if env.Capture != nil && capture != nil {
+				if _, err := capture.Write(env.Capture); err != nil {
+					log.Printf("Stopped capturing requests of test %s: %v", assignment.TestID, err)
+					capture = nil
+				}
+			}
+			if env.Result != nil || env.Error != "" {
+				envelope = env
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	close(exited)
+	waitErr := cmd.Wait()
+
+	if envelope.Error != "" {
+		return nil, errors.New(envelope.Error)
+	}
+	if envelope.Result == nil {
+		return nil, exitError(ctx, waitErr)
+	}
+	return envelope.Result, nil
+}
+
+// sendControl writes the assignment to the subprocess's stdin, followed by a
+// Control line for every rate change until exited is closed.
+func sendControl(stdin io.WriteCloser, assignment *domain.TestAssignment, exited <-chan struct{}) {
+	defer stdin.Close()
+	enc := json.NewEncoder(stdin)
+	if err := enc.Encode(assignment); err != nil {
+		log.Printf("Failed to send test %s to its attack subprocess: %v", assignment.TestID, err)
+		return
+	}
+	for {
+		select {
+		case <-exited:
+			return
+		case rate := <-assignment.Rates:
+			if err := enc.Encode(Control{Rate: rate}); err != nil {
+				log.Printf("Failed to change the rate of test %s in its attack subprocess: %v", assignment.TestID, err)
+				return
+			}
+		}
+	}
+}
+
+// exitError describes why a subprocess ended without reporting a result.
+func exitError(ctx context.Context, waitErr error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("attack subprocess cancelled: %w", ctx.Err())
+	}
+	var exitErr *exec.ExitError
+	if errors.As(waitErr, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return fmt.Errorf("attack subprocess killed by %v (likely out of memory)", status.Signal())
+		}
+		return fmt.Errorf("attack subprocess exited with code %d", exitErr.ExitCode())
+	}
+	if waitErr != nil {
+		return fmt.Errorf("attack subprocess failed: %w", waitErr)
+	}
+	return fmt.Errorf("attack subprocess exited without a result")
+}
+
+// prefixWriter forwards subprocess log output to the worker's log.
+type prefixWriter struct {
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		io.WriteString(os.Stderr, w.prefix+string(w.buf[:i+1]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,111 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/vegeta"
+)
+
+// TestMain serves attack subprocesses started from the test binary, which
+// SubprocessExecutor re-invokes as the running executable. Vegeta attacks run
+// for real; scripts are replaced by rateEcho.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == ExecCommand {
+		run := vegeta.NewVegetaAdapter().Attack
+		if len(os.Args) > 3 && os.Args[3] == ModeScript {
+			run = rateEcho
+		}
+		if err := Serve(os.Stdin, os.Stdout, run); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// rateEcho waits for the first rate change and reports it as its request count.
+func rateEcho(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	select {
+	case rate := <-assignment.Rates:
+		return &domain.TestResult{TestID: assignment.TestID, TotalRequests: int64(rate)}, nil
+	case <-time.After(10 * time.Second):
+		return &domain.TestResult{TestID: assignment.TestID}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type interimRecorder struct {
+	mu      sync.Mutex
+	results []*domain.TestResult
+}
+
+func (r *interimRecorder) ReportInterim(result *domain.TestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+func TestSubprocessCapturesAndReportsInterimResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	executor, err := NewSubprocessExecutor(Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var capture bytes.Buffer
+	interim := &interimRecorder{}
+	assignment := &domain.TestAssignment{
+		TestID:          "isolated",
+		DurationSeconds: "2s",
+		RatePerSecond:   10,
+		TargetsBase64:   base64.StdEncoding.EncodeToString([]byte("GET " + server.URL + "/")),
+		InterimInterval: "500ms",
+		CaptureRequests: true,
+		Interim:         interim,
+		Capture:         &capture,
+	}
+	result, err := executor.Execute(context.Background(), assignment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if captured := int64(bytes.Count(capture.Bytes(), []byte("\n"))); captured != result.TotalRequests || captured == 0 {
+		t.Errorf("captured %d requests, want the %d sent", captured, result.TotalRequests)
+	}
+	interim.mu.Lock()
+	defer interim.mu.Unlock()
+	if len(interim.results) == 0 {
+		t.Error("no interim result reached the worker")
+	}
+}
+
+func TestSubprocessReceivesRateChanges(t *testing.T) {
+	executor, err := NewSubprocessExecutor(Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rates := make(chan uint64, 1)
+	rates <- 42
+	result, err := executor.Execute(context.Background(), &domain.TestAssignment{
+		TestID:   "isolated",
+		Protocol: domain.ExecutorLua,
+		Rates:    rates,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalRequests != 42 {
+		t.Errorf("subprocess saw rate %d, want 42", result.TotalRequests)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package sandbox
+
+import "fmt"
+
+func setOpenFilesLimit(n uint64) error {
+	return fmt.Errorf("open files limit is not supported on this platform")
+}
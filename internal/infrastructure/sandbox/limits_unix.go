@@ -0,0 +1,15 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func setOpenFilesLimit(n uint64) error {
+	if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &syscall.Rlimit{Cur: n, Max: n}); err != nil {
+		return fmt.Errorf("failed to set open files limit: %w", err)
+	}
+	return nil
+}
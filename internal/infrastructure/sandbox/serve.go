@@ -0,0 +1,112 @@
+// internal/infrastructure/sandbox/serve.go
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Serve is the subprocess side of SubprocessExecutor. It reads a test
+// assignment from stdin, runs it with run and writes envelopes to stdout:
+// progress every ProgressInterval, interim results and captured requests as
+// run produces them, and finally the result or error. Rate changes read from
+// stdin are passed to the assignment's Rates. SIGTERM from the worker (test
+// aborted) stops the attack early; the partial result is still reported.
+func Serve(stdin io.Reader, stdout io.Writer, run func(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error)) error {
+	var mu sync.Mutex // Serializes envelope lines on stdout
+	enc := json.NewEncoder(stdout)
+	send := func(env Envelope) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return enc.Encode(env)
+	}
+
+	progress := &domain.ProgressCounter{}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				snapshot := progress.Snapshot()
+				send(Envelope{Progress: &snapshot})
+			}
+		}
+	}()
+
+	envelope := serveAssignment(stdin, send, progress, run)
+	close(done)
+	snapshot := progress.Snapshot()
+	envelope.Progress = &snapshot
+	return send(envelope)
+}
+
+func serveAssignment(stdin io.Reader, send func(Envelope) error, progress *domain.ProgressCounter, run func(context.Context, *domain.TestAssignment) (*domain.TestResult, error)) Envelope {
+	dec := json.NewDecoder(stdin)
+	var assignment domain.TestAssignment
+	if err := dec.Decode(&assignment); err != nil {
+		return Envelope{Error: fmt.Sprintf("failed to decode assignment: %v", err)}
+	}
+	assignment.Progress = progress
+	if assignment.InterimInterval != "" {
+		assignment.Interim = interimSender(send)
+	}
+	if assignment.CaptureRequests {
+		assignment.Capture = captureSender(send)
+	}
+
+	rates := make(chan uint64, 1)
+	assignment.Rates = rates
+	go func() {
+		for {
+			var control Control
+			if err := dec.Decode(&control); err != nil {
+				return // The worker closed stdin
+			}
+			if control.Rate == 0 {
+				continue
+			}
+			select {
+			case <-rates: // Replaced before the attack picked it up
+			default:
+			}
+			rates <- control.Rate
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	result, err := run(ctx, &assignment)
+	if err != nil {
+		return Envelope{Error: err.Error()}
+	}
+	return Envelope{Result: result}
+}
+
+// interimSender passes interim results to the worker.
+type interimSender func(Envelope) error
+
+func (s interimSender) ReportInterim(result *domain.TestResult) {
+	s(Envelope{Interim: result})
+}
+
+// captureSender passes captured requests to the worker, one envelope per Write.
+type captureSender func(Envelope) error
+
+func (s captureSender) Write(p []byte) (int, error) {
+	if err := s(Envelope{Capture: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
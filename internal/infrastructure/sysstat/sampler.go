@@ -0,0 +1,43 @@
+// internal/infrastructure/sysstat/sampler.go
+package sysstat
+
+import (
+	"sync"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Sampler measures the worker process and its host from the operating
+// system's own counters. CPU, memory in use and network traffic are host-wide,
+// so they also cover attacks run in sandboxed subprocesses; resident memory
+// and open files are the worker process's own.
+type Sampler struct {
+	mu      sync.Mutex
+	started bool
+	cpuBusy uint64 // Cumulative host CPU ticks spent busy
+	cpuAll  uint64 // Cumulative host CPU ticks
+	netRx   int64  // Cumulative bytes received on non-loopback interfaces
+	netTx   int64
+}
+
+// NewSampler returns a sampler whose first sample covers the time since it was created.
+func NewSampler() *Sampler {
+	s := &Sampler{}
+	s.Sample()
+	return s
+}
+
+// Sample implements domain.ResourceSampler.
+func (s *Sampler) Sample() (domain.ResourceSample, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sample()
+}
+
+// percent returns part as a percentage of whole, or 0 when whole is 0.
+func percent(part, whole uint64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole) * 100
+}
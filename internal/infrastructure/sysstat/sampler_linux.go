@@ -0,0 +1,135 @@
+//go:build linux
+
+package sysstat
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+func (s *Sampler) sample() (domain.ResourceSample, bool) {
+	busy, all, err := readCPUTicks()
+	if err != nil {
+		return domain.ResourceSample{}, false
+	}
+	var sample domain.ResourceSample
+	if s.started && all > s.cpuAll {
+		sample.CPUPercent = percent(busy-s.cpuBusy, all-s.cpuAll)
+	}
+	s.cpuBusy, s.cpuAll = busy, all
+
+	if rx, tx, err := readNetBytes(); err == nil {
+		if s.started && rx >= s.netRx && tx >= s.netTx {
+			sample.NetRxBytes, sample.NetTxBytes = rx-s.netRx, tx-s.netTx
+		}
+		s.netRx, s.netTx = rx, tx
+	}
+
+	if total, available, err := readMemInfo(); err == nil && total > available {
+		sample.MemoryPercent = percent(total-available, total)
+	}
+	if status, err := readKeyValues("/proc/self/status"); err == nil {
+		sample.MemoryBytes = int64(status["VmRSS"]) * 1024
+	}
+	if entries, err := os.ReadDir("/proc/self/fd"); err == nil {
+		sample.OpenFiles = int64(len(entries))
+	}
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err == nil {
+		sample.OpenFilesLimit = int64(limit.Cur)
+	}
+
+	s.started = true
+	return sample, true
+}
+
+// readCPUTicks returns the host's busy and total CPU ticks from /proc/stat.
+// Idle and I/O wait count as not busy.
+func readCPUTicks() (busy, all uint64, err error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return 0, 0, err
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, os.ErrInvalid
+	}
+	for i, field := range fields[1:] {
+		ticks, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if i >= 8 {
+			break // guest time is already counted in user time
+		}
+		all += ticks
+		if i != 3 && i != 4 { // idle, iowait
+			busy += ticks
+		}
+	}
+	return busy, all, nil
+}
+
+// readNetBytes sums the bytes received and sent on every interface but loopback.
+func readNetBytes() (rx, tx int64, err error) {
+	f, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(name) == "lo" {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			continue
+		}
+		received, _ := strconv.ParseInt(fields[0], 10, 64)
+		sent, _ := strconv.ParseInt(fields[8], 10, 64)
+		rx += received
+		tx += sent
+	}
+	return rx, tx, scanner.Err()
+}
+
+// readMemInfo returns the host's total and available memory in kB.
+func readMemInfo() (total, available uint64, err error) {
+	info, err := readKeyValues("/proc/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+	return info["MemTotal"], info["MemAvailable"], nil
+}
+
+// readKeyValues parses "Key: value [kB]" lines such as those of
+// /proc/meminfo and /proc/self/status, keeping numeric values.
+func readKeyValues(path string) (map[string]uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(string(data), "\n") {
+		key, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[key] = v
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysstat
+
+import "github.com/pace-noge/distributed-load-tester/internal/domain"
+
+// Sampling reads /proc, so other platforms report no samples.
+func (s *Sampler) sample() (domain.ResourceSample, bool) {
+	return domain.ResourceSample{}, false
+}
@@ -4,13 +4,19 @@ package vegeta
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
 	lib "github.com/tsenart/vegeta/v12/lib" // Corrected import path
 )
@@ -23,11 +29,153 @@ func NewVegetaAdapter() *VegetaAdapter {
 	return &VegetaAdapter{}
 }
 
+// Protocols and Features are what the adapter can run, as workers report it
+// to the master.
+var (
+	Protocols = []string{domain.ProtocolHTTP}
+	Features  = []string{domain.FeatureScenario, domain.FeatureTemplates, domain.FeaturePreflight,
+		domain.FeatureThinkTime, domain.FeaturePacingJitter, domain.FeatureRequestID, domain.FeatureSmoke, domain.FeatureCapture,
+		domain.FeatureLabels}
+)
+
 // Attack executes a Vegeta load test based on the provided configuration.
-func (va *VegetaAdapter) Attack(ctx context.Context, vegetaPayloadJSON, durationStr string, rate uint64, targetsBase64 string) (*domain.TestResult, error) {
+func (va *VegetaAdapter) Attack(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	vegetaPayloadJSON := assignment.VegetaPayloadJSON
+	durationStr := assignment.DurationSeconds
+	rate := assignment.RatePerSecond
+	targetsBase64 := assignment.TargetsBase64
 	log.Printf("Starting Vegeta attack with duration=%s, rate=%d, targetsBase64 length=%d", durationStr, rate, len(targetsBase64))
+	setupBegan := time.Now()
 
 	// 1. Parse targets
+	targets, err := parseTargets(targetsBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Parse duration
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration string: %w", err)
+	}
+
+	// 3. Create rate
+	var attackRate lib.Rate // Use lib.Rate
+	if rate > 0 {
+		attackRate = lib.Rate{Freq: int(rate), Per: time.Second}
+	} else {
+		return nil, fmt.Errorf("rate per second must be greater than 0")
+	}
+
+	thinkMin, thinkMax, err := domain.ParseThinkTime(assignment.ThinkTime)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.PacingJitter < 0 || assignment.PacingJitter > 1 {
+		return nil, fmt.Errorf("pacing jitter must be between 0 and 1, got %f", assignment.PacingJitter)
+	}
+	pacer := newHumanPacer(attackRate, thinkMin, thinkMax, assignment.PacingJitter, assignment.Rates)
+
+	// 4. Configure attacker options (from vegetaPayloadJSON)
+	attackOptions, err := domain.ParseAttackOptions(vegetaPayloadJSON)
+	if err != nil {
+		return nil, err
+	}
+	attacker := lib.NewAttacker(attackerOptions(attackOptions)...)
+	apdex, err := domain.NewApdexCollector(assignment.Apdex)
+	if err != nil {
+		return nil, err
+	}
+	interim, stopInterim, err := interimTicker(assignment)
+	if err != nil {
+		return nil, err
+	}
+	defer stopInterim()
+
+	// 5. Start the attack
+	targeter := lib.NewStaticTargeter(targets...)
+	if assignment.TemplateTargets {
+		targeter = templateTargeter(targets, assignment.SequenceStart, assignment.SequenceEnd)
+		log.Printf("Target templating enabled: {{seq}} range [%d, %d)", assignment.SequenceStart, assignment.SequenceEnd)
+	}
+	if labels := labelHeader(assignment.LabelHeaders); labels != nil {
+		targeter = labelTargeter(targeter, labels)
+	}
+	if assignment.RequestIDPrefix != "" {
+		targeter = requestIDTargeter(targeter, assignment.RequestIDPrefix)
+	}
+	setup := time.Since(setupBegan)
+	urls := make([]string, len(targets))
+	for i, target := range targets {
+		urls[i] = target.URL
+	}
+	dnsWarmup := warmDNS(ctx, urls, attackOptions)
+
+	log.Printf("Starting Vegeta attack: rate=%v (effective %.1f/s), duration=%v, targets=%d, thinkTime=%q, jitter=%.2f, requestIDPrefix=%q",
+		attackRate, pacer.Rate(0), duration, len(targets), assignment.ThinkTime, assignment.PacingJitter, assignment.RequestIDPrefix)
+	var m lib.Metrics // Use lib.Metrics directly
+	drift := &scheduleDrift{}
+	results := attacker.Attack(targeter, driftPacer{pacer, drift}, duration, "Load Test")
+
+	// Stop early if the test is aborted; the results gathered so far are kept
+	attackDone := make(chan struct{})
+	defer close(attackDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Printf("Stopping Vegeta attack early: %v", ctx.Err())
+			attacker.Stop()
+		case <-attackDone:
+		}
+	}()
+
+	var smoke *smokeCollector
+	if assignment.Smoke {
+		smoke = newSmokeCollector()
+	}
+	capture := newRequestCapture(assignment)
+collect:
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				break collect
+			}
+			m.Add(res)
+			scoreApdex(apdex, res)
+			drift.sent(res.Seq, res.Timestamp)
+			capture.Add(res)
+			if smoke != nil {
+				smoke.Add(res)
+			}
+			if assignment.Progress != nil {
+				assignment.Progress.Record(res.Latency, res.Code, res.Error)
+			}
+			if assignment.Recorder != nil {
+				assignment.Recorder.Record(domain.RequestRecord{Target: res.URL, Code: res.Code, Latency: res.Latency, Timestamp: res.Timestamp})
+			}
+		case <-interim:
+			partial := interimResult(&m)
+			partial.Apdex = apdex.Result()
+			assignment.Interim.ReportInterim(partial)
+		}
+	}
+	m.Close() // Important: Close the metrics collector to finalize calculations
+	log.Printf("Vegeta attack completed")
+
+	// 6. Convert Vegeta metrics to domain.TestResult
+	result := newTestResult(&m, m)
+	result.GeneratorOverhead = drift.overhead(setup, dnsWarmup)
+	result.Apdex = apdex.Result()
+	if smoke != nil {
+		result.TargetResponses = smoke.Responses()
+	}
+	return result, nil
+}
+
+// parseTargets decodes base64 targets given either as a JSON array of vegeta
+// targets or as plain text with one URL per line.
+func parseTargets(targetsBase64 string) ([]lib.Target, error) {
 	decodedTargets, err := base64.StdEncoding.DecodeString(targetsBase64)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode targets from base64: %w", err)
@@ -42,21 +190,24 @@ func (va *VegetaAdapter) Attack(ctx context.Context, vegetaPayloadJSON, duration
 	if err != nil {
 		// Fallback to simple plain text targets if JSON parsing fails
 		log.Printf("Warning: Failed to decode targets as JSON: %v. Attempting to parse as plain text.", err)
-		targetsReader = bytes.NewReader(decodedTargets) // Reset reader
+		targets = nil
 
-		// Parse as plain text - each line should be a URL
+		// Parse as plain text - each line is "METHOD URL" or a bare URL
 		lines := bytes.Split(decodedTargets, []byte("\n"))
 		for _, line := range lines {
 			lineStr := string(bytes.TrimSpace(line))
-			if lineStr == "" {
-				continue // Skip empty lines
+			if lineStr == "" || strings.HasPrefix(lineStr, "#") {
+				continue // Skip empty lines and comments
 			}
-			// Create a basic GET target for each URL
+			// Bare URLs become GET targets
 			target := lib.Target{
 				Method: "GET",
 				URL:    lineStr,
 				Header: make(http.Header),
 			}
+			if fields := strings.Fields(lineStr); len(fields) >= 2 {
+				target.Method, target.URL = fields[0], fields[1]
+			}
 			targets = append(targets, target)
 		}
 	}
@@ -70,79 +221,109 @@ func (va *VegetaAdapter) Attack(ctx context.Context, vegetaPayloadJSON, duration
 	for i, target := range targets {
 		log.Printf("Target %d: %s %s", i, target.Method, target.URL)
 	}
+	return targets, nil
+}
 
-	// 2. Parse duration
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid duration string: %w", err)
-	}
-
-	// 3. Create rate
-	var attackRate lib.Rate // Use lib.Rate
-	if rate > 0 {
-		attackRate = lib.Rate{Freq: int(rate), Per: time.Second}
-	} else {
-		return nil, fmt.Errorf("rate per second must be greater than 0")
-	}
-
-	// 4. Configure attacker options (from vegetaPayloadJSON)
-	attacker := lib.NewAttacker() // Use lib.NewAttacker
-	if vegetaPayloadJSON != "" {
-		var attackOptions map[string]interface{}
-		err = json.Unmarshal([]byte(vegetaPayloadJSON), &attackOptions)
-		if err != nil {
-			log.Printf("Warning: Failed to unmarshal vegetaPayloadJSON: %v. Using default attacker options.", err)
-			// Continue with default attacker if payload is invalid
-		} else {
-			// Apply specific attacker options if they exist in the payload
-			if timeout, ok := attackOptions["timeout"].(float64); ok {
-				attacker = lib.NewAttacker(lib.Client(&http.Client{Timeout: time.Duration(timeout) * time.Second}))
-			}
-			if redirects, ok := attackOptions["redirects"].(float64); ok {
-				attacker = lib.NewAttacker(lib.Client(&http.Client{
-					CheckRedirect: func(req *http.Request, via []*http.Request) error {
-						if len(via) >= int(redirects) {
-							return http.ErrUseLastResponse
-						}
-						return nil
-					},
-				}))
-			}
-			// Add more options as needed (connections, http2, keepalive, etc.)
-			// Note: Converting map[string]interface{} to direct vegeta.Attacker options can be complex.
-			// For a comprehensive solution, you might need reflection or specific struct mapping.
-			// For this example, we'll just handle a few common ones.
-		}
-	}
-
-	// 5. Start the attack
-	log.Printf("Starting Vegeta attack: rate=%v, duration=%v, targets=%d", attackRate, duration, len(targets))
-	var m lib.Metrics // Use lib.Metrics directly
-	results := attacker.Attack(lib.NewStaticTargeter(targets...), attackRate, duration, "Load Test")
-	for res := range results {
-		m.Add(res)
-	}
-	m.Close() // Important: Close the metrics collector to finalize calculations
-	log.Printf("Vegeta attack completed")
-
-	// 6. Convert Vegeta metrics to domain.TestResult
-	testResult := &domain.TestResult{
+// newTestResult converts closed vegeta metrics into a domain.TestResult,
+// storing metricPayload as the raw Metric JSON.
+func newTestResult(m *lib.Metrics, metricPayload interface{}) *domain.TestResult {
+	// Success is a ratio of two counts, so rounding recovers the exact count
+	successful := int64(math.Round(m.Success * float64(m.Requests)))
+	return &domain.TestResult{
 		Metric: func() []byte {
-			b, err := json.Marshal(m)
+			b, err := json.Marshal(metricPayload)
 			if err != nil {
 				log.Printf("Error marshaling metrics to JSON: %v", err)
 				return []byte("{}")
 			}
 			return b
 		}(), // Store full Vegeta metric output
-		TotalRequests:     int64(m.Requests),
-		CompletedRequests: int64(m.Requests), // Total number of requests
-		DurationMs:        m.Duration.Milliseconds(),
-		SuccessRate:       m.Success,
-		AverageLatencyMs:  float64(m.Latencies.Mean.Milliseconds()),
-		P95LatencyMs:      float64(m.Latencies.P95.Milliseconds()),
-		StatusCodes:       m.StatusCodes,
+		TotalRequests:      int64(m.Requests),
+		CompletedRequests:  int64(m.Requests), // Total number of requests
+		DurationMs:         m.Duration.Milliseconds(),
+		SuccessRate:        m.Success,
+		AverageLatencyMs:   float64(m.Latencies.Mean.Milliseconds()),
+		P95LatencyMs:       float64(m.Latencies.P95.Milliseconds()),
+		StatusCodes:        m.StatusCodes,
+		SuccessfulRequests: successful,
+		FailedRequests:     int64(m.Requests) - successful,
 	}
+}
+
+// attackerOptions maps the parsed vegeta payload onto vegeta's functional
+// attacker options. Options left unset keep vegeta's defaults.
+func attackerOptions(opts *domain.AttackOptions) []func(*lib.Attacker) {
+	var out []func(*lib.Attacker)
 
-	return testResult, nil
+	// TLSConfig replaces the whole tls.Config, so it must run before HTTP2 configures ALPN on it.
+	if opts.Insecure {
+		out = append(out, lib.TLSConfig(&tls.Config{InsecureSkipVerify: true}))
+	}
+	if opts.Timeout != nil {
+		out = append(out, lib.Timeout(time.Duration(*opts.Timeout)))
+	}
+	if opts.Redirects != nil {
+		out = append(out, lib.Redirects(*opts.Redirects))
+	}
+	if opts.KeepAlive != nil {
+		out = append(out, lib.KeepAlive(*opts.KeepAlive))
+	}
+	if opts.Connections != nil {
+		out = append(out, lib.Connections(*opts.Connections))
+	}
+	if opts.MaxConnections != nil {
+		out = append(out, lib.MaxConnections(*opts.MaxConnections))
+	}
+	if opts.LocalAddr != "" {
+		out = append(out, lib.LocalAddr(net.IPAddr{IP: net.ParseIP(opts.LocalAddr)}))
+	}
+	if opts.Proxy != "" {
+		// Already validated by domain.ParseAttackOptions
+		proxyURL, _ := url.Parse(opts.Proxy)
+		out = append(out, lib.Proxy(http.ProxyURL(proxyURL)))
+	}
+	// ConnectTo wraps the current dialer, so it must come after LocalAddr and KeepAlive replace it.
+	if len(opts.Resolve) > 0 {
+		connectTo := make(map[string][]string, len(opts.Resolve))
+		for _, entry := range opts.Resolve {
+			from, to, _ := domain.ParseResolveOverride(entry)
+			connectTo[from] = append(connectTo[from], to)
+		}
+		out = append(out, lib.ConnectTo(connectTo))
+	}
+	if opts.HTTP2 != nil {
+		out = append(out, lib.HTTP2(*opts.HTTP2))
+	}
+	if opts.Workers != nil {
+		out = append(out, lib.Workers(*opts.Workers))
+	}
+	if opts.MaxWorkers != nil {
+		out = append(out, lib.MaxWorkers(*opts.MaxWorkers))
+	}
+	if opts.MaxBody != nil {
+		out = append(out, lib.MaxBody(*opts.MaxBody))
+	}
+	// H2C swaps out the *http.Transport the options above rely on, so it goes last.
+	if opts.H2C {
+		out = append(out, lib.H2C(true))
+	}
+	return out
+}
+
+// requestIDTargeter wraps a targeter so every request carries a unique
+// X-Request-ID of the form "<prefix>-<uuid>".
+func requestIDTargeter(next lib.Targeter, prefix string) lib.Targeter {
+	return func(tgt *lib.Target) error {
+		if err := next(tgt); err != nil {
+			return err
+		}
+		// Static targets share their header maps, so clone before mutating.
+		if tgt.Header == nil {
+			tgt.Header = make(http.Header)
+		} else {
+			tgt.Header = tgt.Header.Clone()
+		}
+		tgt.Header.Set("X-Request-ID", prefix+"-"+uuid.NewString())
+		return nil
+	}
 }
@@ -0,0 +1,13 @@
+package vegeta
+
+import (
+	lib "github.com/tsenart/vegeta/v12/lib"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// scoreApdex classifies a request for the test's Apdex score. Like vegeta's
+// success ratio, responses from 200 to 399 without an error succeed.
+func scoreApdex(apdex *domain.ApdexCollector, res *lib.Result) {
+	apdex.Add(res.URL, res.Latency, res.Error == "" && res.Code >= 200 && res.Code < 400)
+}
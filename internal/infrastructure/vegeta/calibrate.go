@@ -0,0 +1,65 @@
+package vegeta
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// calibrationWorkersPerCPU sets how many concurrent attack workers calibration
+// runs per CPU. Loopback requests are fast, so a few per CPU keep it busy.
+const calibrationWorkersPerCPU = 8
+
+// Calibrate measures the highest rate, in successful requests per second, this
+// machine can generate by attacking an echo server on the loopback interface
+// as fast as possible for duration. The echo server shares the machine, so the
+// result is a conservative estimate of what the worker can send to a remote
+// target.
+func Calibrate(ctx context.Context, duration time.Duration) (uint64, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to start calibration echo server: %w", err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	})}
+	go server.Serve(lis)
+	defer server.Close()
+
+	// A zero rate sends as fast as the workers allow, so the worker count must be capped
+	workers := uint64(calibrationWorkersPerCPU * runtime.NumCPU())
+	attacker := lib.NewAttacker(lib.Workers(workers), lib.MaxWorkers(workers))
+	targeter := lib.NewStaticTargeter(lib.Target{Method: "GET", URL: "http://" + lis.Addr().String() + "/"})
+	log.Printf("Calibrating maximum request rate for %v with %d workers", duration, workers)
+	results := attacker.Attack(targeter, lib.Rate{}, duration, "Calibration")
+
+	attackDone := make(chan struct{})
+	defer close(attackDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			attacker.Stop()
+		case <-attackDone:
+		}
+	}()
+
+	var m lib.Metrics
+	for res := range results {
+		m.Add(res)
+	}
+	m.Close()
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("calibration interrupted: %w", err)
+	}
+	if m.Requests == 0 || m.Success == 0 {
+		return 0, fmt.Errorf("calibration sent no successful requests (errors: %v)", m.Errors)
+	}
+	return uint64(m.Throughput), nil
+}
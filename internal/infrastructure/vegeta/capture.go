@@ -0,0 +1,49 @@
+// internal/infrastructure/vegeta/capture.go
+package vegeta
+
+import (
+	"bytes"
+	"io"
+	"log"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// requestCapture encodes results into an assignment's Capture writer as
+// vegeta JSON lines, one Write per line. Response bodies and headers are left
+// out to keep the volume down. Capturing stops at the first write the writer
+// refuses.
+type requestCapture struct {
+	w   io.Writer
+	buf bytes.Buffer
+	enc lib.Encoder
+}
+
+// newRequestCapture returns nil unless the assignment captures requests.
+func newRequestCapture(assignment *domain.TestAssignment) *requestCapture {
+	if !assignment.CaptureRequests || assignment.Capture == nil {
+		return nil
+	}
+	c := &requestCapture{w: assignment.Capture}
+	c.enc = lib.NewJSONEncoder(&c.buf)
+	return c
+}
+
+// Add captures res. It is not safe for concurrent use.
+func (c *requestCapture) Add(res *lib.Result) {
+	if c == nil || c.w == nil {
+		return
+	}
+	r := *res
+	r.Body, r.Headers = nil, nil
+	c.buf.Reset()
+	if err := c.enc.Encode(&r); err != nil {
+		log.Printf("Warning: Failed to encode captured request: %v", err)
+		return
+	}
+	if _, err := c.w.Write(c.buf.Bytes()); err != nil {
+		log.Printf("Stopped capturing requests: %v", err)
+		c.w = nil
+	}
+}
@@ -0,0 +1,28 @@
+// internal/infrastructure/vegeta/executor.go
+package vegeta
+
+import (
+	"context"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// HTTPExecutor is the domain.ExecutorVegetaHTTP executor. It runs the
+// assignment's scenario if it has one and attacks its targets otherwise.
+type HTTPExecutor struct {
+	attacks   *VegetaAdapter
+	scenarios *ScenarioAdapter
+}
+
+// NewHTTPExecutor creates the vegeta HTTP executor.
+func NewHTTPExecutor() *HTTPExecutor {
+	return &HTTPExecutor{attacks: NewVegetaAdapter(), scenarios: NewScenarioAdapter()}
+}
+
+// Execute implements domain.Executor.
+func (e *HTTPExecutor) Execute(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	if assignment.ScenarioJSON != "" {
+		return e.scenarios.Run(ctx, assignment)
+	}
+	return e.attacks.Attack(ctx, assignment)
+}
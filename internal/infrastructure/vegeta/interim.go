@@ -0,0 +1,34 @@
+// internal/infrastructure/vegeta/interim.go
+package vegeta
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// interimTicker ticks at the assignment's interim interval. Its channel is
+// nil, and never ticks, when the assignment asks for no interim results.
+func interimTicker(assignment *domain.TestAssignment) (<-chan time.Time, func(), error) {
+	if assignment.InterimInterval == "" || assignment.Interim == nil {
+		return nil, func() {}, nil
+	}
+	interval, err := time.ParseDuration(assignment.InterimInterval)
+	if err != nil || interval <= 0 {
+		return nil, nil, fmt.Errorf("invalid interim interval %q", assignment.InterimInterval)
+	}
+	ticker := time.NewTicker(interval)
+	return ticker.C, ticker.Stop, nil
+}
+
+// interimResult converts the metrics gathered so far into a result, leaving
+// m open to further results. m must not be added to until it returns.
+func interimResult(m *lib.Metrics) *domain.TestResult {
+	snapshot := *m
+	snapshot.StatusCodes = maps.Clone(m.StatusCodes)
+	snapshot.Close()
+	return newTestResult(&snapshot, snapshot)
+}
@@ -0,0 +1,46 @@
+// internal/infrastructure/vegeta/labels.go
+package vegeta
+
+import (
+	"net/http"
+
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// labelHeader converts an assignment's label headers to an http.Header, or
+// nil if it has none.
+func labelHeader(labels map[string]string) http.Header {
+	if len(labels) == 0 {
+		return nil
+	}
+	header := make(http.Header, len(labels))
+	for name, value := range labels {
+		header.Set(name, value)
+	}
+	return header
+}
+
+// setLabels adds the label headers to header, replacing any values of the
+// same name so the target always sees the test's labels.
+func setLabels(header, labels http.Header) {
+	for name, values := range labels {
+		header[name] = values
+	}
+}
+
+// labelTargeter wraps a targeter so every request carries the label headers.
+func labelTargeter(next lib.Targeter, labels http.Header) lib.Targeter {
+	return func(tgt *lib.Target) error {
+		if err := next(tgt); err != nil {
+			return err
+		}
+		// Static targets share their header maps, so clone before mutating.
+		if tgt.Header == nil {
+			tgt.Header = make(http.Header, len(labels))
+		} else {
+			tgt.Header = tgt.Header.Clone()
+		}
+		setLabels(tgt.Header, labels)
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+// internal/infrastructure/vegeta/overhead.go
+package vegeta
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// dnsWarmupTimeout caps how long a worker spends resolving target hosts
+// before a test.
+const dnsWarmupTimeout = 5 * time.Second
+
+// driftWindow is how many scheduled requests scheduleDrift remembers. A
+// request sent after this many later ones were scheduled is not measured.
+const driftWindow = 1 << 14
+
+// scheduleDrift measures how late requests go out compared to when the pacer
+// scheduled them. Requests are matched to their schedule by sequence number.
+type scheduleDrift struct {
+	mu    sync.Mutex
+	due   [driftWindow]scheduledHit
+	drift lib.LatencyMetrics
+	count int64
+}
+
+// scheduledHit is when the request with sequence number seq was due.
+type scheduledHit struct {
+	seq uint64
+	at  time.Time
+}
+
+// schedule records that request seq is due at. Only the first schedule of a
+// request counts, as pacers may be asked again once its wait is over.
+func (d *scheduleDrift) schedule(seq uint64, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	slot := &d.due[seq%driftWindow]
+	if slot.seq == seq && !slot.at.IsZero() {
+		return
+	}
+	*slot = scheduledHit{seq: seq, at: at}
+}
+
+// sent records that request seq went out at sentAt.
+func (d *scheduleDrift) sent(seq uint64, sentAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	slot := d.due[seq%driftWindow]
+	if slot.seq != seq || slot.at.IsZero() {
+		return
+	}
+	d.drift.Add(max(sentAt.Sub(slot.at), 0))
+	d.count++
+}
+
+// overhead summarizes the drift measured so far, with the time spent on
+// setup and DNS warm-up before the first request.
+func (d *scheduleDrift) overhead(setup, dnsWarmup time.Duration) *domain.GeneratorOverhead {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	o := &domain.GeneratorOverhead{
+		SetupMs:     durationMs(setup),
+		DNSWarmupMs: durationMs(dnsWarmup),
+	}
+	if d.count > 0 {
+		o.MeanDriftMs = durationMs(d.drift.Total / time.Duration(d.count))
+		o.P95DriftMs = durationMs(d.drift.Quantile(0.95))
+		o.MaxDriftMs = durationMs(d.drift.Max)
+	}
+	return o
+}
+
+// driftPacer wraps a pacer to schedule each request it paces on drift.
+type driftPacer struct {
+	lib.Pacer
+	drift *scheduleDrift
+}
+
+// Pace implements lib.Pacer.
+func (p driftPacer) Pace(elapsed time.Duration, hits uint64) (time.Duration, bool) {
+	wait, stop := p.Pacer.Pace(elapsed, hits)
+	if !stop {
+		p.drift.schedule(hits, time.Now().Add(wait))
+	}
+	return wait, stop
+}
+
+// warmDNS resolves the hosts of rawURLs ahead of a test, so the first
+// requests do not pay for the lookups, and returns the time it took. Hosts
+// that are IP addresses, templated, pinned by a resolve override, or reached
+// through a proxy are skipped. Failed lookups are logged; the requests will
+// fail on their own.
+func warmDNS(ctx context.Context, rawURLs []string, opts *domain.AttackOptions) time.Duration {
+	if opts.Proxy != "" {
+		return 0
+	}
+	pinned := make(map[string]bool, len(opts.Resolve))
+	for _, entry := range opts.Resolve {
+		if from, _, err := domain.ParseResolveOverride(entry); err == nil {
+			host, _, _ := net.SplitHostPort(from)
+			pinned[host] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, raw := range rawURLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		host := u.Hostname()
+		if host == "" || seen[host] || pinned[host] || strings.Contains(host, "{{") || net.ParseIP(host) != nil {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	if len(hosts) == 0 {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsWarmupTimeout)
+	defer cancel()
+	began := time.Now()
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+				log.Printf("Warning: DNS warm-up could not resolve %s: %v", host, err)
+			}
+		}(host)
+	}
+	wg.Wait()
+	took := time.Since(began)
+	log.Printf("DNS warm-up resolved %d hosts in %v", len(hosts), took)
+	return took
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,162 @@
+// internal/infrastructure/vegeta/pacer.go
+package vegeta
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// humanPacer wraps a constant rate with optional think time and jitter so the
+// generated traffic looks less synthetic.
+//
+// Jitter randomly shifts each request by up to +/- jitter * interval. Since the
+// wrapped ConstantPacer catches up on its own schedule, the average rate is preserved.
+//
+// Think time inserts an extra pause (fixed or sampled from a range) between
+// consecutive requests. Unlike jitter this is not compensated, so the effective
+// rate is capped at 1 / (interval + think time).
+//
+// A new rate received on rates applies from the next request on. The base
+// pacer then counts from that point, so raising the rate does not send a burst
+// to catch up on the time already spent at the old rate.
+type humanPacer struct {
+	base     lib.ConstantPacer
+	interval time.Duration
+	jitter   float64
+	thinkMin time.Duration
+	thinkMax time.Duration
+
+	rates     <-chan uint64 // Nil when the rate is fixed
+	since     time.Duration // Elapsed time when the current rate took effect
+	sinceHits uint64        // Hits when the current rate took effect
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// newHumanPacer returns the plain constant pacer when no think time or jitter
+// is configured and the rate cannot change.
+func newHumanPacer(rate lib.Rate, thinkMin, thinkMax time.Duration, jitter float64, rates <-chan uint64) lib.Pacer {
+	base := lib.ConstantPacer{Freq: rate.Freq, Per: rate.Per}
+	if thinkMax <= 0 && jitter <= 0 && rates == nil {
+		return base
+	}
+	return &humanPacer{
+		base:     base,
+		interval: rate.Per / time.Duration(rate.Freq),
+		jitter:   jitter,
+		thinkMin: thinkMin,
+		thinkMax: thinkMax,
+		rates:    rates,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Pace implements lib.Pacer.
+func (p *humanPacer) Pace(elapsed time.Duration, hits uint64) (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case rate := <-p.rates:
+		p.base = lib.ConstantPacer{Freq: int(rate), Per: time.Second}
+		p.interval = time.Second / time.Duration(rate)
+		p.since, p.sinceHits = elapsed, hits
+	default:
+	}
+
+	var wait time.Duration
+	if p.thinkMax > 0 {
+		// Closed-loop pacing: every request waits a full interval plus think time.
+		if hits > 0 {
+			wait = p.interval + p.thinkTime()
+		}
+	} else {
+		var stop bool
+		wait, stop = p.base.Pace(elapsed-p.since, hits-p.sinceHits)
+		if stop {
+			return 0, true
+		}
+	}
+
+	if p.jitter > 0 {
+		offset := time.Duration((p.rnd.Float64()*2 - 1) * p.jitter * float64(p.interval))
+		wait += offset
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, false
+}
+
+// Rate implements lib.Pacer.
+func (p *humanPacer) Rate(elapsed time.Duration) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.thinkMax > 0 {
+		avgThink := (p.thinkMin + p.thinkMax) / 2
+		return float64(time.Second) / float64(p.interval+avgThink)
+	}
+	return p.base.Rate(elapsed)
+}
+
+func (p *humanPacer) thinkTime() time.Duration {
+	if p.thinkMax == p.thinkMin {
+		return p.thinkMin
+	}
+	return p.thinkMin + time.Duration(p.rnd.Int63n(int64(p.thinkMax-p.thinkMin)+1))
+}
+
+// paceIterations starts iterate in its own goroutine at every hit of pacer
+// until duration has passed or ctx is done, then waits for the iterations to
+// finish and returns how many were started. Iterations are numbered from 1.
+// When sem is not nil, an iteration holds a slot of it while running.
+func paceIterations(ctx context.Context, pacer lib.Pacer, drift *scheduleDrift, duration time.Duration, sem chan struct{}, iterate func(ctx context.Context, seq uint64)) uint64 {
+	var wg sync.WaitGroup
+	began := time.Now()
+	var iterations uint64
+pacing:
+	for {
+		elapsed := time.Since(began)
+		if elapsed >= duration {
+			break
+		}
+		wait, stop := pacer.Pace(elapsed, iterations)
+		if stop {
+			break
+		}
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				break pacing
+			}
+			continue
+		}
+
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break pacing
+			}
+		}
+		drift.sent(iterations, time.Now())
+		iterations++
+		wg.Add(1)
+		go func(seq uint64) {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			iterate(ctx, seq)
+		}(iterations)
+	}
+	wg.Wait()
+	return iterations
+}
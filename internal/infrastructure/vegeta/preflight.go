@@ -0,0 +1,83 @@
+// internal/infrastructure/vegeta/preflight.go
+package vegeta
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// Probe sends one request to each of the assignment's targets using the same
+// client options as the attack, and reports which targets answered with a
+// successful (2xx/3xx) response. Templated targets are expanded first; their
+// probes take {{seq}} values from the start of the assignment's range, and
+// SequenceStart is advanced past them so the attack never repeats one.
+func (va *VegetaAdapter) Probe(ctx context.Context, assignment *domain.TestAssignment) ([]domain.ProbeResult, error) {
+	targets, err := parseTargets(assignment.TargetsBase64)
+	if err != nil {
+		return nil, err
+	}
+	attackOptions, err := domain.ParseAttackOptions(assignment.VegetaPayloadJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ProbeResult, 0, len(targets))
+	seq := assignment.SequenceStart
+	defer func() { assignment.SequenceStart = seq }()
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		targeter := lib.NewStaticTargeter(target)
+		if assignment.TemplateTargets {
+			targeter = templateTargeter([]lib.Target{target}, seq, assignment.SequenceEnd)
+			if targetContains(target, placeholderSeq) && seq < assignment.SequenceEnd {
+				seq++
+			}
+		}
+		if labels := labelHeader(assignment.LabelHeaders); labels != nil {
+			targeter = labelTargeter(targeter, labels)
+		}
+		if assignment.RequestIDPrefix != "" {
+			targeter = requestIDTargeter(targeter, assignment.RequestIDPrefix)
+		}
+
+		// An attacker stops for good after one attack, so each probe gets its own
+		attacker := lib.NewAttacker(attackerOptions(attackOptions)...)
+		probe := domain.ProbeResult{Method: target.Method, URL: target.URL, Error: "no response"}
+		for res := range attacker.Attack(targeter, singleHitPacer{}, 0, "Pre-flight") {
+			probe = domain.ProbeResult{
+				Method:     target.Method,
+				URL:        target.URL,
+				StatusCode: res.Code,
+				LatencyMs:  float64(res.Latency.Microseconds()) / 1000,
+				Error:      res.Error,
+				Healthy:    res.Error == "" && res.Code >= 200 && res.Code < 400,
+			}
+		}
+		if probe.Error == "" && !probe.Healthy {
+			probe.Error = fmt.Sprintf("unexpected status %d", probe.StatusCode)
+		}
+		log.Printf("Pre-flight probe %s %s: status=%d latency=%.1fms healthy=%t %s",
+			probe.Method, probe.URL, probe.StatusCode, probe.LatencyMs, probe.Healthy, probe.Error)
+		results = append(results, probe)
+	}
+	return results, nil
+}
+
+// singleHitPacer lets an attack send exactly one request.
+type singleHitPacer struct{}
+
+func (singleHitPacer) Pace(_ time.Duration, hits uint64) (time.Duration, bool) {
+	return 0, hits >= 1
+}
+
+func (singleHitPacer) Rate(time.Duration) float64 {
+	return 0
+}
@@ -0,0 +1,481 @@
+// internal/infrastructure/vegeta/scenario.go
+package vegeta
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// maxScenarioBody caps how much of each response is read for extraction.
+const maxScenarioBody = 10 << 20
+
+var scenarioVarRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ScenarioAdapter implements the domain.ScenarioExecutor interface. Each
+// iteration runs the scenario's steps in order, feeding extracted values into
+// later steps; iterations are started at the assignment's rate.
+type ScenarioAdapter struct{}
+
+// NewScenarioAdapter creates a new scenario executor.
+func NewScenarioAdapter() *ScenarioAdapter {
+	return &ScenarioAdapter{}
+}
+
+// scenarioMetrics is the Metric payload for scenario runs: the usual vegeta
+// metrics across all requests plus a breakdown per step.
+type scenarioMetrics struct {
+	*lib.Metrics
+	Iterations          uint64        `json:"iterations"`
+	CompletedIterations uint64        `json:"completed_iterations"`
+	Steps               []stepMetrics `json:"steps"`
+}
+
+type stepMetrics struct {
+	Name    string       `json:"name"`
+	Metrics *lib.Metrics `json:"metrics"`
+}
+
+// Run executes the scenario described by assignment.ScenarioJSON.
+func (sa *ScenarioAdapter) Run(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	setupBegan := time.Now()
+	scenario, err := domain.ParseScenario(assignment.ScenarioJSON)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(assignment.DurationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration string: %w", err)
+	}
+	if assignment.RatePerSecond == 0 {
+		return nil, fmt.Errorf("rate per second must be greater than 0")
+	}
+	thinkMin, thinkMax, err := domain.ParseThinkTime(assignment.ThinkTime)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.PacingJitter < 0 || assignment.PacingJitter > 1 {
+		return nil, fmt.Errorf("pacing jitter must be between 0 and 1, got %f", assignment.PacingJitter)
+	}
+	attackOptions, err := domain.ParseAttackOptions(assignment.VegetaPayloadJSON)
+	if err != nil {
+		return nil, err
+	}
+	apdex, err := domain.NewApdexCollector(assignment.Apdex)
+	if err != nil {
+		return nil, err
+	}
+	interim, stopInterim, err := interimTicker(assignment)
+	if err != nil {
+		return nil, err
+	}
+	defer stopInterim()
+
+	run := &scenarioRun{
+		scenario: scenario,
+		client:   scenarioClient(attackOptions),
+		cookies:  attackOptions.Cookies,
+		progress: assignment.Progress,
+		recorder: assignment.Recorder,
+		capture:  newRequestCapture(assignment),
+		labels:   labelHeader(assignment.LabelHeaders),
+		apdex:    apdex,
+		thinkMin: thinkMin,
+		thinkMax: thinkMax,
+		overall:  &lib.Metrics{},
+		steps:    make([]*lib.Metrics, len(scenario.Steps)),
+		regexes:  make(map[string]*regexp.Regexp),
+	}
+	for i := range run.steps {
+		run.steps[i] = &lib.Metrics{}
+	}
+	if run.cookies && attackOptions.VirtualUsers != nil {
+		run.users = make([]*http.Client, *attackOptions.VirtualUsers)
+		for i := range run.users {
+			run.users[i] = run.sessionClient()
+		}
+	}
+
+	for _, step := range scenario.Steps {
+		for _, ex := range step.Extract {
+			if ex.Regex != "" {
+				// Already validated by domain.ParseScenario
+				run.regexes[ex.Regex] = regexp.MustCompile(ex.Regex)
+			}
+		}
+	}
+
+	// Bound concurrent iterations only when maxWorkers is set, like vegeta's attacker.
+	var sem chan struct{}
+	if attackOptions.MaxWorkers != nil {
+		sem = make(chan struct{}, *attackOptions.MaxWorkers)
+	}
+
+	setup := time.Since(setupBegan)
+	urls := make([]string, len(scenario.Steps))
+	for i, step := range scenario.Steps {
+		urls[i] = step.URL
+	}
+	dnsWarmup := warmDNS(ctx, urls, attackOptions)
+
+	// Think time is applied between steps, so iterations are paced by rate and jitter only.
+	rate := lib.Rate{Freq: int(assignment.RatePerSecond), Per: time.Second}
+	drift := &scheduleDrift{}
+	pacer := driftPacer{newHumanPacer(rate, 0, 0, assignment.PacingJitter, assignment.Rates), drift}
+
+	log.Printf("Starting scenario %q: %d steps, rate=%v iterations/s, duration=%v, cookies=%t, virtualUsers=%d",
+		scenario.Name, len(scenario.Steps), rate, duration, run.cookies, len(run.users))
+
+	if interim != nil {
+		interimDone := make(chan struct{})
+		defer close(interimDone)
+		go run.reportInterim(interim, interimDone, assignment.Interim)
+	}
+
+	iterations := paceIterations(ctx, pacer, drift, duration, sem, run.iterate)
+
+	run.overall.Close()
+	for _, m := range run.steps {
+		m.Close()
+	}
+	log.Printf("Scenario %q completed: %d iterations, %d completed", scenario.Name, iterations, run.completed)
+
+	payload := scenarioMetrics{
+		Metrics:             run.overall,
+		Iterations:          iterations,
+		CompletedIterations: run.completed,
+	}
+	for i, step := range scenario.Steps {
+		payload.Steps = append(payload.Steps, stepMetrics{Name: step.Name, Metrics: run.steps[i]})
+	}
+	result := newTestResult(run.overall, payload)
+	result.GeneratorOverhead = drift.overhead(setup, dnsWarmup)
+	result.Apdex = run.apdex.Result()
+	return result, nil
+}
+
+// scenarioRun holds the shared state of a single scenario execution.
+type scenarioRun struct {
+	scenario *domain.Scenario
+	client   *http.Client
+	cookies  bool           // Give each virtual user its own cookie jar
+	users    []*http.Client // Fixed virtual users with persistent sessions; empty = one session per iteration
+	thinkMin time.Duration
+	thinkMax time.Duration
+	regexes  map[string]*regexp.Regexp // Compiled extraction patterns, read-only once running
+	progress *domain.ProgressCounter   // Live request counts; may be nil
+	recorder domain.RequestRecorder    // Receives every request; may be nil
+	capture  *requestCapture           // Encodes every request when capturing; may be nil
+	labels   http.Header               // Added to every request; may be nil
+
+	mu        sync.Mutex
+	overall   *lib.Metrics
+	apdex     *domain.ApdexCollector
+	steps     []*lib.Metrics
+	completed uint64
+}
+
+// reportInterim passes the overall metrics gathered so far to reporter at
+// every tick, until done is closed.
+func (r *scenarioRun) reportInterim(ticks <-chan time.Time, done <-chan struct{}, reporter domain.InterimReporter) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticks:
+			r.mu.Lock()
+			result := interimResult(r.overall)
+			result.Apdex = r.apdex.Result()
+			r.mu.Unlock()
+			reporter.ReportInterim(result)
+		}
+	}
+}
+
+// sessionClient returns a client sharing the run's transport with its own
+// cookie jar, so cookies set for one virtual user are never sent by another.
+func (r *scenarioRun) sessionClient() *http.Client {
+	client := *r.client
+	client.Jar, _ = cookiejar.New(nil) // Only fails for a bad PublicSuffixList option
+	return &client
+}
+
+// clientFor picks the client for an iteration. Iterations are assigned to
+// fixed virtual users round-robin, so a session established in one iteration
+// (e.g. by a login step) carries over to that user's later iterations.
+func (r *scenarioRun) clientFor(seq uint64) *http.Client {
+	switch {
+	case !r.cookies:
+		return r.client
+	case len(r.users) > 0:
+		return r.users[(seq-1)%uint64(len(r.users))]
+	default:
+		return r.sessionClient()
+	}
+}
+
+// iterate runs all steps once. A failed step ends the iteration, since later
+// steps usually depend on its extracted values.
+func (r *scenarioRun) iterate(ctx context.Context, seq uint64) {
+	client := r.clientFor(seq)
+	vars := map[string]string{"iteration": strconv.FormatUint(seq, 10)}
+	for i := range r.scenario.Steps {
+		if i > 0 && r.thinkMax > 0 {
+			think := r.thinkMin
+			if r.thinkMax > r.thinkMin {
+				think += time.Duration(rand.Int63n(int64(r.thinkMax - r.thinkMin + 1)))
+			}
+			select {
+			case <-time.After(think):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if !r.runStep(ctx, client, i, seq, vars) {
+			return
+		}
+	}
+	r.mu.Lock()
+	r.completed++
+	r.mu.Unlock()
+}
+
+// runStep performs one step, records its result and applies its extractions.
+func (r *scenarioRun) runStep(ctx context.Context, client *http.Client, index int, seq uint64, vars map[string]string) bool {
+	step := &r.scenario.Steps[index]
+	expand := func(s string) string {
+		return scenarioVarRef.ReplaceAllStringFunc(s, func(ref string) string {
+			if v, ok := vars[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+
+	res := &lib.Result{
+		Attack:    r.scenario.Name,
+		Seq:       seq,
+		Timestamp: time.Now(),
+		Method:    step.Method,
+		URL:       expand(step.URL),
+	}
+	defer func() {
+		res.Latency = time.Since(res.Timestamp)
+		if r.progress != nil {
+			r.progress.Record(res.Latency, res.Code, res.Error)
+		}
+		if r.recorder != nil {
+			r.recorder.Record(domain.RequestRecord{Target: res.URL, Code: res.Code, Latency: res.Latency, Timestamp: res.Timestamp})
+		}
+		r.mu.Lock()
+		r.overall.Add(res)
+		scoreApdex(r.apdex, res)
+		r.steps[index].Add(res)
+		r.capture.Add(res)
+		r.mu.Unlock()
+	}()
+
+	body := expand(step.Body)
+	req, err := http.NewRequestWithContext(ctx, step.Method, res.URL, strings.NewReader(body))
+	if err != nil {
+		res.Error = err.Error()
+		return false
+	}
+	for k, vs := range step.Header {
+		for _, v := range vs {
+			req.Header.Add(k, expand(v))
+		}
+	}
+	setLabels(req.Header, r.labels)
+	res.BytesOut = uint64(len(body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		res.Error = err.Error()
+		return false
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxScenarioBody))
+	res.Code = uint16(resp.StatusCode)
+	res.BytesIn = uint64(len(respBody))
+	if err != nil {
+		res.Error = err.Error()
+		return false
+	}
+
+	if !statusAccepted(step.ExpectStatus, resp.StatusCode) {
+		res.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		return false
+	}
+
+	for _, ex := range step.Extract {
+		value, err := extractValue(ex, r.regexes[ex.Regex], resp.Header, respBody)
+		if err != nil {
+			res.Error = fmt.Sprintf("extract %s: %v", ex.Var, err)
+			return false
+		}
+		vars[ex.Var] = value
+	}
+	return true
+}
+
+func statusAccepted(expected []int, code int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 400
+	}
+	for _, c := range expected {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// extractValue applies a single extraction rule to a response.
+func extractValue(ex domain.ScenarioExtract, re *regexp.Regexp, header http.Header, body []byte) (string, error) {
+	if ex.JSONPath != "" {
+		return extractJSONPath(body, ex.JSONPath)
+	}
+
+	source := string(body)
+	if ex.Header != "" {
+		source = header.Get(ex.Header)
+	}
+	m := re.FindStringSubmatch(source)
+	if m == nil {
+		return "", fmt.Errorf("regex %q did not match", ex.Regex)
+	}
+	if len(m) > 1 {
+		return m[1], nil
+	}
+	return m[0], nil
+}
+
+var (
+	jsonPathSegment = regexp.MustCompile(`^([^\[]*)((?:\[\d+\])*)$`)
+	jsonPathIndex   = regexp.MustCompile(`\d+`)
+)
+
+// extractJSONPath evaluates a simple JSONPath made of dotted keys and array
+// indexes ("$.data.items[0].id") and returns the value as a string.
+func extractJSONPath(body []byte, path string) (string, error) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", fmt.Errorf("response is not JSON: %w", err)
+	}
+
+	cur := doc
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "$"), "."), ".") {
+		if part == "" {
+			continue
+		}
+		m := jsonPathSegment.FindStringSubmatch(part)
+		if m == nil {
+			return "", fmt.Errorf("unsupported jsonPath segment %q", part)
+		}
+		if m[1] != "" {
+			obj, ok := cur.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("%q is not an object", m[1])
+			}
+			if cur, ok = obj[m[1]]; !ok {
+				return "", fmt.Errorf("key %q not found", m[1])
+			}
+		}
+		for _, idx := range jsonPathIndex.FindAllString(m[2], -1) {
+			n, _ := strconv.Atoi(idx)
+			arr, ok := cur.([]interface{})
+			if !ok || n >= len(arr) {
+				return "", fmt.Errorf("index %d out of range in %q", n, part)
+			}
+			cur = arr[n]
+		}
+	}
+
+	switch v := cur.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", fmt.Errorf("value at %q is null", path)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes.TrimSpace(b)), nil
+	}
+}
+
+// scenarioClient builds an HTTP client honoring the subset of attack options
+// that apply outside vegeta's attacker.
+func scenarioClient(opts *domain.AttackOptions) *http.Client {
+	dialer := &net.Dialer{Timeout: lib.DefaultTimeout, KeepAlive: 30 * time.Second}
+	if opts.LocalAddr != "" {
+		dialer.LocalAddr = &net.TCPAddr{IP: net.ParseIP(opts.LocalAddr)}
+	}
+
+	connectTo := make(map[string]string, len(opts.Resolve))
+	for _, entry := range opts.Resolve {
+		from, to, _ := domain.ParseResolveOverride(entry)
+		connectTo[from] = to
+	}
+
+	tr := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if to, ok := connectTo[addr]; ok {
+				addr = to
+			}
+			return dialer.DialContext(ctx, network, addr)
+		},
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: opts.Insecure},
+		MaxIdleConnsPerHost: lib.DefaultConnections,
+		ForceAttemptHTTP2:   opts.HTTP2 == nil || *opts.HTTP2,
+	}
+	if opts.KeepAlive != nil && !*opts.KeepAlive {
+		tr.DisableKeepAlives = true
+	}
+	if opts.Connections != nil {
+		tr.MaxIdleConnsPerHost = *opts.Connections
+	}
+	if opts.MaxConnections != nil {
+		tr.MaxConnsPerHost = *opts.MaxConnections
+	}
+	if opts.Proxy != "" {
+		proxyURL, _ := url.Parse(opts.Proxy)
+		tr.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client := &http.Client{Transport: tr, Timeout: lib.DefaultTimeout}
+	if opts.Timeout != nil {
+		client.Timeout = time.Duration(*opts.Timeout)
+	}
+	redirects := lib.DefaultRedirects
+	if opts.Redirects != nil {
+		redirects = *opts.Redirects
+	}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if redirects < 0 || len(via) > redirects {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	return client
+}
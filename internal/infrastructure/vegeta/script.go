@@ -0,0 +1,616 @@
+// internal/infrastructure/vegeta/script.go
+package vegeta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+const (
+	// scriptCallTimeout bounds one call of a script function, or of the
+	// script's top level. A call still running then is stopped with an error.
+	scriptCallTimeout = time.Minute
+	// maxScriptString caps the strings string.rep builds.
+	maxScriptString = 16 << 20
+	// maxScriptDataDepth caps the nesting of tables passed to json.encode or
+	// returned by setup.
+	maxScriptDataDepth = 32
+	// maxScriptErrors caps how many distinct iteration errors are reported.
+	maxScriptErrors = 10
+)
+
+// ScriptAdapter implements domain.Executor for domain.ExecutorLua tests. The
+// script defines an iteration function, called at the assignment's rate, and
+// optional setup and teardown functions called once before and after.
+//
+// Each concurrent iteration runs in its own Lua VM with only the base, table,
+// string and math libraries, without file access or module loading. Every call
+// is bounded by scriptCallTimeout and each VM by the default Lua stack sizes;
+// a hard memory limit needs isolated attacks.
+type ScriptAdapter struct{}
+
+// NewScriptAdapter creates a new script executor.
+func NewScriptAdapter() *ScriptAdapter {
+	return &ScriptAdapter{}
+}
+
+// scriptMetrics is the Metric payload of script runs: the vegeta metrics of
+// the requests the script sent plus how its iterations went.
+type scriptMetrics struct {
+	*lib.Metrics
+	Iterations          uint64   `json:"iterations"`
+	CompletedIterations uint64   `json:"completed_iterations"`
+	FailedIterations    uint64   `json:"failed_iterations"`
+	ScriptErrors        []string `json:"script_errors,omitempty"` // The first distinct errors iterations raised
+}
+
+// Execute runs the Lua program in assignment.Script.
+func (sa *ScriptAdapter) Execute(ctx context.Context, assignment *domain.TestAssignment) (*domain.TestResult, error) {
+	setupBegan := time.Now()
+	proto, err := compileScript(assignment.Script)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(assignment.DurationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration string: %w", err)
+	}
+	if assignment.RatePerSecond == 0 {
+		return nil, fmt.Errorf("rate per second must be greater than 0")
+	}
+	if assignment.PacingJitter < 0 || assignment.PacingJitter > 1 {
+		return nil, fmt.Errorf("pacing jitter must be between 0 and 1, got %f", assignment.PacingJitter)
+	}
+	attackOptions, err := domain.ParseAttackOptions(assignment.VegetaPayloadJSON)
+	if err != nil {
+		return nil, err
+	}
+	apdex, err := domain.NewApdexCollector(assignment.Apdex)
+	if err != nil {
+		return nil, err
+	}
+	interim, stopInterim, err := interimTicker(assignment)
+	if err != nil {
+		return nil, err
+	}
+	defer stopInterim()
+
+	run := &scriptRun{
+		testID:   assignment.TestID,
+		proto:    proto,
+		client:   scenarioClient(attackOptions),
+		progress: assignment.Progress,
+		recorder: assignment.Recorder,
+		capture:  newRequestCapture(assignment),
+		labels:   labelHeader(assignment.LabelHeaders),
+		apdex:    apdex,
+		overall:  &lib.Metrics{},
+	}
+	defer run.close()
+
+	// Setup runs in the first VM, which is then kept for iterations
+	vm, err := run.newVM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if vm.defines(domain.ScriptSetup) {
+		data, err := vm.call(ctx, domain.ScriptSetup)
+		if err == nil {
+			run.data, err = fromLua(data, 0)
+		}
+		if err != nil {
+			vm.L.Close()
+			return nil, fmt.Errorf("script setup failed: %s", scriptErrorMessage(err))
+		}
+		vm.data = toLua(vm.L, run.data)
+	}
+	run.put(vm)
+
+	// Bound concurrent iterations, and so VMs, only when maxWorkers is set, like vegeta's attacker.
+	var sem chan struct{}
+	if attackOptions.MaxWorkers != nil {
+		sem = make(chan struct{}, *attackOptions.MaxWorkers)
+	}
+
+	setup := time.Since(setupBegan)
+	rate := lib.Rate{Freq: int(assignment.RatePerSecond), Per: time.Second}
+	drift := &scheduleDrift{}
+	pacer := driftPacer{newHumanPacer(rate, 0, 0, assignment.PacingJitter, assignment.Rates), drift}
+
+	log.Printf("Starting script: rate=%v iterations/s, duration=%v", rate, duration)
+
+	if interim != nil {
+		interimDone := make(chan struct{})
+		defer close(interimDone)
+		go run.reportInterim(interim, interimDone, assignment.Interim)
+	}
+
+	iterations := paceIterations(ctx, pacer, drift, duration, sem, run.iterate)
+
+	// Teardown runs even when the test was stopped early
+	if vm, err := run.get(context.WithoutCancel(ctx)); err != nil {
+		log.Printf("Warning: Script teardown skipped: %v", err)
+	} else {
+		if vm.defines(domain.ScriptTeardown) {
+			if _, err := vm.call(context.WithoutCancel(ctx), domain.ScriptTeardown, vm.data); err != nil {
+				log.Printf("Warning: Script teardown failed: %s", scriptErrorMessage(err))
+			}
+		}
+		run.put(vm)
+	}
+
+	run.overall.Close()
+	log.Printf("Script completed: %d iterations, %d completed, %d failed", iterations, run.completed, run.failed)
+
+	result := newTestResult(run.overall, scriptMetrics{
+		Metrics:             run.overall,
+		Iterations:          iterations,
+		CompletedIterations: run.completed,
+		FailedIterations:    run.failed,
+		ScriptErrors:        run.errors,
+	})
+	result.GeneratorOverhead = drift.overhead(setup, 0)
+	result.Apdex = run.apdex.Result()
+	return result, nil
+}
+
+// compileScript compiles a script once for all the VMs that run it.
+func compileScript(script string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(strings.NewReader(script), "script")
+	if err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	proto, err := lua.Compile(chunk, "script")
+	if err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	return proto, nil
+}
+
+// scriptRun holds the shared state of a single script execution.
+type scriptRun struct {
+	testID   string
+	proto    *lua.FunctionProto
+	client   *http.Client
+	data     interface{}             // What setup returned, copied into every VM
+	progress *domain.ProgressCounter // Live request counts; may be nil
+	recorder domain.RequestRecorder  // Receives every request; may be nil
+	capture  *requestCapture         // Encodes every request when capturing; may be nil
+	labels   http.Header             // Added to every request; may be nil
+
+	poolMu sync.Mutex
+	idle   []*scriptVM // VMs not running an iteration
+
+	mu        sync.Mutex
+	overall   *lib.Metrics
+	apdex     *domain.ApdexCollector
+	completed uint64
+	failed    uint64
+	errors    []string
+}
+
+// scriptVM is a Lua VM that has run the script's top level.
+type scriptVM struct {
+	run  *scriptRun
+	L    *lua.LState
+	data lua.LValue // The setup data in this VM
+	seq  uint64     // Iteration being run, for the requests it sends
+}
+
+// newVM creates a sandboxed VM and runs the script's top level in it.
+func (r *scriptRun) newVM(ctx context.Context) (*scriptVM, error) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true, MinimizeStackMemory: true})
+	vm := &scriptVM{run: r, L: L, data: lua.LNil}
+	for _, library := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		if err := L.CallByParam(lua.P{Fn: L.NewFunction(library.open), Protect: true}, lua.LString(library.name)); err != nil {
+			L.Close()
+			return nil, fmt.Errorf("failed to open Lua library %q: %w", library.name, err)
+		}
+	}
+	// No file access, code loading or environment changes
+	for _, name := range []string{"dofile", "loadfile", "load", "loadstring", "module", "require", "getfenv", "setfenv", "_printregs"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	if stringLib, ok := L.GetGlobal(lua.StringLibName).(*lua.LTable); ok {
+		stringLib.RawSetString("rep", L.NewFunction(luaStringRep))
+	}
+	L.SetGlobal("print", L.NewFunction(vm.print))
+	L.SetGlobal("sleep", L.NewFunction(luaSleep))
+	L.SetGlobal("http", L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"request": vm.request,
+		"get":     vm.bodyless(http.MethodGet),
+		"delete":  vm.bodyless(http.MethodDelete),
+		"post":    vm.withBody(http.MethodPost),
+		"put":     vm.withBody(http.MethodPut),
+		"patch":   vm.withBody(http.MethodPatch),
+	}))
+	L.SetGlobal("json", L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"encode": luaJSONEncode,
+		"decode": luaJSONDecode,
+	}))
+
+	callCtx, cancel := context.WithTimeout(ctx, scriptCallTimeout)
+	defer cancel()
+	L.SetContext(callCtx)
+	defer L.RemoveContext()
+	if err := L.CallByParam(lua.P{Fn: L.NewFunctionFromProto(r.proto), Protect: true}); err != nil {
+		L.Close()
+		return nil, fmt.Errorf("script failed: %s", scriptErrorMessage(err))
+	}
+	if !vm.defines(domain.ScriptIteration) {
+		L.Close()
+		return nil, fmt.Errorf("script does not define function %s", domain.ScriptIteration)
+	}
+	if r.data != nil {
+		vm.data = toLua(L, r.data)
+	}
+	return vm, nil
+}
+
+// get takes an idle VM, or creates one if all are busy.
+func (r *scriptRun) get(ctx context.Context) (*scriptVM, error) {
+	r.poolMu.Lock()
+	if n := len(r.idle); n > 0 {
+		vm := r.idle[n-1]
+		r.idle = r.idle[:n-1]
+		r.poolMu.Unlock()
+		return vm, nil
+	}
+	r.poolMu.Unlock()
+	return r.newVM(ctx)
+}
+
+func (r *scriptRun) put(vm *scriptVM) {
+	r.poolMu.Lock()
+	r.idle = append(r.idle, vm)
+	r.poolMu.Unlock()
+}
+
+// close closes the idle VMs; busy ones are closed by their iteration.
+func (r *scriptRun) close() {
+	r.poolMu.Lock()
+	defer r.poolMu.Unlock()
+	for _, vm := range r.idle {
+		vm.L.Close()
+	}
+	r.idle = nil
+}
+
+// iterate calls the script's iteration function once. A VM whose call timed
+// out or was cancelled may be mid-way through anything, so it is discarded.
+func (r *scriptRun) iterate(ctx context.Context, seq uint64) {
+	vm, err := r.get(ctx)
+	if err != nil {
+		r.fail(err)
+		return
+	}
+	vm.seq = seq
+	_, err = vm.call(ctx, domain.ScriptIteration, vm.data, lua.LNumber(seq))
+	switch {
+	case err == nil:
+		r.mu.Lock()
+		r.completed++
+		r.mu.Unlock()
+	case ctx.Err() != nil:
+		// Stopped with the test, not the script's fault
+		vm.L.Close()
+		return
+	default:
+		r.fail(err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			vm.L.Close()
+			return
+		}
+	}
+	r.put(vm)
+}
+
+// fail counts a failed iteration and keeps its error if it is a new one.
+func (r *scriptRun) fail(err error) {
+	message := scriptErrorMessage(err)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed++
+	if len(r.errors) < maxScriptErrors && !slices.Contains(r.errors, message) {
+		r.errors = append(r.errors, message)
+		log.Printf("Script iteration failed: %s", message)
+	}
+}
+
+// reportInterim passes the metrics gathered so far to reporter at every
+// tick, until done is closed.
+func (r *scriptRun) reportInterim(ticks <-chan time.Time, done <-chan struct{}, reporter domain.InterimReporter) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticks:
+			r.mu.Lock()
+			result := interimResult(r.overall)
+			result.Apdex = r.apdex.Result()
+			r.mu.Unlock()
+			reporter.ReportInterim(result)
+		}
+	}
+}
+
+// defines reports whether the script defines the global function name.
+func (vm *scriptVM) defines(name string) bool {
+	_, ok := vm.L.GetGlobal(name).(*lua.LFunction)
+	return ok
+}
+
+// call calls the script's function name with args within scriptCallTimeout
+// and returns its first result.
+func (vm *scriptVM) call(ctx context.Context, name string, args ...lua.LValue) (lua.LValue, error) {
+	callCtx, cancel := context.WithTimeout(ctx, scriptCallTimeout)
+	defer cancel()
+	vm.L.SetContext(callCtx)
+	defer vm.L.RemoveContext()
+	if err := vm.L.CallByParam(lua.P{Fn: vm.L.GetGlobal(name), NRet: 1, Protect: true}, args...); err != nil {
+		if callCtx.Err() != nil {
+			return lua.LNil, fmt.Errorf("%s stopped: %w", name, callCtx.Err())
+		}
+		return lua.LNil, err
+	}
+	ret := vm.L.Get(-1)
+	vm.L.Pop(1)
+	return ret, nil
+}
+
+// print implements print(...), writing to the worker log.
+func (vm *scriptVM) print(L *lua.LState) int {
+	parts := make([]string, L.GetTop())
+	for i := range parts {
+		parts[i] = L.ToStringMeta(L.Get(i + 1)).String()
+	}
+	log.Printf("[script %s] %s", vm.run.testID, strings.Join(parts, "\t"))
+	return 0
+}
+
+// request implements http.request(method, url [, options]), where options
+// may hold a body string and a headers table.
+func (vm *scriptVM) request(L *lua.LState) int {
+	options := L.OptTable(3, L.NewTable())
+	return vm.send(L, strings.ToUpper(L.CheckString(1)), L.CheckString(2), lua.LVAsString(options.RawGetString("body")), options)
+}
+
+// bodyless implements http.get and http.delete: (url [, options]).
+func (vm *scriptVM) bodyless(method string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		return vm.send(L, method, L.CheckString(1), "", L.OptTable(2, L.NewTable()))
+	}
+}
+
+// withBody implements http.post, http.put and http.patch: (url, body [, options]).
+func (vm *scriptVM) withBody(method string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		return vm.send(L, method, L.CheckString(1), L.OptString(2, ""), L.OptTable(3, L.NewTable()))
+	}
+}
+
+// send performs and records one request. It returns a response table with
+// status, body and headers (first value of each), or nil and an error
+// message when no response was received.
+func (vm *scriptVM) send(L *lua.LState, method, url, body string, options *lua.LTable) int {
+	r := vm.run
+	res := &lib.Result{
+		Attack:    "script",
+		Seq:       vm.seq,
+		Timestamp: time.Now(),
+		Method:    method,
+		URL:       url,
+		BytesOut:  uint64(len(body)),
+	}
+	defer func() {
+		res.Latency = time.Since(res.Timestamp)
+		if r.progress != nil {
+			r.progress.Record(res.Latency, res.Code, res.Error)
+		}
+		if r.recorder != nil {
+			r.recorder.Record(domain.RequestRecord{Target: res.URL, Code: res.Code, Latency: res.Latency, Timestamp: res.Timestamp})
+		}
+		r.mu.Lock()
+		r.overall.Add(res)
+		scoreApdex(r.apdex, res)
+		r.capture.Add(res)
+		r.mu.Unlock()
+	}()
+	fail := func(err error) int {
+		res.Error = err.Error()
+		L.Push(lua.LNil)
+		L.Push(lua.LString(res.Error))
+		return 2
+	}
+
+	ctx := L.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		return fail(err)
+	}
+	if headers, ok := options.RawGetString("headers").(*lua.LTable); ok {
+		headers.ForEach(func(k, v lua.LValue) {
+			req.Header.Add(k.String(), v.String())
+		})
+	}
+	setLabels(req.Header, r.labels)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxScenarioBody))
+	res.Code = uint16(resp.StatusCode)
+	res.BytesIn = uint64(len(respBody))
+	if err != nil {
+		return fail(err)
+	}
+
+	headers := L.NewTable()
+	for name := range resp.Header {
+		headers.RawSetString(name, lua.LString(resp.Header.Get(name)))
+	}
+	response := L.NewTable()
+	response.RawSetString("status", lua.LNumber(resp.StatusCode))
+	response.RawSetString("body", lua.LString(respBody))
+	response.RawSetString("headers", headers)
+	L.Push(response)
+	return 1
+}
+
+// luaSleep implements sleep(seconds), returning early when the call is stopped.
+func luaSleep(L *lua.LState) int {
+	d := time.Duration(float64(L.CheckNumber(1)) * float64(time.Second))
+	var done <-chan struct{}
+	if ctx := L.Context(); ctx != nil {
+		done = ctx.Done()
+	}
+	select {
+	case <-time.After(d):
+	case <-done:
+	}
+	return 0
+}
+
+// luaStringRep replaces string.rep, refusing results over maxScriptString.
+func luaStringRep(L *lua.LState) int {
+	s, n := L.CheckString(1), L.CheckInt(2)
+	if n <= 0 {
+		L.Push(lua.LString(""))
+		return 1
+	}
+	if len(s) > 0 && n > maxScriptString/len(s) {
+		L.RaiseError("string.rep result exceeds %d bytes", maxScriptString)
+		return 0
+	}
+	L.Push(lua.LString(strings.Repeat(s, n)))
+	return 1
+}
+
+// luaJSONEncode implements json.encode(value).
+func luaJSONEncode(L *lua.LState) int {
+	value, err := fromLua(L.CheckAny(1), 0)
+	if err == nil {
+		var b []byte
+		if b, err = json.Marshal(value); err == nil {
+			L.Push(lua.LString(b))
+			return 1
+		}
+	}
+	L.RaiseError("json.encode: %v", err)
+	return 0
+}
+
+// luaJSONDecode implements json.decode(string).
+func luaJSONDecode(L *lua.LState) int {
+	var value interface{}
+	if err := json.Unmarshal([]byte(L.CheckString(1)), &value); err != nil {
+		L.RaiseError("json.decode: %v", err)
+		return 0
+	}
+	L.Push(toLua(L, value))
+	return 1
+}
+
+// fromLua copies a Lua value out of its VM. Tables whose keys are 1..n become
+// slices and other tables maps keyed by the keys' string form.
+func fromLua(v lua.LValue, depth int) (interface{}, error) {
+	switch v := v.(type) {
+	case *lua.LNilType:
+		return nil, nil
+	case lua.LBool:
+		return bool(v), nil
+	case lua.LNumber:
+		return float64(v), nil
+	case lua.LString:
+		return string(v), nil
+	case *lua.LTable:
+		if depth >= maxScriptDataDepth {
+			return nil, fmt.Errorf("tables are nested more than %d deep", maxScriptDataDepth)
+		}
+		var keys int
+		v.ForEach(func(lua.LValue, lua.LValue) { keys++ })
+		if n := v.MaxN(); n > 0 && n == keys {
+			list := make([]interface{}, n)
+			for i := range list {
+				item, err := fromLua(v.RawGetInt(i+1), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				list[i] = item
+			}
+			return list, nil
+		}
+		fields := make(map[string]interface{}, keys)
+		var err error
+		v.ForEach(func(key, value lua.LValue) {
+			if err == nil {
+				fields[key.String()], err = fromLua(value, depth+1)
+			}
+		})
+		return fields, err
+	default:
+		return nil, fmt.Errorf("cannot copy a %s value", v.Type())
+	}
+}
+
+// toLua creates the Lua form of a value from fromLua or encoding/json.
+func toLua(L *lua.LState, v interface{}) lua.LValue {
+	switch v := v.(type) {
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []interface{}:
+		list := L.CreateTable(len(v), 0)
+		for _, item := range v {
+			list.Append(toLua(L, item))
+		}
+		return list
+	case map[string]interface{}:
+		fields := L.CreateTable(0, len(v))
+		for key, value := range v {
+			fields.RawSetString(key, toLua(L, value))
+		}
+		return fields
+	default:
+		return lua.LNil
+	}
+}
+
+// scriptErrorMessage strips the Lua stack trace from a script error.
+func scriptErrorMessage(err error) string {
+	var apiErr *lua.ApiError
+	if errors.As(err, &apiErr) && apiErr.Object != nil {
+		return apiErr.Object.String()
+	}
+	return err.Error()
+}
@@ -0,0 +1,56 @@
+// internal/infrastructure/vegeta/smoke.go
+package vegeta
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// smokeCollector groups the results of a smoke run by target, in the order
+// the targets were first hit.
+type smokeCollector struct {
+	responses []domain.TargetResponse
+	latencies []time.Duration
+	index     map[string]int // method + URL -> position in responses
+}
+
+func newSmokeCollector() *smokeCollector {
+	return &smokeCollector{index: make(map[string]int)}
+}
+
+// Add records one result against its target.
+func (c *smokeCollector) Add(res *lib.Result) {
+	key := res.Method + " " + res.URL
+	i, ok := c.index[key]
+	if !ok {
+		i = len(c.responses)
+		c.index[key] = i
+		c.responses = append(c.responses, domain.TargetResponse{
+			Method:      res.Method,
+			URL:         res.URL,
+			StatusCodes: make(map[string]int64),
+		})
+		c.latencies = append(c.latencies, 0)
+	}
+	target := &c.responses[i]
+	target.Requests++
+	target.StatusCodes[strconv.Itoa(int(res.Code))]++
+	c.latencies[i] += res.Latency
+	if target.Error == "" {
+		target.Error = res.Error
+	}
+	if target.SampleBody == "" && len(res.Body) > 0 {
+		target.SampleBody = string(res.Body[:min(len(res.Body), domain.MaxSampleBodyBytes)])
+	}
+}
+
+// Responses returns the per-target summaries gathered so far.
+func (c *smokeCollector) Responses() []domain.TargetResponse {
+	for i := range c.responses {
+		c.responses[i].AvgLatencyMs = float64((c.latencies[i] / time.Duration(c.responses[i].Requests)).Microseconds()) / 1000
+	}
+	return c.responses
+}
@@ -0,0 +1,92 @@
+// internal/infrastructure/vegeta/template.go
+package vegeta
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	lib "github.com/tsenart/vegeta/v12/lib"
+)
+
+// Placeholders expanded in target URLs, headers and bodies when templating is enabled.
+const (
+	placeholderSeq       = "{{seq}}"       // Next value from this worker's partitioned sequence range
+	placeholderUUID      = "{{uuid}}"      // Random UUID
+	placeholderTimestamp = "{{timestamp}}" // Unix time in milliseconds
+)
+
+// templateTargeter round-robins over targets like vegeta's static targeter,
+// expanding placeholders in each generated request. {{seq}} values are taken
+// from [seqStart, seqEnd) and the attack stops once the range is exhausted,
+// so workers with disjoint ranges never generate colliding values.
+func templateTargeter(targets []lib.Target, seqStart, seqEnd uint64) lib.Targeter {
+	hasSeq := make([]bool, len(targets))
+	hasPlaceholder := make([]bool, len(targets))
+	for i, t := range targets {
+		hasSeq[i] = targetContains(t, placeholderSeq)
+		hasPlaceholder[i] = targetContains(t, "{{")
+	}
+
+	var (
+		mu   sync.Mutex
+		next = seqStart
+		n    int
+	)
+	return func(tgt *lib.Target) error {
+		mu.Lock()
+		i := n % len(targets)
+		n++
+		seq := next
+		if hasSeq[i] {
+			if next >= seqEnd {
+				mu.Unlock()
+				return lib.ErrNoTargets
+			}
+			next++
+		}
+		mu.Unlock()
+
+		t := targets[i]
+		if !hasPlaceholder[i] {
+			*tgt = t
+			return nil
+		}
+
+		r := strings.NewReplacer(
+			placeholderSeq, strconv.FormatUint(seq, 10),
+			placeholderUUID, uuid.NewString(),
+			placeholderTimestamp, strconv.FormatInt(time.Now().UnixMilli(), 10),
+		)
+		*tgt = lib.Target{
+			Method: t.Method,
+			URL:    r.Replace(t.URL),
+			Body:   []byte(r.Replace(string(t.Body))),
+			Header: make(http.Header, len(t.Header)),
+		}
+		for k, vs := range t.Header {
+			for _, v := range vs {
+				tgt.Header.Add(k, r.Replace(v))
+			}
+		}
+		return nil
+	}
+}
+
+// targetContains reports whether s appears in the target's URL, body or headers.
+func targetContains(t lib.Target, s string) bool {
+	if strings.Contains(t.URL, s) || strings.Contains(string(t.Body), s) {
+		return true
+	}
+	for _, vs := range t.Header {
+		for _, v := range vs {
+			if strings.Contains(v, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+// internal/infrastructure/vegeta/version.go
+package vegeta
+
+import "runtime/debug"
+
+// libraryModule is the module path of the vegeta library.
+const libraryModule = "github.com/tsenart/vegeta/v12"
+
+// LibraryVersion returns the version of the vegeta library the binary was
+// built with, or an empty string if the build information is unavailable.
+func LibraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != libraryModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
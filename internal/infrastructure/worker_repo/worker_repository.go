@@ -107,3 +107,16 @@ func (r *InMemoryWorkerRepository) MarkWorkerOffline(ctx context.Context, worker
 	}
 	return fmt.Errorf("worker with ID %s not found to mark offline", workerID)
 }
+
+// DeleteWorker removes a worker's registration from memory.
+func (r *InMemoryWorkerRepository) DeleteWorker(ctx context.Context, workerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.workers[workerID]; !ok {
+		return fmt.Errorf("worker with ID %s not found", workerID)
+	}
+	delete(r.workers, workerID)
+	log.Printf("Worker %s removed.", workerID)
+	return nil
+}
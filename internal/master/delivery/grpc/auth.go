@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	userUsecase "github.com/pace-noge/distributed-load-tester/internal/user/usecase"
+)
+
+// masterServicePrefix starts the full method names of the client-facing
+// RPCs; WorkerService calls from workers carry no user credentials.
+const masterServicePrefix = "/loadtester.MasterService/"
+
+// AuthUnaryInterceptor requires MasterService calls to send a login token or
+// API key as "authorization: Bearer <token>" metadata, checked the same way
+// as on the HTTP API. The handlers act for the token's user, whatever
+// requester_id the request names.
+func AuthUnaryInterceptor(users *userUsecase.UserUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, masterServicePrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, users)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor authenticates streaming MasterService calls like
+// AuthUnaryInterceptor.
+func AuthStreamInterceptor(users *userUsecase.UserUsecase) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, masterServicePrefix) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), users)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns ctx carrying the user of the call's token.
+func authenticate(ctx context.Context, users *userUsecase.UserUsecase) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token format: Bearer token required")
+	}
+	user, err := users.ValidateJWTToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	if user.MustChangePassword {
+		return nil, status.Error(codes.PermissionDenied, "password change required: set a new password with POST /api/auth/change-password")
+	}
+	ctx = domain.WithCaller(ctx, user)
+	return domain.WithAuditActor(ctx, user.ID, user.Username), nil
+}
+
+// authenticatedStream is a server stream whose context carries its caller.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// caller returns the authenticated user of a MasterService call.
+func caller(ctx context.Context) (*domain.UserProfile, error) {
+	user, ok := domain.CallerFrom(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller not authenticated")
+	}
+	return user, nil
+}
@@ -3,12 +3,16 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
@@ -32,20 +36,36 @@ func NewGRPCServer(uc *masterUsecase.MasterUsecase) *GRPCServer {
 
 // RegisterWorker handles worker registration (Unary RPC).
 func (s *GRPCServer) RegisterWorker(ctx context.Context, req *pb.WorkerInfo) (*pb.RegisterResponse, error) {
-	log.Printf("Worker %s attempting to register from %s", req.Id, req.Address)
+	log.Printf("Worker %s attempting to register from %s (version: %s, region: %q, max rate: %d req/s, protocols: %v, features: %v, executors: %v)",
+		req.Id, req.Address, req.Version, req.Region, req.MaxRate, req.Protocols, req.Features, req.Executors)
 	worker := &domain.Worker{
 		ID:       req.Id,
 		Address:  req.Address,
 		Status:   "READY", // Initial status
 		LastSeen: time.Now(),
+		MaxRate:  req.MaxRate,
+		Region:   req.Region,
+		WorkerCapabilities: domain.WorkerCapabilities{
+			Version:         req.Version,
+			Protocols:       req.Protocols,
+			Features:        req.Features,
+			Executors:       req.Executors,
+			MaxPayloadBytes: req.MaxPayloadBytes,
+		},
 	}
+	heartbeat := s.usecase.NegotiateHeartbeat(req.Id, time.Duration(req.HeartbeatIntervalMs)*time.Millisecond)
 	err := s.usecase.RegisterWorker(ctx, worker)
 	if err != nil {
 		log.Printf("Failed to register worker %s: %v", req.Id, err)
 		return &pb.RegisterResponse{Success: false, Message: fmt.Sprintf("Failed to register: %v", err)}, status.Errorf(codes.Internal, "registration failed: %v", err)
 	}
-	log.Printf("Worker %s registered successfully.", req.Id)
-	return &pb.RegisterResponse{Success: true, Message: "Worker registered successfully"}, nil
+	log.Printf("Worker %s registered successfully (heartbeat every %v).", req.Id, heartbeat)
+	return &pb.RegisterResponse{
+		Success:             true,
+		Message:             "Worker registered successfully",
+		HeartbeatIntervalMs: uint32(heartbeat.Milliseconds()),
+		MaxMissedHeartbeats: uint32(s.usecase.MaxMissedHeartbeats()),
+	}, nil
 }
 
 // StreamWorkerStatus handles bidirectional streaming for worker status updates.
@@ -66,12 +86,13 @@ func (s *GRPCServer) StreamWorkerStatus(stream pb.WorkerService_StreamWorkerStat
 			return ctx.Err()
 		default:
 			statusMsg, err := stream.Recv()
+			receivedAt := time.Now()
 			if err == io.EOF {
 				if workerID != "" {
-					log.Printf("Worker %s stream closed by client. Marking offline.", workerID)
+					log.Printf("Worker %s stream closed by client.", workerID)
 					markOfflineCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 					defer cancel()
-					s.usecase.MarkWorkerOffline(markOfflineCtx, workerID)
+					s.usecase.WorkerDisconnected(markOfflineCtx, workerID)
 				}
 				return nil
 			}
@@ -80,7 +101,7 @@ func (s *GRPCServer) StreamWorkerStatus(stream pb.WorkerService_StreamWorkerStat
 				if workerID != "" {
 					markOfflineCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 					defer cancel()
-					s.usecase.MarkWorkerOffline(markOfflineCtx, workerID)
+					s.usecase.WorkerDisconnected(markOfflineCtx, workerID)
 				}
 				return status.Errorf(codes.Unavailable, "stream error: %v", err)
 			}
@@ -95,26 +116,76 @@ func (s *GRPCServer) StreamWorkerStatus(stream pb.WorkerService_StreamWorkerStat
 				return status.Errorf(codes.InvalidArgument, "worker ID mismatch in stream")
 			}
 
+			if statusMsg.Fin {
+				// The worker is shutting down and closes the stream next
+				s.usecase.WorkerShutdown(ctx, workerID)
+				return nil
+			}
+
 			log.Printf("Received status from worker %s: %s, test: %s, progress: %d/%d",
 				statusMsg.WorkerId, statusMsg.Status.String(), statusMsg.TestId, statusMsg.CompletedRequests, statusMsg.TotalRequests)
+			if statusMsg.ClockRttUs > 0 {
+				s.usecase.RecordClockOffset(workerID, time.Duration(statusMsg.ClockOffsetUs)*time.Microsecond, time.Duration(statusMsg.ClockRttUs)*time.Microsecond)
+			}
+
+			// Track live progress for watchers and the test's error budget
+			var abortTestID, abortReason, rateTestID string
+			var ratePerSecond uint64
+			if statusMsg.TestId != "" && statusMsg.Status != pb.StatusType_READY {
+				abortReason = s.usecase.RecordWorkerProgress(ctx, statusMsg.WorkerId, statusMsg.TestId, statusMsg.Status.String(),
+					statusMsg.CompletedRequests, statusMsg.TotalRequests, statusMsg.FailedRequests, statusMsg.LatencyTotalUs, statusMsg.StatusCodes, parseResourceSample(statusMsg.ResourcesJson))
+				if statusMsg.Status == pb.StatusType_BUSY { // Only a worker still attacking is told to stop or change its rate
+					if abortReason != "" {
+						abortTestID = statusMsg.TestId
+					} else if rate, ok := s.usecase.TakeRateChange(statusMsg.TestId, statusMsg.WorkerId); ok {
+						rateTestID, ratePerSecond = statusMsg.TestId, rate
+					}
+				}
+			}
 
 			// Update worker status in usecase
 			err = s.usecase.UpdateWorkerStatus(ctx, statusMsg.WorkerId, statusMsg.Status.String(), statusMsg.TestId,
 				statusMsg.Message, statusMsg.CompletedRequests, statusMsg.TotalRequests)
+			var ack *pb.WorkerStatusAck
 			if err != nil {
 				log.Printf("Error updating worker status for %s: %v", statusMsg.WorkerId, err)
 				// Send a negative ACK back if status update fails
-				stream.Send(&pb.WorkerStatusAck{Accepted: false, Message: fmt.Sprintf("Failed to update status: %v", err)})
+				ack = &pb.WorkerStatusAck{Accepted: false, Message: fmt.Sprintf("Failed to update status: %v", err), AbortTestId: abortTestID,
+					RateTestId: rateTestID, RatePerSecond: ratePerSecond}
+			} else if abortTestID != "" {
+				// Tell the worker to stop; the test exceeded its error budget or lost a worker
+				ack = &pb.WorkerStatusAck{Accepted: true, Message: "Test aborted: " + abortReason, AbortTestId: abortTestID}
+			} else if rateTestID != "" {
+				// Another worker of the test was lost and its rate spread over the rest
+				ack = &pb.WorkerStatusAck{Accepted: true, Message: fmt.Sprintf("Rate changed to %d req/s", ratePerSecond),
+					RateTestId: rateTestID, RatePerSecond: ratePerSecond}
+			} else if statusMsg.Status == pb.StatusType_READY && s.usecase.WorkerShouldRestart(statusMsg.WorkerId) {
+				// Drained for a rollout; its supervisor restarts it on the new version
+				ack = &pb.WorkerStatusAck{Accepted: true, Message: "Drained for upgrade", Shutdown: true}
 			} else {
 				// Send a positive ACK back
-				stream.Send(&pb.WorkerStatusAck{Accepted: true, Message: "Status received"})
+				ack = &pb.WorkerStatusAck{Accepted: true, Message: "Status received"}
 			}
+			// Let the worker measure its clock's offset from the master's
+			ack.StatusSentAtUnixNano, ack.ReceivedAtUnixNano = statusMsg.SentAtUnixNano, receivedAt.UnixNano()
+			ack.SentAtUnixNano = time.Now().UnixNano()
+			stream.Send(ack)
 
 			// If worker signals error for a test, handle it
 			if statusMsg.TestId != "" && statusMsg.Status == pb.StatusType_ERROR {
 				log.Printf("Worker %s signaling test %s error.", statusMsg.WorkerId, statusMsg.TestId)
-				// Just log the error - the worker should handle result submission properly
 				log.Printf("Worker %s reported error for test %s: %s", statusMsg.WorkerId, statusMsg.TestId, statusMsg.Message)
+
+				// The worker submits no result after an error, so count it as failed now
+				var probeResults []domain.ProbeResult
+				if statusMsg.ProbeResultsJson != "" {
+					if err := json.Unmarshal([]byte(statusMsg.ProbeResultsJson), &probeResults); err != nil {
+						log.Printf("Ignoring malformed probe results from worker %s: %v", statusMsg.WorkerId, err)
+					}
+				}
+				if err := s.usecase.RecordWorkerTestError(ctx, statusMsg.TestId, statusMsg.WorkerId, probeResults); err != nil {
+					log.Printf("Error recording failure of worker %s for test %s: %v", statusMsg.WorkerId, statusMsg.TestId, err)
+				}
 			}
 		}
 	}
@@ -129,28 +200,82 @@ func (s *GRPCServer) AssignTest(ctx context.Context, req *pb.TestAssignment) (*p
 
 // SubmitTest handles external API requests to submit a new test (Unary RPC).
 func (s *GRPCServer) SubmitTest(ctx context.Context, req *pb.TestRequest) (*pb.TestSubmissionResponse, error) {
-	// Authentication/Authorization check (basic example)
-	if req.RequesterId == "" {
-		return &pb.TestSubmissionResponse{Success: false, Message: "Unauthorized: Requester ID missing"}, status.Errorf(codes.Unauthenticated, "requester ID missing")
+	user, err := caller(ctx)
+	if err != nil {
+		return &pb.TestSubmissionResponse{Success: false, Message: "Unauthorized: caller not authenticated"}, err
+	}
+	if req.OverrideGuardrails && user.Role != "admin" {
+		return &pb.TestSubmissionResponse{Success: false, Message: "Only admins can override guardrails"}, status.Error(codes.PermissionDenied, "only admins can override guardrails")
+	}
+	if s.usecase.WaitNeedsAdmin(req.MaxWaitForWorkers) && user.Role != "admin" {
+		return &pb.TestSubmissionResponse{Success: false, Message: "Only admins can wait longer than the master's default for workers"},
+			status.Error(codes.PermissionDenied, "only admins can wait longer than the master's default for workers")
 	}
 
 	testReq := &domain.TestRequest{
-		Name:              req.Name,
-		VegetaPayloadJSON: req.VegetaPayloadJson,
-		DurationSeconds:   req.DurationSeconds,
-		RatePerSecond:     req.RatePerSecond,
-		TargetsBase64:     req.TargetsBase64,
-		RequesterID:       req.RequesterId,
+		Name:               req.Name,
+		VegetaPayloadJSON:  req.VegetaPayloadJson,
+		DurationSeconds:    req.DurationSeconds,
+		RatePerSecond:      req.RatePerSecond,
+		TargetsBase64:      req.TargetsBase64,
+		RequesterID:        user.ID,
+		WorkerCount:        req.WorkerCount,
+		RateDistribution:   req.RateDistribution,
+		RateWeights:        req.RateWeights,
+		ThinkTime:          req.ThinkTime,
+		PacingJitter:       req.PacingJitter,
+		InjectRequestID:    req.InjectRequestId,
+		TemplateTargets:    req.TemplateTargets,
+		SequenceStart:      req.SequenceStart,
+		ScenarioJSON:       req.ScenarioJson,
+		GraphQLJSON:        req.GraphqlJson,
+		Preflight:          req.Preflight,
+		Smoke:              req.Smoke,
+		InterimInterval:    req.InterimInterval,
+		CaptureRequests:    req.CaptureRequests,
+		Protocol:           req.Protocol,
+		Script:             req.Script,
+		TargetBuild:        req.TargetBuild,
+		Tags:               req.Tags,
+		LabelHeaders:       req.LabelHeaders,
+		ConfirmationToken:  req.ConfirmationToken,
+		Priority:           req.Priority,
+		PartialPolicy:      req.PartialPolicy,
+		MaxWaitForWorkers:  req.MaxWaitForWorkers,
+		OnWorkerFailure:    req.OnWorkerFailure,
+		Regions:            masterUsecase.RegionSharesFromPB(req.Regions),
+		Apdex:              masterUsecase.ApdexConfigFromPB(req.ApdexThreshold, req.ApdexTargets),
+		OverrideGuardrails: req.OverrideGuardrails,
 	}
 
+	if p, ok := peer.FromContext(ctx); ok {
+		source := domain.AuditSourceFrom(ctx)
+		source.IP = peerHost(p.Addr.String())
+		ctx = domain.WithAuditSource(ctx, source)
+	}
 	testID, err := s.usecase.SubmitTest(ctx, testReq)
+	if errors.Is(err, domain.ErrConfirmationRequired) {
+		return &pb.TestSubmissionResponse{Success: false, Message: err.Error()}, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		log.Printf("Error submitting test: %v", err)
 		return &pb.TestSubmissionResponse{Success: false, Message: fmt.Sprintf("Test submission failed: %v", err)}, status.Errorf(codes.Internal, "test submission failed: %v", err)
 	}
 
 	log.Printf("Test submitted successfully with ID: %s", testID)
-	return &pb.TestSubmissionResponse{TestId: testID, Success: true, Message: "Test submitted successfully"}, nil
+	message := "Test submitted successfully"
+	if testReq.CapacityWarning != "" {
+		message += " (capacity warning: " + testReq.CapacityWarning + ")"
+	}
+	return &pb.TestSubmissionResponse{TestId: testID, Success: true, Message: message}, nil
+}
+
+// peerHost strips the port from a peer address.
+func peerHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
 }
 
 // GetDashboardStatus provides dashboard data for the UI (Unary RPC).
@@ -215,17 +340,56 @@ func (s *GRPCServer) SubmitTestResult(ctx context.Context, req *pb.TestResultSub
 
 	// Convert protobuf message to domain entity
 	testResult := &domain.TestResult{
-		ID:                req.WorkerId + "_" + req.TestId, // Generate unique ID
-		TestID:            req.TestId,
-		WorkerID:          req.WorkerId,
-		TotalRequests:     req.TotalRequests,
-		CompletedRequests: req.CompletedRequests,
-		SuccessRate:       req.SuccessRate,
-		AverageLatencyMs:  req.AverageLatencyMs,
-		P95LatencyMs:      req.P95LatencyMs,
-		DurationMs:        req.DurationMs,
-		Metric:            []byte(req.VegetaMetricsBase64), // Convert string back to bytes
-		Timestamp:         time.Unix(req.Timestamp, 0),
+		ID:                 req.WorkerId + "_" + req.TestId, // Generate unique ID
+		TestID:             req.TestId,
+		WorkerID:           req.WorkerId,
+		TotalRequests:      req.TotalRequests,
+		CompletedRequests:  req.CompletedRequests,
+		SuccessRate:        req.SuccessRate,
+		SuccessfulRequests: req.SuccessfulRequests,
+		FailedRequests:     req.FailedRequests,
+		AverageLatencyMs:   req.AverageLatencyMs,
+		P95LatencyMs:       req.P95LatencyMs,
+		DurationMs:         req.DurationMs,
+		Metric:             []byte(req.VegetaMetricsBase64), // Convert string back to bytes
+		Timestamp:          time.Unix(req.Timestamp, 0),
+	}
+	if req.ResourceUsageJson != "" {
+		var usage domain.ResourceUsage
+		if err := json.Unmarshal([]byte(req.ResourceUsageJson), &usage); err != nil {
+			log.Printf("Ignoring malformed resource usage from worker %s: %v", req.WorkerId, err)
+		} else {
+			testResult.ResourceUsage = &usage
+		}
+	}
+	if req.GeneratorOverheadJson != "" {
+		var overhead domain.GeneratorOverhead
+		if err := json.Unmarshal([]byte(req.GeneratorOverheadJson), &overhead); err != nil {
+			log.Printf("Ignoring malformed generator overhead from worker %s: %v", req.WorkerId, err)
+		} else {
+			testResult.GeneratorOverhead = &overhead
+		}
+	}
+	if req.EnvironmentJson != "" {
+		var environment domain.RunEnvironment
+		if err := json.Unmarshal([]byte(req.EnvironmentJson), &environment); err != nil {
+			log.Printf("Ignoring malformed environment from worker %s: %v", req.WorkerId, err)
+		} else {
+			testResult.Environment = &environment
+		}
+	}
+	if req.ApdexJson != "" {
+		var apdex domain.Apdex
+		if err := json.Unmarshal([]byte(req.ApdexJson), &apdex); err != nil {
+			log.Printf("Ignoring malformed apdex score from worker %s: %v", req.WorkerId, err)
+		} else {
+			testResult.Apdex = &apdex
+		}
+	}
+	if req.TargetResponsesJson != "" {
+		if err := json.Unmarshal([]byte(req.TargetResponsesJson), &testResult.TargetResponses); err != nil {
+			log.Printf("Ignoring malformed target responses from worker %s: %v", req.WorkerId, err)
+		}
 	}
 
 	// Save the test result to database via usecase
@@ -238,9 +402,180 @@ func (s *GRPCServer) SubmitTestResult(ctx context.Context, req *pb.TestResultSub
 		}, status.Errorf(codes.Internal, "failed to save test result: %v", err)
 	}
 
+	s.usecase.RecordWorkerProgress(ctx, req.WorkerId, req.TestId, "COMPLETED", 0, 0, 0, 0, nil, nil)
 	log.Printf("Successfully saved test result from worker %s for test %s", req.WorkerId, req.TestId)
 	return &pb.TestResultResponse{
 		Success: true,
 		Message: "Test result saved successfully",
 	}, nil
 }
+
+// SubmitInterimResult handles the cumulative results workers flush while
+// long tests run.
+func (s *GRPCServer) SubmitInterimResult(ctx context.Context, req *pb.TestResultSubmission) (*pb.TestResultResponse, error) {
+	result := &domain.InterimResult{Sequence: int(req.InterimSequence)}
+	result.TestResult = domain.TestResult{
+		TestID:             req.TestId,
+		WorkerID:           req.WorkerId,
+		TotalRequests:      req.TotalRequests,
+		CompletedRequests:  req.CompletedRequests,
+		SuccessRate:        req.SuccessRate,
+		SuccessfulRequests: req.SuccessfulRequests,
+		FailedRequests:     req.FailedRequests,
+		AverageLatencyMs:   req.AverageLatencyMs,
+		P95LatencyMs:       req.P95LatencyMs,
+		DurationMs:         req.DurationMs,
+		Metric:             []byte(req.VegetaMetricsBase64),
+		Timestamp:          time.Unix(req.Timestamp, 0),
+	}
+	if req.ApdexJson != "" {
+		var apdex domain.Apdex
+		if err := json.Unmarshal([]byte(req.ApdexJson), &apdex); err == nil {
+			result.Apdex = &apdex
+		}
+	}
+	if result.Sequence < 1 {
+		return &pb.TestResultResponse{Success: false, Message: "interim sequence must be at least 1"},
+			status.Error(codes.InvalidArgument, "interim sequence must be at least 1")
+	}
+
+	if err := s.usecase.SaveInterimResult(ctx, result); err != nil {
+		log.Printf("Failed to save interim result %d from worker %s for test %s: %v", result.Sequence, req.WorkerId, req.TestId, err)
+		return &pb.TestResultResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save interim result: %v", err),
+		}, status.Errorf(codes.Internal, "failed to save interim result: %v", err)
+	}
+	return &pb.TestResultResponse{Success: true, Message: "Interim result saved successfully"}, nil
+}
+
+// UploadRequestCapture receives the requests a worker captured in a test
+// (Client-streaming RPC). The capture is only stored once all of it arrived.
+func (s *GRPCServer) UploadRequestCapture(stream pb.WorkerService_UploadRequestCaptureServer) error {
+	var capture *domain.RequestCapture
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if capture == nil {
+			capture = &domain.RequestCapture{TestID: chunk.TestId, WorkerID: chunk.WorkerId, Requests: chunk.Requests, Truncated: chunk.Truncated}
+		}
+		if len(data)+len(chunk.Data) > domain.MaxRequestCaptureBytes {
+			return status.Errorf(codes.ResourceExhausted, "request capture exceeds %d bytes", domain.MaxRequestCaptureBytes)
+		}
+		data = append(data, chunk.Data...)
+	}
+	if capture == nil {
+		return status.Error(codes.InvalidArgument, "empty request capture")
+	}
+
+	if err := s.usecase.SaveRequestCapture(stream.Context(), capture, data); err != nil {
+		log.Printf("Failed to save request capture from worker %s for test %s: %v", capture.WorkerID, capture.TestID, err)
+		return status.Errorf(codes.FailedPrecondition, "failed to save request capture: %v", err)
+	}
+	return stream.SendAndClose(&pb.TestResultResponse{Success: true, Message: "Request capture saved successfully"})
+}
+
+// attachmentChunkSize is the size of each streamed attachment chunk.
+const attachmentChunkSize = 1 << 20
+
+// GetAttachment streams an attachment's content to a worker (Server-streaming RPC).
+func (s *GRPCServer) GetAttachment(req *pb.AttachmentRequest, stream pb.WorkerService_GetAttachmentServer) error {
+	attachment, data, err := s.usecase.GetAttachmentData(stream.Context(), req.AttachmentId)
+	if err != nil {
+		log.Printf("Worker %s requested attachment %s: %v", req.WorkerId, req.AttachmentId, err)
+		return status.Errorf(codes.NotFound, "failed to get attachment: %v", err)
+	}
+	log.Printf("Streaming attachment %s (%d bytes) to worker %s", attachment.ID, attachment.Size, req.WorkerId)
+
+	first := &pb.AttachmentChunk{
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Sha256:      attachment.SHA256,
+	}
+	for offset := 0; offset == 0 || offset < len(data); offset += attachmentChunkSize {
+		chunk := &pb.AttachmentChunk{}
+		if offset == 0 {
+			chunk = first
+		}
+		chunk.Data = data[offset:min(offset+attachmentChunkSize, len(data))]
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WatchTest streams live progress for a test until it finishes (Server-streaming RPC).
+func (s *GRPCServer) WatchTest(req *pb.WatchTestRequest, stream pb.MasterService_WatchTestServer) error {
+	interval := time.Second
+	if req.IntervalMs > 0 {
+		interval = max(time.Duration(req.IntervalMs)*time.Millisecond, 100*time.Millisecond)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		progress, err := s.usecase.GetTestProgress(stream.Context(), req.TestId)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "failed to get test progress: %v", err)
+		}
+		if err := stream.Send(toPBTestProgress(progress)); err != nil {
+			return err
+		}
+		if !masterUsecase.IsTestActive(progress.Status) {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func toPBTestProgress(p *domain.TestProgress) *pb.TestProgress {
+	out := &pb.TestProgress{
+		TestId:        p.TestID,
+		Status:        p.Status,
+		ElapsedMs:     p.ElapsedMs,
+		Requests:      p.Requests,
+		Failures:      p.Failures,
+		Rps:           p.RPS,
+		ErrorRate:     p.ErrorRate,
+		MeanLatencyMs: p.MeanLatencyMs,
+	}
+	for _, w := range p.Workers {
+		out.Workers = append(out.Workers, &pb.WorkerProgress{
+			WorkerId:      w.WorkerID,
+			Status:        w.Status,
+			Requests:      w.Requests,
+			Expected:      w.Expected,
+			Failures:      w.Failures,
+			Rps:           w.RPS,
+			MeanLatencyMs: w.MeanLatencyMs,
+		})
+	}
+	return out
+}
+
+// parseResourceSample decodes the resource sample of a status update, or
+// returns nil if there is none or it is malformed.
+func parseResourceSample(data string) *domain.ResourceSample {
+	if data == "" {
+		return nil
+	}
+	var sample domain.ResourceSample
+	if err := json.Unmarshal([]byte(data), &sample); err != nil {
+		log.Printf("Ignoring malformed resource sample: %v", err)
+		return nil
+	}
+	return &sample
+}
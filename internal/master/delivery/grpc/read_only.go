@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readOnlyMethods are the RPCs a read-only master still serves. Worker
+// registration, status reports, results and test submission all write.
+var readOnlyMethods = map[string]bool{
+	"/loadtester.MasterService/GetDashboardStatus":  true,
+	"/loadtester.MasterService/WatchTest":           true,
+	"/loadtester.MasterService/ListTests":           true,
+	"/loadtester.MasterService/GetTestResults":      true,
+	"/loadtester.MasterService/GetAggregatedResult": true,
+	"/loadtester.MasterService/StreamTestEvents":    true,
+	"/loadtester.WorkerService/GetAttachment":       true,
+}
+
+// ReadOnlyUnaryInterceptor rejects unary RPCs that would change state.
+func ReadOnlyUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !readOnlyMethods[info.FullMethod] {
+		return nil, status.Errorf(codes.Unavailable, "master is read-only: %s is disabled", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+// ReadOnlyStreamInterceptor rejects streaming RPCs that would change state.
+func ReadOnlyStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !readOnlyMethods[info.FullMethod] {
+		return status.Errorf(codes.Unavailable, "master is read-only: %s is disabled", info.FullMethod)
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,249 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// ListTests lists a requester's tests, filtered, sorted and paginated (Unary RPC).
+func (s *GRPCServer) ListTests(ctx context.Context, req *pb.ListTestsRequest) (*pb.ListTestsResponse, error) {
+	user, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.SortBy != "" && !slices.Contains(domain.TestSortFields, req.SortBy) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sort_by %q: must be one of %s", req.SortBy, strings.Join(domain.TestSortFields, ", "))
+	}
+	filter := domain.TestListFilter{
+		UserID:       user.ID,
+		Status:       strings.ToUpper(req.Status),
+		NameContains: req.NameContains,
+		Tag:          strings.ToLower(req.Tag),
+		TargetHost:   req.TargetHost,
+		SortBy:       req.SortBy,
+		Ascending:    req.Ascending,
+		Limit:        int(req.Limit),
+		Offset:       max(int(req.Offset), 0),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if req.All {
+		if user.Role != "admin" {
+			return nil, status.Error(codes.PermissionDenied, "only admins can list every user's tests")
+		}
+		filter.UserID = ""
+	}
+	if req.CreatedAfter > 0 {
+		filter.CreatedAfter = time.Unix(req.CreatedAfter, 0)
+	}
+	if req.CreatedBefore > 0 {
+		filter.CreatedBefore = time.Unix(req.CreatedBefore, 0)
+	}
+
+	tests, total, err := s.usecase.ListTests(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tests: %v", err)
+	}
+	resp := &pb.ListTestsResponse{Total: int32(total)}
+	for _, test := range tests {
+		resp.Tests = append(resp.Tests, &pb.TestSummary{
+			Id:              test.ID,
+			Name:            test.Name,
+			Status:          test.Status,
+			RequesterId:     test.RequesterID,
+			CreatedAt:       test.CreatedAt.Unix(),
+			DurationSeconds: test.DurationSeconds,
+			RatePerSecond:   test.RatePerSecond,
+			WorkerCount:     test.WorkerCount,
+			Tags:            test.Tags,
+			TargetBuild:     test.TargetBuild,
+		})
+	}
+	return resp, nil
+}
+
+// GetTestResults returns a page of a test's raw worker results (Unary RPC).
+func (s *GRPCServer) GetTestResults(ctx context.Context, req *pb.TestResultsRequest) (*pb.TestResultsResponse, error) {
+	page := domain.ResultPage{
+		Limit:         max(int(req.Limit), 0),
+		Offset:        max(int(req.Offset), 0),
+		WithoutMetric: !req.IncludeMetric,
+	}
+	results, total, err := s.usecase.GetRawTestResultsPage(ctx, req.TestId, page)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get test results: %v", err)
+	}
+	resp := &pb.TestResultsResponse{Total: int32(total)}
+	for _, result := range results {
+		submission, err := toPBTestResult(result)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode result of worker %s: %v", result.WorkerID, err)
+		}
+		resp.Results = append(resp.Results, submission)
+	}
+	return resp, nil
+}
+
+// toPBTestResult converts a stored result back to the message it was
+// submitted as.
+func toPBTestResult(result *domain.TestResult) (*pb.TestResultSubmission, error) {
+	submission := &pb.TestResultSubmission{
+		TestId:              result.TestID,
+		WorkerId:            result.WorkerID,
+		TotalRequests:       result.TotalRequests,
+		CompletedRequests:   result.CompletedRequests,
+		DurationMs:          result.DurationMs,
+		SuccessRate:         result.SuccessRate,
+		AverageLatencyMs:    result.AverageLatencyMs,
+		P95LatencyMs:        result.P95LatencyMs,
+		VegetaMetricsBase64: string(result.Metric),
+		Timestamp:           result.Timestamp.Unix(),
+		SuccessfulRequests:  result.SuccessfulRequests,
+		FailedRequests:      result.FailedRequests,
+	}
+	if len(result.StatusCodes) > 0 {
+		submission.StatusCodes = make(map[string]int64, len(result.StatusCodes))
+		for code, count := range result.StatusCodes {
+			submission.StatusCodes[code] = int64(count)
+		}
+	}
+	var err error
+	if submission.ResourceUsageJson, err = optionalJSON(result.ResourceUsage); err != nil {
+		return nil, err
+	}
+	if submission.GeneratorOverheadJson, err = optionalJSON(result.GeneratorOverhead); err != nil {
+		return nil, err
+	}
+	if submission.EnvironmentJson, err = optionalJSON(result.Environment); err != nil {
+		return nil, err
+	}
+	if submission.ApdexJson, err = optionalJSON(result.Apdex); err != nil {
+		return nil, err
+	}
+	if len(result.TargetResponses) > 0 {
+		if submission.TargetResponsesJson, err = optionalJSON(result.TargetResponses); err != nil {
+			return nil, err
+		}
+	}
+	return submission, nil
+}
+
+// optionalJSON encodes v, or returns an empty string for a nil pointer.
+func optionalJSON[T any](v T) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetAggregatedResult returns the aggregated result of a test (Unary RPC).
+func (s *GRPCServer) GetAggregatedResult(ctx context.Context, req *pb.AggregatedResultRequest) (*pb.AggregatedResult, error) {
+	result, err := s.usecase.GetAggregatedTestResult(ctx, req.TestId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "aggregated result not found for test %s; results may still be processing", req.TestId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get aggregated test result: %v", err)
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode aggregated result: %v", err)
+	}
+	resp := &pb.AggregatedResult{
+		TestId:             result.TestID,
+		TotalRequests:      result.TotalRequests,
+		SuccessfulRequests: result.SuccessfulRequests,
+		FailedRequests:     result.FailedRequests,
+		AvgLatencyMs:       result.AvgLatencyMs,
+		P95LatencyMs:       result.P95LatencyMs,
+		DurationMs:         result.DurationMs,
+		ThroughputRps:      result.ThroughputRPS,
+		OverallStatus:      result.OverallStatus,
+		CompletedAt:        result.CompletedAt.Unix(),
+		ResultJson:         string(resultJSON),
+	}
+	if result.Apdex != nil {
+		resp.ApdexScore = result.Apdex.Score
+	}
+	if len(result.ErrorRates) > 0 {
+		resp.ErrorRates = make(map[string]int64, len(result.ErrorRates))
+		for errorType, count := range result.ErrorRates {
+			resp.ErrorRates[errorType] = int64(count)
+		}
+	}
+	return resp, nil
+}
+
+// CancelTest removes a queued test so it never runs (Unary RPC). Like the
+// HTTP API, only the test's requester or an admin can cancel it.
+func (s *GRPCServer) CancelTest(ctx context.Context, req *pb.CancelTestRequest) (*pb.CancelTestResponse, error) {
+	user, err := caller(ctx)
+	if err != nil {
+		return &pb.CancelTestResponse{Success: false, Message: "Unauthorized: caller not authenticated"}, err
+	}
+	if err := s.usecase.CancelQueuedTest(ctx, req.TestId, user); err != nil {
+		code := codes.FailedPrecondition
+		switch {
+		case strings.Contains(err.Error(), "insufficient permissions"):
+			code = codes.PermissionDenied
+		case strings.Contains(err.Error(), "not found"):
+			code = codes.NotFound
+		}
+		return &pb.CancelTestResponse{Success: false, Message: err.Error()}, status.Error(code, err.Error())
+	}
+	log.Printf("Test %s cancelled over gRPC by %s", req.TestId, user.Username)
+	return &pb.CancelTestResponse{Success: true, Message: fmt.Sprintf("Test %s removed from the queue", req.TestId)}, nil
+}
+
+// StreamTestEvents streams a test's events as they are published
+// (Server-streaming RPC), until the client cancels or, with until_completed,
+// the test completes.
+func (s *GRPCServer) StreamTestEvents(req *pb.TestEventsRequest, stream pb.MasterService_StreamTestEventsServer) error {
+	ctx := stream.Context()
+	events, err := s.usecase.SubscribeTestEvents(ctx, req.TestId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return status.Errorf(codes.NotFound, "failed to stream test events: %v", err)
+		}
+		return status.Errorf(codes.Internal, "failed to stream test events: %v", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-events:
+			progressJSON, err := optionalJSON(event.Progress)
+			if err != nil {
+				log.Printf("Error marshaling progress of %s event: %v", event.Type, err)
+			}
+			if err := stream.Send(&pb.TestEvent{
+				Type:         event.Type,
+				TestId:       event.TestID,
+				WorkerId:     event.WorkerID,
+				Status:       event.Status,
+				Message:      event.Message,
+				TimeMs:       event.Time.UnixMilli(),
+				ProgressJson: progressJSON,
+			}); err != nil {
+				return err
+			}
+			if req.UntilCompleted && event.Type == domain.EventTestCompleted {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// EnableGateway serves the HTTP bindings of the MasterService RPCs under
+// /api/v2, translated to calls of server by grpc-gateway. The routes sit
+// behind the same authentication as the rest of /api, and gRPC status codes
+// become the matching HTTP status codes.
+func (h *HTTPHandler) EnableGateway(ctx context.Context, server pb.MasterServiceServer) error {
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}))
+	if err := pb.RegisterMasterServiceHandlerServer(ctx, mux, server); err != nil {
+		return fmt.Errorf("failed to register gateway handlers: %w", err)
+	}
+	h.api.PathPrefix("/v2/").Handler(gatewayCaller(mux))
+	return nil
+}
+
+// gatewayCaller hands the user authMiddleware authenticated to the RPC
+// handlers, which act for the caller rather than any requester ID in the
+// request.
+func gatewayCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, ok := r.Context().Value(userContextKey).(*domain.UserProfile); ok {
+			r = r.WithContext(domain.WithCaller(r.Context(), user))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
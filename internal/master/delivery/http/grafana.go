@@ -0,0 +1,283 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// grafanaMetrics are the time series fields a Grafana target can select.
+var grafanaMetrics = []string{"rps", "requests", "failures", "errorRate", "meanLatencyMs"}
+
+// grafanaSearchLimit caps the tests offered when a Grafana search has text.
+const grafanaSearchLimit = 10
+
+// grafanaTimeRange is the dashboard time range sent with every Grafana query.
+type grafanaTimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// grafanaQueryRequest is the body of a JSON data source /query call.
+type grafanaQueryRequest struct {
+	Range      grafanaTimeRange `json:"range"`
+	IntervalMs int64            `json:"intervalMs"`
+	Targets    []struct {
+		Target string `json:"target"`
+		RefID  string `json:"refId"`
+		Hide   bool   `json:"hide"`
+	} `json:"targets"`
+}
+
+// grafanaSeries is one time series in a /query response. Each datapoint is
+// [value, unix milliseconds].
+type grafanaSeries struct {
+	Target     string       `json:"target"`
+	RefID      string       `json:"refId,omitempty"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// grafanaAnnotationRequest is the body of a JSON data source /annotations call.
+type grafanaAnnotationRequest struct {
+	Range      grafanaTimeRange `json:"range"`
+	Annotation json.RawMessage  `json:"annotation"`
+}
+
+// grafanaAnnotation marks one test on a dashboard, from submission to the
+// end of its duration.
+type grafanaAnnotation struct {
+	Annotation json.RawMessage `json:"annotation,omitempty"`
+	Time       int64           `json:"time"`
+	TimeEnd    int64           `json:"timeEnd"`
+	Title      string          `json:"title"`
+	Text       string          `json:"text"`
+	Tags       []string        `json:"tags"`
+}
+
+// registerGrafanaRoutes serves test metrics with the contract of Grafana's
+// JSON (SimpleJSON) data source. It is registered before the rest of /api so
+// that Grafana can also authenticate with basic auth.
+func (h *HTTPHandler) registerGrafanaRoutes(r *mux.Router) {
+	grafana := r.PathPrefix("/api/grafana").Subrouter()
+	grafana.Use(h.grafanaAuthMiddleware)
+	grafana.HandleFunc("", grafanaTestConnection).Methods("GET")
+	grafana.HandleFunc("/", grafanaTestConnection).Methods("GET")
+	grafana.HandleFunc("/search", h.grafanaSearch).Methods("POST")
+	grafana.HandleFunc("/query", h.grafanaQuery).Methods("POST")
+	grafana.HandleFunc("/annotations", h.grafanaAnnotations).Methods("POST")
+}
+
+// grafanaAuthMiddleware accepts HTTP basic auth as well as a bearer token,
+// since a data source cannot renew tokens when they expire.
+func (h *HTTPHandler) grafanaAuthMiddleware(next http.Handler) http.Handler {
+	bearer := h.authMiddleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			bearer.ServeHTTP(w, r)
+			return
+		}
+		user, err := h.userUsecase.VerifyCredentials(r.Context(), username, password)
+		if err != nil {
+			log.Printf("Grafana basic auth failed for %s: %v", username, err)
+			if errors.Is(err, domain.ErrLoginLocked) {
+				http.Error(w, "Too many failed login attempts", http.StatusTooManyRequests)
+				return
+			}
+			if errors.Is(err, domain.ErrPasswordChangeRequired) {
+				http.Error(w, "Password change required", http.StatusForbidden)
+				return
+			}
+			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		ctx := context.WithValue(r.Context(), userContextKey, user)
+		ctx = domain.WithAuditActor(ctx, user.ID, user.Username)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// grafanaTestConnection answers the data source's "Save & test" check.
+func grafanaTestConnection(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// grafanaOwner returns whose tests a Grafana request covers: the user's own,
+// or every user's for an admin.
+func grafanaOwner(r *http.Request) (string, bool) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		return "", false
+	}
+	if user.Role == "admin" {
+		return "", true
+	}
+	return user.ID, true
+}
+
+// grafanaSearch lists the targets a panel can query: each metric summed over
+// all tests, and each metric of the tests whose name or URLs match the text.
+func (h *HTTPHandler) grafanaSearch(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := grafanaOwner(r)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		Target string `json:"target"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	targets := append([]string{}, grafanaMetrics...)
+	if query := strings.TrimSpace(req.Target); query != "" {
+		tests, err := h.usecase.SearchTests(r.Context(), query, ownerID, grafanaSearchLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to search tests: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for _, test := range tests {
+			for _, metric := range grafanaMetrics {
+				targets = append(targets, metric+":"+test.ID)
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(targets)
+}
+
+// grafanaQuery returns the requested series. A target is a metric, summed
+// over every test that ran in the range, or "metric:testId" for one test.
+func (h *HTTPHandler) grafanaQuery(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := grafanaOwner(r)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	var req grafanaQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Workers report every second, so finer intervals are rounded up
+	interval := time.Duration((req.IntervalMs+999)/1000) * time.Second
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	series := make([]grafanaSeries, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		if target.Hide || target.Target == "" {
+			continue
+		}
+		metric, testID, _ := strings.Cut(target.Target, ":")
+		if !isGrafanaMetric(metric) {
+			http.Error(w, fmt.Sprintf("Unknown metric %q (expected one of %s)", metric, strings.Join(grafanaMetrics, ", ")), http.StatusBadRequest)
+			return
+		}
+
+		var points []domain.TimeSeriesPoint
+		var err error
+		if testID == "" {
+			points, err = h.usecase.GetCombinedTimeSeries(r.Context(), ownerID, req.Range.From, req.Range.To, interval)
+		} else {
+			var ts *domain.TestTimeSeries
+			if ts, err = h.usecase.GetTestTimeSeries(r.Context(), testID, interval); err == nil {
+				points = ts.Points
+			}
+		}
+		if err != nil {
+			code := http.StatusInternalServerError
+			if strings.Contains(err.Error(), "not found") {
+				code = http.StatusNotFound
+			}
+			http.Error(w, fmt.Sprintf("Failed to query %s: %v", target.Target, err), code)
+			return
+		}
+
+		out := grafanaSeries{Target: target.Target, RefID: target.RefID, Datapoints: [][2]float64{}}
+		for _, point := range points {
+			if point.Time.Before(req.Range.From) || point.Time.After(req.Range.To) {
+				continue
+			}
+			out.Datapoints = append(out.Datapoints, [2]float64{grafanaMetricValue(point, metric), float64(point.Time.UnixMilli())})
+		}
+		series = append(series, out)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
+
+// grafanaAnnotations marks each test that ran in the range.
+func (h *HTTPHandler) grafanaAnnotations(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := grafanaOwner(r)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	var req grafanaAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	tests, err := h.usecase.GetTestsRunBetween(r.Context(), ownerID, req.Range.From, req.Range.To)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get tests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	annotations := make([]grafanaAnnotation, 0, len(tests))
+	for _, test := range tests {
+		duration, _ := time.ParseDuration(test.DurationSeconds)
+		annotations = append(annotations, grafanaAnnotation{
+			Annotation: req.Annotation,
+			Time:       test.CreatedAt.UnixMilli(),
+			TimeEnd:    test.CreatedAt.Add(duration).UnixMilli(),
+			Title:      test.Name,
+			Text:       fmt.Sprintf("%d req/s for %s · %s", test.RatePerSecond, test.DurationSeconds, test.Status),
+			Tags:       []string{"load-test", test.ID},
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(annotations)
+}
+
+// isGrafanaMetric reports whether metric is one of grafanaMetrics.
+func isGrafanaMetric(metric string) bool {
+	for _, m := range grafanaMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// grafanaMetricValue picks metric out of a time series point.
+func grafanaMetricValue(point domain.TimeSeriesPoint, metric string) float64 {
+	switch metric {
+	case "requests":
+		return float64(point.Requests)
+	case "failures":
+		return float64(point.Failures)
+	case "errorRate":
+		return point.ErrorRate
+	case "meanLatencyMs":
+		return point.MeanLatencyMs
+	default:
+		return point.RPS
+	}
+}
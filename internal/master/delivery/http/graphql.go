@@ -0,0 +1,751 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// graphqlSchema lets the dashboard fetch tests, workers, results, analytics
+// and users in one round trip. There are no mutations; changes go through
+// the REST endpoints. Counts are Float since they can exceed GraphQL's
+// 32-bit Int.
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	"The logged-in user."
+	me: User!
+	"The caller's tests, or every user's with all (admins only). sortBy is one of created_at, name, status, rate, workers."
+	tests(filter: TestFilter, sortBy: String, ascending: Boolean, limit: Int, offset: Int, all: Boolean): TestConnection!
+	test(id: ID!): Test
+	dashboard: Dashboard!
+	"Every registered worker (admins only)."
+	workers: [Worker!]!
+	"Dates are YYYY-MM-DD; both or neither must be given."
+	analytics(startDate: String, endDate: String, build: String): Analytics!
+	"Every user (admins only)."
+	users: [User!]!
+}
+
+input TestFilter {
+	status: String
+	name: String
+	tag: String
+	host: String
+	createdAfter: Time
+	createdBefore: Time
+}
+
+type TestConnection {
+	total: Int!
+	tests: [Test!]!
+}
+
+type Test {
+	id: ID!
+	name: String!
+	status: String!
+	requesterId: String!
+	requester: User
+	createdAt: Time!
+	durationSeconds: String!
+	ratePerSecond: Float!
+	workerCount: Int!
+	rateDistribution: String!
+	protocol: String!
+	priority: String!
+	targetBuild: String!
+	tags: [String!]!
+	assignedWorkerIds: [String!]!
+	completedWorkers: [String!]!
+	failedWorkers: [String!]!
+	results(limit: Int, offset: Int): ResultPage!
+	"Null until the test's results are aggregated."
+	aggregatedResult: AggregatedResult
+}
+
+type ResultPage {
+	total: Int!
+	results: [Result!]!
+}
+
+type Result {
+	id: ID!
+	workerId: String!
+	timestamp: Time!
+	totalRequests: Float!
+	completedRequests: Float!
+	successfulRequests: Float!
+	failedRequests: Float!
+	durationMs: Float!
+	successRate: Float!
+	averageLatencyMs: Float!
+	p95LatencyMs: Float!
+	region: String!
+	statusCodes: [Count!]!
+}
+
+type AggregatedResult {
+	totalRequests: Float!
+	successfulRequests: Float!
+	failedRequests: Float!
+	avgLatencyMs: Float!
+	p95LatencyMs: Float!
+	durationMs: Float!
+	throughputRps: Float!
+	overallStatus: String!
+	completedAt: Time!
+	errorRates: [Count!]!
+	"Null unless the workers scored the test's requests."
+	apdexScore: Float
+	apdexRating: String
+}
+
+type Count {
+	key: String!
+	count: Float!
+}
+
+type Dashboard {
+	totalWorkers: Int!
+	availableWorkers: Int!
+	busyWorkers: Int!
+	activeTests: [ActiveTest!]!
+}
+
+type ActiveTest {
+	testId: ID!
+	testName: String!
+	status: String!
+	assignedWorkers: Int!
+	completedWorkers: Int!
+	failedWorkers: Int!
+	totalRequestsSent: Float!
+	totalRequestsCompleted: Float!
+	progress: Float!
+	test: Test
+}
+
+type Worker {
+	id: ID!
+	address: String!
+	status: String!
+	lastSeen: Time!
+	currentTestId: String!
+	lastProgressMessage: String!
+	completedRequests: Float!
+	totalRequests: Float!
+	maxRate: Float!
+	region: String!
+	version: String!
+	connectionState: String!
+	draining: Boolean!
+}
+
+type Analytics {
+	overview: AnalyticsOverview!
+	targets(target: String): [TargetAnalytics!]!
+}
+
+type AnalyticsOverview {
+	totalTests: Float!
+	totalRequests: Float!
+	successRate: Float!
+	averageResponseTime: Float!
+	medianResponseTime: Float!
+	p95ResponseTime: Float!
+	p99ResponseTime: Float!
+	percentileSource: String!
+	topErrorCodes: [Count!]!
+	testsPerDay: [Count!]!
+	requestsPerDay: [Count!]!
+}
+
+type TargetAnalytics {
+	target: String!
+	testCount: Float!
+	totalRequests: Float!
+	successRate: Float!
+	averageResponseTime: Float!
+	p95ResponseTime: Float!
+	p99ResponseTime: Float!
+	errorBreakdown: [Count!]!
+}
+
+type User {
+	id: ID!
+	username: String!
+	email: String!
+	firstName: String!
+	lastName: String!
+	role: String!
+	isActive: Boolean!
+	createdAt: Time!
+	lastLoginAt: Time
+}
+`
+
+// Limits on GraphQL queries, so one request cannot fan out without bound.
+const (
+	graphqlMaxDepth       = 8
+	graphqlMaxParallelism = 10
+	graphqlMaxResults     = 100 // Largest tests or results page
+)
+
+// newGraphQLHandler parses the schema against the resolvers of h. It
+// panics if they disagree, which is caught when the master starts.
+func (h *HTTPHandler) newGraphQLHandler() *relay.Handler {
+	schema := graphql.MustParseSchema(graphqlSchema, &graphqlResolver{h},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(graphqlMaxDepth),
+		graphql.MaxParallelism(graphqlMaxParallelism))
+	return &relay.Handler{Schema: schema}
+}
+
+// graphqlUser returns the user authMiddleware put in ctx.
+func graphqlUser(ctx context.Context) (*domain.UserProfile, error) {
+	user, ok := ctx.Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		return nil, errors.New("unauthorized: user not found in context")
+	}
+	return user, nil
+}
+
+// graphqlAdmin returns the user in ctx, or an error unless they are an admin.
+func graphqlAdmin(ctx context.Context) (*domain.UserProfile, error) {
+	user, err := graphqlUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != "admin" {
+		return nil, errors.New("admin access required")
+	}
+	return user, nil
+}
+
+// pageArgs clamps a GraphQL limit and offset to [1, graphqlMaxResults] and
+// a non-negative offset.
+func pageArgs(limit, offset *int32, defaultLimit int) (int, int) {
+	l, o := defaultLimit, 0
+	if limit != nil && *limit > 0 {
+		l = min(int(*limit), graphqlMaxResults)
+	}
+	if offset != nil && *offset > 0 {
+		o = int(*offset)
+	}
+	return l, o
+}
+
+// counts lists a map of counts by key, sorted by key.
+func counts[N int | int64](m map[string]N) []*countResolver {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	list := make([]*countResolver, len(keys))
+	for i, key := range keys {
+		list[i] = &countResolver{key: key, count: int64(m[key])}
+	}
+	return list
+}
+
+type graphqlResolver struct {
+	h *HTTPHandler
+}
+
+func (r *graphqlResolver) Me(ctx context.Context) (*userResolver, error) {
+	user, err := graphqlUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &userResolver{user}, nil
+}
+
+type testFilterInput struct {
+	Status        *string
+	Name          *string
+	Tag           *string
+	Host          *string
+	CreatedAfter  *graphql.Time
+	CreatedBefore *graphql.Time
+}
+
+func (r *graphqlResolver) Tests(ctx context.Context, args struct {
+	Filter    *testFilterInput
+	SortBy    *string
+	Ascending *bool
+	Limit     *int32
+	Offset    *int32
+	All       *bool
+}) (*testConnectionResolver, error) {
+	user, err := graphqlUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset := pageArgs(args.Limit, args.Offset, 20)
+	filter := domain.TestListFilter{UserID: user.ID, Limit: limit, Offset: offset}
+	if args.All != nil && *args.All {
+		if user.Role != "admin" {
+			return nil, errors.New("only admins can list every user's tests")
+		}
+		filter.UserID = ""
+	}
+	if args.SortBy != nil {
+		if !slices.Contains(domain.TestSortFields, *args.SortBy) {
+			return nil, fmt.Errorf("invalid sortBy %q (expected one of %s)", *args.SortBy, strings.Join(domain.TestSortFields, ", "))
+		}
+		filter.SortBy = *args.SortBy
+	}
+	filter.Ascending = args.Ascending != nil && *args.Ascending
+	if f := args.Filter; f != nil {
+		if f.Status != nil {
+			filter.Status = strings.ToUpper(*f.Status)
+		}
+		if f.Name != nil {
+			filter.NameContains = *f.Name
+		}
+		if f.Tag != nil {
+			filter.Tag = strings.ToLower(*f.Tag)
+		}
+		if f.Host != nil {
+			filter.TargetHost = *f.Host
+		}
+		if f.CreatedAfter != nil {
+			filter.CreatedAfter = f.CreatedAfter.Time
+		}
+		if f.CreatedBefore != nil {
+			filter.CreatedBefore = f.CreatedBefore.Time
+		}
+	}
+
+	tests, total, err := r.h.usecase.ListTests(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tests: %w", err)
+	}
+	conn := &testConnectionResolver{total: int32(total)}
+	for _, test := range tests {
+		conn.tests = append(conn.tests, &testResolver{r.h, test})
+	}
+	return conn, nil
+}
+
+func (r *graphqlResolver) Test(ctx context.Context, args struct{ ID graphql.ID }) (*testResolver, error) {
+	test, err := r.h.usecase.GetTestRequest(ctx, string(args.ID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	return &testResolver{r.h, test}, nil
+}
+
+func (r *graphqlResolver) Dashboard(ctx context.Context) (*dashboardResolver, error) {
+	dashboard, err := r.h.usecase.GetDashboardStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard status: %w", err)
+	}
+	return &dashboardResolver{r.h, dashboard}, nil
+}
+
+func (r *graphqlResolver) Workers(ctx context.Context) ([]*workerResolver, error) {
+	if _, err := graphqlAdmin(ctx); err != nil {
+		return nil, err
+	}
+	workers, err := r.h.usecase.ListWorkers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workers: %w", err)
+	}
+	list := make([]*workerResolver, len(workers))
+	for i := range workers {
+		list[i] = &workerResolver{&workers[i]}
+	}
+	return list, nil
+}
+
+func (r *graphqlResolver) Analytics(ctx context.Context, args struct {
+	StartDate *string
+	EndDate   *string
+	Build     *string
+}) (*analyticsResolver, error) {
+	user, err := graphqlUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := domain.AnalyticsRequest{UserID: user.ID}
+	if args.Build != nil {
+		req.TargetBuild = *args.Build
+	}
+	if (args.StartDate == nil) != (args.EndDate == nil) {
+		return nil, errors.New("give both startDate and endDate, or neither")
+	}
+	if args.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02", *args.StartDate)
+		if err != nil {
+			return nil, errors.New("invalid startDate format (expected YYYY-MM-DD)")
+		}
+		endDate, err := time.Parse("2006-01-02", *args.EndDate)
+		if err != nil {
+			return nil, errors.New("invalid endDate format (expected YYYY-MM-DD)")
+		}
+		req.TimeRange = &domain.AnalyticsTimeRange{StartDate: startDate, EndDate: endDate}
+	}
+	return &analyticsResolver{r.h, req}, nil
+}
+
+func (r *graphqlResolver) Users(ctx context.Context) ([]*userResolver, error) {
+	if _, err := graphqlAdmin(ctx); err != nil {
+		return nil, err
+	}
+	users, err := r.h.userUsecase.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	list := make([]*userResolver, len(users))
+	for i, user := range users {
+		list[i] = &userResolver{user}
+	}
+	return list, nil
+}
+
+type testConnectionResolver struct {
+	total int32
+	tests []*testResolver
+}
+
+func (c *testConnectionResolver) Total() int32           { return c.total }
+func (c *testConnectionResolver) Tests() []*testResolver { return c.tests }
+
+type testResolver struct {
+	h    *HTTPHandler
+	test *domain.TestRequest
+}
+
+func (t *testResolver) ID() graphql.ID              { return graphql.ID(t.test.ID) }
+func (t *testResolver) Name() string                { return t.test.Name }
+func (t *testResolver) Status() string              { return t.test.Status }
+func (t *testResolver) RequesterId() string         { return t.test.RequesterID }
+func (t *testResolver) CreatedAt() graphql.Time     { return graphql.Time{Time: t.test.CreatedAt} }
+func (t *testResolver) DurationSeconds() string     { return t.test.DurationSeconds }
+func (t *testResolver) RatePerSecond() float64      { return float64(t.test.RatePerSecond) }
+func (t *testResolver) WorkerCount() int32          { return int32(t.test.WorkerCount) }
+func (t *testResolver) RateDistribution() string    { return t.test.RateDistribution }
+func (t *testResolver) Protocol() string            { return t.test.Protocol }
+func (t *testResolver) Priority() string            { return t.test.Priority }
+func (t *testResolver) TargetBuild() string         { return t.test.TargetBuild }
+func (t *testResolver) Tags() []string              { return nonNil(t.test.Tags) }
+func (t *testResolver) AssignedWorkerIds() []string { return nonNil(t.test.AssignedWorkersIDs) }
+func (t *testResolver) CompletedWorkers() []string  { return nonNil(t.test.CompletedWorkers) }
+func (t *testResolver) FailedWorkers() []string     { return nonNil(t.test.FailedWorkers) }
+
+// Requester is null if the user no longer exists, or unless the caller is an
+// admin or the requester: like the users query, it exposes account details.
+func (t *testResolver) Requester(ctx context.Context) (*userResolver, error) {
+	caller, err := graphqlUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.Role != "admin" && caller.ID != t.test.RequesterID {
+		return nil, nil
+	}
+	user, err := t.h.userUsecase.GetUserProfile(ctx, t.test.RequesterID)
+	if err != nil {
+		return nil, nil
+	}
+	return &userResolver{user}, nil
+}
+
+func (t *testResolver) Results(ctx context.Context, args struct {
+	Limit  *int32
+	Offset *int32
+}) (*resultPageResolver, error) {
+	limit, offset := pageArgs(args.Limit, args.Offset, graphqlMaxResults)
+	page := domain.ResultPage{Limit: limit, Offset: offset, WithoutMetric: true}
+	results, total, err := t.h.usecase.GetRawTestResultsPage(ctx, t.test.ID, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test results: %w", err)
+	}
+	resolver := &resultPageResolver{total: int32(total)}
+	for _, result := range results {
+		resolver.results = append(resolver.results, &resultResolver{result})
+	}
+	return resolver, nil
+}
+
+func (t *testResolver) AggregatedResult(ctx context.Context) (*aggregatedResultResolver, error) {
+	result, err := t.h.usecase.GetAggregatedTestResult(ctx, t.test.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get aggregated test result: %w", err)
+	}
+	return &aggregatedResultResolver{result}, nil
+}
+
+// nonNil returns list, or an empty list for nil, for non-null list fields.
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+type resultPageResolver struct {
+	total   int32
+	results []*resultResolver
+}
+
+func (p *resultPageResolver) Total() int32               { return p.total }
+func (p *resultPageResolver) Results() []*resultResolver { return p.results }
+
+type resultResolver struct {
+	result *domain.TestResult
+}
+
+func (r *resultResolver) ID() graphql.ID             { return graphql.ID(r.result.ID) }
+func (r *resultResolver) WorkerId() string           { return r.result.WorkerID }
+func (r *resultResolver) Timestamp() graphql.Time    { return graphql.Time{Time: r.result.Timestamp} }
+func (r *resultResolver) TotalRequests() float64     { return float64(r.result.TotalRequests) }
+func (r *resultResolver) CompletedRequests() float64 { return float64(r.result.CompletedRequests) }
+func (r *resultResolver) DurationMs() float64        { return float64(r.result.DurationMs) }
+func (r *resultResolver) SuccessRate() float64       { return r.result.SuccessRate }
+func (r *resultResolver) AverageLatencyMs() float64  { return r.result.AverageLatencyMs }
+func (r *resultResolver) P95LatencyMs() float64      { return r.result.P95LatencyMs }
+func (r *resultResolver) Region() string             { return r.result.Region }
+func (r *resultResolver) StatusCodes() []*countResolver {
+	return counts(r.result.StatusCodes)
+}
+
+func (r *resultResolver) SuccessfulRequests() float64 {
+	successful, _ := r.result.SuccessCounts()
+	return float64(successful)
+}
+
+func (r *resultResolver) FailedRequests() float64 {
+	_, failed := r.result.SuccessCounts()
+	return float64(failed)
+}
+
+type aggregatedResultResolver struct {
+	result *domain.TestResultAggregated
+}
+
+func (a *aggregatedResultResolver) TotalRequests() float64 { return float64(a.result.TotalRequests) }
+func (a *aggregatedResultResolver) SuccessfulRequests() float64 {
+	return float64(a.result.SuccessfulRequests)
+}
+func (a *aggregatedResultResolver) FailedRequests() float64 { return float64(a.result.FailedRequests) }
+func (a *aggregatedResultResolver) AvgLatencyMs() float64   { return a.result.AvgLatencyMs }
+func (a *aggregatedResultResolver) P95LatencyMs() float64   { return a.result.P95LatencyMs }
+func (a *aggregatedResultResolver) DurationMs() float64     { return float64(a.result.DurationMs) }
+func (a *aggregatedResultResolver) ThroughputRps() float64  { return a.result.ThroughputRPS }
+func (a *aggregatedResultResolver) OverallStatus() string   { return a.result.OverallStatus }
+func (a *aggregatedResultResolver) CompletedAt() graphql.Time {
+	return graphql.Time{Time: a.result.CompletedAt}
+}
+func (a *aggregatedResultResolver) ErrorRates() []*countResolver { return counts(a.result.ErrorRates) }
+func (a *aggregatedResultResolver) ApdexScore() *float64 {
+	if a.result.Apdex == nil {
+		return nil
+	}
+	return &a.result.Apdex.Score
+}
+func (a *aggregatedResultResolver) ApdexRating() *string {
+	if a.result.Apdex == nil {
+		return nil
+	}
+	return &a.result.Apdex.Rating
+}
+
+type countResolver struct {
+	key   string
+	count int64
+}
+
+func (c *countResolver) Key() string    { return c.key }
+func (c *countResolver) Count() float64 { return float64(c.count) }
+
+type dashboardResolver struct {
+	h         *HTTPHandler
+	dashboard *domain.DashboardStatus
+}
+
+func (d *dashboardResolver) TotalWorkers() int32     { return int32(d.dashboard.TotalWorkers) }
+func (d *dashboardResolver) AvailableWorkers() int32 { return int32(d.dashboard.AvailableWorkers) }
+func (d *dashboardResolver) BusyWorkers() int32      { return int32(d.dashboard.BusyWorkers) }
+func (d *dashboardResolver) ActiveTests() []*activeTestResolver {
+	list := make([]*activeTestResolver, len(d.dashboard.ActiveTests))
+	for i := range d.dashboard.ActiveTests {
+		list[i] = &activeTestResolver{d.h, &d.dashboard.ActiveTests[i]}
+	}
+	return list
+}
+
+type activeTestResolver struct {
+	h    *HTTPHandler
+	test *domain.ActiveTestSummary
+}
+
+func (a *activeTestResolver) TestId() graphql.ID      { return graphql.ID(a.test.TestID) }
+func (a *activeTestResolver) TestName() string        { return a.test.TestName }
+func (a *activeTestResolver) Status() string          { return a.test.Status }
+func (a *activeTestResolver) AssignedWorkers() int32  { return int32(a.test.AssignedWorkers) }
+func (a *activeTestResolver) CompletedWorkers() int32 { return int32(a.test.CompletedWorkers) }
+func (a *activeTestResolver) FailedWorkers() int32    { return int32(a.test.FailedWorkers) }
+func (a *activeTestResolver) TotalRequestsSent() float64 {
+	return float64(a.test.TotalRequestsSent)
+}
+func (a *activeTestResolver) TotalRequestsCompleted() float64 {
+	return float64(a.test.TotalRequestsCompleted)
+}
+func (a *activeTestResolver) Progress() float64 { return a.test.Progress }
+
+func (a *activeTestResolver) Test(ctx context.Context) (*testResolver, error) {
+	return (&graphqlResolver{a.h}).Test(ctx, struct{ ID graphql.ID }{graphql.ID(a.test.TestID)})
+}
+
+type workerResolver struct {
+	worker *domain.WorkerDetail
+}
+
+func (w *workerResolver) ID() graphql.ID              { return graphql.ID(w.worker.ID) }
+func (w *workerResolver) Address() string             { return w.worker.Address }
+func (w *workerResolver) Status() string              { return w.worker.Status }
+func (w *workerResolver) LastSeen() graphql.Time      { return graphql.Time{Time: w.worker.LastSeen} }
+func (w *workerResolver) CurrentTestId() string       { return w.worker.CurrentTestID }
+func (w *workerResolver) LastProgressMessage() string { return w.worker.LastProgressMessage }
+func (w *workerResolver) CompletedRequests() float64  { return float64(w.worker.CompletedRequests) }
+func (w *workerResolver) TotalRequests() float64      { return float64(w.worker.TotalRequests) }
+func (w *workerResolver) MaxRate() float64            { return float64(w.worker.MaxRate) }
+func (w *workerResolver) Region() string              { return w.worker.Region }
+func (w *workerResolver) Version() string             { return w.worker.Version }
+func (w *workerResolver) ConnectionState() string     { return w.worker.ConnectionState }
+func (w *workerResolver) Draining() bool              { return w.worker.Draining }
+
+// analyticsResolver computes each part of the analytics only when it is
+// asked for.
+type analyticsResolver struct {
+	h   *HTTPHandler
+	req domain.AnalyticsRequest
+}
+
+func (a *analyticsResolver) Overview(ctx context.Context) (*analyticsOverviewResolver, error) {
+	req := a.req
+	overview, err := a.h.usecase.GetAnalyticsOverview(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics overview: %w", err)
+	}
+	return &analyticsOverviewResolver{overview}, nil
+}
+
+func (a *analyticsResolver) Targets(ctx context.Context, args struct{ Target *string }) ([]*targetAnalyticsResolver, error) {
+	req := a.req
+	if args.Target != nil {
+		req.TargetURL = *args.Target
+	}
+	targets, err := a.h.usecase.GetTargetAnalytics(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target analytics: %w", err)
+	}
+	list := make([]*targetAnalyticsResolver, len(targets))
+	for i := range targets {
+		list[i] = &targetAnalyticsResolver{&targets[i]}
+	}
+	return list, nil
+}
+
+type analyticsOverviewResolver struct {
+	overview *domain.AnalyticsOverview
+}
+
+func (o *analyticsOverviewResolver) TotalTests() float64    { return float64(o.overview.TotalTests) }
+func (o *analyticsOverviewResolver) TotalRequests() float64 { return float64(o.overview.TotalRequests) }
+func (o *analyticsOverviewResolver) SuccessRate() float64   { return o.overview.SuccessRate }
+func (o *analyticsOverviewResolver) AverageResponseTime() float64 {
+	return o.overview.AverageResponseTime
+}
+func (o *analyticsOverviewResolver) MedianResponseTime() float64 {
+	return o.overview.MedianResponseTime
+}
+func (o *analyticsOverviewResolver) P95ResponseTime() float64 { return o.overview.P95ResponseTime }
+func (o *analyticsOverviewResolver) P99ResponseTime() float64 { return o.overview.P99ResponseTime }
+func (o *analyticsOverviewResolver) PercentileSource() string { return o.overview.PercentileSource }
+func (o *analyticsOverviewResolver) TopErrorCodes() []*countResolver {
+	return errorCodeCounts(o.overview.TopErrorCodes)
+}
+
+func (o *analyticsOverviewResolver) TestsPerDay() []*countResolver {
+	list := make([]*countResolver, len(o.overview.TestsPerDay))
+	for i, day := range o.overview.TestsPerDay {
+		list[i] = &countResolver{key: day.Date, count: day.Count}
+	}
+	return list
+}
+
+func (o *analyticsOverviewResolver) RequestsPerDay() []*countResolver {
+	list := make([]*countResolver, len(o.overview.RequestsPerDay))
+	for i, day := range o.overview.RequestsPerDay {
+		list[i] = &countResolver{key: day.Date, count: day.Count}
+	}
+	return list
+}
+
+// errorCodeCounts lists error code statistics as counts by status code.
+func errorCodeCounts(stats []domain.ErrorCodeStats) []*countResolver {
+	list := make([]*countResolver, len(stats))
+	for i, stat := range stats {
+		list[i] = &countResolver{key: stat.StatusCode, count: stat.Count}
+	}
+	return list
+}
+
+type targetAnalyticsResolver struct {
+	target *domain.TargetAnalytics
+}
+
+func (t *targetAnalyticsResolver) Target() string         { return t.target.Target }
+func (t *targetAnalyticsResolver) TestCount() float64     { return float64(t.target.TestCount) }
+func (t *targetAnalyticsResolver) TotalRequests() float64 { return float64(t.target.TotalRequests) }
+func (t *targetAnalyticsResolver) SuccessRate() float64   { return t.target.SuccessRate }
+func (t *targetAnalyticsResolver) AverageResponseTime() float64 {
+	return t.target.AverageResponseTime
+}
+func (t *targetAnalyticsResolver) P95ResponseTime() float64 { return t.target.P95ResponseTime }
+func (t *targetAnalyticsResolver) P99ResponseTime() float64 { return t.target.P99ResponseTime }
+func (t *targetAnalyticsResolver) ErrorBreakdown() []*countResolver {
+	return errorCodeCounts(t.target.ErrorBreakdown)
+}
+
+type userResolver struct {
+	user *domain.UserProfile
+}
+
+func (u *userResolver) ID() graphql.ID          { return graphql.ID(u.user.ID) }
+func (u *userResolver) Username() string        { return u.user.Username }
+func (u *userResolver) Email() string           { return u.user.Email }
+func (u *userResolver) FirstName() string       { return u.user.FirstName }
+func (u *userResolver) LastName() string        { return u.user.LastName }
+func (u *userResolver) Role() string            { return u.user.Role }
+func (u *userResolver) IsActive() bool          { return u.user.IsActive }
+func (u *userResolver) CreatedAt() graphql.Time { return graphql.Time{Time: u.user.CreatedAt} }
+func (u *userResolver) LastLoginAt() *graphql.Time {
+	if u.user.LastLoginAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *u.user.LastLoginAt}
+}
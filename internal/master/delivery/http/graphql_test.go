@@ -0,0 +1,29 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+func TestRequesterHiddenFromOtherNonAdmins(t *testing.T) {
+	// No user usecase: a non-admin caller must not reach the profile lookup
+	resolver := &testResolver{h: &HTTPHandler{}, test: &domain.TestRequest{ID: "t1", RequesterID: "owner"}}
+	ctx := context.WithValue(context.Background(), userContextKey, &domain.UserProfile{ID: "someone-else", Role: "user"})
+
+	user, err := resolver.Requester(ctx)
+	if err != nil {
+		t.Fatalf("Requester returned error: %v", err)
+	}
+	if user != nil {
+		t.Errorf("Requester = %+v, want null for a non-admin who did not submit the test", user.user)
+	}
+}
+
+func TestRequesterRequiresCaller(t *testing.T) {
+	resolver := &testResolver{h: &HTTPHandler{}, test: &domain.TestRequest{ID: "t1", RequesterID: "owner"}}
+	if _, err := resolver.Requester(context.Background()); err == nil {
+		t.Error("Requester without a caller in the context succeeded, want an error")
+	}
+}
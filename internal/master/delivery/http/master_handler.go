@@ -3,9 +3,13 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -29,21 +33,23 @@ type HTTPHandler struct {
 	Router      *mux.Router
 	usecase     *masterUsecase.MasterUsecase
 	userUsecase *userUsecase.UserUsecase
-	jwtSecret   string
+	api         *mux.Router // Authenticated /api routes
 }
 
 // NewHTTPHandler creates a new HTTPHandler instance.
-func NewHTTPHandler(uc *masterUsecase.MasterUsecase, userUc *userUsecase.UserUsecase, jwtSecret string) *HTTPHandler {
+func NewHTTPHandler(uc *masterUsecase.MasterUsecase, userUc *userUsecase.UserUsecase) *HTTPHandler {
 	h := &HTTPHandler{
 		usecase:     uc,
 		userUsecase: userUc,
-		jwtSecret:   jwtSecret,
 	}
 	r := mux.NewRouter()
 
 	// CORS middleware
 	r.Use(h.corsMiddleware)
 
+	// Note where each request came from for the audit log
+	r.Use(auditSourceMiddleware)
+
 	// Register user management routes with their own prefix
 	userHandler := userHttp.NewUserHandler(userUc)
 	userMux := http.NewServeMux()
@@ -53,25 +59,102 @@ func NewHTTPHandler(uc *masterUsecase.MasterUsecase, userUc *userUsecase.UserUse
 	r.PathPrefix("/api/auth").Handler(userMux)
 	r.PathPrefix("/api/users").Handler(userMux)
 
+	// Approval callbacks from external systems authenticate with the approval secret, not a JWT
+	r.HandleFunc("/api/approvals/{testId}", h.approvalCallback).Methods("POST")
+
+	// Public shared links are opened without an account; their signed token is the credential
+	r.HandleFunc("/api/public/shared/{token}", h.viewPublicSharedLink).Methods("GET")
+
+	// Grafana JSON data source; accepts basic auth besides bearer tokens
+	h.registerGrafanaRoutes(r)
+
 	// API routes (protected by auth middleware)
 	api := r.PathPrefix("/api").Subrouter()
 	api.Use(h.authMiddleware)
+	h.api = api
 	api.HandleFunc("/test/submit", h.submitTest).Methods("POST")
+	api.HandleFunc("/test/validate", h.validateTest).Methods("POST")
+	api.HandleFunc("/test/estimate", h.estimateTest).Methods("POST")
 	api.HandleFunc("/dashboard", h.getDashboardStatus).Methods("GET")
 	api.HandleFunc("/tests", h.getTests).Methods("GET")
+	api.HandleFunc("/tests/{testId}", h.getTest).Methods("GET")
 	api.HandleFunc("/tests/{testId}/results", h.getTestResults).Methods("GET")
 	api.HandleFunc("/tests/{testId}/aggregated-result", h.getAggregatedTestResult).Methods("GET")
 	api.HandleFunc("/tests/{testId}/aggregate", h.triggerAggregation).Methods("POST")
+	api.HandleFunc("/tests/{testId}/aggregates", h.getAggregateDocuments).Methods("GET")
+	api.HandleFunc("/tests/{testId}/aggregates/{name}", h.getAggregateDocument).Methods("GET")
+	api.HandleFunc("/tests/{testId}/export", h.exportTest).Methods("GET")
+	api.HandleFunc("/tests/{testId}/progress", h.getTestProgress).Methods("GET")
+	api.HandleFunc("/tests/{testId}/timeseries", h.getTestTimeSeries).Methods("GET")
+	api.HandleFunc("/tests/{testId}/throughput", h.getTestThroughput).Methods("GET")
+	api.HandleFunc("/tests/{testId}/status-timeline", h.getTestStatusTimeline).Methods("GET")
+	api.HandleFunc("/tests/{testId}/capacity", h.getTestCapacity).Methods("GET")
+	api.HandleFunc("/tests/{testId}/interim", h.getTestInterim).Methods("GET")
+	api.HandleFunc("/tests/{testId}/requests", h.downloadRequestCaptures).Methods("GET")
+	api.HandleFunc("/tests/{testId}/events", h.streamTestEvents).Methods("GET")
+	api.HandleFunc("/tests/{testId}/timeline", h.getTestTimeline).Methods("GET")
+	api.HandleFunc("/tests/{testId}/approve", h.requireAdmin(h.approveTest)).Methods("POST")
+	api.HandleFunc("/tests/{testId}/reject", h.requireAdmin(h.rejectTest)).Methods("POST")
+
+	// Tests waiting for workers
+	api.HandleFunc("/queue", h.getQueue).Methods("GET")
+	api.HandleFunc("/queue/{testId}", h.cancelQueuedTest).Methods("DELETE")
+	api.HandleFunc("/queue/{testId}/promote", h.promoteQueuedTest).Methods("POST")
 
 	// Sharing and inbox endpoints
 	api.HandleFunc("/tests/{testId}/share", h.shareTest).Methods("POST")
 	api.HandleFunc("/shared/{linkId}", h.accessSharedLink).Methods("GET")
+	api.HandleFunc("/shared/{linkId}", h.revokeSharedLink).Methods("DELETE")
+	api.HandleFunc("/shares", h.getMySharedLinks).Methods("GET")
 	api.HandleFunc("/inbox", h.getInbox).Methods("GET")
+	api.HandleFunc("/inbox/summary", h.getInboxSummary).Methods("GET")
 	api.HandleFunc("/inbox/{linkId}/read", h.markInboxItemRead).Methods("POST")
 
+	// Teams that tests can be shared with; any user may list them, admins manage them
+	api.HandleFunc("/teams", h.getTeams).Methods("GET")
+	api.HandleFunc("/teams", h.requireAdmin(h.createTeam)).Methods("POST")
+	api.HandleFunc("/teams/{teamId}", h.getTeam).Methods("GET")
+	api.HandleFunc("/teams/{teamId}", h.requireAdmin(h.updateTeam)).Methods("PUT")
+	api.HandleFunc("/teams/{teamId}", h.requireAdmin(h.deleteTeam)).Methods("DELETE")
+
+	// Attachments referenced by targets as request bodies or multipart files
+	api.HandleFunc("/attachments", h.uploadAttachment).Methods("POST")
+	api.HandleFunc("/attachments", h.getAttachments).Methods("GET")
+	api.HandleFunc("/attachments/{attachmentId}", h.getAttachment).Methods("GET")
+	api.HandleFunc("/attachments/{attachmentId}", h.deleteAttachment).Methods("DELETE")
+
 	// Analytics routes
 	api.HandleFunc("/analytics/overview", h.getAnalyticsOverview).Methods("GET")
 	api.HandleFunc("/analytics/targets", h.getTargetAnalytics).Methods("GET")
+	api.HandleFunc("/analytics/regions", h.getRegionAnalytics).Methods("GET")
+	api.HandleFunc("/analytics/tests/{testId}/heatmap", h.getLatencyHeatmap).Methods("GET")
+	api.HandleFunc("/analytics/tests/{testId}/percentiles", h.getLatencyPercentiles).Methods("GET")
+
+	// GraphQL queries across tests, workers, results, analytics and users for the dashboard
+	api.Handle("/graphql", h.newGraphQLHandler()).Methods("POST")
+
+	// Global search across tests and, for admins, users
+	api.HandleFunc("/search", h.search).Methods("GET")
+
+	// Test ownership transfer (admin only)
+	api.HandleFunc("/admin/tests/transfer", h.requireAdmin(h.transferTests)).Methods("POST")
+
+	// Audit log (admin only)
+	api.HandleFunc("/admin/audit", h.requireAdmin(h.getAuditLog)).Methods("GET")
+
+	// Rolling worker upgrades (admin only)
+	api.HandleFunc("/admin/workers/rollout", h.requireAdmin(h.getRollout)).Methods("GET")
+	api.HandleFunc("/admin/workers/rollout", h.requireAdmin(h.startRollout)).Methods("POST")
+	api.HandleFunc("/admin/workers/rollout", h.requireAdmin(h.cancelRollout)).Methods("DELETE")
+
+	// Worker management (admin only)
+	api.HandleFunc("/admin/workers", h.requireAdmin(h.listWorkers)).Methods("GET")
+	api.HandleFunc("/admin/workers/{workerId}/offline", h.requireAdmin(h.forceWorkerOffline)).Methods("POST")
+	api.HandleFunc("/admin/workers/{workerId}/ping", h.requireAdmin(h.pingWorker)).Methods("POST")
+	api.HandleFunc("/admin/workers/{workerId}", h.requireAdmin(h.removeWorker)).Methods("DELETE")
+
+	// Master runtime state (admin only)
+	api.HandleFunc("/admin/runtime", h.requireAdmin(h.getRuntimeStats)).Methods("GET")
 
 	h.Router = r
 	return h
@@ -100,7 +183,7 @@ func (h *HTTPHandler) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*") // Adjust in production
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Idempotency-Key")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -110,10 +193,57 @@ func (h *HTTPHandler) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// readOnlyWriteAllowlist lists the non-GET endpoints that don't change any
+// state, so they keep working in read-only mode.
+var readOnlyWriteAllowlist = map[string]bool{
+	"/api/auth/login":          true,
+	"/api/test/validate":       true,
+	"/api/test/estimate":       true,
+	"/api/graphql":             true,
+	"/api/grafana/search":      true,
+	"/api/grafana/query":       true,
+	"/api/grafana/annotations": true,
+}
+
+// EnableReadOnly rejects every request that could change state with 503
+// Service Unavailable, for masters serving reads from a replica database.
+func (h *HTTPHandler) EnableReadOnly() {
+	h.Router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				if !readOnlyWriteAllowlist[r.URL.Path] {
+					http.Error(w, "This master is read-only; changes are disabled", http.StatusServiceUnavailable)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+}
+
+// auditSourceMiddleware records the client address of each request in its
+// context. X-Forwarded-For is kept separately since any client can set it.
+func auditSourceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		ctx := domain.WithAuditSource(r.Context(), domain.AuditSource{IP: ip, ForwardedFor: r.Header.Get("X-Forwarded-For")})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // authMiddleware validates JWT tokens.
 func (h *HTTPHandler) authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" && isEventStream(r) && r.URL.Query().Get("token") != "" {
+			// Browsers' EventSource cannot set headers, so event streams may pass the token in the query
+			authHeader = "Bearer " + r.URL.Query().Get("token")
+		}
 		if authHeader == "" {
 			http.Error(w, "Authorization header required", http.StatusUnauthorized)
 			return
@@ -132,13 +262,30 @@ func (h *HTTPHandler) authMiddleware(next http.Handler) http.Handler {
 			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 			return
 		}
+		if user.MustChangePassword {
+			http.Error(w, "Password change required: set a new password with POST /api/auth/change-password", http.StatusForbidden)
+			return
+		}
 
 		// Add user to context for downstream handlers
 		ctx := context.WithValue(r.Context(), userContextKey, user)
+		ctx = domain.WithAuditActor(ctx, user.ID, user.Username)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// requireAdmin restricts a handler to users with the admin role.
+func (h *HTTPHandler) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+		if !ok || user.Role != "admin" {
+			http.Error(w, "Admin access required", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
 // submitTest handles requests to submit a new load test.
 func (h *HTTPHandler) submitTest(w http.ResponseWriter, r *http.Request) {
 	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
@@ -154,23 +301,154 @@ func (h *HTTPHandler) submitTest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.RequesterId = user.ID // Set requester ID from authenticated user
+	if req.OverrideGuardrails && user.Role != "admin" {
+		http.Error(w, "Only admins can override guardrails", http.StatusForbidden)
+		return
+	}
+	if h.usecase.WaitNeedsAdmin(req.MaxWaitForWorkers) && user.Role != "admin" {
+		http.Error(w, "Only admins can wait longer than the master's default for workers", http.StatusForbidden)
+		return
+	}
+
+	// Retries carrying the same Idempotency-Key get the test the first one created
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > domain.MaxIdempotencyKeyLength {
+		http.Error(w, fmt.Sprintf("Idempotency-Key must be at most %d characters", domain.MaxIdempotencyKeyLength), http.StatusBadRequest)
+		return
+	}
 
 	// Call the gRPC method directly via the usecase
-	resp, err := h.usecase.SubmitTest(r.Context(), &domain.TestRequest{
-		Name:              req.Name,
-		VegetaPayloadJSON: req.VegetaPayloadJson,
-		DurationSeconds:   req.DurationSeconds,
-		RatePerSecond:     req.RatePerSecond,
-		TargetsBase64:     req.TargetsBase64,
-		RequesterID:       req.RequesterId,
-		WorkerCount:       req.WorkerCount,
-	})
+	testReq := testRequestFromPB(&req)
+	resp, duplicate, err := h.usecase.SubmitTestOnce(r.Context(), testReq, idempotencyKey)
+	var confirmation *domain.ConfirmationError
+	if errors.As(err, &confirmation) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPreconditionRequired)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"message":              "Test exceeds the confirmation thresholds: submit it again with confirmation_token to run it",
+			"confirmationRequired": true,
+			"confirmationToken":    confirmation.Token,
+			"reasons":              confirmation.Reasons,
+			"expiresAt":            confirmation.ExpiresAt,
+		})
+		return
+	}
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to submit test: %v", err), http.StatusInternalServerError)
+		code := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrExceedsFleetCapacity) {
+			code = http.StatusUnprocessableEntity
+		}
+		http.Error(w, fmt.Sprintf("Failed to submit test: %v", err), code)
+		return
+	}
+
+	if duplicate {
+		w.Header().Set("Idempotent-Replayed", "true")
+		json.NewEncoder(w).Encode(map[string]interface{}{"testId": resp, "message": "Test already submitted with this Idempotency-Key", "duplicate": true})
+		return
+	}
+
+	response := map[string]interface{}{"testId": resp, "message": "Test submitted successfully"}
+	if testReq.CapacityWarning != "" {
+		response["capacityWarning"] = testReq.CapacityWarning
+		response["workerCount"] = testReq.WorkerCount
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// validateTest checks a test submission without saving or queueing it, and
+// estimates the load a valid test will generate.
+func (h *HTTPHandler) validateTest(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	var req pb.TestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	req.RequesterId = user.ID
+
+	testReq := testRequestFromPB(&req)
+	errs := h.usecase.ValidateTestRequest(r.Context(), testReq)
+	if errs == nil {
+		errs = []domain.ValidationError{}
+	}
+	response := map[string]interface{}{"valid": len(errs) == 0, "errors": errs}
+	if len(errs) == 0 {
+		response["estimate"] = h.usecase.EstimateTestCost(r.Context(), testReq)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// estimateTest predicts what a test submission would cost to run: its
+// requests, bandwidth, worker time and the storage its results take up.
+// An invalid test is refused with its validation errors.
+func (h *HTTPHandler) estimateTest(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	var req pb.TestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
 		return
 	}
+	req.RequesterId = user.ID
 
-	json.NewEncoder(w).Encode(map[string]string{"testId": resp, "message": "Test submitted successfully"})
+	testReq := testRequestFromPB(&req)
+	if errs := h.usecase.ValidateTestRequest(r.Context(), testReq); len(errs) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"valid": false, "errors": errs})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.usecase.EstimateTestCost(r.Context(), testReq))
+}
+
+// testRequestFromPB maps a submission payload onto a domain test request.
+func testRequestFromPB(req *pb.TestRequest) *domain.TestRequest {
+	return &domain.TestRequest{
+		Name:               req.Name,
+		VegetaPayloadJSON:  req.VegetaPayloadJson,
+		DurationSeconds:    req.DurationSeconds,
+		RatePerSecond:      req.RatePerSecond,
+		TargetsBase64:      req.TargetsBase64,
+		RequesterID:        req.RequesterId,
+		WorkerCount:        req.WorkerCount,
+		RateDistribution:   req.RateDistribution,
+		RateWeights:        req.RateWeights,
+		ThinkTime:          req.ThinkTime,
+		PacingJitter:       req.PacingJitter,
+		InjectRequestID:    req.InjectRequestId,
+		TemplateTargets:    req.TemplateTargets,
+		SequenceStart:      req.SequenceStart,
+		ScenarioJSON:       req.ScenarioJson,
+		GraphQLJSON:        req.GraphqlJson,
+		Preflight:          req.Preflight,
+		Priority:           req.Priority,
+		PartialPolicy:      req.PartialPolicy,
+		MaxWaitForWorkers:  req.MaxWaitForWorkers,
+		OnWorkerFailure:    req.OnWorkerFailure,
+		OverrideGuardrails: req.OverrideGuardrails,
+		Smoke:              req.Smoke,
+		InterimInterval:    req.InterimInterval,
+		CaptureRequests:    req.CaptureRequests,
+		Protocol:           req.Protocol,
+		Script:             req.Script,
+		TargetBuild:        req.TargetBuild,
+		Tags:               req.Tags,
+		LabelHeaders:       req.LabelHeaders,
+		ConfirmationToken:  req.ConfirmationToken,
+		Regions:            masterUsecase.RegionSharesFromPB(req.Regions),
+		Apdex:              masterUsecase.ApdexConfigFromPB(req.ApdexThreshold, req.ApdexTargets),
+	}
 }
 
 // getDashboardStatus provides dashboard data.
@@ -183,7 +461,8 @@ func (h *HTTPHandler) getDashboardStatus(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(dashboard)
 }
 
-// getTests retrieves a list of tests with optional pagination.
+// getTests lists the user's tests, or for admins every user's with all=true
+// or another user's with user=<id>, filtered, sorted and paginated by the query parameters.
 func (h *HTTPHandler) getTests(w http.ResponseWriter, r *http.Request) {
 	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
 	if !ok {
@@ -205,7 +484,54 @@ func (h *HTTPHandler) getTests(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	tests, total, err := h.usecase.GetTestRequestsPaginatedByUser(r.Context(), user.ID, limit, offset)
+	query := r.URL.Query()
+	filter := domain.TestListFilter{
+		UserID:       user.ID,
+		Status:       strings.ToUpper(query.Get("status")),
+		NameContains: query.Get("name"),
+		Tag:          strings.ToLower(query.Get("tag")),
+		TargetHost:   query.Get("host"),
+		SortBy:       query.Get("sort"),
+		Limit:        limit,
+		Offset:       offset,
+	}
+	if query.Get("all") == "true" {
+		if user.Role != "admin" {
+			http.Error(w, "Forbidden: only admins can list every user's tests", http.StatusForbidden)
+			return
+		}
+		filter.UserID = ""
+	}
+	if owner := query.Get("user"); owner != "" && owner != user.ID {
+		if user.Role != "admin" {
+			http.Error(w, "Forbidden: only admins can list another user's tests", http.StatusForbidden)
+			return
+		}
+		filter.UserID = owner
+	}
+	if filter.SortBy != "" && !slices.Contains(domain.TestSortFields, filter.SortBy) {
+		http.Error(w, fmt.Sprintf("Invalid sort %q (expected one of %s)", filter.SortBy, strings.Join(domain.TestSortFields, ", ")), http.StatusBadRequest)
+		return
+	}
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		http.Error(w, "Invalid order (expected asc or desc)", http.StatusBadRequest)
+		return
+	}
+	var err error
+	if filter.CreatedAfter, err = parseListTime(query.Get("from"), false); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid from: %v", err), http.StatusBadRequest)
+		return
+	}
+	if filter.CreatedBefore, err = parseListTime(query.Get("to"), true); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid to: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	tests, total, err := h.usecase.ListTests(r.Context(), filter)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get tests: %v", err), http.StatusInternalServerError)
 		return
@@ -222,7 +548,91 @@ func (h *HTTPHandler) getTests(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// getTestResults retrieves raw results for a specific test.
+// parseListTime parses a test listing's from or to bound, an RFC 3339 time or
+// a YYYY-MM-DD date. A date as the upper bound includes the whole day.
+func parseListTime(value string, upper bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or an RFC 3339 time, got %q", value)
+	}
+	if upper {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// search finds tests by name or target URL and, for admins, users by name or
+// email. Admins search every user's tests; others only their own.
+func (h *HTTPHandler) search(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		http.Error(w, "Query parameter q is required", http.StatusBadRequest)
+		return
+	}
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 && v <= 100 {
+			limit = v
+		}
+	}
+
+	isAdmin := user.Role == "admin"
+	ownerID := user.ID
+	if isAdmin {
+		ownerID = ""
+	}
+	results, err := h.usecase.SearchTests(r.Context(), query, ownerID, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to search tests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if isAdmin {
+		users, err := h.userUsecase.SearchUsers(r.Context(), query, limit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to search users: %v", err), http.StatusInternalServerError)
+			return
+		}
+		results = append(results, users...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"query": query, "results": results})
+}
+
+// getTest retrieves a single test, including the rate each worker was given.
+func (h *HTTPHandler) getTest(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+	test, err := h.usecase.GetTestRequest(r.Context(), testID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, fmt.Sprintf("Test %s not found", testID), http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get test: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if scrub, _ := strconv.ParseBool(r.URL.Query().Get("scrub")); scrub {
+		test = h.usecase.ScrubTestRequest(test)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	h.encodeMaybeScrubbed(w, r, test)
+}
+
+// getTestResults retrieves raw results for a specific test, optionally one
+// page at a time, without the raw metric or with only some fields.
 func (h *HTTPHandler) getTestResults(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	testID := vars["testId"]
@@ -231,12 +641,98 @@ func (h *HTTPHandler) getTestResults(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	results, err := h.usecase.GetRawTestResults(r.Context(), testID)
+	query := r.URL.Query()
+	var page domain.ResultPage
+	if l := query.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			page.Limit = v
+		}
+	}
+	if o := query.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			page.Offset = v
+		}
+	}
+	if include, err := strconv.ParseBool(query.Get("includeMetric")); err == nil {
+		page.WithoutMetric = !include
+	}
+	var fields []string
+	if f := query.Get("fields"); f != "" {
+		for _, field := range strings.Split(f, ",") {
+			field = strings.TrimSpace(field)
+			if !slices.Contains(resultFields, field) {
+				http.Error(w, fmt.Sprintf("Unknown field %q (expected any of %s)", field, strings.Join(resultFields, ", ")), http.StatusBadRequest)
+				return
+			}
+			fields = append(fields, field)
+		}
+		if !slices.Contains(fields, "metric") {
+			page.WithoutMetric = true
+		}
+	}
+	// The raw metric is encoded, so its errors and URLs cannot be redacted
+	if scrub, _ := strconv.ParseBool(query.Get("scrub")); scrub {
+		page.WithoutMetric = true
+	}
+
+	results, total, err := h.usecase.GetRawTestResultsPage(r.Context(), testID, page)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get test results: %v", err), http.StatusInternalServerError)
 		return
 	}
-	json.NewEncoder(w).Encode(results)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if results == nil {
+		results = []*domain.TestResult{}
+	}
+	if fields == nil {
+		h.encodeMaybeScrubbed(w, r, results)
+		return
+	}
+	selected, err := selectFields(results, fields)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to select result fields: %v", err), http.StatusInternalServerError)
+		return
+	}
+	h.encodeMaybeScrubbed(w, r, selected)
+}
+
+// resultFields lists the JSON fields of a raw result that the results
+// endpoint's fields parameter may select.
+var resultFields = jsonFieldNames(reflect.TypeOf(domain.TestResult{}))
+
+// jsonFieldNames returns the JSON names of a struct type's encoded fields.
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// selectFields re-encodes each of items keeping only the given JSON fields.
+func selectFields[T any](items []T, fields []string) ([]map[string]json.RawMessage, error) {
+	selected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		kept := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				kept[field] = value
+			}
+		}
+		selected = append(selected, kept)
+	}
+	return selected, nil
 }
 
 // getAggregatedTestResult retrieves the aggregated result for a specific test.
@@ -257,87 +753,577 @@ func (h *HTTPHandler) getAggregatedTestResult(w http.ResponseWriter, r *http.Req
 		http.Error(w, fmt.Sprintf("Failed to get aggregated test result: %v", err), http.StatusInternalServerError)
 		return
 	}
-	json.NewEncoder(w).Encode(aggregatedResult)
+	h.encodeMaybeScrubbed(w, r, aggregatedResult)
 }
 
-// triggerAggregation manually triggers aggregation for a specific test.
-func (h *HTTPHandler) triggerAggregation(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	testID := vars["testId"]
-	if testID == "" {
-		http.Error(w, "Test ID is required", http.StatusBadRequest)
+// getTestProgress returns the live progress of a running test.
+func (h *HTTPHandler) getTestProgress(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	progress, err := h.usecase.GetTestProgress(r.Context(), testID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get test progress: %v", err), code)
 		return
 	}
-
-	// Trigger aggregation in a goroutine
-	go h.usecase.TriggerAggregation(context.Background(), testID)
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": fmt.Sprintf("Aggregation triggered for test %s", testID),
-	})
+	json.NewEncoder(w).Encode(progress)
 }
 
-// getAnalyticsOverview provides comprehensive analytics overview
-func (h *HTTPHandler) getAnalyticsOverview(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters for time range
-	query := r.URL.Query()
-
-	var req domain.AnalyticsRequest
+// getTestTimeSeries returns a test's metrics over time.
+func (h *HTTPHandler) getTestTimeSeries(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
 
-	// Parse optional time range
-	startDateStr := query.Get("startDate")
-	endDateStr := query.Get("endDate")
+	interval, ok := intervalParam(w, r)
+	if !ok {
+		return
+	}
 
-	if startDateStr != "" && endDateStr != "" {
-		startDate, err := time.Parse("2006-01-02", startDateStr)
-		if err != nil {
-			http.Error(w, "Invalid start date format (expected YYYY-MM-DD)", http.StatusBadRequest)
-			return
+	series, err := h.usecase.GetTestTimeSeries(r.Context(), testID, interval)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "invalid interval"):
+			code = http.StatusBadRequest
 		}
+		http.Error(w, fmt.Sprintf("Failed to get test time series: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
 
-		endDate, err := time.Parse("2006-01-02", endDateStr)
-		if err != nil {
-			http.Error(w, "Invalid end date format (expected YYYY-MM-DD)", http.StatusBadRequest)
-			return
-		}
+// getTestThroughput returns the rates a test sent and completed requests at
+// over time, against the rate it asked for.
+func (h *HTTPHandler) getTestThroughput(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
 
-		req.TimeRange = &domain.AnalyticsTimeRange{
-			StartDate: startDate,
-			EndDate:   endDate,
+	throughput, err := h.usecase.GetTestThroughput(r.Context(), testID, r.URL.Query().Get("resolution"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "invalid resolution"):
+			code = http.StatusBadRequest
 		}
-	}
-
-	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
-	if !ok {
-		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		http.Error(w, fmt.Sprintf("Failed to get test throughput: %v", err), code)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(throughput)
+}
 
-	req.UserID = user.ID
+// getTestInterim returns the rolling aggregate of a long test's interim
+// results and its aggregate at every flush.
+func (h *HTTPHandler) getTestInterim(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
 
-	overview, err := h.usecase.GetAnalyticsOverview(r.Context(), &req)
+	report, err := h.usecase.GetInterimReport(r.Context(), testID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to get analytics overview: %v", err), http.StatusInternalServerError)
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get interim results: %v", err), code)
 		return
 	}
-
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(overview)
+	json.NewEncoder(w).Encode(report)
 }
 
-// getTargetAnalytics provides analytics for specific targets
-func (h *HTTPHandler) getTargetAnalytics(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	query := r.URL.Query()
+// getTestTimeline returns every recorded step of a test, oldest first.
+func (h *HTTPHandler) getTestTimeline(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
 
-	var req domain.AnalyticsRequest
+	timeline, err := h.usecase.GetTestTimeline(r.Context(), testID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get test timeline: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"testId": testID, "timeline": timeline})
+}
 
-	// Parse optional target URL filter
-	req.TargetURL = query.Get("target")
+// getAggregateDocuments returns a test's named aggregates, its summary first.
+func (h *HTTPHandler) getAggregateDocuments(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
 
-	// Parse optional time range
-	startDateStr := query.Get("startDate")
+	docs, err := h.usecase.GetAggregateDocuments(r.Context(), testID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get aggregates: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"testId": testID, "aggregates": docs})
+}
+
+// getAggregateDocument returns one of a test's named aggregates.
+func (h *HTTPHandler) getAggregateDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	doc, err := h.usecase.GetAggregateDocument(r.Context(), vars["testId"], vars["name"])
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get aggregate: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(doc)
+}
+
+// downloadRequestCaptures streams the requests a test's workers captured as
+// NDJSON, in the format `vegeta plot`, `vegeta hist` and `vegeta report` read.
+func (h *HTTPHandler) downloadRequestCaptures(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	captures, err := h.usecase.GetRequestCaptures(r.Context(), testID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get captured requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(captures) == 0 {
+		http.Error(w, fmt.Sprintf("No captured requests for test %s; submit it with capture_requests to keep them", testID), http.StatusNotFound)
+		return
+	}
+
+	truncated := false
+	for _, capture := range captures {
+		truncated = truncated || capture.Truncated
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("loadtest-%s-requests.ndjson", testID)))
+	w.Header().Set("X-Capture-Truncated", strconv.FormatBool(truncated))
+	if err := h.usecase.WriteRequestCaptures(r.Context(), testID, captures, w); err != nil {
+		// The status line is already sent, so the download just ends early
+		log.Printf("Failed to stream captured requests of test %s: %v", testID, err)
+	}
+}
+
+// getTestStatusTimeline returns a test's response status codes over time.
+func (h *HTTPHandler) getTestStatusTimeline(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	interval, ok := intervalParam(w, r)
+	if !ok {
+		return
+	}
+
+	timeline, err := h.usecase.GetTestStatusTimeline(r.Context(), testID, interval)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "invalid interval"):
+			code = http.StatusBadRequest
+		}
+		http.Error(w, fmt.Sprintf("Failed to get test status timeline: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
+
+// intervalParam parses the optional interval query parameter, writing a 400
+// response and returning false when it is malformed.
+func intervalParam(w http.ResponseWriter, r *http.Request) (time.Duration, bool) {
+	s := r.URL.Query().Get("interval")
+	if s == "" {
+		return 0, true
+	}
+	interval, err := time.ParseDuration(s)
+	if err != nil {
+		http.Error(w, "Invalid interval (expected a duration such as 10s or 1m)", http.StatusBadRequest)
+		return 0, false
+	}
+	return interval, true
+}
+
+// getTestCapacity estimates the highest throughput a test's target sustained
+// within an SLO given by maxLatencyMs and maxErrorRate (0.0-1.0).
+func (h *HTTPHandler) getTestCapacity(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	var slo domain.CapacitySLO
+	for name, field := range map[string]*float64{"maxLatencyMs": &slo.MaxLatencyMs, "maxErrorRate": &slo.MaxErrorRate} {
+		if s := r.URL.Query().Get(name); s != "" {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid %s (expected a number)", name), http.StatusBadRequest)
+				return
+			}
+			*field = v
+		}
+	}
+
+	estimate, err := h.usecase.EstimateCapacity(r.Context(), testID, slo)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "invalid SLO"):
+			code = http.StatusBadRequest
+		}
+		http.Error(w, fmt.Sprintf("Failed to estimate capacity: %v", err), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(estimate)
+}
+
+// getAuditLog returns audit log entries, newest first. Entries can be
+// filtered by actor, action, target and a since/until time range (RFC 3339)
+// and are paginated with limit and offset.
+func (h *HTTPHandler) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.AuditFilter{
+		ActorID: q.Get("actor"),
+		Action:  q.Get("action"),
+		Target:  q.Get("target"),
+		Limit:   100,
+	}
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid %s (expected an RFC 3339 time such as 2024-01-02T15:04:05Z)", name), http.StatusBadRequest)
+				return
+			}
+			*dst = t
+		}
+	}
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			filter.Limit = min(v, masterUsecase.MaxAuditPageSize)
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			filter.Offset = v
+		}
+	}
+
+	entries, total, err := h.usecase.GetAuditLog(r.Context(), filter)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not enabled") {
+			code = http.StatusNotImplemented
+		}
+		http.Error(w, fmt.Sprintf("Failed to get audit log: %v", err), code)
+		return
+	}
+
+	response := map[string]interface{}{
+		"entries": entries,
+		"total":   total,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// sseKeepAliveInterval is how often an idle event stream sends a comment so
+// proxies do not close it.
+const sseKeepAliveInterval = 15 * time.Second
+
+// isEventStream reports whether r asks for a Server-Sent Events stream.
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream") && strings.HasSuffix(r.URL.Path, "/events")
+}
+
+// streamTestEvents streams a test's events as Server-Sent Events. The stream
+// opens with a "progress" event holding the test's current progress, and
+// the aggregated result if there is one; it then carries each event from the
+// event bus under its type (test.assigned, test.metrics, test.completed, ...),
+// with an "aggregate" event after each test.aggregated. It stays open until
+// the client disconnects.
+func (h *HTTPHandler) streamTestEvents(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := h.usecase.SubscribeTestEvents(r.Context(), testID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to stream test events: %v", err), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+
+	// Subscribed first, so nothing is missed between this snapshot and the stream
+	if progress, err := h.usecase.GetTestProgress(r.Context(), testID); err == nil {
+		writeSSE(w, "progress", progress)
+	}
+	if aggregated, err := h.usecase.GetAggregatedTestResult(r.Context(), testID); err == nil && aggregated != nil {
+		writeSSE(w, "aggregate", aggregated)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-events:
+			writeSSE(w, event.Type, event)
+			if event.Type == domain.EventTestAggregated {
+				if aggregated, err := h.usecase.GetAggregatedTestResult(r.Context(), testID); err == nil && aggregated != nil {
+					writeSSE(w, "aggregate", aggregated)
+				}
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes one Server-Sent Event with v encoded as JSON.
+func writeSSE(w http.ResponseWriter, event string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error marshaling %s event: %v", event, err)
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
+
+// encodeMaybeScrubbed writes v as JSON, redacted when the request has ?scrub=true.
+func (h *HTTPHandler) encodeMaybeScrubbed(w http.ResponseWriter, r *http.Request, v interface{}) {
+	if scrub, _ := strconv.ParseBool(r.URL.Query().Get("scrub")); !scrub {
+		json.NewEncoder(w).Encode(v)
+		return
+	}
+	body, err := h.usecase.ScrubJSON(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to scrub response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// triggerAggregation manually triggers aggregation for a specific test.
+func (h *HTTPHandler) triggerAggregation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	testID := vars["testId"]
+	if testID == "" {
+		http.Error(w, "Test ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Trigger aggregation in a goroutine
+	go h.usecase.TriggerAggregation(context.Background(), testID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": fmt.Sprintf("Aggregation triggered for test %s", testID),
+	})
+}
+
+// approvalDecision is the request body for approval decisions.
+type approvalDecision struct {
+	Approved  bool   `json:"approved"`
+	Reason    string `json:"reason"`
+	DecidedBy string `json:"decided_by"`
+}
+
+// approveTest lets an admin release a test awaiting approval.
+func (h *HTTPHandler) approveTest(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(userContextKey).(*domain.UserProfile)
+	h.applyApprovalDecision(w, r, mux.Vars(r)["testId"], approvalDecision{Approved: true, DecidedBy: user.Username})
+}
+
+// rejectTest lets an admin reject a test awaiting approval.
+func (h *HTTPHandler) rejectTest(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(userContextKey).(*domain.UserProfile)
+	var decision approvalDecision
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&decision); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+	}
+	decision.Approved = false
+	decision.DecidedBy = user.Username
+	h.applyApprovalDecision(w, r, mux.Vars(r)["testId"], decision)
+}
+
+// approvalCallback receives approval decisions from the external approval system.
+func (h *HTTPHandler) approvalCallback(w http.ResponseWriter, r *http.Request) {
+	if !h.usecase.ValidateApprovalSecret(r.Header.Get("X-Approval-Secret")) {
+		http.Error(w, "Invalid approval secret", http.StatusUnauthorized)
+		return
+	}
+
+	var decision approvalDecision
+	if err := json.NewDecoder(r.Body).Decode(&decision); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if decision.DecidedBy == "" {
+		decision.DecidedBy = "approval-webhook"
+	}
+	h.applyApprovalDecision(w, r, mux.Vars(r)["testId"], decision)
+}
+
+func (h *HTTPHandler) applyApprovalDecision(w http.ResponseWriter, r *http.Request, testID string, decision approvalDecision) {
+	var err error
+	if decision.Approved {
+		err = h.usecase.ApproveTest(r.Context(), testID, decision.DecidedBy)
+	} else {
+		err = h.usecase.RejectTest(r.Context(), testID, decision.DecidedBy, decision.Reason)
+	}
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			http.Error(w, fmt.Sprintf("Test %s not found", testID), http.StatusNotFound)
+		case strings.Contains(err.Error(), "not awaiting approval"), errors.Is(err, domain.ErrInvalidStatusTransition):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, fmt.Sprintf("Failed to apply approval decision: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	status := "PENDING"
+	if !decision.Approved {
+		status = masterUsecase.TestStatusRejected
+	}
+	json.NewEncoder(w).Encode(map[string]string{"testId": testID, "status": status})
+}
+
+// exportTest renders a stored test as a standalone vegeta or k6 script.
+func (h *HTTPHandler) exportTest(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	testID := vars["testId"]
+	if testID == "" {
+		http.Error(w, "Test ID is required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = masterUsecase.ExportFormatVegeta
+	}
+
+	scrub, _ := strconv.ParseBool(r.URL.Query().Get("scrub"))
+	script, err := h.usecase.ExportTest(r.Context(), testID, format, scrub)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, fmt.Sprintf("Test %s not found", testID), http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "unsupported export format") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to export test: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("loadtest-%s.sh", testID)
+	if format == masterUsecase.ExportFormatK6 {
+		filename = fmt.Sprintf("loadtest-%s.js", testID)
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write([]byte(script))
+}
+
+// getAnalyticsOverview provides comprehensive analytics overview
+func (h *HTTPHandler) getAnalyticsOverview(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters for time range
+	query := r.URL.Query()
+
+	var req domain.AnalyticsRequest
+
+	// Parse optional build filter
+	req.TargetBuild = query.Get("build")
+
+	// Parse optional time range
+	startDateStr := query.Get("startDate")
+	endDateStr := query.Get("endDate")
+
+	if startDateStr != "" && endDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			http.Error(w, "Invalid start date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			http.Error(w, "Invalid end date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+
+		req.TimeRange = &domain.AnalyticsTimeRange{
+			StartDate: startDate,
+			EndDate:   endDate,
+		}
+	}
+
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	req.UserID = user.ID
+
+	overview, err := h.usecase.GetAnalyticsOverview(r.Context(), &req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get analytics overview: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(overview)
+}
+
+// getTargetAnalytics provides analytics for specific targets
+func (h *HTTPHandler) getTargetAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	query := r.URL.Query()
+
+	var req domain.AnalyticsRequest
+
+	// Parse optional target URL and build filters
+	req.TargetURL = query.Get("target")
+	req.TargetBuild = query.Get("build")
+
+	// Parse optional time range
+	startDateStr := query.Get("startDate")
 	endDateStr := query.Get("endDate")
 
 	if startDateStr != "" && endDateStr != "" {
@@ -377,6 +1363,97 @@ func (h *HTTPHandler) getTargetAnalytics(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(targetAnalytics)
 }
 
+// getRegionAnalytics compares latency by worker region across multi-region tests
+func (h *HTTPHandler) getRegionAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var req domain.AnalyticsRequest
+	req.TargetBuild = query.Get("build")
+	startDateStr := query.Get("startDate")
+	endDateStr := query.Get("endDate")
+	if startDateStr != "" && endDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			http.Error(w, "Invalid start date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			http.Error(w, "Invalid end date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		req.TimeRange = &domain.AnalyticsTimeRange{StartDate: startDate, EndDate: endDate}
+	}
+
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	req.UserID = user.ID
+
+	regionAnalytics, err := h.usecase.GetRegionAnalytics(r.Context(), &req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get region analytics: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(regionAnalytics)
+}
+
+// getLatencyHeatmap returns a test's requests bucketed by time and latency.
+func (h *HTTPHandler) getLatencyHeatmap(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	var interval time.Duration
+	if s := r.URL.Query().Get("interval"); s != "" {
+		var err error
+		if interval, err = time.ParseDuration(s); err != nil {
+			http.Error(w, "Invalid interval (expected a duration such as 1s or 500ms)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cells, err := h.usecase.GetLatencyHeatmap(r.Context(), testID, interval)
+	if err != nil {
+		writeRequestRecordsError(w, testID, err)
+		return
+	}
+	if cells == nil {
+		cells = []domain.HeatmapCell{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cells)
+}
+
+// getLatencyPercentiles returns exact latency percentiles for a test.
+func (h *HTTPHandler) getLatencyPercentiles(w http.ResponseWriter, r *http.Request) {
+	testID := mux.Vars(r)["testId"]
+
+	percentiles, err := h.usecase.GetLatencyPercentiles(r.Context(), testID, r.URL.Query().Get("target"))
+	if err != nil {
+		writeRequestRecordsError(w, testID, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(percentiles)
+}
+
+// writeRequestRecordsError maps a request record query error to a response.
+func writeRequestRecordsError(w http.ResponseWriter, testID string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "not enabled"):
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, fmt.Sprintf("Test %s not found", testID), http.StatusNotFound)
+	case strings.Contains(err.Error(), "invalid interval"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, fmt.Sprintf("Failed to query request records: %v", err), http.StatusInternalServerError)
+	}
+}
+
 // shareTest handles sharing a test and returns a shareable link.
 func (h *HTTPHandler) shareTest(w http.ResponseWriter, r *http.Request) {
 	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
@@ -390,22 +1467,73 @@ func (h *HTTPHandler) shareTest(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Test ID is required", http.StatusBadRequest)
 		return
 	}
-	// Check for optional userId query param
+	// Optional link lifetime; the usecase default applies when absent
+	var ttl time.Duration
+	if s := r.URL.Query().Get("expiresIn"); s != "" {
+		var err error
+		if ttl, err = time.ParseDuration(s); err != nil {
+			http.Error(w, "Invalid expiresIn (expected a duration such as 24h or 168h)", http.StatusBadRequest)
+			return
+		}
+	}
+	// Check for optional userId, teamId or public query param
 	userIdParam := r.URL.Query().Get("userId")
+	teamIdParam := r.URL.Query().Get("teamId")
+	public := r.URL.Query().Get("public") == "true"
+	if userIdParam != "" && teamIdParam != "" || public && (userIdParam != "" || teamIdParam != "") {
+		http.Error(w, "Share with one of userId, teamId or public", http.StatusBadRequest)
+		return
+	}
 	var link *domain.SharedLink
 	var err error
-	if userIdParam != "" {
+	if public {
+		// Link anyone can open without an account
+		link, err = h.usecase.ShareTestPublicly(r.Context(), testID, user, ttl)
+	} else if userIdParam != "" {
 		// Share to another user's inbox
-		link, err = h.usecase.ShareTestToUserInbox(r.Context(), testID, user.ID, userIdParam)
+		link, err = h.usecase.ShareTestToUserInbox(r.Context(), testID, user.ID, userIdParam, ttl)
+	} else if teamIdParam != "" {
+		// Share to the inbox of every team member
+		link, err = h.usecase.ShareTestToTeam(r.Context(), testID, user.ID, teamIdParam, ttl)
 	} else {
 		// Regular share (generate link only)
-		link, err = h.usecase.ShareTest(r.Context(), testID, user.ID)
+		link, err = h.usecase.ShareTest(r.Context(), testID, user.ID, ttl)
 	}
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to share test: %v", err), http.StatusInternalServerError)
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "invalid expiry"):
+			code = http.StatusBadRequest
+		case strings.Contains(err.Error(), "team not found"), strings.Contains(err.Error(), "test not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "insufficient permissions"):
+			code = http.StatusForbidden
+		case strings.Contains(err.Error(), "not enabled"):
+			code = http.StatusNotImplemented
+		}
+		http.Error(w, fmt.Sprintf("Failed to share test: %v", err), code)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]string{"link": "/api/shared/" + link.ID, "expiresAt": link.ExpiresAt.Format(time.RFC3339)})
+	response := map[string]string{"link": "/api/shared/" + link.ID, "expiresAt": link.ExpiresAt.Format(time.RFC3339)}
+	if link.Public {
+		response["publicLink"] = "/api/public/shared/" + link.PublicToken
+		response["publicUrl"] = "/public/" + link.PublicToken
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// viewPublicSharedLink shows the read-only view of a test shared with a
+// public link, without authentication.
+func (h *HTTPHandler) viewPublicSharedLink(w http.ResponseWriter, r *http.Request) {
+	view, err := h.usecase.OpenPublicSharedLink(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	json.NewEncoder(w).Encode(view)
 }
 
 // accessSharedLink allows a user to access a shared test link and adds it to their history.
@@ -421,12 +1549,54 @@ func (h *HTTPHandler) accessSharedLink(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Link ID is required", http.StatusBadRequest)
 		return
 	}
-	test, err := h.usecase.AccessSharedLink(r.Context(), linkID, user.ID)
+	view, err := h.usecase.AccessSharedLink(r.Context(), linkID, user.ID)
+	if err != nil {
+		code := http.StatusForbidden
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to access shared link: %v", err), code)
+		return
+	}
+	json.NewEncoder(w).Encode(view)
+}
+
+// getMySharedLinks lists the links the current user created, with usage stats.
+func (h *HTTPHandler) getMySharedLinks(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	links, err := h.usecase.GetMySharedLinks(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to access shared link: %v", err), http.StatusForbidden)
+		http.Error(w, fmt.Sprintf("Failed to get shared links: %v", err), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"shares": links})
+}
+
+// revokeSharedLink revokes a link created by the current user; admins may revoke any link.
+func (h *HTTPHandler) revokeSharedLink(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	linkID := mux.Vars(r)["linkId"]
+
+	if err := h.usecase.RevokeSharedLink(r.Context(), linkID, user); err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "insufficient permissions"):
+			code = http.StatusForbidden
+		}
+		http.Error(w, fmt.Sprintf("Failed to revoke shared link: %v", err), code)
 		return
 	}
-	json.NewEncoder(w).Encode(test)
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // getInbox returns the user's inbox of shared tests.
@@ -444,6 +1614,32 @@ func (h *HTTPHandler) getInbox(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{"inbox": inbox})
 }
 
+// getInboxSummary returns the user's unread count and newest inbox items,
+// as many as the limit query parameter asks for.
+func (h *HTTPHandler) getInboxSummary(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v < 1 || v > masterUsecase.MaxInboxSummaryItems {
+			http.Error(w, fmt.Sprintf("Invalid limit: must be between 1 and %d", masterUsecase.MaxInboxSummaryItems), http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+	summary, err := h.usecase.GetInboxSummary(r.Context(), user.ID, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get inbox summary: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
 // markInboxItemRead marks a shared inbox item as read for the user.
 func (h *HTTPHandler) markInboxItemRead(w http.ResponseWriter, r *http.Request) {
 	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
@@ -463,3 +1659,165 @@ func (h *HTTPHandler) markInboxItemRead(w http.ResponseWriter, r *http.Request)
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// uploadAttachment stores a file sent as the "file" field of a multipart form.
+func (h *HTTPHandler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, masterUsecase.MaxAttachmentSize+1<<20) // Allow for multipart overhead
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid upload: %v", err), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.usecase.UploadAttachment(r.Context(), user.ID, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "too large") {
+			code = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("Failed to upload attachment: %v", err), code)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// getAttachments lists the current user's attachments.
+func (h *HTTPHandler) getAttachments(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	attachments, err := h.usecase.GetAttachmentsByUser(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get attachments: %v", err), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(attachments)
+}
+
+// getAttachment returns an attachment's metadata.
+func (h *HTTPHandler) getAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID := mux.Vars(r)["attachmentId"]
+
+	attachment, err := h.usecase.GetAttachment(r.Context(), attachmentID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to get attachment: %v", err), code)
+		return
+	}
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// deleteAttachment removes an attachment owned by the current user.
+func (h *HTTPHandler) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	attachmentID := mux.Vars(r)["attachmentId"]
+
+	if err := h.usecase.DeleteAttachment(r.Context(), attachmentID, user); err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = http.StatusNotFound
+		case strings.Contains(err.Error(), "insufficient permissions"):
+			code = http.StatusForbidden
+		}
+		http.Error(w, fmt.Sprintf("Failed to delete attachment: %v", err), code)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getTeams lists every team with its members.
+func (h *HTTPHandler) getTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.usecase.GetTeams(r.Context())
+	if err != nil {
+		writeTeamError(w, "Failed to get teams", err)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"teams": teams})
+}
+
+// getTeam returns a team with its members.
+func (h *HTTPHandler) getTeam(w http.ResponseWriter, r *http.Request) {
+	team, err := h.usecase.GetTeam(r.Context(), mux.Vars(r)["teamId"])
+	if err != nil {
+		writeTeamError(w, "Failed to get team", err)
+		return
+	}
+	json.NewEncoder(w).Encode(team)
+}
+
+// createTeam creates a team from a name, description and member user IDs.
+func (h *HTTPHandler) createTeam(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(userContextKey).(*domain.UserProfile)
+	var req domain.TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	team, err := h.usecase.CreateTeam(r.Context(), req, user.ID)
+	if err != nil {
+		writeTeamError(w, "Failed to create team", err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(team)
+}
+
+// updateTeam replaces a team's name, description and members.
+func (h *HTTPHandler) updateTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	team, err := h.usecase.UpdateTeam(r.Context(), mux.Vars(r)["teamId"], req)
+	if err != nil {
+		writeTeamError(w, "Failed to update team", err)
+		return
+	}
+	json.NewEncoder(w).Encode(team)
+}
+
+// deleteTeam removes a team.
+func (h *HTTPHandler) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	if err := h.usecase.DeleteTeam(r.Context(), mux.Vars(r)["teamId"]); err != nil {
+		writeTeamError(w, "Failed to delete team", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTeamError maps a team usecase error to an HTTP status.
+func writeTeamError(w http.ResponseWriter, message string, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case strings.Contains(err.Error(), "invalid team"), strings.Contains(err.Error(), "unknown team member"):
+		code = http.StatusBadRequest
+	case strings.Contains(err.Error(), "not found"):
+		code = http.StatusNotFound
+	case strings.Contains(err.Error(), "already exists"):
+		code = http.StatusConflict
+	case strings.Contains(err.Error(), "not enabled"):
+		code = http.StatusNotImplemented
+	}
+	http.Error(w, fmt.Sprintf("%s: %v", message, err), code)
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// getQueue lists the tests waiting for workers, in the order they will run.
+func (h *HTTPHandler) getQueue(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"queue": h.usecase.GetQueue(r.Context(), user)})
+}
+
+// cancelQueuedTest removes a test of the current user from the queue; admins
+// may remove any test.
+func (h *HTTPHandler) cancelQueuedTest(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	if err := h.usecase.CancelQueuedTest(r.Context(), mux.Vars(r)["testId"], user); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to remove test from the queue: %v", err), queueErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// promoteQueuedTest raises the priority of a queued test, by one level or to
+// the priority given in the body.
+func (h *HTTPHandler) promoteQueuedTest(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		Priority string `json:"priority"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+	}
+
+	testID := mux.Vars(r)["testId"]
+	priority, err := h.usecase.PromoteQueuedTest(r.Context(), testID, req.Priority, user)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to promote test: %v", err), queueErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"testId": testID, "priority": priority})
+}
+
+// queueErrorStatus maps a queue management error to its HTTP status.
+func queueErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "not found"), strings.Contains(err.Error(), "not queued"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "insufficient permissions"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "already gathering workers"):
+		return http.StatusConflict
+	case strings.Contains(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// getRollout reports the rolling worker upgrade in progress, or the last one.
+func (h *HTTPHandler) getRollout(w http.ResponseWriter, r *http.Request) {
+	rollout := h.usecase.GetRollout()
+	if rollout == nil {
+		http.Error(w, "No rollout has been started", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rollout)
+}
+
+// startRollout starts upgrading the workers to a target version in batches.
+func (h *HTTPHandler) startRollout(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		TargetVersion  string `json:"targetVersion"`
+		BatchSize      int    `json:"batchSize"`
+		BatchTimeout   string `json:"batchTimeout"` // Go duration such as "15m"
+		RestartWorkers bool   `json:"restartWorkers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	options := domain.RolloutOptions{
+		TargetVersion:  req.TargetVersion,
+		BatchSize:      req.BatchSize,
+		RestartWorkers: req.RestartWorkers,
+	}
+	if req.BatchTimeout != "" {
+		timeout, err := time.ParseDuration(req.BatchTimeout)
+		if err != nil {
+			http.Error(w, "Invalid batchTimeout (expected a duration such as 15m)", http.StatusBadRequest)
+			return
+		}
+		options.BatchTimeout = timeout
+	}
+
+	rollout, err := h.usecase.StartRollout(r.Context(), options, user)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, domain.ErrRolloutInProgress):
+			status = http.StatusConflict
+		case strings.Contains(err.Error(), "failed to list workers"):
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, fmt.Sprintf("Failed to start rollout: %v", err), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(rollout)
+}
+
+// cancelRollout stops the rollout in progress.
+func (h *HTTPHandler) cancelRollout(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	if err := h.usecase.CancelRollout(r.Context(), user); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to cancel rollout: %v", err), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// transferTests gives tests to another user (admin only), either the listed
+// ones or every test of a user, typically one who is leaving.
+func (h *HTTPHandler) transferTests(w http.ResponseWriter, r *http.Request) {
+	var req domain.TestTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if req.ToUserID != "" {
+		owner, err := h.userUsecase.GetUserProfile(r.Context(), req.ToUserID)
+		if err != nil {
+			http.Error(w, "toUserId: user not found", http.StatusBadRequest)
+			return
+		}
+		if !owner.IsActive {
+			http.Error(w, "toUserId: user is deactivated", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.usecase.TransferTests(r.Context(), req)
+	if err != nil {
+		if result == nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
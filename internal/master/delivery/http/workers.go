@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// listWorkers lists every registered worker with full detail.
+func (h *HTTPHandler) listWorkers(w http.ResponseWriter, r *http.Request) {
+	workers, err := h.usecase.ListWorkers(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list workers: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"workers": workers})
+}
+
+// forceWorkerOffline marks a worker offline, such as a zombie that stopped
+// running tests but was never detected as gone.
+func (h *HTTPHandler) forceWorkerOffline(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	if err := h.usecase.ForceWorkerOffline(r.Context(), mux.Vars(r)["workerId"], user); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to force worker offline: %v", err), workerErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeWorker deletes the registration of an offline worker.
+func (h *HTTPHandler) removeWorker(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value(userContextKey).(*domain.UserProfile)
+	if !ok {
+		http.Error(w, "Unauthorized: User not found in context", http.StatusUnauthorized)
+		return
+	}
+	if err := h.usecase.RemoveWorker(r.Context(), mux.Vars(r)["workerId"], user); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to remove worker: %v", err), workerErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pingWorker checks that a worker answers at its registered address.
+func (h *HTTPHandler) pingWorker(w http.ResponseWriter, r *http.Request) {
+	ping, err := h.usecase.PingWorker(r.Context(), mux.Vars(r)["workerId"])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Ping failed: %v", err), workerErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ping)
+}
+
+// workerErrorStatus maps worker management errors to HTTP status codes.
+func workerErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "did not answer"), strings.Contains(err.Error(), "not connected"):
+		return http.StatusBadGateway
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "force it offline"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// getRuntimeStats reports the master's goroutines, worker connections and
+// in-memory test state, to spot leaks.
+func (h *HTTPHandler) getRuntimeStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.usecase.RuntimeStats())
+}
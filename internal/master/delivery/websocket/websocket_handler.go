@@ -5,23 +5,37 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/pace-noge/distributed-load-tester/internal/infrastructure/auth"
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
 	masterUsecase "github.com/pace-noge/distributed-load-tester/internal/master/usecase"
+	userUsecase "github.com/pace-noge/distributed-load-tester/internal/user/usecase"
+)
+
+// broadcastBuffer is how many outgoing messages the hub queues before
+// dropping new ones.
+const broadcastBuffer = 256
+
+// Topics a client can subscribe to. Test and worker topics are followed by
+// an ID, as in "test:<id>".
+const (
+	topicDashboard    = "dashboard"
+	topicTestPrefix   = "test:"
+	topicWorkerPrefix = "worker:"
 )
 
 // WebSocketHandler handles WebSocket connections for real-time dashboard updates
 type WebSocketHandler struct {
 	masterUsecase *masterUsecase.MasterUsecase
-	jwtSecretKey  string
+	userUsecase   *userUsecase.UserUsecase // Validates the connections' tokens
 	upgrader      websocket.Upgrader
-	clients       map[*websocket.Conn]bool
-	broadcast     chan []byte
-	register      chan *websocket.Conn
-	unregister    chan *websocket.Conn
+	clients       map[*client]bool
+	broadcast     chan outgoingMessage
+	register      chan *client
+	unregister    chan *client
 	mu            sync.RWMutex
 }
 
@@ -31,21 +45,116 @@ type DashboardMessage struct {
 	Data interface{} `json:"data"`
 }
 
+// SubscriptionRequest is sent by clients to choose which updates they receive.
+// Either field holds one topic: "dashboard", "test:<id>" or "worker:<id>".
+type SubscriptionRequest struct {
+	Subscribe   string `json:"subscribe,omitempty"`
+	Unsubscribe string `json:"unsubscribe,omitempty"`
+}
+
+// outgoingMessage is an encoded message and the topics it belongs to.
+type outgoingMessage struct {
+	data   []byte
+	topics []string
+	// Sent to clients that never subscribed, which receive dashboard
+	// snapshots and state events but not per-second metrics
+	unsubscribed bool
+	// Set for messages meant for one user's connections only, whatever
+	// their subscriptions
+	userID string
+}
+
+// client is one WebSocket connection and the topics it subscribed to.
+type client struct {
+	conn    *websocket.Conn
+	userID  string     // The user the connection's token belongs to
+	writeMu sync.Mutex // gorilla/websocket allows one concurrent writer
+
+	mu     sync.RWMutex
+	topics map[string]bool // nil until the first subscribe message
+}
+
+// write sends one message to the client.
+func (c *client) write(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// wants reports whether message should be sent to the client.
+func (c *client) wants(message outgoingMessage) bool {
+	if message.userID != "" {
+		return message.userID == c.userID
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.topics == nil {
+		return message.unsubscribed
+	}
+	for _, topic := range message.topics {
+		if c.topics[topic] {
+			return true
+		}
+	}
+	return false
+}
+
+// updateSubscription applies a subscription request. Subscribing for the
+// first time replaces the default of dashboard snapshots and all events.
+func (c *client) updateSubscription(req SubscriptionRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.topics == nil {
+		c.topics = make(map[string]bool)
+	}
+	if req.Subscribe != "" {
+		c.topics[req.Subscribe] = true
+	}
+	if req.Unsubscribe != "" {
+		delete(c.topics, req.Unsubscribe)
+	}
+}
+
+// validTopic reports whether topic names something a client can subscribe to.
+func validTopic(topic string) bool {
+	if topic == topicDashboard {
+		return true
+	}
+	for _, prefix := range []string{topicTestPrefix, topicWorkerPrefix} {
+		if strings.HasPrefix(topic, prefix) && len(topic) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTopics returns the topics an event is delivered on.
+func eventTopics(event domain.Event) []string {
+	var topics []string
+	if event.TestID != "" {
+		topics = append(topics, topicTestPrefix+event.TestID)
+	}
+	if event.WorkerID != "" {
+		topics = append(topics, topicWorkerPrefix+event.WorkerID)
+	}
+	return topics
+}
+
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(masterUC *masterUsecase.MasterUsecase, jwtSecretKey string) *WebSocketHandler {
+func NewWebSocketHandler(masterUC *masterUsecase.MasterUsecase, userUC *userUsecase.UserUsecase) *WebSocketHandler {
 	return &WebSocketHandler{
 		masterUsecase: masterUC,
-		jwtSecretKey:  jwtSecretKey,
+		userUsecase:   userUC,
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// In production, you should check the origin properly
 				return true
 			},
 		},
-		clients:    make(map[*websocket.Conn]bool),
-		broadcast:  make(chan []byte),
-		register:   make(chan *websocket.Conn),
-		unregister: make(chan *websocket.Conn),
+		clients:    make(map[*client]bool),
+		broadcast:  make(chan outgoingMessage, broadcastBuffer),
+		register:   make(chan *client),
+		unregister: make(chan *client),
 	}
 }
 
@@ -54,46 +163,64 @@ func (h *WebSocketHandler) StartHub(ctx context.Context) {
 	// Start the periodic dashboard update broadcaster
 	go h.startDashboardBroadcaster(ctx)
 
+	// Push test and worker events as they happen to the clients following
+	// them. State changes also refresh the dashboard; metrics are too
+	// frequent and only go to subscribers of the test or worker.
+	h.masterUsecase.Events().Subscribe("websocket", func(event domain.Event) {
+		if ctx.Err() != nil {
+			return
+		}
+		if event.Type == domain.EventInboxUpdated {
+			h.sendInboxUpdates(ctx, event.UserIDs)
+			return
+		}
+		isMetrics := event.Type == domain.EventTestMetrics
+		h.broadcastMessage(ctx, DashboardMessage{Type: "event", Data: event}, eventTopics(event), !isMetrics)
+		if !isMetrics {
+			h.broadcastDashboard(ctx)
+		}
+	})
+
 	for {
 		select {
 		case <-ctx.Done():
 			log.Println("WebSocket hub shutting down")
 			return
-		case client := <-h.register:
+		case c := <-h.register:
 			h.mu.Lock()
-			h.clients[client] = true
+			h.clients[c] = true
 			h.mu.Unlock()
 			log.Printf("WebSocket client registered. Total clients: %d", len(h.clients))
 
 			// Send initial dashboard data to new client
-			h.sendDashboardDataToClient(client)
+			h.sendDashboardDataToClient(c)
 
-		case client := <-h.unregister:
+		case c := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				client.Close()
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				c.conn.Close()
 			}
 			h.mu.Unlock()
 			log.Printf("WebSocket client unregistered. Total clients: %d", len(h.clients))
 
-		case data := <-h.broadcast:
-			h.mu.RLock()
-			for client := range h.clients {
-				select {
-				case <-ctx.Done():
-					h.mu.RUnlock()
+		case message := <-h.broadcast:
+			h.mu.Lock()
+			for c := range h.clients {
+				if ctx.Err() != nil {
+					h.mu.Unlock()
 					return
-				default:
-					err := client.WriteMessage(websocket.TextMessage, data)
-					if err != nil {
-						log.Printf("Error writing to WebSocket client: %v", err)
-						client.Close()
-						delete(h.clients, client)
-					}
+				}
+				if !c.wants(message) {
+					continue
+				}
+				if err := c.write(websocket.TextMessage, message.data); err != nil {
+					log.Printf("Error writing to WebSocket client: %v", err)
+					c.conn.Close()
+					delete(h.clients, c)
 				}
 			}
-			h.mu.RUnlock()
+			h.mu.Unlock()
 		}
 	}
 }
@@ -107,9 +234,14 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	_, err := auth.ValidateJWT(token)
+	user, err := h.userUsecase.ValidateJWTToken(r.Context(), token)
 	if err != nil {
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		log.Printf("WebSocket token validation failed: %v", err)
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+	if user.MustChangePassword {
+		http.Error(w, "Password change required", http.StatusForbidden)
 		return
 	}
 
@@ -121,11 +253,12 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Register the new client
-	h.register <- conn
+	c := &client{conn: conn, userID: user.ID}
+	h.register <- c
 
 	// Handle client disconnection and cleanup
 	defer func() {
-		h.unregister <- conn
+		h.unregister <- c
 	}()
 
 	// Handle incoming messages from client and keep connection alive
@@ -145,7 +278,7 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		for {
 			select {
 			case <-ticker.C:
-				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				if err := c.write(websocket.PingMessage, nil); err != nil {
 					log.Printf("Error sending ping to WebSocket client: %v", err)
 					return
 				}
@@ -155,13 +288,54 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 
 	// Read loop for incoming messages
 	for {
-		_, _, err := conn.ReadMessage()
+		_, data, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
+		h.handleClientMessage(c, data)
+	}
+}
+
+// handleClientMessage applies a subscription request from a client and
+// acknowledges it, or reports why it was rejected.
+func (h *WebSocketHandler) handleClientMessage(c *client, data []byte) {
+	var req SubscriptionRequest
+	if err := json.Unmarshal(data, &req); err != nil || (req.Subscribe == "" && req.Unsubscribe == "") {
+		h.sendToClient(c, DashboardMessage{Type: "error", Data: map[string]string{
+			"message": `expected {"subscribe": "<topic>"} or {"unsubscribe": "<topic>"}`,
+		}})
+		return
+	}
+	for _, topic := range []string{req.Subscribe, req.Unsubscribe} {
+		if topic != "" && !validTopic(topic) {
+			h.sendToClient(c, DashboardMessage{Type: "error", Data: map[string]string{
+				"message": "unknown topic " + topic + `: use "dashboard", "test:<id>" or "worker:<id>"`,
+			}})
+			return
+		}
+	}
+
+	c.updateSubscription(req)
+	if req.Subscribe != "" {
+		h.sendToClient(c, DashboardMessage{Type: "subscribed", Data: map[string]string{"topic": req.Subscribe}})
+	}
+	if req.Unsubscribe != "" {
+		h.sendToClient(c, DashboardMessage{Type: "unsubscribed", Data: map[string]string{"topic": req.Unsubscribe}})
+	}
+}
+
+// sendToClient sends a message to a single client.
+func (h *WebSocketHandler) sendToClient(c *client, message DashboardMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling %s message for client: %v", message.Type, err)
+		return
+	}
+	if err := c.write(websocket.TextMessage, data); err != nil {
+		log.Printf("Error sending %s message to WebSocket client: %v", message.Type, err)
 	}
 }
 
@@ -175,43 +349,89 @@ func (h *WebSocketHandler) startDashboardBroadcaster(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			dashboardData, err := h.masterUsecase.GetDashboardStatus(ctx)
-			if err != nil {
-				log.Printf("Error fetching dashboard data for broadcast: %v", err)
-				continue
-			}
+			h.broadcastDashboard(ctx)
+		}
+	}
+}
 
-			message := DashboardMessage{
-				Type: "dashboard_update",
-				Data: dashboardData,
-			}
+// broadcastDashboard fetches dashboard data and broadcasts it to all clients
+func (h *WebSocketHandler) broadcastDashboard(ctx context.Context) {
+	dashboardData, err := h.masterUsecase.GetDashboardStatus(ctx)
+	if err != nil {
+		log.Printf("Error fetching dashboard data for broadcast: %v", err)
+		return
+	}
+	h.broadcastMessage(ctx, DashboardMessage{
+		Type: "dashboard_update",
+		Data: dashboardData,
+	}, []string{topicDashboard}, true)
+}
 
-			data, err := json.Marshal(message)
-			if err != nil {
-				log.Printf("Error marshaling dashboard data for broadcast: %v", err)
-				continue
-			}
+// broadcastMessage sends a message to the clients subscribed to any of its
+// topics, and to clients without subscriptions when unsubscribed is set.
+// It is skipped when there are no clients or the hub is busy.
+func (h *WebSocketHandler) broadcastMessage(ctx context.Context, message DashboardMessage, topics []string, unsubscribed bool) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling %s message for broadcast: %v", message.Type, err)
+		return
+	}
 
-			// Only broadcast if there are connected clients
-			h.mu.RLock()
-			if len(h.clients) > 0 {
-				select {
-				case h.broadcast <- data:
-				case <-ctx.Done():
-					h.mu.RUnlock()
-					return
-				default:
-					// Broadcast channel is full, skip this update
-					log.Println("Broadcast channel full, skipping dashboard update")
-				}
-			}
-			h.mu.RUnlock()
+	// Only broadcast if there are connected clients
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.clients) > 0 {
+		select {
+		case h.broadcast <- outgoingMessage{data: data, topics: topics, unsubscribed: unsubscribed}:
+		case <-ctx.Done():
+		default:
+			// Broadcast channel is full, skip this update
+			log.Printf("Broadcast channel full, skipping %s message", message.Type)
+		}
+	}
+}
+
+// sendInboxUpdates sends each user's connections an inbox_update message with
+// their new inbox summary, for notification badges.
+func (h *WebSocketHandler) sendInboxUpdates(ctx context.Context, userIDs []string) {
+	for _, userID := range userIDs {
+		if !h.hasUserClients(userID) {
+			continue
+		}
+		summary, err := h.masterUsecase.GetInboxSummary(ctx, userID, 0)
+		if err != nil {
+			log.Printf("Error fetching inbox summary of user %s: %v", userID, err)
+			continue
+		}
+		data, err := json.Marshal(DashboardMessage{Type: "inbox_update", Data: summary})
+		if err != nil {
+			log.Printf("Error marshaling inbox_update message: %v", err)
+			continue
+		}
+		select {
+		case h.broadcast <- outgoingMessage{data: data, userID: userID}:
+		case <-ctx.Done():
+			return
+		default:
+			log.Printf("Broadcast channel full, skipping inbox_update message")
+		}
+	}
+}
+
+// hasUserClients reports whether a user has any open connection.
+func (h *WebSocketHandler) hasUserClients(userID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients {
+		if c.userID == userID {
+			return true
 		}
 	}
+	return false
 }
 
 // sendDashboardDataToClient sends initial dashboard data to a specific client
-func (h *WebSocketHandler) sendDashboardDataToClient(client *websocket.Conn) {
+func (h *WebSocketHandler) sendDashboardDataToClient(c *client) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -232,7 +452,7 @@ func (h *WebSocketHandler) sendDashboardDataToClient(client *websocket.Conn) {
 		return
 	}
 
-	err = client.WriteMessage(websocket.TextMessage, data)
+	err = c.write(websocket.TextMessage, data)
 	if err != nil {
 		log.Printf("Error sending initial dashboard data to new client: %v", err)
 	}
@@ -252,7 +472,7 @@ func (h *WebSocketHandler) BroadcastTestUpdate(testUpdate interface{}) {
 	}
 
 	select {
-	case h.broadcast <- data:
+	case h.broadcast <- outgoingMessage{data: data, unsubscribed: true}:
 	default:
 		log.Println("Broadcast channel full, skipping test update")
 	}
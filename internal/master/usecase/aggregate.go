@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// aggregateResults combines the raw results of a test's workers. Latencies are
+// weighted by each worker's request count, the duration is the wall-clock span
+// from the earliest worker start to the latest worker end, and throughput is
+// the test's total requests over that span. The workers' Apdex counts are
+// summed into the test's score.
+func aggregateResults(testID string, results []*domain.TestResult) *domain.TestResultAggregated {
+	var totalRequests, successfulRequests, failedRequests int64
+	var weightedLatencyMs float64
+	var p95s []weightedValue
+	var start, end time.Time
+	errorRates := make(map[string]int) // Map of error types/status codes to counts
+
+	for _, res := range results {
+		successful, failed := res.SuccessCounts()
+		totalRequests += res.TotalRequests
+		successfulRequests += successful
+		failedRequests += failed
+
+		weightedLatencyMs += res.AverageLatencyMs * float64(res.TotalRequests)
+		p95s = append(p95s, weightedValue{value: res.P95LatencyMs, weight: res.TotalRequests})
+
+		resStart, resEnd := resultSpan(res)
+		if start.IsZero() || resStart.Before(start) {
+			start = resStart
+		}
+		if resEnd.After(end) {
+			end = resEnd
+		}
+
+		// Parse status codes
+		for code, count := range res.StatusCodes {
+			if code[0] != '2' { // Assuming 2xx are successful
+				errorRates[code] += count
+			}
+		}
+	}
+
+	avgLatencyMs := 0.0
+	if totalRequests > 0 {
+		avgLatencyMs = weightedLatencyMs / float64(totalRequests)
+	}
+
+	wallClock := end.Sub(start)
+	throughput := 0.0
+	if wallClock > 0 {
+		throughput = float64(totalRequests) / wallClock.Seconds()
+	}
+
+	overallStatus := "COMPLETED_SUCCESS"
+	if failedRequests > 0 {
+		overallStatus = "COMPLETED_WITH_ERRORS"
+	}
+
+	return &domain.TestResultAggregated{
+		TestID:             testID,
+		TotalRequests:      totalRequests,
+		SuccessfulRequests: successfulRequests,
+		FailedRequests:     failedRequests,
+		AvgLatencyMs:       avgLatencyMs,
+		P95LatencyMs:       weightedPercentile(p95s, 0.95),
+		ErrorRates:         errorRates,
+		DurationMs:         wallClock.Milliseconds(),
+		ThroughputRPS:      throughput,
+		OverallStatus:      overallStatus,
+		CompletedAt:        time.Now(),
+		Apdex:              domain.MergeApdex(resultApdex(results)),
+	}
+}
+
+// resultSpan returns when a worker's attack started and when its last response
+// arrived. Vegeta records both in the stored metrics; older or foreign metrics
+// fall back to the submission timestamp minus the attack duration.
+func resultSpan(res *domain.TestResult) (time.Time, time.Time) {
+	var metric struct {
+		Earliest time.Time `json:"earliest"`
+		End      time.Time `json:"end"`
+	}
+	if err := json.Unmarshal(res.Metric, &metric); err == nil && !metric.Earliest.IsZero() && !metric.End.IsZero() {
+		return metric.Earliest, metric.End
+	}
+	return res.Timestamp.Add(-time.Duration(res.DurationMs) * time.Millisecond), res.Timestamp
+}
+
+// weightedValue is a per-worker statistic weighted by its request count.
+type weightedValue struct {
+	value  float64
+	weight int64
+}
+
+// weightedPercentile returns the value below which fraction p of the total
+// weight falls. Per-worker percentiles can't be merged exactly without the
+// raw latencies, so this approximates the test-wide percentile, letting
+// workers that sent more requests count for more.
+func weightedPercentile(values []weightedValue, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].value < values[j].value })
+
+	var total int64
+	for _, v := range values {
+		total += v.weight
+	}
+	if total == 0 {
+		return values[int(p*float64(len(values)-1))].value
+	}
+
+	threshold := p * float64(total)
+	var cumulative int64
+	for _, v := range values {
+		cumulative += v.weight
+		if float64(cumulative) >= threshold {
+			return v.value
+		}
+	}
+	return values[len(values)-1].value
+}
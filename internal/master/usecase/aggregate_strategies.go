@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Names of the built-in aggregate strategies.
+const (
+	AggregateApdex       = "apdex"        // Apdex score of the test's latencies against a threshold
+	AggregateTrimmedMean = "trimmed_mean" // Mean latency without the fastest and slowest requests
+	AggregatePerMinute   = "per_minute"   // Requests, errors and latency in one-minute buckets
+)
+
+// BuiltinAggregateStrategies lists the built-in aggregate strategies, which
+// are all enabled unless the master is told otherwise.
+var BuiltinAggregateStrategies = []string{AggregateApdex, AggregateTrimmedMean, AggregatePerMinute}
+
+// AggregateStrategyOptions tune the built-in aggregate strategies.
+type AggregateStrategyOptions struct {
+	ApdexThreshold time.Duration // Responses this fast satisfy; up to four times it they are tolerated
+	TrimFraction   float64       // Share of requests the trimmed mean drops from each end, below 0.5
+}
+
+// RegisterBuiltinAggregateStrategies registers the built-in aggregate
+// strategies in registry.
+func RegisterBuiltinAggregateStrategies(registry *domain.AggregateStrategyRegistry, opts AggregateStrategyOptions) error {
+	if opts.ApdexThreshold <= 0 {
+		return fmt.Errorf("invalid apdex threshold %v: must be positive", opts.ApdexThreshold)
+	}
+	if opts.TrimFraction < 0 || opts.TrimFraction >= 0.5 {
+		return fmt.Errorf("invalid trim fraction %v: must be at least 0 and below 0.5", opts.TrimFraction)
+	}
+	builtins := map[string]domain.AggregateStrategy{
+		AggregateApdex:       apdexStrategy{threshold: opts.ApdexThreshold},
+		AggregateTrimmedMean: trimmedMeanStrategy{fraction: opts.TrimFraction},
+		AggregatePerMinute:   domain.AggregateStrategyFunc(aggregatePerMinute),
+	}
+	for name, strategy := range builtins {
+		if err := registry.Register(name, strategy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregatePipeline is the aggregate strategies run each time a test is aggregated.
+type aggregatePipeline struct {
+	names      []string
+	strategies []domain.AggregateStrategy
+	repo       domain.AggregateDocumentRepository
+}
+
+// SetAggregateStrategies makes every aggregation of a test also compute the
+// aggregates named in enabled, from the strategies in registry, and save
+// each in repo as a document of that name.
+func (uc *MasterUsecase) SetAggregateStrategies(registry *domain.AggregateStrategyRegistry, enabled []string, repo domain.AggregateDocumentRepository) error {
+	pipeline := aggregatePipeline{repo: repo}
+	for _, name := range enabled {
+		strategy, err := registry.Get(name)
+		if err != nil {
+			return fmt.Errorf("%w (available: %v)", err, registry.Names())
+		}
+		pipeline.names = append(pipeline.names, name)
+		pipeline.strategies = append(pipeline.strategies, strategy)
+	}
+	uc.aggregates = pipeline
+	return nil
+}
+
+// runAggregateStrategies computes and saves the enabled aggregates of a test
+// whose fixed aggregate is summary. A failing strategy is logged and doesn't
+// keep the others from running.
+func (uc *MasterUsecase) runAggregateStrategies(ctx context.Context, testID string, results []*domain.TestResult, summary *domain.TestResultAggregated) {
+	if len(uc.aggregates.strategies) == 0 {
+		return
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		log.Printf("Warning: Skipping aggregate strategies of test %s: %v", testID, err)
+		return
+	}
+	input := &domain.AggregateInput{
+		Test:    test,
+		Results: results,
+		Summary: summary,
+		TimeSeries: func(ctx context.Context, interval time.Duration) ([]domain.TimeSeriesPoint, error) {
+			uc.flushMetrics(ctx) // Include the progress samples still waiting for the flush job
+			return uc.metricsRepo.GetTimeSeries(ctx, testID, interval)
+		},
+	}
+
+	for i, strategy := range uc.aggregates.strategies {
+		name := uc.aggregates.names[i]
+		document, err := strategy.Aggregate(ctx, input)
+		if err != nil {
+			log.Printf("Warning: Aggregate %s of test %s failed: %v", name, testID, err)
+			continue
+		}
+		if document == nil {
+			continue
+		}
+		data, err := json.Marshal(document)
+		if err != nil {
+			log.Printf("Warning: Failed to encode aggregate %s of test %s: %v", name, testID, err)
+			continue
+		}
+		err = uc.aggregates.repo.SaveAggregateDocument(ctx, &domain.AggregateDocument{
+			TestID: testID, Name: name, Document: data, ComputedAt: time.Now(),
+		})
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// GetAggregateDocuments returns a test's named aggregates: its fixed summary
+// followed by those of the enabled strategies, as last computed.
+func (uc *MasterUsecase) GetAggregateDocuments(ctx context.Context, testID string) ([]*domain.AggregateDocument, error) {
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+	docs := []*domain.AggregateDocument{}
+	summary, err := uc.aggregatedResultRepo.GetAggregatedResultByTestID(ctx, testID)
+	if err == nil && summary != nil {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode summary: %w", err)
+		}
+		docs = append(docs, &domain.AggregateDocument{
+			TestID: testID, Name: domain.AggregateSummary, Document: data, ComputedAt: summary.CompletedAt,
+		})
+	}
+	if uc.aggregates.repo == nil {
+		return docs, nil
+	}
+	named, err := uc.aggregates.repo.GetAggregateDocuments(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	return append(docs, named...), nil
+}
+
+// GetAggregateDocument returns one of a test's named aggregates.
+func (uc *MasterUsecase) GetAggregateDocument(ctx context.Context, testID, name string) (*domain.AggregateDocument, error) {
+	docs, err := uc.GetAggregateDocuments(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if doc.Name == name {
+			return doc, nil
+		}
+	}
+	return nil, fmt.Errorf("aggregate %q of test %s not found", name, testID)
+}
+
+// apdexStrategy scores a test's latencies with Apdex: (satisfied + tolerating/2) / requests.
+// Workers count the requests they classify against the test's threshold;
+// for results without counts, such as those of older workers, only latency
+// percentiles are known, so the share of requests under a limit is
+// interpolated between them. Failed requests always frustrate.
+type apdexStrategy struct {
+	threshold time.Duration // Used for tests stored without a threshold of their own
+}
+
+type apdexAggregate struct {
+	ThresholdMs float64 `json:"thresholdMs"`
+	Score       float64 `json:"score"`
+	Rating      string  `json:"rating"` // excellent, good, fair, poor or unacceptable
+	domain.ApdexCounts
+	Estimated bool `json:"estimated"` // Some counts were interpolated from latency percentiles
+}
+
+func (s apdexStrategy) Aggregate(ctx context.Context, input *domain.AggregateInput) (interface{}, error) {
+	threshold := s.threshold
+	if input.Test != nil && input.Test.Apdex != nil && input.Test.Apdex.Threshold != "" {
+		if d, err := time.ParseDuration(input.Test.Apdex.Threshold); err == nil {
+			threshold = d
+		}
+	}
+
+	var counts domain.ApdexCounts
+	estimated := false
+	for _, res := range input.Results {
+		if res.Apdex != nil {
+			counts.Satisfied += res.Apdex.Satisfied
+			counts.Tolerating += res.Apdex.Tolerating
+			counts.Frustrated += res.Apdex.Frustrated
+			continue
+		}
+		quantiles, ok := latencyQuantiles(res)
+		if !ok {
+			continue // No latency percentiles to place the requests by
+		}
+		successful, _ := res.SuccessCounts()
+		within := quantileShareBelow(quantiles, threshold)
+		withinTolerance := quantileShareBelow(quantiles, 4*threshold)
+		satisfied := int64(math.Round(float64(successful) * within))
+		tolerating := int64(math.Round(float64(successful) * (withinTolerance - within)))
+		counts.Satisfied += satisfied
+		counts.Tolerating += tolerating
+		counts.Frustrated += res.TotalRequests - satisfied - tolerating
+		estimated = true
+	}
+	if counts == (domain.ApdexCounts{}) {
+		return nil, nil
+	}
+
+	score := counts.Score()
+	return &apdexAggregate{
+		ThresholdMs: float64(threshold) / float64(time.Millisecond),
+		Score:       math.Round(score*1000) / 1000,
+		Rating:      domain.ApdexRating(score),
+		ApdexCounts: counts,
+		Estimated:   estimated,
+	}, nil
+}
+
+// quantile is a latency below which a share of a worker's requests fell.
+type quantile struct {
+	share   float64
+	latency time.Duration
+}
+
+// latencyQuantiles reads the latency percentiles vegeta stores in a worker's
+// metrics, from its fastest request to its slowest.
+func latencyQuantiles(res *domain.TestResult) ([]quantile, bool) {
+	var metric struct {
+		Latencies struct {
+			Min time.Duration `json:"min"`
+			P50 time.Duration `json:"50th"`
+			P90 time.Duration `json:"90th"`
+			P95 time.Duration `json:"95th"`
+			P99 time.Duration `json:"99th"`
+			Max time.Duration `json:"max"`
+		} `json:"latencies"`
+	}
+	if err := json.Unmarshal(res.Metric, &metric); err != nil || metric.Latencies.Max == 0 {
+		return nil, false
+	}
+	l := metric.Latencies
+	return []quantile{{0, l.Min}, {0.5, l.P50}, {0.9, l.P90}, {0.95, l.P95}, {0.99, l.P99}, {1, l.Max}}, true
+}
+
+// quantileShareBelow estimates the share of requests at most limit slow,
+// interpolating linearly between the quantiles around it.
+func quantileShareBelow(quantiles []quantile, limit time.Duration) float64 {
+	if limit < quantiles[0].latency {
+		return 0
+	}
+	for i := 1; i < len(quantiles); i++ {
+		lo, hi := quantiles[i-1], quantiles[i]
+		if limit >= hi.latency {
+			continue
+		}
+		span := hi.latency - lo.latency
+		if span <= 0 {
+			return lo.share
+		}
+		return lo.share + (hi.share-lo.share)*float64(limit-lo.latency)/float64(span)
+	}
+	return 1
+}
+
+// trimmedMeanStrategy averages the latency of a test's requests after
+// dropping a share of the fastest and of the slowest, so a few outliers such
+// as a cold start don't skew it. Latencies are known per second of progress
+// rather than per request, so whole seconds are ranked by their mean latency
+// and the seconds at the edges are trimmed, in part if need be.
+type trimmedMeanStrategy struct {
+	fraction float64
+}
+
+type trimmedMeanAggregate struct {
+	TrimFraction         float64 `json:"trimFraction"`
+	MeanLatencyMs        float64 `json:"meanLatencyMs"`
+	TrimmedMeanLatencyMs float64 `json:"trimmedMeanLatencyMs"`
+	Requests             int64   `json:"requests"`        // Requests in the progress metrics
+	TrimmedRequests      int64   `json:"trimmedRequests"` // Requests dropped from the two ends together
+}
+
+func (s trimmedMeanStrategy) Aggregate(ctx context.Context, input *domain.AggregateInput) (interface{}, error) {
+	points, err := input.TimeSeries(ctx, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	var seconds []weightedValue
+	var total int64
+	var weightedSum float64
+	for _, p := range points {
+		if p.Requests == 0 {
+			continue
+		}
+		seconds = append(seconds, weightedValue{value: p.MeanLatencyMs, weight: p.Requests})
+		total += p.Requests
+		weightedSum += p.MeanLatencyMs * float64(p.Requests)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	sort.Slice(seconds, func(i, j int) bool { return seconds[i].value < seconds[j].value })
+
+	// Keep the requests ranked between trim and total-trim
+	trim := s.fraction * float64(total)
+	lo, hi := trim, float64(total)-trim
+	var kept, keptSum, cumulative float64
+	for _, sec := range seconds {
+		start, end := cumulative, cumulative+float64(sec.weight)
+		cumulative = end
+		overlap := math.Min(end, hi) - math.Max(start, lo)
+		if overlap > 0 {
+			kept += overlap
+			keptSum += sec.value * overlap
+		}
+	}
+	trimmedMean := 0.0
+	if kept > 0 {
+		trimmedMean = keptSum / kept
+	}
+
+	return &trimmedMeanAggregate{
+		TrimFraction:         s.fraction,
+		MeanLatencyMs:        weightedSum / float64(total),
+		TrimmedMeanLatencyMs: trimmedMean,
+		Requests:             total,
+		TrimmedRequests:      total - int64(math.Round(kept)),
+	}, nil
+}
+
+type perMinuteAggregate struct {
+	IntervalMs int64                    `json:"intervalMs"`
+	Buckets    []domain.TimeSeriesPoint `json:"buckets"` // Minutes without requests are omitted
+}
+
+// aggregatePerMinute buckets a test's progress metrics by minute.
+func aggregatePerMinute(ctx context.Context, input *domain.AggregateInput) (interface{}, error) {
+	points, err := input.TimeSeries(ctx, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
+	return &perMinuteAggregate{IntervalMs: time.Minute.Milliseconds(), Buckets: points}, nil
+}
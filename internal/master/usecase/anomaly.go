@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Thresholds for flagging anomalies in the daily analytics trends. A day is
+// compared with an exponentially weighted moving average (EWMA) of the days
+// before it and flagged when it sits several weighted standard deviations
+// above it and has also risen by a minimum amount, so that an almost flat
+// series does not flag noise.
+const (
+	anomalyEWMAAlpha      = 0.3 // Weight of each new day in the baseline
+	anomalyZThreshold     = 3.0 // Standard deviations above the baseline
+	anomalyMinHistory     = 5   // Days with results needed before a day can be flagged
+	anomalyMinLatencyRise = 0.2 // Latency must also exceed the baseline by 20%
+	anomalyMinErrorRise   = 1.0 // Error rate must also exceed the baseline by 1 percentage point
+)
+
+// Metrics checked for anomalies.
+const (
+	AnomalyMetricLatency   = "latency"   // Request-weighted average latency, ms
+	AnomalyMetricErrorRate = "errorRate" // Failed requests, %
+)
+
+// dailyStats accumulates one day of aggregated results for trend analysis.
+type dailyStats struct {
+	date            string
+	latencySum      float64 // Average latency weighted by request count
+	latencyRequests int64
+	requests        int64
+	successful      int64
+	tests           []testTrendPoint
+}
+
+// testTrendPoint is one test's contribution to a day's trend.
+type testTrendPoint struct {
+	testID    string
+	latency   float64
+	errorRate float64
+}
+
+func (d *dailyStats) add(testID string, result *domain.TestResultAggregated) {
+	d.requests += result.TotalRequests
+	d.successful += result.SuccessfulRequests
+	if result.AvgLatencyMs > 0 {
+		d.latencySum += result.AvgLatencyMs * float64(result.TotalRequests)
+		d.latencyRequests += result.TotalRequests
+	}
+	d.tests = append(d.tests, testTrendPoint{testID: testID, latency: result.AvgLatencyMs, errorRate: errorRate(result.TotalRequests, result.SuccessfulRequests)})
+}
+
+func (d *dailyStats) latency() float64 {
+	if d.latencyRequests == 0 {
+		return 0
+	}
+	return d.latencySum / float64(d.latencyRequests)
+}
+
+func (d *dailyStats) errorRate() float64 {
+	return errorRate(d.requests, d.successful)
+}
+
+func errorRate(total, successful int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(total-successful) / float64(total) * 100
+}
+
+// performanceTrend returns the per-day latency and success rate of the days
+// that have results, oldest first.
+func performanceTrend(days []*dailyStats) []domain.PerformancePoint {
+	trend := make([]domain.PerformancePoint, 0, len(days))
+	for _, d := range days {
+		trend = append(trend, domain.PerformancePoint{
+			Date:         d.date,
+			ResponseTime: d.latency(),
+			SuccessRate:  100 - d.errorRate(),
+			RequestCount: d.requests,
+		})
+	}
+	return trend
+}
+
+// anomalyMetric is a daily trend checked for anomalies.
+type anomalyMetric struct {
+	name    string
+	day     func(*dailyStats) (float64, bool) // The day's value, if it has one
+	test    func(testTrendPoint) float64
+	minRise func(baseline float64) float64 // Smallest rise over the baseline worth flagging
+}
+
+var anomalyMetrics = []anomalyMetric{
+	{
+		name:    AnomalyMetricLatency,
+		day:     func(d *dailyStats) (float64, bool) { return d.latency(), d.latencyRequests > 0 },
+		test:    func(t testTrendPoint) float64 { return t.latency },
+		minRise: func(baseline float64) float64 { return baseline * anomalyMinLatencyRise },
+	},
+	{
+		name:    AnomalyMetricErrorRate,
+		day:     func(d *dailyStats) (float64, bool) { return d.errorRate(), d.requests > 0 },
+		test:    func(t testTrendPoint) float64 { return t.errorRate },
+		minRise: func(float64) float64 { return anomalyMinErrorRise },
+	},
+}
+
+// detectAnomalies flags the days, oldest first, whose latency or error rate
+// rose well above the trend of the days before them.
+func detectAnomalies(days []*dailyStats) []domain.AnalyticsAnomaly {
+	anomalies := []domain.AnalyticsAnomaly{}
+	for _, metric := range anomalyMetrics {
+		anomalies = append(anomalies, metric.detect(days)...)
+	}
+	sort.SliceStable(anomalies, func(i, j int) bool { return anomalies[i].Date < anomalies[j].Date })
+	return anomalies
+}
+
+// detect runs the EWMA check over the metric's daily values.
+func (m anomalyMetric) detect(days []*dailyStats) []domain.AnalyticsAnomaly {
+	var anomalies []domain.AnalyticsAnomaly
+	var mean, variance float64
+	history := 0
+	for _, d := range days {
+		x, ok := m.day(d)
+		if !ok {
+			continue
+		}
+		if history == 0 {
+			mean = x
+		} else if history >= anomalyMinHistory {
+			// Flooring the deviation makes the z-score threshold also
+			// enforce the minimum rise on a steady series
+			stddev := math.Max(math.Sqrt(variance), m.minRise(mean)/anomalyZThreshold)
+			if stddev > 0 {
+				if z := (x - mean) / stddev; z >= anomalyZThreshold {
+					anomalies = append(anomalies, domain.AnalyticsAnomaly{
+						Date:     d.date,
+						Metric:   m.name,
+						Value:    x,
+						Baseline: mean,
+						ZScore:   z,
+						TestIDs:  testsAbove(d.tests, m.test, mean),
+					})
+				}
+			}
+		}
+		// Fold the day into the EWMA and its variance
+		diff := x - mean
+		increment := anomalyEWMAAlpha * diff
+		mean += increment
+		variance = (1 - anomalyEWMAAlpha) * (variance + diff*increment)
+		history++
+	}
+	return anomalies
+}
+
+// testsAbove returns the IDs of the tests whose value exceeds baseline, worst first.
+func testsAbove(tests []testTrendPoint, value func(testTrendPoint) float64, baseline float64) []string {
+	var above []testTrendPoint
+	for _, t := range tests {
+		if value(t) > baseline {
+			above = append(above, t)
+		}
+	}
+	sort.SliceStable(above, func(i, j int) bool { return value(above[i]) > value(above[j]) })
+	ids := make([]string, 0, len(above))
+	for _, t := range above {
+		ids = append(ids, t.testID)
+	}
+	return ids
+}
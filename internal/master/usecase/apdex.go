@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// SetDefaultApdexThreshold sets the Apdex threshold of the tests submitted
+// without one.
+func (uc *MasterUsecase) SetDefaultApdexThreshold(threshold time.Duration) error {
+	if threshold <= 0 {
+		return fmt.Errorf("invalid apdex threshold %v: must be positive", threshold)
+	}
+	uc.apdexThreshold = threshold
+	return nil
+}
+
+// testApdex returns a submitted test's Apdex settings with the default
+// threshold filled in, so the test keeps the threshold it ran with.
+func (uc *MasterUsecase) testApdex(config *domain.ApdexConfig) *domain.ApdexConfig {
+	apdex := &domain.ApdexConfig{}
+	if config != nil {
+		*apdex = *config
+	}
+	if apdex.Threshold == "" {
+		threshold := uc.apdexThreshold
+		if threshold == 0 {
+			threshold = domain.DefaultApdexThreshold
+		}
+		apdex.Threshold = threshold.String()
+	}
+	return apdex
+}
+
+// ApdexConfigFromPB maps the Apdex thresholds of a submission payload onto
+// domain settings, nil if it sets none.
+func ApdexConfigFromPB(threshold string, targets map[string]string) *domain.ApdexConfig {
+	if threshold == "" && len(targets) == 0 {
+		return nil
+	}
+	return &domain.ApdexConfig{Threshold: threshold, Targets: targets}
+}
+
+// setAssignmentApdex passes a test's Apdex thresholds on to its workers.
+// Tests stored before they had any leave the workers' default.
+func setAssignmentApdex(assignment *pb.TestAssignment, testReq *domain.TestRequest) *pb.TestAssignment {
+	if testReq.Apdex != nil {
+		assignment.ApdexThreshold = testReq.Apdex.Threshold
+		assignment.ApdexTargets = testReq.Apdex.Targets
+	}
+	return assignment
+}
+
+// resultApdex lists the Apdex score of each result.
+func resultApdex(results []*domain.TestResult) []*domain.Apdex {
+	scores := make([]*domain.Apdex, 0, len(results))
+	for _, res := range results {
+		scores = append(scores, res.Apdex)
+	}
+	return scores
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Test statuses used by the approval workflow.
+const (
+	TestStatusAwaitingApproval = domain.TestStatusAwaitingApproval
+	TestStatusRejected         = domain.TestStatusRejected
+)
+
+// approvalWebhookAttempts is how many times the approval webhook is tried before giving up.
+const approvalWebhookAttempts = 3
+
+// approvalWebhookEvent is the JSON body POSTed to the approval webhook.
+type approvalWebhookEvent struct {
+	Event           string    `json:"event"`
+	TestID          string    `json:"test_id"`
+	Name            string    `json:"name"`
+	RequesterID     string    `json:"requester_id"`
+	RatePerSecond   uint64    `json:"rate_per_second"`
+	DurationSeconds string    `json:"duration_seconds"`
+	WorkerCount     uint32    `json:"worker_count"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// SetApprovalWebhook enables the approval workflow. Once set, submitted tests
+// wait in AWAITING_APPROVAL until approved or rejected. The secret signs
+// webhook calls and authenticates callbacks from the external system.
+func (uc *MasterUsecase) SetApprovalWebhook(url, secret string) {
+	uc.approvalWebhookURL = url
+	uc.approvalWebhookSecret = secret
+	uc.events.Subscribe("approval-webhook", func(event domain.Event) {
+		if event.Type == domain.EventTestSubmitted && event.Status == TestStatusAwaitingApproval && event.Test != nil {
+			go uc.notifyApprovalWebhook(event.Test)
+		}
+	})
+}
+
+// ValidateApprovalSecret reports whether token matches the configured approval
+// secret. It always fails when no secret is configured.
+func (uc *MasterUsecase) ValidateApprovalSecret(token string) bool {
+	if uc.approvalWebhookSecret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(uc.approvalWebhookSecret)) == 1
+}
+
+// ApproveTest releases a test awaiting approval to the scheduler.
+func (uc *MasterUsecase) ApproveTest(ctx context.Context, testID, approvedBy string) error {
+	testReq, err := uc.awaitingApproval(ctx, testID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.testRepo.UpdateTestStatus(ctx, testID, "PENDING", nil, nil); err != nil {
+		return fmt.Errorf("failed to update test status: %w", err)
+	}
+	testReq.Status = "PENDING"
+	log.Printf("Test %s approved by %s.", testID, approvedBy)
+	uc.events.Publish(domain.Event{Type: domain.EventTestUpdated, TestID: testID, Status: "PENDING",
+		Message: "Approved by " + approvedBy})
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTestApprove, testID, map[string]string{"approvedBy": approvedBy}))
+
+	return uc.enqueueTest(ctx, testReq)
+}
+
+// RejectTest rejects a test awaiting approval so it is never scheduled.
+func (uc *MasterUsecase) RejectTest(ctx context.Context, testID, rejectedBy, reason string) error {
+	if _, err := uc.awaitingApproval(ctx, testID); err != nil {
+		return err
+	}
+
+	if err := uc.testRepo.UpdateTestStatus(ctx, testID, TestStatusRejected, nil, nil); err != nil {
+		return fmt.Errorf("failed to update test status: %w", err)
+	}
+	log.Printf("Test %s rejected by %s: %s", testID, rejectedBy, reason)
+	uc.events.Publish(domain.Event{Type: domain.EventTestUpdated, TestID: testID, Status: TestStatusRejected,
+		Message: reason})
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTestReject, testID,
+		map[string]string{"rejectedBy": rejectedBy, "reason": reason}))
+	return nil
+}
+
+// awaitingApproval returns a test awaiting approval.
+func (uc *MasterUsecase) awaitingApproval(ctx context.Context, testID string) (*domain.TestRequest, error) {
+	stored, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Status != TestStatusAwaitingApproval {
+		return nil, fmt.Errorf("test %s is not awaiting approval (status: %s)", testID, stored.Status)
+	}
+	return stored, nil
+}
+
+// notifyApprovalWebhook POSTs the submitted test to the approval webhook,
+// retrying with backoff. The test stays in AWAITING_APPROVAL either way.
+func (uc *MasterUsecase) notifyApprovalWebhook(testReq *domain.TestRequest) {
+	body, err := json.Marshal(approvalWebhookEvent{
+		Event:           "test.awaiting_approval",
+		TestID:          testReq.ID,
+		Name:            testReq.Name,
+		RequesterID:     testReq.RequesterID,
+		RatePerSecond:   testReq.RatePerSecond,
+		DurationSeconds: testReq.DurationSeconds,
+		WorkerCount:     testReq.WorkerCount,
+		CreatedAt:       testReq.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("Failed to encode approval webhook for test %s: %v", testReq.ID, err)
+		return
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	backoff := time.Second
+	for attempt := 1; attempt <= approvalWebhookAttempts; attempt++ {
+		err = uc.postApprovalWebhook(client, body)
+		if err == nil {
+			log.Printf("Approval webhook delivered for test %s", testReq.ID)
+			return
+		}
+		log.Printf("Attempt %d: Approval webhook for test %s failed: %v", attempt, testReq.ID, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	log.Printf("Giving up on approval webhook for test %s; it can still be approved via the admin endpoint.", testReq.ID)
+}
+
+func (uc *MasterUsecase) postApprovalWebhook(client *http.Client, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, uc.approvalWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if uc.approvalWebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(uc.approvalWebhookSecret))
+		mac.Write(body)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// MaxAttachmentSize is the largest accepted attachment upload.
+const MaxAttachmentSize = 64 << 20
+
+// UploadAttachment stores a file that targets can reference as a request body.
+func (uc *MasterUsecase) UploadAttachment(ctx context.Context, userID, name, contentType string, content io.Reader) (*domain.Attachment, error) {
+	data, err := io.ReadAll(io.LimitReader(content, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment too large: limit is %d bytes", MaxAttachmentSize)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		UploadedBy:  userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := uc.attachmentRepo.SaveAttachment(ctx, attachment, data); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAttachment returns an attachment's metadata.
+func (uc *MasterUsecase) GetAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	return uc.attachmentRepo.GetAttachment(ctx, attachmentID)
+}
+
+// GetAttachmentData returns an attachment's metadata and content for a worker.
+func (uc *MasterUsecase) GetAttachmentData(ctx context.Context, attachmentID string) (*domain.Attachment, []byte, error) {
+	attachment, err := uc.attachmentRepo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := uc.attachmentRepo.GetAttachmentData(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, data, nil
+}
+
+// GetAttachmentsByUser lists the attachments a user uploaded.
+func (uc *MasterUsecase) GetAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error) {
+	return uc.attachmentRepo.GetAttachmentsByUser(ctx, userID)
+}
+
+// DeleteAttachment removes an attachment. Only its uploader or an admin may delete it.
+func (uc *MasterUsecase) DeleteAttachment(ctx context.Context, attachmentID string, user *domain.UserProfile) error {
+	attachment, err := uc.attachmentRepo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != user.ID && user.Role != "admin" {
+		return fmt.Errorf("insufficient permissions")
+	}
+	return uc.attachmentRepo.DeleteAttachment(ctx, attachmentID)
+}
+
+// validateAttachmentRefs checks that every attachment referenced by the
+// targets exists, so a bad reference fails at submit rather than on workers.
+func (uc *MasterUsecase) validateAttachmentRefs(ctx context.Context, targetsBase64 string) error {
+	ids, err := domain.AttachmentIDs(targetsBase64)
+	if err != nil {
+		return fmt.Errorf("invalid targets: %w", err)
+	}
+	for _, id := range ids {
+		if _, err := uc.attachmentRepo.GetAttachment(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
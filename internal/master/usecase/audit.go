@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// MaxAuditPageSize caps how many audit entries one request can return.
+const MaxAuditPageSize = 500
+
+// SetAuditRepository records test and sharing actions in repo and serves
+// the audit log from it.
+func (uc *MasterUsecase) SetAuditRepository(repo domain.AuditRepository) {
+	uc.auditRepo = repo
+}
+
+// audit records entry in the audit log when it is enabled. Failures are
+// logged rather than failing the action that was already taken.
+func (uc *MasterUsecase) audit(ctx context.Context, entry *domain.AuditEntry) {
+	if uc.auditRepo == nil {
+		return
+	}
+	if err := uc.auditRepo.SaveAuditEntry(ctx, entry); err != nil {
+		log.Printf("Warning: Failed to record %s in audit log: %v", entry.Action, err)
+	}
+}
+
+// GetAuditLog returns the audit entries matching filter, newest first, and
+// the number of matching entries.
+func (uc *MasterUsecase) GetAuditLog(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, int, error) {
+	if uc.auditRepo == nil {
+		return nil, 0, fmt.Errorf("audit log is not enabled")
+	}
+	if filter.Limit <= 0 || filter.Limit > MaxAuditPageSize {
+		filter.Limit = MaxAuditPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return uc.auditRepo.GetAuditEntries(ctx, filter)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SLO used by capacity estimates when the request sets none. A test with an
+// error budget uses its maximum error rate instead of the default.
+const (
+	DefaultCapacityMaxLatencyMs = 500
+	DefaultCapacityMaxErrorRate = 0.01
+)
+
+// Capacity estimates bin the test's per-second metrics by throughput. They
+// need capacityMinSeconds of metrics, and a knee is only reported when the
+// throughput spread and the rise in latency across it are wide enough to
+// tell a bend from noise.
+const (
+	capacityBins        = 10
+	capacityMinSeconds  = 10
+	capacityMinSpread   = 0.2 // Highest throughput at least 20% above the lowest
+	capacityMinKneeRise = 0.5 // Latency at the highest throughput at least 50% above the lowest
+)
+
+// EstimateCapacity fits a test's throughput against its latency and error
+// rate and estimates the highest throughput that met slo and the knee of the
+// latency curve. Zero SLO fields take their defaults.
+//
+// The curve is built from the throughput the test actually reached, second
+// by second; a test that held one rate throughout gives a single point and no
+// knee, which the estimate's note explains.
+func (uc *MasterUsecase) EstimateCapacity(ctx context.Context, testID string, slo domain.CapacitySLO) (*domain.CapacityEstimate, error) {
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	if slo.MaxLatencyMs < 0 || slo.MaxErrorRate < 0 || slo.MaxErrorRate > 1 {
+		return nil, fmt.Errorf("invalid SLO: latency must be positive and error rate between 0 and 1")
+	}
+	if slo.MaxLatencyMs == 0 {
+		slo.MaxLatencyMs = DefaultCapacityMaxLatencyMs
+	}
+	if slo.MaxErrorRate == 0 {
+		slo.MaxErrorRate = DefaultCapacityMaxErrorRate
+		if opts, err := domain.ParseAttackOptions(test.VegetaPayloadJSON); err == nil && opts.ErrorBudget != nil {
+			slo.MaxErrorRate = opts.ErrorBudget.MaxErrorRate
+		}
+	}
+
+	points, err := uc.metricsRepo.GetTimeSeries(ctx, testID, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	// The first and last seconds are partial: workers start and stop within them
+	if len(points) > 2 {
+		points = points[1 : len(points)-1]
+	}
+
+	estimate := &domain.CapacityEstimate{TestID: testID, SLO: slo, Curve: []domain.CapacityPoint{}}
+	if len(points) < capacityMinSeconds {
+		estimate.Note = fmt.Sprintf("Not enough metrics: %d seconds recorded, %d needed", len(points), capacityMinSeconds)
+		return estimate, nil
+	}
+
+	estimate.Curve = capacityCurve(points)
+	for _, p := range estimate.Curve {
+		if p.MeanLatencyMs > slo.MaxLatencyMs || p.ErrorRate > slo.MaxErrorRate {
+			break
+		}
+		estimate.MaxSustainableRPS = p.RPS
+	}
+
+	lowest, highest := estimate.Curve[0], estimate.Curve[len(estimate.Curve)-1]
+	switch {
+	case highest.RPS < lowest.RPS*(1+capacityMinSpread):
+		estimate.Note = "Throughput barely varied during the test, so the curve has no knee; spread the load over a wider range of rates"
+	case highest.MeanLatencyMs < lowest.MeanLatencyMs*(1+capacityMinKneeRise):
+		estimate.Note = "Latency stayed flat across the throughput reached; the knee lies above the tested load"
+	default:
+		estimate.KneeRPS = latencyKnee(estimate.Curve)
+	}
+	return estimate, nil
+}
+
+// capacityCurve groups per-second points into equal-width throughput bins,
+// lowest first, leaving out empty bins.
+func capacityCurve(points []domain.TimeSeriesPoint) []domain.CapacityPoint {
+	minRPS, maxRPS := points[0].RPS, points[0].RPS
+	for _, p := range points {
+		minRPS = min(minRPS, p.RPS)
+		maxRPS = max(maxRPS, p.RPS)
+	}
+	width := (maxRPS - minRPS) / capacityBins
+
+	type bin struct {
+		rpsSum, latencySum float64
+		requests, failures int64
+		seconds            int
+	}
+	bins := make([]bin, capacityBins)
+	for _, p := range points {
+		i := 0
+		if width > 0 {
+			i = min(int((p.RPS-minRPS)/width), capacityBins-1)
+		}
+		bins[i].rpsSum += p.RPS
+		bins[i].latencySum += p.MeanLatencyMs * float64(p.Requests)
+		bins[i].requests += p.Requests
+		bins[i].failures += p.Failures
+		bins[i].seconds++
+	}
+
+	curve := []domain.CapacityPoint{}
+	for _, b := range bins {
+		if b.seconds == 0 || b.requests == 0 {
+			continue
+		}
+		curve = append(curve, domain.CapacityPoint{
+			RPS:           b.rpsSum / float64(b.seconds),
+			MeanLatencyMs: b.latencySum / float64(b.requests),
+			ErrorRate:     float64(b.failures) / float64(b.requests),
+			Seconds:       b.seconds,
+		})
+	}
+	return curve
+}
+
+// latencyKnee finds where a rising latency curve bends upward: with both axes
+// scaled to [0, 1], the point furthest below the straight line joining the
+// curve's ends (the "Kneedle" method). It returns 0 for fewer than three points.
+func latencyKnee(curve []domain.CapacityPoint) float64 {
+	if len(curve) < 3 {
+		return 0
+	}
+	first, last := curve[0], curve[len(curve)-1]
+	rpsRange := last.RPS - first.RPS
+	latencyRange := last.MeanLatencyMs - first.MeanLatencyMs
+	if rpsRange <= 0 || latencyRange <= 0 {
+		return 0
+	}
+
+	var knee, best float64
+	for _, p := range curve[1 : len(curve)-1] {
+		x := (p.RPS - first.RPS) / rpsRange
+		y := (p.MeanLatencyMs - first.MeanLatencyMs) / latencyRange
+		if d := x - y; d > best {
+			best, knee = d, p.RPS
+		}
+	}
+	return knee
+}
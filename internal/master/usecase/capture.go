@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetRequestCaptureRepository stores the requests workers capture in tests
+// submitted with CaptureRequests.
+func (uc *MasterUsecase) SetRequestCaptureRepository(repo domain.RequestCaptureRepository) {
+	uc.captureRepo = repo
+}
+
+// SaveRequestCapture stores the gzipped requests a worker captured in a test.
+// Captures of tests that did not ask for them are refused.
+func (uc *MasterUsecase) SaveRequestCapture(ctx context.Context, capture *domain.RequestCapture, data []byte) error {
+	if uc.captureRepo == nil {
+		return fmt.Errorf("request capture is not supported by this master")
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, capture.TestID)
+	if err != nil {
+		return err
+	}
+	if !test.CaptureRequests {
+		return fmt.Errorf("test %s was not submitted with capture_requests", capture.TestID)
+	}
+
+	capture.Size, capture.CreatedAt = int64(len(data)), time.Now()
+	if err := uc.captureRepo.SaveRequestCapture(ctx, capture, data); err != nil {
+		return err
+	}
+	log.Printf("Saved %d captured requests (%d bytes) from worker %s for test %s", capture.Requests, capture.Size, capture.WorkerID, capture.TestID)
+	if capture.Truncated {
+		log.Printf("Warning: Worker %s reached the request capture limit in test %s; later requests were not captured", capture.WorkerID, capture.TestID)
+	}
+	return nil
+}
+
+// GetRequestCaptures lists the captures of a test's workers.
+func (uc *MasterUsecase) GetRequestCaptures(ctx context.Context, testID string) ([]*domain.RequestCapture, error) {
+	if uc.captureRepo == nil {
+		return nil, fmt.Errorf("request capture is not supported by this master")
+	}
+	return uc.captureRepo.GetRequestCaptures(ctx, testID)
+}
+
+// WriteRequestCaptures writes the requests every worker of a test captured to
+// w, worker by worker, as vegeta JSON results one per line. Requests are in
+// time order within each worker's part only; vegeta sorts them when plotting.
+func (uc *MasterUsecase) WriteRequestCaptures(ctx context.Context, testID string, captures []*domain.RequestCapture, w io.Writer) error {
+	for _, capture := range captures {
+		data, err := uc.captureRepo.GetRequestCaptureData(ctx, testID, capture.WorkerID)
+		if err != nil {
+			return err
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("request capture of worker %s is corrupt: %w", capture.WorkerID, err)
+		}
+		if _, err := io.Copy(w, zr); err != nil {
+			return fmt.Errorf("failed to write request capture of worker %s: %w", capture.WorkerID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Default thresholds above which a submitted test must be confirmed.
+const (
+	DefaultConfirmRate     = 10000     // Combined req/s of all workers
+	DefaultConfirmDuration = time.Hour // Test duration
+)
+
+// confirmationTTL is how long a confirmation token stays valid.
+const confirmationTTL = 10 * time.Minute
+
+// confirmationPolicy holds the thresholds of the two-step submission of very
+// large tests, and the key confirmation tokens are signed with.
+type confirmationPolicy struct {
+	maxRate     uint64        // 0 = rate is not checked
+	maxDuration time.Duration // 0 = duration is not checked
+	key         []byte        // Random per master; tokens do not survive a restart
+}
+
+// SetConfirmationThresholds makes tests whose combined rate is above maxRate
+// req/s, or that run longer than maxDuration, need confirmation: the first
+// submission is refused with a *domain.ConfirmationError whose token must be
+// sent back with the same test. Zero disables a threshold.
+func (uc *MasterUsecase) SetConfirmationThresholds(maxRate uint64, maxDuration time.Duration) error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to generate confirmation key: %w", err)
+	}
+	uc.confirmation = confirmationPolicy{maxRate: maxRate, maxDuration: maxDuration, key: key}
+	return nil
+}
+
+// checkConfirmation refuses a validated test above the confirmation
+// thresholds unless it carries a valid token issued for the same test.
+func (uc *MasterUsecase) checkConfirmation(testReq *domain.TestRequest, now time.Time) error {
+	reasons := uc.confirmation.reasons(testReq)
+	if len(reasons) == 0 {
+		return nil
+	}
+	digest, err := confirmationDigest(testReq)
+	if err != nil {
+		return err
+	}
+	if uc.confirmation.valid(testReq.ConfirmationToken, digest, now) {
+		return nil
+	}
+	expiresAt := now.Add(confirmationTTL)
+	return &domain.ConfirmationError{
+		Token:     uc.confirmation.token(digest, expiresAt),
+		Reasons:   reasons,
+		ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+	}
+}
+
+// reasons describes each threshold the test exceeds.
+func (p *confirmationPolicy) reasons(testReq *domain.TestRequest) []string {
+	if p.key == nil {
+		return nil
+	}
+	var reasons []string
+	if p.maxRate > 0 {
+		rate := sumRates(distributeRate(testReq, max(int(testReq.WorkerCount), 1)))
+		if rate > p.maxRate {
+			reasons = append(reasons, fmt.Sprintf("combined rate of %d req/s is above %d req/s", rate, p.maxRate))
+		}
+	}
+	if p.maxDuration > 0 {
+		if duration, err := time.ParseDuration(testReq.DurationSeconds); err == nil && duration > p.maxDuration {
+			reasons = append(reasons, fmt.Sprintf("duration of %v is longer than %v", duration, p.maxDuration))
+		}
+	}
+	return reasons
+}
+
+// token issues a confirmation token for the test with digest, valid until
+// expiresAt. It is "<expiry unix seconds>.<hex HMAC>".
+func (p *confirmationPolicy) token(digest []byte, expiresAt time.Time) string {
+	expiry := expiresAt.Unix()
+	return strconv.FormatInt(expiry, 10) + "." + hex.EncodeToString(p.sign(digest, expiry))
+}
+
+// valid reports whether token was issued by this master for the test with
+// digest and has not expired at now.
+func (p *confirmationPolicy) valid(token string, digest []byte, now time.Time) bool {
+	expiryText, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiry, err := strconv.ParseInt(expiryText, 10, 64)
+	if err != nil || now.Unix() > expiry {
+		return false
+	}
+	mac, err := hex.DecodeString(signature)
+	return err == nil && hmac.Equal(mac, p.sign(digest, expiry))
+}
+
+func (p *confirmationPolicy) sign(digest []byte, expiry int64) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	binary.Write(mac, binary.BigEndian, expiry)
+	mac.Write(digest)
+	return mac.Sum(nil)
+}
+
+// confirmationDigest hashes everything a submitter chooses about a test,
+// including who submits it, so a token only confirms the test it was issued
+// for.
+func confirmationDigest(testReq *domain.TestRequest) ([]byte, error) {
+	test := *testReq
+	test.ID, test.CreatedAt, test.ConfirmationToken = "", time.Time{}, ""
+	data, err := json.Marshal(&test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode test for confirmation: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	return digest[:], nil
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// dashboardEventTimeout bounds the lookup of a single test after an event.
+const dashboardEventTimeout = 5 * time.Second
+
+// dashboardCache holds the tests shown on the dashboard so that serving it
+// does not scan the test table. Events keep it current one test at a time;
+// the dashboard cache job also reloads it periodically to pick up changes
+// made without an event, and on read-only replicas, which see no events.
+type dashboardCache struct {
+	mu     sync.RWMutex
+	loaded bool
+	tests  map[string]*domain.TestRequest // Tests shown on the dashboard, by ID
+}
+
+// showOnDashboard reports whether a test in status belongs on the dashboard.
+func showOnDashboard(status string) bool {
+	return status == "RUNNING" || status == "PENDING" || status == "PARTIALLY_FAILED"
+}
+
+// replace swaps in a freshly loaded set of tests.
+func (c *dashboardCache) replace(tests []*domain.TestRequest) {
+	shown := make(map[string]*domain.TestRequest)
+	for _, test := range tests {
+		if showOnDashboard(test.Status) {
+			shown[test.ID] = test
+		}
+	}
+	c.mu.Lock()
+	c.tests = shown
+	c.loaded = true
+	c.mu.Unlock()
+}
+
+// apply updates the cached copy of one test.
+func (c *dashboardCache) apply(test *domain.TestRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.loaded {
+		return
+	}
+	if showOnDashboard(test.Status) {
+		c.tests[test.ID] = test
+	} else {
+		delete(c.tests, test.ID)
+	}
+}
+
+// snapshot returns the cached tests, newest first, or false before the first load.
+func (c *dashboardCache) snapshot() ([]*domain.TestRequest, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.loaded {
+		return nil, false
+	}
+	tests := make([]*domain.TestRequest, 0, len(c.tests))
+	for _, test := range c.tests {
+		tests = append(tests, test)
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].CreatedAt.After(tests[j].CreatedAt) })
+	return tests, true
+}
+
+// StartDashboardCacheJob loads the dashboard cache, keeps it current from
+// test events, and reloads it from the test repository every interval.
+// Until it has loaded, GetDashboardStatus reads the repository directly.
+func (uc *MasterUsecase) StartDashboardCacheJob(ctx context.Context, interval time.Duration) {
+	uc.events.Subscribe("dashboard-cache", func(event domain.Event) {
+		// Metrics and aggregation events leave the test itself unchanged
+		if event.TestID == "" || event.Type == domain.EventTestMetrics || event.Type == domain.EventTestAggregated || ctx.Err() != nil {
+			return
+		}
+		test := event.Test
+		if test == nil {
+			lookupCtx, cancel := context.WithTimeout(ctx, dashboardEventTimeout)
+			defer cancel()
+			var err error
+			if test, err = uc.testRepo.GetTestRequestByID(lookupCtx, event.TestID); err != nil {
+				log.Printf("Error refreshing test %s in dashboard cache: %v", event.TestID, err)
+				return
+			}
+		}
+		uc.dashboard.apply(test)
+	})
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Starting dashboard cache job with interval: %v", interval)
+
+	for {
+		if tests, err := uc.testRepo.GetAllTestRequests(ctx); err != nil {
+			log.Printf("Error reloading dashboard cache: %v", err)
+		} else {
+			uc.dashboard.replace(tests)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Dashboard cache job stopped due to context cancellation")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetDashboardStatus compiles and returns the current dashboard status.
+// Workers come from the in-memory worker registry and tests from the
+// dashboard cache, so polling it does not touch the database once the
+// dashboard cache job is running.
+func (uc *MasterUsecase) GetDashboardStatus(ctx context.Context) (*domain.DashboardStatus, error) {
+	allWorkers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all workers for dashboard: %w", err)
+	}
+
+	totalWorkers := uint32(len(allWorkers))
+	var availableWorkers uint32
+	var busyWorkers uint32
+	workerSummaries := make([]domain.WorkerSummary, 0, totalWorkers)
+	workerVersions := make(map[string]int)
+
+	for _, w := range allWorkers {
+		if w.Status == "READY" {
+			availableWorkers++
+		} else if w.Status == "BUSY" {
+			busyWorkers++
+		}
+		if w.Status != "OFFLINE" {
+			version := w.Version
+			if version == "" {
+				version = "unknown"
+			}
+			workerVersions[version]++
+		}
+		workerSummaries = append(workerSummaries, domain.WorkerSummary{
+			WorkerID:          w.ID,
+			StatusMessage:     w.LastProgressMessage,
+			StatusType:        w.Status,
+			CurrentTestID:     w.CurrentTestID,
+			CompletedRequests: w.CompletedRequests,
+			TotalRequests:     w.TotalRequests,
+			MaxRate:           w.MaxRate,
+			ConnectionState:   uc.workerConnectionState(w.ID),
+			Version:           w.Version,
+			Draining:          uc.isDraining(w.ID),
+			Region:            w.Region,
+		})
+	}
+
+	tests, ok := uc.dashboard.snapshot()
+	if !ok {
+		if tests, err = uc.testRepo.GetAllTestRequests(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get all tests for dashboard: %w", err)
+		}
+	}
+
+	activeTests := make([]domain.ActiveTestSummary, 0)
+	for _, test := range tests {
+		if !showOnDashboard(test.Status) {
+			continue
+		}
+
+		// Calculate progress based on assigned workers vs completed/failed
+		var progress float64
+		if len(test.AssignedWorkersIDs) > 0 {
+			progress = float64(len(test.CompletedWorkers)+len(test.FailedWorkers)) / float64(len(test.AssignedWorkersIDs))
+		}
+
+		// Sum request counts from the workers still running this test
+		var totalReqsSent int64
+		var totalReqsCompleted int64
+		for _, ws := range workerSummaries {
+			if ws.CurrentTestID == test.ID {
+				totalReqsSent += ws.TotalRequests
+				totalReqsCompleted += ws.CompletedRequests
+			}
+		}
+
+		activeTests = append(activeTests, domain.ActiveTestSummary{
+			TestID:                 test.ID,
+			TestName:               test.Name,
+			AssignedWorkers:        uint32(len(test.AssignedWorkersIDs)),
+			CompletedWorkers:       uint32(len(test.CompletedWorkers)),
+			FailedWorkers:          uint32(len(test.FailedWorkers)),
+			Status:                 test.Status,
+			TotalRequestsSent:      totalReqsSent,
+			TotalRequestsCompleted: totalReqsCompleted,
+			TotalDurationMs:        0, // Placeholder, can be improved with more data
+			Progress:               progress,
+		})
+	}
+
+	return &domain.DashboardStatus{
+		TotalWorkers:     totalWorkers,
+		AvailableWorkers: availableWorkers,
+		BusyWorkers:      busyWorkers,
+		ActiveTests:      activeTests,
+		WorkerSummaries:  workerSummaries,
+		WorkerVersions:   workerVersions,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// testsOfBuild keeps the tests run against build of their target; an empty
+// build keeps every test.
+func testsOfBuild(tests []*domain.TestRequest, build string) []*domain.TestRequest {
+	if build == "" {
+		return tests
+	}
+	var matching []*domain.TestRequest
+	for _, test := range tests {
+		if test.TargetBuild == build {
+			matching = append(matching, test)
+		}
+	}
+	return matching
+}
+
+// runEnvironments lists the distinct environments worker results were
+// produced with, in the order they first appear.
+func runEnvironments(results []*domain.TestResult) []domain.RunEnvironment {
+	var environments []domain.RunEnvironment
+	seen := make(map[domain.RunEnvironment]bool)
+	for _, r := range results {
+		if r.Environment == nil || seen[*r.Environment] {
+			continue
+		}
+		seen[*r.Environment] = true
+		environments = append(environments, *r.Environment)
+	}
+	return environments
+}
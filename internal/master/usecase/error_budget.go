@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// TestStatusAbortedErrorBudget marks a test stopped because its error budget ran out.
+const TestStatusAbortedErrorBudget = domain.TestStatusAbortedErrorBudget
+
+// budgetSample is the test-wide change in request counts from one worker report.
+type budgetSample struct {
+	at       time.Time
+	requests int64
+	failures int64
+}
+
+// spendErrorBudget adds a worker report to the budget window and reports
+// whether the error rate over the window exceeds the budget. The window is
+// only judged once the test has run for its full length and it holds enough
+// requests, so a few early failures cannot trip the breaker. Callers hold lt.mu.
+func (lt *liveTest) spendErrorBudget(now time.Time, requests, failures int64) (float64, bool) {
+	if lt.budget == nil {
+		return 0, false
+	}
+	window := lt.budget.WindowDuration()
+
+	lt.samples = append(lt.samples, budgetSample{at: now, requests: requests, failures: failures})
+	cutoff := now.Add(-window)
+	kept := lt.samples[:0]
+	var windowRequests, windowFailures int64
+	for _, s := range lt.samples {
+		if s.at.After(cutoff) {
+			kept = append(kept, s)
+			windowRequests += s.requests
+			windowFailures += s.failures
+		}
+	}
+	lt.samples = kept
+
+	if now.Sub(lt.startedAt) < window || windowRequests < lt.budget.MinWindowRequests() {
+		return 0, false
+	}
+	errorRate := float64(windowFailures) / float64(windowRequests)
+	return errorRate, errorRate > lt.budget.MaxErrorRate
+}
+
+// abortForErrorBudget marks a running test as aborted. Its workers are told to
+// stop in the acknowledgement of their next progress report.
+func (uc *MasterUsecase) abortForErrorBudget(testID string, errorRate float64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		log.Printf("Error loading test %s to abort it: %v", testID, err)
+		return
+	}
+	if !domain.CanTransitionTestStatus(test.Status, TestStatusAbortedErrorBudget) {
+		return
+	}
+
+	log.Printf("🛑 Test %s exceeded its error budget (%.1f%% errors); aborting", testID, errorRate*100)
+	if err := uc.testRepo.UpdateTestStatus(ctx, testID, TestStatusAbortedErrorBudget, test.CompletedWorkers, test.FailedWorkers); errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return // Finished or aborted by another update in the meantime
+	} else if err != nil {
+		log.Printf("Error marking test %s as %s: %v", testID, TestStatusAbortedErrorBudget, err)
+		return
+	}
+
+	message := fmt.Sprintf("Error rate %.1f%% exceeded the error budget", errorRate*100)
+	uc.events.Publish(domain.Event{Type: domain.EventSLABreached, TestID: testID, Status: TestStatusAbortedErrorBudget, Message: message})
+	uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testID, Status: TestStatusAbortedErrorBudget, Message: message})
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// maxEstimateSamples caps how many earlier tests are read to average byte sizes.
+const maxEstimateSamples = 5
+
+// Typical stored sizes, for estimating the space a test's results take up.
+const (
+	resultRowBytes     = 4 << 10 // A worker's result or interim result, with its vegeta metrics
+	metricSampleBytes  = 160     // A worker's progress sample for one second
+	captureEntryBytes  = 256     // A captured request, without its body, before compression
+	requestRecordBytes = 48      // A ClickHouse request record, after column compression
+)
+
+// EstimateTestCost predicts the requests, bandwidth and worker time a validated
+// test will use. Rates are split across workers as they would be on assignment;
+// a scenario's rate counts iterations, each sending one request per step.
+func (uc *MasterUsecase) EstimateTestCost(ctx context.Context, testReq *domain.TestRequest) *domain.CostEstimate {
+	duration, err := time.ParseDuration(testReq.DurationSeconds)
+	if err != nil || duration <= 0 || testReq.RatePerSecond == 0 {
+		return nil
+	}
+	workerCount := int(testReq.WorkerCount)
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	requestsPerIteration := uint64(1)
+	if testReq.ScenarioJSON != "" {
+		scenario, err := domain.ParseScenario(testReq.ScenarioJSON)
+		if err != nil {
+			return nil
+		}
+		requestsPerIteration = uint64(len(scenario.Steps))
+	}
+
+	rps := sumRates(distributeRate(testReq, workerCount)) * requestsPerIteration
+	estimate := &domain.CostEstimate{
+		TotalRequests:       uint64(float64(rps) * duration.Seconds()),
+		RequestsPerSecond:   rps,
+		WorkerSeconds:       float64(workerCount) * duration.Seconds(),
+		WorkerHours:         float64(workerCount) * duration.Hours(),
+		AvgRequestBodyBytes: requestBodyBytes(testReq),
+	}
+
+	if err := uc.estimateBytes(ctx, testReq, estimate); err != nil {
+		// Byte sizes are a best-effort extra; the request counts still stand
+		log.Printf("Failed to estimate response sizes for %s: %v", testReq.RequesterID, err)
+	}
+	if estimate.SampleTests == 0 {
+		estimate.EstimatedBytesOut = uint64(estimate.AvgRequestBodyBytes * float64(estimate.TotalRequests))
+	}
+	estimate.Storage = uc.estimateStorage(testReq, estimate, workerCount, duration)
+	return estimate
+}
+
+// requestBodyBytes averages the bodies of a test's targets, or of its
+// scenario's steps, which are sent in turn. Bodies that are attachments or
+// come from a script are not known and count as empty.
+func requestBodyBytes(testReq *domain.TestRequest) float64 {
+	var total, count int
+	if testReq.ScenarioJSON != "" {
+		scenario, err := domain.ParseScenario(testReq.ScenarioJSON)
+		if err != nil {
+			return 0
+		}
+		for _, step := range scenario.Steps {
+			total += len(step.Body)
+			count++
+		}
+	} else if testReq.TargetsBase64 != "" {
+		targets, err := decodeTargets(testReq.TargetsBase64)
+		if err != nil {
+			return 0
+		}
+		for _, target := range targets {
+			total += len(target.Body)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
+
+// estimateStorage predicts the space the results of a test with estimate
+// will take up: its workers' results, their progress samples, and its
+// captured requests and request records when those are kept.
+func (uc *MasterUsecase) estimateStorage(testReq *domain.TestRequest, estimate *domain.CostEstimate, workerCount int, duration time.Duration) domain.StorageEstimate {
+	workers := uint64(workerCount)
+	results := workers + 1 // Plus the aggregated result
+	if interval, err := time.ParseDuration(testReq.InterimInterval); err == nil && interval > 0 {
+		results += workers * uint64(duration/interval)
+	}
+	storage := domain.StorageEstimate{
+		ResultBytes:     results * resultRowBytes,
+		TimeSeriesBytes: workers * uint64(duration.Seconds()) * metricSampleBytes,
+	}
+	if testReq.CaptureRequests {
+		storage.CaptureBytes = min(estimate.TotalRequests*captureEntryBytes, workers*domain.MaxRequestCaptureBytes)
+	}
+	if uc.requestRecords != nil {
+		storage.RequestRecordBytes = estimate.TotalRequests * requestRecordBytes
+	}
+	storage.TotalBytes = storage.ResultBytes + storage.TimeSeriesBytes + storage.CaptureBytes + storage.RequestRecordBytes
+	return storage
+}
+
+// estimateBytes fills in byte averages from the requester's most recent
+// finished tests that hit exactly the same targets.
+func (uc *MasterUsecase) estimateBytes(ctx context.Context, testReq *domain.TestRequest, estimate *domain.CostEstimate) error {
+	if testReq.RequesterID == "" {
+		return nil
+	}
+	key := uc.estimateTargetKey(testReq)
+	if key == "" {
+		return nil
+	}
+
+	tests, err := uc.testRepo.GetTestRequestsByUser(ctx, testReq.RequesterID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].CreatedAt.After(tests[j].CreatedAt) })
+
+	var requests, bytesIn, bytesOut uint64
+	for _, test := range tests {
+		if estimate.SampleTests >= maxEstimateSamples {
+			break
+		}
+		if test.ID == testReq.ID || IsTestActive(test.Status) || uc.estimateTargetKey(test) != key {
+			continue
+		}
+		results, err := uc.testResultRepo.GetResultsByTestID(ctx, test.ID)
+		if err != nil {
+			return err
+		}
+		sampled := false
+		for _, result := range results {
+			var metric struct {
+				Requests uint64 `json:"requests"`
+				BytesIn  struct {
+					Total uint64 `json:"total"`
+				} `json:"bytes_in"`
+				BytesOut struct {
+					Total uint64 `json:"total"`
+				} `json:"bytes_out"`
+			}
+			if err := json.Unmarshal(result.Metric, &metric); err != nil || metric.Requests == 0 {
+				continue
+			}
+			requests += metric.Requests
+			bytesIn += metric.BytesIn.Total
+			bytesOut += metric.BytesOut.Total
+			sampled = true
+		}
+		if sampled {
+			estimate.SampleTests++
+		}
+	}
+	if requests == 0 {
+		return nil
+	}
+
+	estimate.AvgResponseBytes = float64(bytesIn) / float64(requests)
+	estimate.AvgRequestBytes = float64(bytesOut) / float64(requests)
+	estimate.EstimatedBytesIn = uint64(estimate.AvgResponseBytes * float64(estimate.TotalRequests))
+	estimate.EstimatedBytesOut = uint64(estimate.AvgRequestBytes * float64(estimate.TotalRequests))
+	return nil
+}
+
+// estimateTargetKey identifies the set of endpoints a test hits, so earlier
+// runs against the same endpoints can be found regardless of target order.
+func (uc *MasterUsecase) estimateTargetKey(test *domain.TestRequest) string {
+	targets := uc.testTargetURLs(test)
+	if len(targets) == 0 {
+		return ""
+	}
+	sort.Strings(targets)
+	return strings.Join(targets, "\n")
+}
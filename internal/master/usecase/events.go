@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// eventSubscriberBuffer is how many events a subscriber can fall behind by
+// before further events to it are dropped.
+const eventSubscriberBuffer = 256
+
+// EventBus fans domain events out to subscribers. Publishing never blocks:
+// each subscriber has its own queue and goroutine, so a slow subscriber only
+// delays itself.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []*eventSubscriber
+}
+
+type eventSubscriber struct {
+	name   string
+	events chan domain.Event
+}
+
+// NewEventBus creates an event bus with no subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe calls handler for every event published from now on, one at a
+// time and in order. The name identifies the subscriber in logs. The
+// returned function unsubscribes; events still queued are dropped.
+func (b *EventBus) Subscribe(name string, handler func(domain.Event)) (unsubscribe func()) {
+	sub := &eventSubscriber{name: name, events: make(chan domain.Event, eventSubscriberBuffer)}
+	go func() {
+		for event := range sub.events {
+			handler(event)
+		}
+	}()
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, sub)
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			for i, s := range b.subscribers {
+				if s == sub {
+					b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+					break
+				}
+			}
+			close(sub.events)
+		})
+	}
+}
+
+// Publish delivers event to every subscriber, dropping it for subscribers
+// whose queue is full.
+func (b *EventBus) Publish(event domain.Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("Event subscriber %s is falling behind, dropped %s event for test %s", sub.name, event.Type, event.TestID)
+		}
+	}
+}
+
+// Events returns the bus on which the master publishes test and worker events.
+func (uc *MasterUsecase) Events() *EventBus {
+	return uc.events
+}
+
+// SubscribeTestEvents returns the events of one test until ctx is done. It
+// fails when the test does not exist. Events the caller does not receive in
+// time are dropped rather than holding up the bus.
+func (uc *MasterUsecase) SubscribeTestEvents(ctx context.Context, testID string) (<-chan domain.Event, error) {
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+
+	events := make(chan domain.Event, eventSubscriberBuffer)
+	unsubscribe := uc.events.Subscribe("test-events:"+testID, func(event domain.Event) {
+		if event.TestID != testID {
+			return
+		}
+		select {
+		case events <- event:
+		default:
+			log.Printf("Event stream for test %s is falling behind, dropped %s event", testID, event.Type)
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return events, nil
+}
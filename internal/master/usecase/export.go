@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Supported export formats for ExportTest.
+const (
+	ExportFormatVegeta = "vegeta"
+	ExportFormatK6     = "k6"
+)
+
+// exportTarget mirrors the JSON target format understood by vegeta. Body is
+// base64 encoded in JSON, exactly as vegeta expects it.
+type exportTarget struct {
+	Method string              `json:"method"`
+	URL    string              `json:"url"`
+	Body   []byte              `json:"body,omitempty"`
+	Header map[string][]string `json:"header,omitempty"`
+}
+
+// ExportTest renders a stored test definition as a standalone script so the
+// run can be reproduced outside the platform. The script drives the test's
+// full rate from a single machine. With scrub set, internal hostnames, IPs and
+// auth headers are redacted so the script can be shared outside the org.
+func (uc *MasterUsecase) ExportTest(ctx context.Context, testID, format string, scrub bool) (string, error) {
+	testReq, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get test request: %w", err)
+	}
+
+	if executor := domain.ExecutorName(testReq.Protocol); executor != domain.ExecutorVegetaHTTP {
+		return "", fmt.Errorf("unsupported export format %q for %s tests: only plain target tests can be exported", format, executor)
+	}
+	if testReq.ScenarioJSON != "" {
+		return "", fmt.Errorf("unsupported export format %q for scenario tests: only plain target tests can be exported", format)
+	}
+	if ids, _ := domain.AttachmentIDs(testReq.TargetsBase64); len(ids) > 0 {
+		return "", fmt.Errorf("unsupported export format %q for tests with attachments: attachment bodies are not included in exports", format)
+	}
+
+	targets, err := decodeTargets(testReq.TargetsBase64)
+	if err != nil {
+		return "", err
+	}
+	addExportLabels(targets, testReq)
+	opts, err := domain.ParseAttackOptions(testReq.VegetaPayloadJSON)
+	if err != nil {
+		return "", err
+	}
+
+	var script string
+	switch format {
+	case ExportFormatVegeta, "":
+		script, err = renderVegetaScript(testReq, targets, opts)
+	case ExportFormatK6:
+		script, err = renderK6Script(testReq, targets, opts)
+	default:
+		return "", fmt.Errorf("unsupported export format %q: must be %q or %q", format, ExportFormatVegeta, ExportFormatK6)
+	}
+	if err != nil || !scrub {
+		return script, err
+	}
+	return uc.redactor.Redact(script), nil
+}
+
+// addExportLabels adds the test's label headers to every target, so
+// reproduced traffic is labelled like the original. No worker runs an export,
+// so domain.LabelWorkerID becomes "export".
+func addExportLabels(targets []exportTarget, testReq *domain.TestRequest) {
+	labels := domain.ExpandLabelHeaders(assignmentLabels(testReq), domain.LabelWorkerID, "export")
+	for i := range targets {
+		for name, value := range labels {
+			for existing := range targets[i].Header {
+				if strings.EqualFold(existing, name) {
+					delete(targets[i].Header, existing)
+				}
+			}
+			if targets[i].Header == nil {
+				targets[i].Header = make(map[string][]string, len(labels))
+			}
+			targets[i].Header[http.CanonicalHeaderKey(name)] = []string{value}
+		}
+	}
+}
+
+// decodeTargets accepts the same target formats as the worker: a JSON array
+// of targets, or one target per line ("GET http://..." or a bare URL).
+func decodeTargets(targetsBase64 string) ([]exportTarget, error) {
+	decoded, err := base64.StdEncoding.DecodeString(targetsBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode targets from base64: %w", err)
+	}
+
+	var targets []exportTarget
+	if err := json.Unmarshal(decoded, &targets); err != nil {
+		targets = nil
+		for _, line := range strings.Split(string(decoded), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			parts := strings.Fields(line)
+			if len(parts) >= 2 {
+				targets = append(targets, exportTarget{Method: parts[0], URL: parts[1]})
+			} else {
+				targets = append(targets, exportTarget{Method: "GET", URL: parts[0]})
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets found in the provided targets data")
+	}
+	for i := range targets {
+		if targets[i].Method == "" {
+			targets[i].Method = "GET"
+		}
+	}
+	return targets, nil
+}
+
+// renderVegetaScript produces a shell script running the vegeta CLI.
+func renderVegetaScript(testReq *domain.TestRequest, targets []exportTarget, opts *domain.AttackOptions) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#!/bin/sh\n")
+	fmt.Fprintf(&b, "# Vegeta reproduction of test %q (%s)\n", testReq.Name, testReq.ID)
+	if testReq.ThinkTime != "" || testReq.PacingJitter > 0 {
+		fmt.Fprintf(&b, "# NOTE: think time (%q) and pacing jitter (%.2f) have no vegeta CLI equivalent and are not reproduced.\n",
+			testReq.ThinkTime, testReq.PacingJitter)
+	}
+	if testReq.RequestIDPrefix != "" {
+		fmt.Fprintf(&b, "# NOTE: per-request X-Request-ID injection (prefix %q) is not reproduced.\n", testReq.RequestIDPrefix)
+	}
+	b.WriteString("set -e\n\n")
+
+	b.WriteString("cat > targets.json <<'EOF'\n")
+	for _, t := range targets {
+		line, err := json.Marshal(t)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode target: %w", err)
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString("EOF\n\n")
+
+	flags := []string{
+		"-format=json",
+		"-targets=targets.json",
+		fmt.Sprintf("-rate=%d/s", testReq.RatePerSecond),
+		fmt.Sprintf("-duration=%s", testReq.DurationSeconds),
+	}
+	if opts.Timeout != nil {
+		flags = append(flags, fmt.Sprintf("-timeout=%s", time.Duration(*opts.Timeout)))
+	}
+	if opts.Redirects != nil {
+		flags = append(flags, fmt.Sprintf("-redirects=%d", *opts.Redirects))
+	}
+	if opts.KeepAlive != nil {
+		flags = append(flags, fmt.Sprintf("-keepalive=%t", *opts.KeepAlive))
+	}
+	if opts.HTTP2 != nil {
+		flags = append(flags, fmt.Sprintf("-http2=%t", *opts.HTTP2))
+	}
+	if opts.H2C {
+		flags = append(flags, "-h2c")
+	}
+	if opts.Insecure {
+		flags = append(flags, "-insecure")
+	}
+	if opts.Connections != nil {
+		flags = append(flags, fmt.Sprintf("-connections=%d", *opts.Connections))
+	}
+	if opts.MaxConnections != nil {
+		flags = append(flags, fmt.Sprintf("-max-connections=%d", *opts.MaxConnections))
+	}
+	if opts.Workers != nil {
+		flags = append(flags, fmt.Sprintf("-workers=%d", *opts.Workers))
+	}
+	if opts.MaxWorkers != nil {
+		flags = append(flags, fmt.Sprintf("-max-workers=%d", *opts.MaxWorkers))
+	}
+	if opts.MaxBody != nil {
+		flags = append(flags, fmt.Sprintf("-max-body=%d", *opts.MaxBody))
+	}
+	if opts.LocalAddr != "" {
+		flags = append(flags, fmt.Sprintf("-laddr=%s", opts.LocalAddr))
+	}
+	for _, entry := range opts.Resolve {
+		from, to, _ := domain.ParseResolveOverride(entry)
+		flags = append(flags, fmt.Sprintf("-connect-to=%s:%s", from, to))
+	}
+
+	// The vegeta CLI takes its proxy from the environment.
+	if opts.Proxy != "" {
+		fmt.Fprintf(&b, "export HTTP_PROXY='%s' HTTPS_PROXY='%s'\n\n", opts.Proxy, opts.Proxy)
+	}
+	fmt.Fprintf(&b, "vegeta attack \\\n  %s \\\n  | tee results.bin | vegeta report\n", strings.Join(flags, " \\\n  "))
+	return b.String(), nil
+}
+
+// renderK6Script produces a k6 script using a constant-arrival-rate scenario,
+// which matches vegeta's open-model pacing.
+func renderK6Script(testReq *domain.TestRequest, targets []exportTarget, opts *domain.AttackOptions) (string, error) {
+	type k6Target struct {
+		Method  string            `json:"method"`
+		URL     string            `json:"url"`
+		Body    string            `json:"body,omitempty"`
+		Headers map[string]string `json:"headers,omitempty"`
+	}
+	k6Targets := make([]k6Target, 0, len(targets))
+	for _, t := range targets {
+		kt := k6Target{Method: t.Method, URL: t.URL, Body: string(t.Body)}
+		if len(t.Header) > 0 {
+			kt.Headers = make(map[string]string, len(t.Header))
+			for k, v := range t.Header {
+				kt.Headers[k] = strings.Join(v, ", ")
+			}
+		}
+		k6Targets = append(k6Targets, kt)
+	}
+	targetsJSON, err := json.MarshalIndent(k6Targets, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode targets: %w", err)
+	}
+
+	thinkMin, thinkMax, err := domain.ParseThinkTime(testReq.ThinkTime)
+	if err != nil {
+		return "", err
+	}
+
+	// Keep enough VUs around to sustain the rate even with one-second responses.
+	preAllocatedVUs := testReq.RatePerSecond
+	if preAllocatedVUs < 1 {
+		preAllocatedVUs = 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// k6 reproduction of test %q (%s)\n", testReq.Name, testReq.ID)
+	if testReq.PacingJitter > 0 {
+		fmt.Fprintf(&b, "// NOTE: pacing jitter (%.2f) has no k6 equivalent and is not reproduced.\n", testReq.PacingJitter)
+	}
+	if opts.Proxy != "" {
+		fmt.Fprintf(&b, "// NOTE: run with HTTPS_PROXY=%s HTTP_PROXY=%s to reproduce the outbound proxy.\n", opts.Proxy, opts.Proxy)
+	}
+	b.WriteString("import http from 'k6/http';\n")
+	b.WriteString("import exec from 'k6/execution';\n")
+	b.WriteString("import { sleep } from 'k6';\n\n")
+
+	b.WriteString("export const options = {\n")
+	if opts.Insecure {
+		b.WriteString("  insecureSkipTLSVerify: true,\n")
+	}
+	if opts.KeepAlive != nil && !*opts.KeepAlive {
+		b.WriteString("  noConnectionReuse: true,\n")
+	}
+	if opts.Redirects != nil {
+		redirects := *opts.Redirects
+		if redirects < 0 {
+			redirects = 0
+		}
+		fmt.Fprintf(&b, "  maxRedirects: %d,\n", redirects)
+	}
+	if opts.LocalAddr != "" {
+		fmt.Fprintf(&b, "  localIPs: '%s',\n", opts.LocalAddr)
+	}
+	if len(opts.Resolve) > 0 {
+		b.WriteString("  hosts: {\n")
+		for _, entry := range opts.Resolve {
+			from, to, _ := domain.ParseResolveOverride(entry)
+			fmt.Fprintf(&b, "    '%s': '%s',\n", from, to)
+		}
+		b.WriteString("  },\n")
+	}
+	b.WriteString("  scenarios: {\n")
+	b.WriteString("    load: {\n")
+	b.WriteString("      executor: 'constant-arrival-rate',\n")
+	fmt.Fprintf(&b, "      rate: %d,\n", testReq.RatePerSecond)
+	b.WriteString("      timeUnit: '1s',\n")
+	fmt.Fprintf(&b, "      duration: '%s',\n", testReq.DurationSeconds)
+	fmt.Fprintf(&b, "      preAllocatedVUs: %d,\n", preAllocatedVUs)
+	fmt.Fprintf(&b, "      maxVUs: %d,\n", preAllocatedVUs*10)
+	b.WriteString("    },\n")
+	b.WriteString("  },\n")
+	b.WriteString("};\n\n")
+
+	fmt.Fprintf(&b, "const targets = %s;\n\n", targetsJSON)
+
+	b.WriteString("export default function () {\n")
+	b.WriteString("  const t = targets[exec.scenario.iterationInTest % targets.length];\n")
+	b.WriteString("  const params = { headers: Object.assign({}, t.headers) };\n")
+	if opts.Timeout != nil {
+		fmt.Fprintf(&b, "  params.timeout = '%dms';\n", time.Duration(*opts.Timeout).Milliseconds())
+	}
+	if testReq.RequestIDPrefix != "" {
+		fmt.Fprintf(&b, "  params.headers['X-Request-ID'] = `%s-${exec.vu.idInTest}-${exec.vu.iterationInScenario}`;\n", testReq.RequestIDPrefix)
+	}
+	b.WriteString("  http.request(t.method, t.url, t.body || null, params);\n")
+	if thinkMax > 0 {
+		fmt.Fprintf(&b, "  sleep(%g + Math.random() * %g);\n", thinkMin.Seconds(), (thinkMax - thinkMin).Seconds())
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
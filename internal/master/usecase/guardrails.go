@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// testGuardrails keeps queued tests from starting while too many tests are
+// running or another test has recently hit one of their target hosts. Tests
+// submitted with OverrideGuardrails start regardless.
+type testGuardrails struct {
+	mu            sync.Mutex
+	maxConcurrent int                  // 0 = no limit
+	cooldown      time.Duration        // 0 = tests may share target hosts
+	running       map[string][]string  // testID -> target hosts, for tests started by this master
+	cooldownUntil map[string]time.Time // host -> end of the cooldown after the last test that hit it
+}
+
+// SetMaxConcurrentTests caps how many tests may run at once. Further tests
+// wait in the queue. Zero, the default, sets no limit.
+func (uc *MasterUsecase) SetMaxConcurrentTests(max int) {
+	uc.guardrails.mu.Lock()
+	defer uc.guardrails.mu.Unlock()
+	uc.guardrails.maxConcurrent = max
+}
+
+// SetTargetCooldown keeps tests that share a target host from running at the
+// same time, and from starting until cooldown has passed since the last one
+// finished. Zero, the default, disables the check.
+func (uc *MasterUsecase) SetTargetCooldown(cooldown time.Duration) {
+	uc.guardrails.mu.Lock()
+	defer uc.guardrails.mu.Unlock()
+	uc.guardrails.cooldown = cooldown
+}
+
+// startGuardrails loads the tests already running, so a restarted master
+// counts them, and releases tests from the guardrails as they finish.
+func (uc *MasterUsecase) startGuardrails(ctx context.Context) {
+	uc.events.Subscribe("guardrails", func(event domain.Event) {
+		if event.Type == domain.EventTestCompleted && uc.guardrails.finished(event.TestID, time.Now()) {
+			uc.testQueue.wake()
+		}
+	})
+
+	tests, err := uc.testRepo.GetAllTestRequests(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load running tests for the guardrails: %v", err)
+		return
+	}
+	for _, test := range tests {
+		if test.Status == "RUNNING" {
+			uc.guardrails.started(test)
+		}
+	}
+}
+
+// reconcileGuardrails releases tests that left RUNNING without a completion
+// event, such as those cleaned up after their workers went offline.
+func (uc *MasterUsecase) reconcileGuardrails(ctx context.Context) {
+	released := false
+	for _, testID := range uc.guardrails.runningIDs() {
+		test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+		if err == nil && test.Status == "RUNNING" {
+			continue
+		}
+		released = uc.guardrails.finished(testID, time.Now()) || released
+	}
+	if released {
+		uc.testQueue.wake()
+	}
+}
+
+// mayStart reports whether a queued test may be given workers now.
+func (uc *MasterUsecase) mayStart(test *domain.TestRequest) bool {
+	return uc.guardrails.heldBy(test, time.Now()) == ""
+}
+
+// heldBy describes the guardrail keeping a test from starting at now, or
+// returns "" if it may start.
+func (g *testGuardrails) heldBy(test *domain.TestRequest, now time.Time) string {
+	if test.OverrideGuardrails {
+		return ""
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.maxConcurrent > 0 && len(g.running) >= g.maxConcurrent {
+		return fmt.Sprintf("concurrent test limit of %d reached", g.maxConcurrent)
+	}
+	if g.cooldown <= 0 {
+		return ""
+	}
+	for _, host := range targetHosts(test) {
+		for _, hosts := range g.running {
+			if slices.Contains(hosts, host) {
+				return fmt.Sprintf("another test is running against %s", host)
+			}
+		}
+		if until := g.cooldownUntil[host]; now.Before(until) {
+			return fmt.Sprintf("%s is cooling down until %s", host, until.Format(time.RFC3339))
+		}
+	}
+	return ""
+}
+
+// started records that a test is running.
+func (g *testGuardrails) started(test *domain.TestRequest) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running == nil {
+		g.running = make(map[string][]string)
+	}
+	g.running[test.ID] = targetHosts(test)
+}
+
+// finished records that a test stopped running at now and starts the cooldown
+// of its hosts. It returns false if the test was not running.
+func (g *testGuardrails) finished(testID string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	hosts, ok := g.running[testID]
+	if !ok {
+		return false
+	}
+	delete(g.running, testID)
+	if g.cooldownUntil == nil {
+		g.cooldownUntil = make(map[string]time.Time)
+	}
+	for _, host := range hosts {
+		g.cooldownUntil[host] = now.Add(g.cooldown)
+	}
+	return true
+}
+
+// runningIDs returns the IDs of the running tests.
+func (g *testGuardrails) runningIDs() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ids := make([]string, 0, len(g.running))
+	for id := range g.running {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// targetHosts returns the distinct hosts a test sends requests to, taken from
+// its indexed targets: URLs, or "METHOD URL" for scenario steps.
+func targetHosts(test *domain.TestRequest) []string {
+	var hosts []string
+	for _, target := range test.TargetURLs {
+		fields := strings.Fields(target)
+		if len(fields) == 0 {
+			continue
+		}
+		u, err := url.Parse(fields[len(fields)-1])
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if host := strings.ToLower(u.Host); !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Bounds of the heartbeat interval a worker may negotiate.
+const (
+	minHeartbeatInterval = time.Second
+	maxHeartbeatInterval = time.Minute
+)
+
+// heartbeatCheckInterval is how often the master looks for workers that
+// missed too many heartbeats.
+const heartbeatCheckInterval = time.Second
+
+// HeartbeatPolicy sets how often workers report while idle and how many of
+// those reports they may miss before the master marks them offline. Workers
+// may ask for another interval at registration; the master clamps it to
+// between one second and one minute.
+type HeartbeatPolicy struct {
+	Interval  time.Duration // Interval of workers that do not ask for one
+	MaxMissed int           // Heartbeats a worker may miss before it is marked offline
+}
+
+// DefaultHeartbeatPolicy is the policy of a master that does not set one.
+var DefaultHeartbeatPolicy = HeartbeatPolicy{
+	Interval:  5 * time.Second,
+	MaxMissed: 3,
+}
+
+// SetHeartbeatPolicy changes the heartbeat policy. Workers that are already
+// registered keep the interval they negotiated.
+func (uc *MasterUsecase) SetHeartbeatPolicy(policy HeartbeatPolicy) error {
+	if policy.Interval < minHeartbeatInterval || policy.Interval > maxHeartbeatInterval || policy.MaxMissed < 1 {
+		return fmt.Errorf("invalid heartbeat policy %+v: interval must be between %v and %v, and missed heartbeats at least 1",
+			policy, minHeartbeatInterval, maxHeartbeatInterval)
+	}
+	uc.heartbeatPolicy = policy
+	return nil
+}
+
+// MaxMissedHeartbeats is how many heartbeats a worker may miss before it is
+// marked offline.
+func (uc *MasterUsecase) MaxMissedHeartbeats() int {
+	return uc.heartbeatPolicy.MaxMissed
+}
+
+// NegotiateHeartbeat returns the interval a registering worker that asked
+// for requested, or zero for the default, must send heartbeats at.
+func (uc *MasterUsecase) NegotiateHeartbeat(workerID string, requested time.Duration) time.Duration {
+	interval := uc.heartbeatPolicy.Interval
+	if requested != 0 {
+		interval = min(max(requested, minHeartbeatInterval), maxHeartbeatInterval)
+	}
+	uc.workerHeartbeats.Store(workerID, interval)
+	return interval
+}
+
+// heartbeatInterval returns the interval a worker negotiated, or the default
+// one for workers registered before the master started.
+func (uc *MasterUsecase) heartbeatInterval(workerID string) time.Duration {
+	if interval, ok := uc.workerHeartbeats.Load(workerID); ok {
+		return interval.(time.Duration)
+	}
+	return uc.heartbeatPolicy.Interval
+}
+
+// missedHeartbeats counts the heartbeats a worker has missed since it was
+// last heard from.
+func (uc *MasterUsecase) missedHeartbeats(worker *domain.Worker, now time.Time) int {
+	return int(now.Sub(worker.LastSeen) / uc.heartbeatInterval(worker.ID))
+}
+
+// startHeartbeatMonitor marks workers offline once they miss too many
+// heartbeats, re-queueing the tests they were running.
+func (uc *MasterUsecase) startHeartbeatMonitor(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			uc.cleanupStaleWorkers(ctx)
+		}
+	}
+}
+
+// cleanupStaleWorkers marks workers that missed too many heartbeats offline
+// and hands the tests they were running to requeueWorkerTest.
+func (uc *MasterUsecase) cleanupStaleWorkers(ctx context.Context) {
+	allWorkers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		log.Printf("Error fetching all workers for stale cleanup: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, worker := range allWorkers {
+		if worker.Status == "OFFLINE" {
+			continue
+		}
+		if missed := uc.missedHeartbeats(worker, now); missed >= uc.heartbeatPolicy.MaxMissed {
+			log.Printf("Worker %s (%s) missed %d heartbeats. Marking offline.", worker.ID, worker.Address, missed)
+			uc.takeWorkerOffline(ctx, worker.ID)
+		}
+	}
+}
+
+// WorkerShutdown handles the last status a worker sends before it shuts
+// down, taking it offline at once rather than after missed heartbeats.
+func (uc *MasterUsecase) WorkerShutdown(ctx context.Context, workerID string) {
+	log.Printf("Worker %s is shutting down. Marking offline.", workerID)
+	uc.takeWorkerOffline(ctx, workerID)
+}
+
+// WorkerDisconnected handles a worker's status stream closing without a FIN.
+// An idle worker is marked offline at once. A worker running a test is left
+// to cleanupStaleWorkers: if it reconnects in time it carries on, otherwise
+// it is taken offline and its test handled by requeueWorkerTest.
+func (uc *MasterUsecase) WorkerDisconnected(ctx context.Context, workerID string) {
+	if worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID); err == nil && worker != nil && worker.CurrentTestID != "" {
+		log.Printf("Worker %s disconnected while running test %s; waiting for its heartbeats to resume.", workerID, worker.CurrentTestID)
+		return
+	}
+	uc.MarkWorkerOffline(ctx, workerID)
+}
+
+// takeWorkerOffline marks a worker offline, stops offering it to tests and
+// re-queues the test it was running, if any.
+func (uc *MasterUsecase) takeWorkerOffline(ctx context.Context, workerID string) {
+	var testID string
+	if worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID); err == nil && worker != nil {
+		testID = worker.CurrentTestID // Marking it offline clears the test
+	}
+	uc.MarkWorkerOffline(ctx, workerID)
+	uc.removeWorkerFromAvailabilityQueue(workerID)
+	uc.requeueWorkerTest(ctx, workerID, testID)
+}
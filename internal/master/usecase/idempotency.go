@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetIdempotencyRepository remembers the Idempotency-Key of submissions in
+// repo for window, so SubmitTestOnce can recognize retries.
+func (uc *MasterUsecase) SetIdempotencyRepository(repo domain.IdempotencyRepository, window time.Duration) {
+	uc.idempotencyRepo = repo
+	uc.idempotencyWindow = window
+}
+
+// SubmitTestOnce submits a test unless its requester already submitted one
+// with the same idempotency key within the idempotency window, in which case
+// it returns that test's ID and true. An empty key always submits.
+func (uc *MasterUsecase) SubmitTestOnce(ctx context.Context, testReq *domain.TestRequest, key string) (string, bool, error) {
+	if key == "" || uc.idempotencyRepo == nil {
+		testID, err := uc.SubmitTest(ctx, testReq)
+		return testID, false, err
+	}
+
+	// Retries usually arrive together, so check and submit one at a time
+	uc.idempotencyMu.Lock()
+	defer uc.idempotencyMu.Unlock()
+
+	now := time.Now()
+	existing, err := uc.idempotencyRepo.GetIdempotencyKey(ctx, testReq.RequesterID, key, now.Add(-uc.idempotencyWindow))
+	if err != nil {
+		return "", false, err
+	}
+	if existing != nil {
+		log.Printf("Duplicate submission with idempotency key %q from user %s, returning test %s",
+			key, testReq.RequesterID, existing.TestID)
+		return existing.TestID, true, nil
+	}
+
+	testID, err := uc.SubmitTest(ctx, testReq)
+	if err != nil {
+		return "", false, err
+	}
+	record := &domain.IdempotencyKey{UserID: testReq.RequesterID, Key: key, TestID: testID, CreatedAt: now}
+	if err := uc.idempotencyRepo.SaveIdempotencyKey(ctx, record, now.Add(-uc.idempotencyWindow)); err != nil {
+		// The test is already queued; only a retry of it would be missed
+		log.Printf("Warning: Failed to save idempotency key for test %s: %v", testID, err)
+	}
+	return testID, false, nil
+}
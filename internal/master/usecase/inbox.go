@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Number of items an inbox summary shows by default and at most.
+const (
+	DefaultInboxSummaryItems = 5
+	MaxInboxSummaryItems     = 50
+)
+
+// SetUserRepository makes inbox items carry the display names of the users
+// who shared them.
+func (uc *MasterUsecase) SetUserRepository(repo domain.UserRepository) {
+	uc.userRepo = repo
+}
+
+// GetInbox lists the links shared with a user or opened by them, newest
+// first, with whether they read each one and who sent it.
+func (uc *MasterUsecase) GetInbox(ctx context.Context, userID string) ([]*domain.InboxItem, error) {
+	links, err := uc.sharedLinkRepo.GetInboxForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.InboxItem, 0, len(links))
+	senderNames := make(map[string]string)
+	for _, link := range links {
+		name, ok := senderNames[link.SharedBy]
+		if !ok {
+			name = uc.senderName(ctx, link.SharedBy)
+			senderNames[link.SharedBy] = name
+		}
+		items = append(items, &domain.InboxItem{
+			SharedLink:   link,
+			Read:         slices.Contains(link.ReadBy, userID),
+			SharedByName: name,
+		})
+	}
+	return items, nil
+}
+
+// GetInboxSummary counts a user's inbox items and returns the newest limit
+// of them; a limit of 0 means DefaultInboxSummaryItems.
+func (uc *MasterUsecase) GetInboxSummary(ctx context.Context, userID string, limit int) (*domain.InboxSummary, error) {
+	if limit <= 0 {
+		limit = DefaultInboxSummaryItems
+	}
+	limit = min(limit, MaxInboxSummaryItems)
+
+	items, err := uc.GetInbox(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &domain.InboxSummary{Total: len(items), Latest: items[:min(limit, len(items))]}
+	for _, item := range items {
+		if !item.Read && !item.IsExpired {
+			summary.Unread++
+		}
+	}
+	return summary, nil
+}
+
+// MarkInboxItemRead marks an inbox item read for a user, so that their other
+// sessions update their unread count.
+func (uc *MasterUsecase) MarkInboxItemRead(ctx context.Context, linkID, userID string) error {
+	if err := uc.sharedLinkRepo.MarkInboxItemRead(ctx, linkID, userID); err != nil {
+		return err
+	}
+	uc.events.Publish(domain.Event{Type: domain.EventInboxUpdated, UserIDs: []string{userID}})
+	return nil
+}
+
+// senderName returns the display name of the user who shared a link, or ""
+// if users cannot be looked up or the user no longer exists.
+func (uc *MasterUsecase) senderName(ctx context.Context, userID string) string {
+	if uc.userRepo == nil {
+		return ""
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.DisplayName()
+}
+
+// publishInboxUpdate tells the recipients of a shared link that their inbox
+// changed. The sharer is not notified of links they sent themselves. The
+// event carries no test ID, so it never reaches the test's event streams.
+func (uc *MasterUsecase) publishInboxUpdate(link *domain.SharedLink, recipients []string) {
+	userIDs := slices.DeleteFunc(slices.Clone(recipients), func(id string) bool { return id == link.SharedBy })
+	if len(userIDs) == 0 {
+		return
+	}
+	uc.events.Publish(domain.Event{Type: domain.EventInboxUpdated, UserIDs: userIDs})
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// interimAggregates holds the latest interim result of each worker of the
+// running tests, from which their rolling aggregates are computed. Each
+// result is cumulative, so the memory held does not grow with a test's
+// duration; older flushes are only kept in the repository.
+type interimAggregates struct {
+	mu    sync.Mutex
+	tests map[string]map[string]*domain.InterimResult // testID -> workerID -> latest result
+}
+
+// SetInterimResultRepository stores the interim results workers flush while
+// long tests run, and forgets a test's rolling aggregate once it finishes.
+func (uc *MasterUsecase) SetInterimResultRepository(repo domain.InterimResultRepository) {
+	uc.interimRepo = repo
+	uc.interim.tests = make(map[string]map[string]*domain.InterimResult)
+	uc.events.Subscribe("interim", func(event domain.Event) {
+		if event.Type == domain.EventTestCompleted {
+			uc.interim.mu.Lock()
+			delete(uc.interim.tests, event.TestID)
+			uc.interim.mu.Unlock()
+		}
+	})
+}
+
+// SaveInterimResult stores a worker's cumulative result of a running test and
+// updates the test's rolling aggregate.
+func (uc *MasterUsecase) SaveInterimResult(ctx context.Context, result *domain.InterimResult) error {
+	if uc.interimRepo == nil {
+		return fmt.Errorf("interim results are not supported by this master")
+	}
+	if err := uc.interimRepo.SaveInterimResult(ctx, result); err != nil {
+		return err
+	}
+
+	uc.interim.mu.Lock()
+	workers, ok := uc.interim.tests[result.TestID]
+	if !ok {
+		workers = make(map[string]*domain.InterimResult)
+		uc.interim.tests[result.TestID] = workers
+	}
+	if latest, ok := workers[result.WorkerID]; !ok || latest.Sequence < result.Sequence {
+		workers[result.WorkerID] = result
+	}
+	uc.interim.mu.Unlock()
+
+	log.Printf("Saved interim result %d from worker %s for test %s (%d requests so far)",
+		result.Sequence, result.WorkerID, result.TestID, result.TotalRequests)
+	uc.events.Publish(domain.Event{Type: domain.EventTestInterim, TestID: result.TestID, WorkerID: result.WorkerID,
+		Message: fmt.Sprintf("Interim result %d: %d requests", result.Sequence, result.TotalRequests)})
+	return nil
+}
+
+// GetInterimReport returns the rolling aggregate of a test's latest interim
+// results and its aggregate at every flush so far. Once the test finishes,
+// the rolling aggregate is rebuilt from the repository.
+func (uc *MasterUsecase) GetInterimReport(ctx context.Context, testID string) (*domain.InterimReport, error) {
+	if uc.interimRepo == nil {
+		return nil, fmt.Errorf("interim results are not supported by this master")
+	}
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+
+	var latest []*domain.TestResult
+	uc.interim.mu.Lock()
+	for _, result := range uc.interim.tests[testID] {
+		latest = append(latest, &result.TestResult)
+	}
+	uc.interim.mu.Unlock()
+	if latest == nil {
+		stored, err := uc.interimRepo.GetLatestInterimResults(ctx, testID)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range stored {
+			latest = append(latest, &result.TestResult)
+		}
+	}
+
+	history, err := uc.interimRepo.GetInterimResults(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.InterimReport{TestID: testID, Snapshots: interimSnapshots(testID, history)}
+	if len(latest) > 0 {
+		report.Rolling = rollingAggregate(testID, latest)
+	}
+	return report, nil
+}
+
+// rollingAggregate aggregates the latest interim results of a test's
+// workers. The test is still running, so the result is marked IN_PROGRESS
+// and dated by the latest flush.
+func rollingAggregate(testID string, results []*domain.TestResult) *domain.TestResultAggregated {
+	aggregated := aggregateResults(testID, results)
+	aggregated.OverallStatus = "IN_PROGRESS"
+	aggregated.CompletedAt = time.Time{}
+	for _, result := range results {
+		if result.Timestamp.After(aggregated.CompletedAt) {
+			aggregated.CompletedAt = result.Timestamp
+		}
+	}
+	return aggregated
+}
+
+// interimSnapshots aggregates a test's interim results flush by flush.
+// Workers that missed a flush are left out of it.
+func interimSnapshots(testID string, results []*domain.InterimResult) []domain.InterimSnapshot {
+	bySequence := make(map[int][]*domain.TestResult)
+	for _, result := range results {
+		bySequence[result.Sequence] = append(bySequence[result.Sequence], &result.TestResult)
+	}
+
+	snapshots := make([]domain.InterimSnapshot, 0, len(bySequence))
+	for sequence, flushed := range bySequence {
+		aggregated := rollingAggregate(testID, flushed)
+		snapshots = append(snapshots, domain.InterimSnapshot{
+			Sequence:       sequence,
+			Time:           aggregated.CompletedAt,
+			Workers:        len(flushed),
+			TotalRequests:  aggregated.TotalRequests,
+			FailedRequests: aggregated.FailedRequests,
+			AvgLatencyMs:   aggregated.AvgLatencyMs,
+			P95LatencyMs:   aggregated.P95LatencyMs,
+			ThroughputRPS:  aggregated.ThroughputRPS,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Sequence < snapshots[j].Sequence })
+	return snapshots
+}
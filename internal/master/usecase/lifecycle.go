@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// DefaultIdleWorkerConnTimeout is how long a worker may go unheard from before
+// the master closes its connection to it, unless configured otherwise.
+const DefaultIdleWorkerConnTimeout = 10 * time.Minute
+
+// goroutineGrowthWarning is how many goroutines above the count at the first
+// sweep make the lifecycle job warn of a likely leak.
+const goroutineGrowthWarning = 5000
+
+// lifecycleStats counts what the lifecycle sweeps found and released.
+type lifecycleStats struct {
+	mu                sync.Mutex
+	baseGoroutines    int
+	peakGoroutines    int
+	closedConnections int64
+	releasedTests     int64
+	lastSweepAt       time.Time
+}
+
+// SetIdleWorkerConnTimeout sets how long a worker may go unheard from before
+// the lifecycle job closes the master's connection to it.
+func (uc *MasterUsecase) SetIdleWorkerConnTimeout(timeout time.Duration) error {
+	if timeout < time.Minute {
+		return fmt.Errorf("invalid idle worker connection timeout %v: must be at least 1m", timeout)
+	}
+	uc.idleConnTimeout = timeout
+	return nil
+}
+
+// StartLifecycleJob periodically releases the in-memory state of finished
+// tests and departed workers, and records the goroutine count.
+func (uc *MasterUsecase) StartLifecycleJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Starting lifecycle job with interval: %v", interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Lifecycle job stopped due to context cancellation")
+			return
+		case <-ticker.C:
+			uc.sweepLifecycle(ctx)
+		}
+	}
+}
+
+// sweepLifecycle runs one pass of the lifecycle job.
+func (uc *MasterUsecase) sweepLifecycle(ctx context.Context) {
+	released := uc.releaseFinishedTests(ctx)
+	closed := uc.closeIdleWorkerConnections(ctx, time.Now())
+
+	goroutines := runtime.NumGoroutine()
+	uc.lifecycle.mu.Lock()
+	if uc.lifecycle.baseGoroutines == 0 {
+		uc.lifecycle.baseGoroutines = goroutines
+	}
+	uc.lifecycle.peakGoroutines = max(uc.lifecycle.peakGoroutines, goroutines)
+	uc.lifecycle.releasedTests += int64(released)
+	uc.lifecycle.closedConnections += int64(closed)
+	uc.lifecycle.lastSweepAt = time.Now()
+	base := uc.lifecycle.baseGoroutines
+	uc.lifecycle.mu.Unlock()
+
+	if released > 0 || closed > 0 {
+		log.Printf("Lifecycle sweep released %d finished tests and closed %d idle worker connections (%d goroutines)", released, closed, goroutines)
+	}
+	if goroutines-base >= goroutineGrowthWarning {
+		log.Printf("Warning: %d goroutines are running, %d more than at startup; the master may be leaking them", goroutines, goroutines-base)
+	}
+}
+
+// releaseTestAssignments forgets which workers a test was assigned to, once
+// it no longer needs tracking.
+func (uc *MasterUsecase) releaseTestAssignments(testID string) {
+	uc.mu.Lock()
+	uc.activeTestAssignments.Delete(testID)
+	uc.mu.Unlock()
+}
+
+// releaseFinishedTests drops the assignment entries of tests that reached a
+// final status, or no longer exist, and returns how many it dropped. Most
+// are released as their last worker reports; this catches aborted and
+// failed tests.
+func (uc *MasterUsecase) releaseFinishedTests(ctx context.Context) int {
+	var testIDs []string
+	uc.activeTestAssignments.Range(func(key, _ interface{}) bool {
+		testIDs = append(testIDs, key.(string))
+		return true
+	})
+
+	released := 0
+	for _, testID := range testIDs {
+		test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+		if err != nil && ctx.Err() != nil {
+			return released
+		}
+		if err == nil && !domain.IsFinalTestStatus(test.Status) {
+			continue
+		}
+		uc.releaseTestAssignments(testID)
+		released++
+	}
+	return released
+}
+
+// closeIdleWorkerConnections closes the master's connections to workers that
+// were removed, went offline, or haven't been heard from for the idle
+// timeout, and returns how many it closed. A worker that reports again is
+// reconnected.
+func (uc *MasterUsecase) closeIdleWorkerConnections(ctx context.Context, now time.Time) int {
+	timeout := uc.idleConnTimeout
+	if timeout == 0 {
+		timeout = DefaultIdleWorkerConnTimeout
+	}
+
+	closed := 0
+	uc.activeWorkerClients.Range(func(key, val interface{}) bool {
+		workerID := key.(string)
+		worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+		if err != nil && ctx.Err() != nil {
+			return false
+		}
+		if err == nil && worker != nil && worker.Status != "OFFLINE" && now.Sub(worker.LastSeen) < timeout {
+			return true
+		}
+		// Only close the connection this sweep saw, not one a new registration put in its place
+		if uc.activeWorkerClients.CompareAndDelete(workerID, val) {
+			uc.workerConnStates.Delete(workerID)
+			val.(*grpc.ClientConn).Close()
+			log.Printf("Closed idle connection to worker %s", workerID)
+			closed++
+		}
+		return true
+	})
+	return closed
+}
+
+// RuntimeStats returns the master's goroutine count and the in-memory state
+// the lifecycle job keeps bounded.
+func (uc *MasterUsecase) RuntimeStats() *domain.RuntimeStats {
+	stats := &domain.RuntimeStats{Goroutines: runtime.NumGoroutine()}
+	uc.activeWorkerClients.Range(func(_, _ interface{}) bool {
+		stats.WorkerConnections++
+		return true
+	})
+	uc.activeTestAssignments.Range(func(_, _ interface{}) bool {
+		stats.TestAssignments++
+		return true
+	})
+	uc.liveProgress.Range(func(_, _ interface{}) bool {
+		stats.LiveTests++
+		return true
+	})
+
+	uc.lifecycle.mu.Lock()
+	defer uc.lifecycle.mu.Unlock()
+	stats.PeakGoroutines = max(uc.lifecycle.peakGoroutines, stats.Goroutines)
+	stats.ClosedConnections = uc.lifecycle.closedConnections
+	stats.ReleasedTests = uc.lifecycle.releasedTests
+	if !uc.lifecycle.lastSweepAt.IsZero() {
+		lastSweepAt := uc.lifecycle.lastSweepAt
+		stats.LastSweepAt = &lastSweepAt
+	}
+	return stats
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"sort" // Required for sorting p95Latencies
+	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	"github.com/pace-noge/distributed-load-tester/internal/utils"
 	pb "github.com/pace-noge/distributed-load-tester/proto"
 )
 
@@ -26,13 +29,81 @@ type MasterUsecase struct {
 	testResultRepo        domain.TestResultRepository
 	aggregatedResultRepo  domain.AggregatedResultRepository
 	activeWorkerClients   sync.Map // Map[string]*grpc.ClientConn
+	workerConnStates      sync.Map // Map[string]connectivity.State // workerID -> state of its connection
+	workerHeartbeats      sync.Map // Map[string]time.Duration // workerID -> negotiated heartbeat interval
+	workerClocks          sync.Map // Map[string]workerClock // workerID -> master's clock minus the worker's, as it measured it
 	activeTestAssignments sync.Map // Map[string]map[string]bool // testID -> workerID -> assigned
 	// For managing test distribution to workers
-	testQueue          chan *domain.TestRequest
+	testQueue          *testQueue
 	workerAvailability chan string     // Channel for available worker IDs
 	availableWorkers   map[string]bool // Track which workers are already in the availability queue
-	mu                 sync.Mutex      // Protects access to testQueue, workerAvailability, and availableWorkers
+	mu                 sync.Mutex      // Protects access to workerAvailability and availableWorkers
 	sharedLinkRepo     domain.SharedLinkRepository
+	attachmentRepo     domain.AttachmentRepository
+	metricsRepo        domain.MetricsRepository
+
+	// Approval workflow (disabled when approvalWebhookURL is empty)
+	approvalWebhookURL    string
+	approvalWebhookSecret string
+
+	redactor *utils.Redactor // Scrubs exports and results shared outside the organization
+
+	liveProgress sync.Map // Map[string]*liveTest // testID -> live worker progress
+
+	events *EventBus // Test and worker events for the WebSocket hub and other integrations
+
+	requestRecords domain.RequestRecordStore // Per-request records; nil unless a ClickHouse sink is configured
+
+	metricsMu      sync.Mutex
+	pendingMetrics []domain.MetricSample // Progress samples waiting to be written by the metrics flush job
+
+	dashboard dashboardCache // Tests shown on the dashboard, kept current by the dashboard cache job
+
+	auditRepo domain.AuditRepository // nil unless the audit log is enabled
+
+	teamRepo domain.TeamRepository // nil unless teams are enabled
+
+	rejectOverCapacity  bool   // Fail tests that ask workers for more than their calibrated maximum rate
+	fleetCapacityPolicy string // What SubmitTest does with tests the fleet cannot carry; empty = FleetCapacityWarn
+
+	guardrails   testGuardrails     // Concurrent test limit and target cooldown
+	confirmation confirmationPolicy // Thresholds above which submitted tests must be confirmed
+
+	rpcPolicy       WorkerRPCPolicy // Deadlines, retries and reconnect backoff of RPCs to workers
+	heartbeatPolicy HeartbeatPolicy // Heartbeat interval of workers and how many they may miss
+	startDelay      time.Duration   // How long after assignment a test starts on all its workers; 0 = as each accepts
+
+	idempotencyRepo   domain.IdempotencyRepository // nil unless submissions may carry an Idempotency-Key
+	idempotencyWindow time.Duration                // How long a key maps retries to the test it created
+	idempotencyMu     sync.Mutex                   // Serializes submissions that carry a key
+
+	rollout workerRollout // Rolling upgrade of the workers, and the workers it drains
+
+	interimRepo domain.InterimResultRepository // nil unless workers may flush interim results
+	interim     interimAggregates              // Latest interim result of each worker of running tests
+
+	captureRepo domain.RequestCaptureRepository // nil unless workers may upload the requests they captured
+
+	preferencesRepo domain.PreferencesRepository // nil unless tests take omitted settings from their requester's preferences
+
+	publicShareKey []byte // Signs public shared link tokens; nil unless links may be public
+
+	userRepo domain.UserRepository // nil unless inbox items name their senders
+
+	timelineRepo domain.TimelineRepository // nil unless the lifecycle of tests is recorded
+
+	apdexThreshold time.Duration // Apdex threshold of tests submitted without one; 0 = domain.DefaultApdexThreshold
+
+	rateAttainmentWarning float64 // Rate attainment (%) below which aggregates warn; 0 = domain.DefaultRateAttainmentWarning
+
+	idleConnTimeout time.Duration // Workers unheard from this long lose their connection; 0 = DefaultIdleWorkerConnTimeout
+	lifecycle       lifecycleStats
+
+	workerWaitTimeout    time.Duration // How long tests wait for workers by default; 0 = DefaultWorkerWaitTimeout
+	housekeepingInterval time.Duration // Idle time after which the distribution routine checks on the queue; 0 = DefaultHousekeepingInterval
+	maxResumeAge         time.Duration // Queued tests older than this are cancelled rather than resumed at startup; 0 = resume all
+
+	aggregates aggregatePipeline // Named aggregates computed besides the fixed one; empty unless strategies are enabled
 }
 
 // NewMasterUsecase creates a new MasterUsecase instance.
@@ -42,38 +113,64 @@ func NewMasterUsecase(
 	trr domain.TestResultRepository,
 	arr domain.AggregatedResultRepository,
 	slr domain.SharedLinkRepository, // new
+	atr domain.AttachmentRepository,
+	mr domain.MetricsRepository,
 ) *MasterUsecase {
 
+	// Default rules always compile
+	redactor, _ := utils.NewRedactor(utils.DefaultRedactionRules())
+
 	uc := &MasterUsecase{
 		workerRepo:           wr,
 		testRepo:             tr,
 		testResultRepo:       trr,
 		aggregatedResultRepo: arr,
-		sharedLinkRepo:       slr,                                 // new
-		testQueue:            make(chan *domain.TestRequest, 100), // Buffered channel for tests
-		workerAvailability:   make(chan string, 200),              // Buffered channel for available worker IDs
-		availableWorkers:     make(map[string]bool),               // Track workers in availability queue
+		sharedLinkRepo:       slr, // new
+		attachmentRepo:       atr,
+		metricsRepo:          mr,
+		testQueue:            newTestQueue(),
+		workerAvailability:   make(chan string, 200), // Buffered channel for available worker IDs
+		availableWorkers:     make(map[string]bool),  // Track workers in availability queue
+		redactor:             redactor,
+		events:               NewEventBus(),
+		rpcPolicy:            DefaultWorkerRPCPolicy,
+		heartbeatPolicy:      DefaultHeartbeatPolicy,
+		startDelay:           DefaultStartDelay,
+		maxResumeAge:         DefaultMaxResumeAge,
 	}
-	go uc.startTestDistributionRoutine()
 	return uc
 }
 
-// RegisterWorker registers a new worker with the master.
+// StartTestDistribution starts assigning queued tests to available workers.
+// Masters that only serve reads, such as read-only replicas, never call it.
+func (uc *MasterUsecase) StartTestDistribution() {
+	go uc.startHeartbeatMonitor(context.Background())
+	go uc.startTestDistributionRoutine()
+}
+
+// RegisterWorker registers a new worker with the master. The connection to
+// the worker's gRPC endpoint is made in the background, so a slow address
+// does not hold up registration.
 func (uc *MasterUsecase) RegisterWorker(ctx context.Context, worker *domain.Worker) error {
-	// Attempt to connect to the worker's gRPC endpoint
-	conn, err := grpc.DialContext(ctx, worker.Address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	options := append(uc.rpcPolicy.dialOptions(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(worker.Address, options...)
 	if err != nil {
-		return fmt.Errorf("failed to connect to worker %s at %s: %w", worker.ID, worker.Address, err)
+		return fmt.Errorf("invalid address %q for worker %s: %w", worker.Address, worker.ID, err)
 	}
 
-	uc.activeWorkerClients.Store(worker.ID, conn)
+	// A worker registering again replaces its previous connection
+	if previous, ok := uc.activeWorkerClients.Swap(worker.ID, conn); ok {
+		previous.(*grpc.ClientConn).Close()
+	}
 	worker.Status = "READY"
 	err = uc.workerRepo.RegisterWorker(ctx, worker)
 	if err != nil {
 		conn.Close() // Close connection if DB registration fails
-		uc.activeWorkerClients.Delete(worker.ID)
+		uc.activeWorkerClients.CompareAndDelete(worker.ID, conn)
 		return fmt.Errorf("failed to save worker to repository: %w", err)
 	}
+	go uc.watchWorkerConnection(worker.ID, conn)
+	uc.noteRolloutRegistration(worker.ID, worker.Version)
 
 	// Add worker to availability queue
 	uc.addWorkerToAvailabilityQueue(worker.ID)
@@ -90,6 +187,7 @@ func (uc *MasterUsecase) UpdateWorkerStatus(ctx context.Context, workerID string
 
 	// If worker becomes READY, push to availability queue
 	if status == "READY" {
+		uc.reconnectWorker(ctx, workerID)
 		uc.addWorkerToAvailabilityQueue(workerID)
 	}
 	return nil
@@ -103,8 +201,10 @@ func (uc *MasterUsecase) MarkWorkerOffline(ctx context.Context, workerID string)
 		log.Printf("Failed to mark worker %s offline in DB: %v", workerID, err)
 		// Don't return error to allow other cleanup
 	}
+	uc.events.Publish(domain.Event{Type: domain.EventWorkerOffline, WorkerID: workerID})
 
 	// Close gRPC connection and remove from active clients
+	uc.workerConnStates.Delete(workerID)
 	if connVal, ok := uc.activeWorkerClients.LoadAndDelete(workerID); ok {
 		if conn, ok := connVal.(*grpc.ClientConn); ok {
 			conn.Close()
@@ -123,43 +223,32 @@ func (uc *MasterUsecase) SubmitTest(ctx context.Context, testReq *domain.TestReq
 	testReq.CompletedWorkers = []string{}
 	testReq.FailedWorkers = []string{}
 
-	// Set default worker count if not specified
-	if testReq.WorkerCount == 0 {
-		testReq.WorkerCount = 1
+	// Reject invalid definitions before anything is saved
+	if errs := uc.ValidateTestRequest(ctx, testReq); len(errs) > 0 {
+		return "", errs[0]
 	}
 
-	// Set default rate distribution mode if not specified
-	if testReq.RateDistribution == "" {
-		testReq.RateDistribution = "shared" // Default to shared distribution
+	// Make the submitter confirm very large tests, in case the rate or duration was mistyped
+	if err := uc.checkConfirmation(testReq, time.Now()); err != nil {
+		return "", err
 	}
 
-	// Validate rate distribution mode
-	validModes := []string{"shared", "same", "weighted", "ramped", "burst"}
-	isValid := false
-	for _, mode := range validModes {
-		if testReq.RateDistribution == mode {
-			isValid = true
-			break
-		}
+	// Check the rate against what the registered workers can generate
+	if err := uc.checkFleetCapacity(ctx, testReq); err != nil {
+		return "", err
 	}
-	if !isValid {
-		return "", fmt.Errorf("invalid rate_distribution: must be one of %v", validModes)
+
+	testReq.TargetURLs = uc.testTargetURLs(testReq)
+
+	// Injected request IDs are prefixed with the test ID so target logs can be grepped per run
+	if testReq.InjectRequestID {
+		testReq.RequestIDPrefix = testReq.ID
 	}
+	testReq.Apdex = uc.testApdex(testReq.Apdex)
 
-	// Validate weighted distribution
-	if testReq.RateDistribution == "weighted" {
-		if len(testReq.RateWeights) == 0 {
-			return "", fmt.Errorf("rate_weights must be provided for weighted distribution")
-		}
-		if len(testReq.RateWeights) != int(testReq.WorkerCount) {
-			return "", fmt.Errorf("rate_weights length (%d) must match worker_count (%d)", len(testReq.RateWeights), testReq.WorkerCount)
-		}
-		// Validate weights are positive
-		for i, weight := range testReq.RateWeights {
-			if weight <= 0 {
-				return "", fmt.Errorf("rate_weights[%d] must be positive, got %f", i, weight)
-			}
-		}
+	// Hold the test for external approval before it can be scheduled
+	if uc.approvalWebhookURL != "" {
+		testReq.Status = TestStatusAwaitingApproval
 	}
 
 	err := uc.testRepo.SaveTestRequest(ctx, testReq)
@@ -167,95 +256,114 @@ func (uc *MasterUsecase) SubmitTest(ctx context.Context, testReq *domain.TestReq
 		return "", fmt.Errorf("failed to save test request: %w", err)
 	}
 
-	// Put test into queue for assignment
-	select {
-	case uc.testQueue <- testReq:
-		log.Printf("Test %s submitted and added to assignment queue (requires %d workers, rate distribution: %s).",
-			testReq.ID, testReq.WorkerCount, testReq.RateDistribution)
+	auditCtx := ctx
+	if domain.AuditSourceFrom(ctx).ActorID == "" {
+		auditCtx = domain.WithAuditActor(ctx, testReq.RequesterID, "")
+	}
+	uc.audit(auditCtx, domain.NewAuditEntry(auditCtx, domain.AuditTestSubmit, testReq.ID, testReq))
+	uc.events.Publish(domain.Event{Type: domain.EventTestSubmitted, TestID: testReq.ID, Status: testReq.Status, Test: testReq})
+	if testReq.Status == TestStatusAwaitingApproval {
+		log.Printf("Test %s submitted and awaiting approval.", testReq.ID)
 		return testReq.ID, nil
-	case <-ctx.Done():
-		return "", ctx.Err()
-	case <-time.After(5 * time.Second): // Timeout if queue is full
-		return "", fmt.Errorf("test queue is full, please try again later")
 	}
+
+	if err := uc.enqueueTest(ctx, testReq); err != nil {
+		return "", err
+	}
+	return testReq.ID, nil
+}
+
+// enqueueTest puts a test into the queue for assignment. While the queue
+// window is full the test waits in the database, which it is already saved
+// to as PENDING.
+func (uc *MasterUsecase) enqueueTest(ctx context.Context, testReq *domain.TestRequest) error {
+	if uc.testQueue.admit(testReq) {
+		log.Printf("Test %s submitted and added to assignment queue (requires %d workers, rate distribution: %s, priority: %s).",
+			testReq.ID, testReq.WorkerCount, testReq.RateDistribution, testReq.Priority)
+	} else {
+		log.Printf("Test %s submitted; the queue window is full, so it waits in the database (requires %d workers, priority: %s).",
+			testReq.ID, testReq.WorkerCount, testReq.Priority)
+	}
+	uc.recordTimeline(testReq.ID, domain.TimelineTestQueued, "",
+		fmt.Sprintf("Queued at %s priority, needs %d workers", testReq.Priority, testReq.WorkerCount))
+	return nil
+}
+
+// DefaultHousekeepingInterval is how long the distribution routine waits for
+// a queued test before it checks for stuck tests, retries tests held by the
+// guardrails and loads tests waiting in the database, unless configured
+// otherwise.
+const DefaultHousekeepingInterval = 10 * time.Second
+
+// SetHousekeepingInterval sets how long the distribution routine waits for a
+// queued test before doing its housekeeping.
+func (uc *MasterUsecase) SetHousekeepingInterval(interval time.Duration) error {
+	if interval < time.Second {
+		return fmt.Errorf("invalid housekeeping interval %v: must be at least 1s", interval)
+	}
+	uc.housekeepingInterval = interval
+	return nil
+}
+
+// housekeepingTick returns the configured housekeeping interval.
+func (uc *MasterUsecase) housekeepingTick() time.Duration {
+	if uc.housekeepingInterval > 0 {
+		return uc.housekeepingInterval
+	}
+	return DefaultHousekeepingInterval
 }
 
 // startTestDistributionRoutine is a goroutine that continuously assigns tests to available workers.
 func (uc *MasterUsecase) startTestDistributionRoutine() {
 	log.Println("Starting test distribution routine...")
+	uc.startGuardrails(context.Background())
+	uc.resumeQueuedTests(context.Background())
 	for {
 		select {
-		case testReq := <-uc.testQueue:
-			log.Printf("Picked up test %s from queue. Looking for %d available workers...", testReq.ID, testReq.WorkerCount)
-
-			// Collect the required number of workers
-			var assignedWorkers []string
-			timeout := time.After(30 * time.Second) // Wait up to 30 seconds to gather workers
-
-			for uint32(len(assignedWorkers)) < testReq.WorkerCount {
-				select {
-				case workerID := <-uc.workerAvailability:
-					assignedWorkers = append(assignedWorkers, workerID)
-					uc.removeWorkerFromAvailabilityQueue(workerID) // Remove from tracking
-					log.Printf("Worker %s assigned to test %s (%d/%d workers collected)",
-						workerID, testReq.ID, len(assignedWorkers), testReq.WorkerCount)
-				case <-timeout:
-					log.Printf("Timeout waiting for workers for test %s. Only %d/%d workers available",
-						testReq.ID, len(assignedWorkers), testReq.WorkerCount)
-
-					// If we have at least one worker, proceed with partial assignment
-					if len(assignedWorkers) > 0 {
-						log.Printf("Proceeding with partial assignment for test %s using %d workers",
-							testReq.ID, len(assignedWorkers))
-						break
-					} else {
-						// No workers available, re-queue the test
-						log.Printf("No workers available for test %s, re-queueing", testReq.ID)
-						select {
-						case uc.testQueue <- testReq:
-						default:
-							log.Printf("Failed to re-queue test %s, marking as failed", testReq.ID)
-							uc.testRepo.UpdateTestStatus(context.Background(), testReq.ID, "FAILED",
-								testReq.CompletedWorkers, append(testReq.FailedWorkers, "NoWorkersAvailable"))
-						}
-						continue
-					}
-				}
+		case <-uc.testQueue.ready:
+			uc.refillQueue(context.Background())
+			testReq := uc.testQueue.pop(time.Now(), uc.mayStart)
+			if testReq == nil {
+				continue
+			}
+			log.Printf("Picked up test %s (priority: %s) from queue. Looking for %d available workers...",
+				testReq.ID, testReq.Priority, testReq.WorkerCount)
+
+			// Collect the required number of workers, applying the test's partial
+			// assignment policy if fewer turn up in time
+			assignedWorkers := uc.gatherWorkers(testReq, uc.workerWaitFor(testReq))
+			uc.recordTimeline(testReq.ID, domain.TimelineWorkersCollected, "",
+				fmt.Sprintf("Collected %d of %d workers: %s", len(assignedWorkers), testReq.WorkerCount, strings.Join(assignedWorkers, ", ")))
+			if uint32(len(assignedWorkers)) < testReq.WorkerCount && !uc.acceptPartialAssignment(testReq, assignedWorkers) {
+				continue
 			}
 
 			// Assign test to all collected workers concurrently
 			uc.assignTestToMultipleWorkers(context.Background(), testReq, assignedWorkers)
+			uc.testQueue.assigned()
 
-		case <-time.After(10 * time.Second):
-			// Periodically check for workers that might have gone offline without notifying
-			// and re-queue tests if assigned to offline workers.
-			uc.cleanupStaleWorkers(context.Background())
-			// Also check for stuck tests due to worker count mismatches
+		case <-time.After(uc.housekeepingTick()):
+			// Check for stuck tests due to worker count mismatches
 			uc.fixStuckTests(context.Background())
+			// Retry tests held by the guardrails, whose hold may have ended
+			uc.reconcileGuardrails(context.Background())
+			uc.refillQueue(context.Background())
+			uc.testQueue.wake()
 		}
 	}
 }
 
 // assignTestToWorker sends a test assignment to a specific worker via gRPC.
 func (uc *MasterUsecase) assignTestToWorker(ctx context.Context, testReq *domain.TestRequest, workerID string) {
-	connVal, ok := uc.activeWorkerClients.Load(workerID)
+	client, ok := uc.workerClient(workerID)
 	if !ok {
 		log.Printf("Worker %s connection not found. Re-queueing test %s.", workerID, testReq.ID)
-		select {
-		case uc.testQueue <- testReq: // Re-queue the test
-		default:
-			log.Printf("Failed to re-queue test %s, test queue full.", testReq.ID)
-			// Mark test as failed if it can't be re-queued
-			uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "FAILED", testReq.CompletedWorkers, append(testReq.FailedWorkers, "NoWorkersAvailable"))
-		}
+		uc.testQueue.push(testReq) // Re-queue the test
 		// Also mark worker as offline if it was expected to be available but isn't
 		uc.MarkWorkerOffline(ctx, workerID)
 		return
 	}
 
-	conn := connVal.(*grpc.ClientConn)
-	client := pb.NewWorkerServiceClient(conn)
-
 	// Update test status to RUNNING (but don't assign worker until successful)
 	uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "RUNNING", nil, nil) // Update overall test status
 
@@ -268,36 +376,40 @@ func (uc *MasterUsecase) assignTestToWorker(ctx context.Context, testReq *domain
 		DurationSeconds:   testReq.DurationSeconds,
 		RatePerSecond:     testReq.RatePerSecond,
 		TargetsBase64:     testReq.TargetsBase64,
-	}
-
-	assignmentCtx, cancel := context.WithTimeout(ctx, 10*time.Second) // Timeout for assignment RPC
-	defer cancel()
-
-	resp, err := client.AssignTest(assignmentCtx, assignment)
+		ThinkTime:         testReq.ThinkTime,
+		PacingJitter:      testReq.PacingJitter,
+		RequestIdPrefix:   testReq.RequestIDPrefix,
+		TemplateTargets:   testReq.TemplateTargets,
+		ScenarioJson:      testReq.ScenarioJSON,
+		Preflight:         testReq.Preflight,
+		Smoke:             testReq.Smoke,
+		InterimInterval:   testReq.InterimInterval,
+		CaptureRequests:   testReq.CaptureRequests,
+		Protocol:          testReq.Protocol,
+		Script:            testReq.Script,
+		LabelHeaders:      assignmentLabels(testReq),
+	}
+	setAssignmentApdex(assignment, testReq)
+	if testReq.TemplateTargets {
+		seqRange := sequenceRanges(testReq.SequenceStart, testReq.DurationSeconds, []uint64{testReq.RatePerSecond}, sequenceReserve(testReq))[0]
+		assignment.SequenceStart, assignment.SequenceEnd = seqRange[0], seqRange[1]
+	}
+
+	resp, err := client.AssignTest(ctx, assignment)
 	if err != nil {
 		log.Printf("Failed to assign test %s to worker %s: %v", testReq.ID, workerID, err)
 		// Mark worker as offline, re-queue test
 		uc.MarkWorkerOffline(ctx, workerID)
 		uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, workerID)
-		select {
-		case uc.testQueue <- testReq:
-			log.Printf("Test %s re-queued due to assignment failure with worker %s.", testReq.ID, workerID)
-		default:
-			log.Printf("Failed to re-queue test %s, test queue full. Marking test as failed.", testReq.ID)
-			uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "FAILED", testReq.CompletedWorkers, append(testReq.FailedWorkers, "AssignmentFailed"))
-		}
+		uc.testQueue.push(testReq)
+		log.Printf("Test %s re-queued due to assignment failure with worker %s.", testReq.ID, workerID)
 		return
 	}
 
 	if !resp.Accepted {
 		log.Printf("Worker %s rejected test %s assignment: %s. Re-queueing test.", workerID, testReq.ID, resp.Message)
 		uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, workerID)
-		select {
-		case uc.testQueue <- testReq:
-		default:
-			log.Printf("Failed to re-queue test %s, test queue full. Marking test as failed.", testReq.ID)
-			uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "FAILED", testReq.CompletedWorkers, append(testReq.FailedWorkers, "WorkerRejected"))
-		}
+		uc.testQueue.push(testReq)
 		return
 	}
 
@@ -325,163 +437,53 @@ func (uc *MasterUsecase) TriggerAggregation(ctx context.Context, testID string)
 // aggregateTestResults fetches all raw results for a test and performs aggregation.
 func (uc *MasterUsecase) aggregateTestResults(ctx context.Context, testID string) {
 	log.Printf("Starting aggregation for test: %s", testID)
-	results, err := uc.testResultRepo.GetResultsByTestID(ctx, testID)
+	aggregatedResult, err := uc.RecomputeAggregatedResult(ctx, testID)
 	if err != nil {
-		log.Printf("Error fetching raw results for aggregation for test %s: %v", testID, err)
+		log.Printf("Error aggregating results for test %s: %v", testID, err)
 		return
 	}
-
-	if len(results) == 0 {
+	if aggregatedResult == nil {
 		log.Printf("No raw results found for test %s to aggregate.", testID)
 		return
 	}
-
-	// Simple aggregation logic (can be expanded)
-	var totalRequests, successfulRequests, failedRequests, totalDuration int64
-	var totalLatencyMs float64
-	var p95Latencies []float64
-	errorRates := make(map[string]int) // Map of error types/status codes to counts
-
-	for _, res := range results {
-		totalRequests += res.TotalRequests
-		totalDuration += res.DurationMs
-		successfulRequests += int64(res.SuccessRate * float64(res.TotalRequests)) // Approximate successful requests
-		failedRequests += (res.TotalRequests - int64(res.SuccessRate*float64(res.TotalRequests)))
-
-		totalLatencyMs += res.AverageLatencyMs * float64(res.CompletedRequests) // Weighted average
-		p95Latencies = append(p95Latencies, res.P95LatencyMs)
-
-		// Parse status codes
-		for code, count := range res.StatusCodes {
-			if code[0] != '2' { // Assuming 2xx are successful
-				errorRates[code] += count
-			}
-		}
-	}
-
-	avgLatencyMs := 0.0
-	if totalRequests > 0 {
-		avgLatencyMs = totalLatencyMs / float64(totalRequests)
-	}
-
-	// Calculate overall P95 (simple median of P95s for now, more complex if using raw latencies)
-	sort.Float64s(p95Latencies)
-	p95LatencyMs := 0.0
-	if len(p95Latencies) > 0 {
-		p95LatencyMs = p95Latencies[int(0.95*float64(len(p95Latencies)))]
-	}
-
-	overallStatus := "COMPLETED_SUCCESS"
-	if failedRequests > 0 {
-		overallStatus = "COMPLETED_WITH_ERRORS"
-	}
-
-	// errorRatesJSON, _ := json.Marshal(errorRates)
-
-	aggregatedResult := &domain.TestResultAggregated{
-		TestID:             testID,
-		TotalRequests:      totalRequests,
-		SuccessfulRequests: successfulRequests,
-		FailedRequests:     failedRequests,
-		AvgLatencyMs:       avgLatencyMs,
-		P95LatencyMs:       p95LatencyMs,
-		ErrorRates:         errorRates,
-		DurationMs:         totalDuration / int64(len(results)), // Average duration across workers
-		OverallStatus:      overallStatus,
-		CompletedAt:        time.Now(),
-	}
-
-	err = uc.aggregatedResultRepo.SaveAggregatedResult(ctx, aggregatedResult)
-	if err != nil {
-		log.Printf("Error saving aggregated result for test %s: %v", testID, err)
-		return
-	}
 	log.Printf("Aggregated results saved for test: %s", testID)
 
 	// Optionally, delete raw results to save space after aggregation
 	// uc.testResultRepo.DeleteResultsByTestID(ctx, testID)
 }
 
-// GetDashboardStatus compiles and returns the current dashboard status.
-func (uc *MasterUsecase) GetDashboardStatus(ctx context.Context) (*domain.DashboardStatus, error) {
-	allWorkers, err := uc.workerRepo.GetAllWorkers(ctx)
+// RecomputeAggregatedResult aggregates the raw worker results of a test and
+// saves the aggregate, replacing any existing one. It returns nil without
+// saving when the test has no raw results.
+func (uc *MasterUsecase) RecomputeAggregatedResult(ctx context.Context, testID string) (*domain.TestResultAggregated, error) {
+	results, err := uc.testResultRepo.GetResultsByTestID(ctx, testID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all workers for dashboard: %w", err)
+		return nil, fmt.Errorf("failed to fetch raw results for test %s: %w", testID, err)
 	}
 
-	totalWorkers := uint32(len(allWorkers))
-	var availableWorkers uint32
-	var busyWorkers uint32
-	workerSummaries := make([]domain.WorkerSummary, 0, totalWorkers)
+	if len(results) == 0 {
+		return nil, nil
+	}
 
-	for _, w := range allWorkers {
-		if w.Status == "READY" {
-			availableWorkers++
-		} else if w.Status == "BUSY" {
-			busyWorkers++
+	uc.recordTimeline(testID, domain.TimelineAggregationStarted, "", fmt.Sprintf("Aggregating the results of %d workers", len(results)))
+	aggregatedResult := aggregateResults(testID, results)
+	if test, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		log.Printf("Error loading test %s to compare its requested and achieved rates: %v", testID, err)
+	} else {
+		aggregatedResult.RateAttainment = uc.rateAttainment(test, results)
+		if aggregatedResult.RateAttainment.Status == domain.RateAttainmentWarning && len(results) >= len(test.AssignedWorkersIDs) {
+			log.Printf("Warning: test %s sent %.1f req/s of the %.1f req/s requested (%.1f%%); its workers may have been saturated",
+				testID, aggregatedResult.RateAttainment.AchievedRate, aggregatedResult.RateAttainment.RequestedRate, aggregatedResult.RateAttainment.RateAttainment)
 		}
-		workerSummaries = append(workerSummaries, domain.WorkerSummary{
-			WorkerID:          w.ID,
-			StatusMessage:     w.LastProgressMessage,
-			StatusType:        w.Status,
-			CurrentTestID:     w.CurrentTestID,
-			CompletedRequests: w.CompletedRequests,
-			TotalRequests:     w.TotalRequests,
-		})
 	}
-
-	allTests, err := uc.testRepo.GetAllTestRequests(ctx)
+	err = uc.aggregatedResultRepo.SaveAggregatedResult(ctx, aggregatedResult)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all tests for dashboard: %w", err)
-	}
-
-	activeTests := make([]domain.ActiveTestSummary, 0)
-	for _, test := range allTests {
-		if test.Status == "RUNNING" || test.Status == "PENDING" || test.Status == "PARTIALLY_FAILED" {
-			// Calculate progress based on assigned workers vs completed/failed
-			var progress float64
-			if len(test.AssignedWorkersIDs) > 0 {
-				progress = float64(len(test.CompletedWorkers)+len(test.FailedWorkers)) / float64(len(test.AssignedWorkersIDs))
-			}
-
-			// Aggregate request counts from worker summaries if available
-			var totalReqsSent int64
-			var totalReqsCompleted int64
-			// var totalDurationMs int64 // Will be average duration from assigned workers
-
-			// Iterate over active worker summaries to sum up progress for the current test
-			for _, ws := range workerSummaries {
-				if ws.CurrentTestID == test.ID {
-					totalReqsSent += ws.TotalRequests
-					totalReqsCompleted += ws.CompletedRequests
-					// We don't have individual worker durations here, so this will be an approximation or
-					// require fetching raw results to calculate more accurately.
-					// For now, let's keep it simple or set to 0.
-				}
-			}
-
-			activeTests = append(activeTests, domain.ActiveTestSummary{
-				TestID:                 test.ID,
-				TestName:               test.Name,
-				AssignedWorkers:        uint32(len(test.AssignedWorkersIDs)),
-				CompletedWorkers:       uint32(len(test.CompletedWorkers)),
-				FailedWorkers:          uint32(len(test.FailedWorkers)),
-				Status:                 test.Status,
-				TotalRequestsSent:      totalReqsSent,
-				TotalRequestsCompleted: totalReqsCompleted,
-				TotalDurationMs:        0, // Placeholder, can be improved with more data
-				Progress:               progress,
-			})
-		}
+		return nil, fmt.Errorf("failed to save aggregated result for test %s: %w", testID, err)
 	}
-
-	return &domain.DashboardStatus{
-		TotalWorkers:     totalWorkers,
-		AvailableWorkers: availableWorkers,
-		BusyWorkers:      busyWorkers,
-		ActiveTests:      activeTests,
-		WorkerSummaries:  workerSummaries,
-	}, nil
+	uc.runAggregateStrategies(ctx, testID, results, aggregatedResult)
+	uc.events.Publish(domain.Event{Type: domain.EventTestAggregated, TestID: testID,
+		Message: fmt.Sprintf("Aggregated results of %d workers", len(results))})
+	return aggregatedResult, nil
 }
 
 // GetAllTestRequests retrieves all stored test requests.
@@ -494,9 +496,10 @@ func (uc *MasterUsecase) GetTestRequestsPaginated(ctx context.Context, limit, of
 	return uc.testRepo.GetTestRequestsPaginated(ctx, limit, offset)
 }
 
-// GetTestRequestsPaginatedByUser retrieves test requests for a specific user with pagination.
-func (uc *MasterUsecase) GetTestRequestsPaginatedByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.TestRequest, int, error) {
-	return uc.testRepo.GetTestRequestsPaginatedByUser(ctx, userID, limit, offset)
+// ListTests retrieves the page of tests selected by filter and how many tests
+// match it in total.
+func (uc *MasterUsecase) ListTests(ctx context.Context, filter domain.TestListFilter) ([]*domain.TestRequest, int, error) {
+	return uc.testRepo.ListTests(ctx, filter)
 }
 
 // GetRawTestResults retrieves all raw test results for a given test ID.
@@ -504,9 +507,95 @@ func (uc *MasterUsecase) GetRawTestResults(ctx context.Context, testID string) (
 	return uc.testResultRepo.GetResultsByTestID(ctx, testID)
 }
 
+// GetRawTestResultsPage retrieves one page of a test's raw results and how
+// many results the test has.
+func (uc *MasterUsecase) GetRawTestResultsPage(ctx context.Context, testID string, page domain.ResultPage) ([]*domain.TestResult, int, error) {
+	return uc.testResultRepo.GetResultsPage(ctx, testID, page)
+}
+
+// SetRedactionRules replaces the rules used to scrub exports and results.
+func (uc *MasterUsecase) SetRedactionRules(rules utils.RedactionRules) error {
+	redactor, err := utils.NewRedactor(rules)
+	if err != nil {
+		return err
+	}
+	uc.redactor = redactor
+	return nil
+}
+
+// ScrubJSON encodes v as JSON with internal hostnames, IPs and auth headers redacted.
+func (uc *MasterUsecase) ScrubJSON(v interface{}) ([]byte, error) {
+	return uc.redactor.RedactJSON(v)
+}
+
+// ScrubTestRequest returns a copy of test whose targets, scenario, GraphQL
+// definition and script are redacted. The targets are base64-encoded, so ScrubJSON alone would miss them.
+func (uc *MasterUsecase) ScrubTestRequest(test *domain.TestRequest) *domain.TestRequest {
+	scrubbed := *test
+	if decoded, err := base64.StdEncoding.DecodeString(test.TargetsBase64); err == nil {
+		scrubbed.TargetsBase64 = base64.StdEncoding.EncodeToString([]byte(uc.redactor.Redact(string(decoded))))
+	}
+	scrubbed.ScenarioJSON = uc.redactor.Redact(test.ScenarioJSON)
+	scrubbed.GraphQLJSON = uc.redactor.Redact(test.GraphQLJSON)
+	scrubbed.Script = uc.redactor.Redact(test.Script)
+	return &scrubbed
+}
+
 // GetAggregatedTestResult retrieves the aggregated result for a given test ID.
+// Ramped tests also get a capacity estimate against the default SLO, and
+// multi-region tests their results by region. The build of the target and
+// the environments of the workers are added so the result can be audited.
 func (uc *MasterUsecase) GetAggregatedTestResult(ctx context.Context, testID string) (*domain.TestResultAggregated, error) {
-	return uc.aggregatedResultRepo.GetAggregatedResultByTestID(ctx, testID)
+	result, err := uc.aggregatedResultRepo.GetAggregatedResultByTestID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	test, testErr := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if testErr == nil {
+		result.TargetBuild = test.TargetBuild
+	}
+	if testErr == nil && test.RateDistribution == "ramped" {
+		if estimate, err := uc.EstimateCapacity(ctx, testID, domain.CapacitySLO{}); err != nil {
+			log.Printf("Error estimating capacity for test %s: %v", testID, err)
+		} else {
+			result.Capacity = estimate
+		}
+	}
+	if results, err := uc.testResultRepo.GetResultsByTestID(ctx, testID); err != nil {
+		log.Printf("Error loading worker results of test %s for resource warnings: %v", testID, err)
+	} else {
+		result.ResourceWarnings = resourceWarnings(results)
+		result.Environments = runEnvironments(results)
+		if testErr == nil {
+			result.Regions = regionBreakdowns(test, results)
+		}
+	}
+	return result, nil
+}
+
+// resourceWarnings lists the saturation and overhead warnings of each worker
+// result, so a test whose load generators held it back can be told apart
+// from a slow target.
+func resourceWarnings(results []*domain.TestResult) []string {
+	var warnings []string
+	for _, r := range results {
+		var workerWarnings []string
+		if r.ResourceUsage != nil {
+			workerWarnings = append(workerWarnings, r.ResourceUsage.SaturationWarnings()...)
+		}
+		if r.GeneratorOverhead != nil {
+			workerWarnings = append(workerWarnings, r.GeneratorOverhead.Warnings()...)
+		}
+		for _, warning := range workerWarnings {
+			warnings = append(warnings, fmt.Sprintf("worker %s: %s", r.WorkerID, warning))
+		}
+	}
+	return warnings
+}
+
+// GetTestRequest retrieves a single test request, including its distribution plan.
+func (uc *MasterUsecase) GetTestRequest(ctx context.Context, testID string) (*domain.TestRequest, error) {
+	return uc.testRepo.GetTestRequestByID(ctx, testID)
 }
 
 // GetTestRequestsByUser retrieves all test requests for a specific user.
@@ -516,90 +605,181 @@ func (uc *MasterUsecase) GetTestRequestsByUser(ctx context.Context, userID strin
 
 // --- Shared Link & Inbox Logic ---
 
-func (uc *MasterUsecase) ShareTest(ctx context.Context, testID, sharedBy string) (*domain.SharedLink, error) {
-	expiresAt := time.Now().Add(72 * time.Hour) // 3 days
-	return uc.sharedLinkRepo.CreateSharedLink(ctx, testID, sharedBy, expiresAt)
+// Shared link lifetimes accepted by ShareTest and ShareTestToUserInbox.
+const (
+	DefaultShareLinkTTL = 72 * time.Hour
+	MaxShareLinkTTL     = 30 * 24 * time.Hour
+)
+
+// shareLinkExpiry returns when a link created now with ttl expires; a zero
+// ttl means DefaultShareLinkTTL.
+func shareLinkExpiry(ttl time.Duration) (time.Time, error) {
+	if ttl == 0 {
+		ttl = DefaultShareLinkTTL
+	}
+	if ttl < time.Minute || ttl > MaxShareLinkTTL {
+		return time.Time{}, fmt.Errorf("invalid expiry %v: must be between 1m and %v", ttl, MaxShareLinkTTL)
+	}
+	return time.Now().Add(ttl), nil
+}
+
+// ShareTest creates a link to a test that expires after ttl.
+func (uc *MasterUsecase) ShareTest(ctx context.Context, testID, sharedBy string, ttl time.Duration) (*domain.SharedLink, error) {
+	expiresAt, err := shareLinkExpiry(ttl)
+	if err != nil {
+		return nil, err
+	}
+	link, err := uc.sharedLinkRepo.CreateSharedLink(ctx, testID, sharedBy, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.snapshotSharedResult(ctx, link); err != nil {
+		return nil, err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditShareCreate, testID, map[string]string{"linkId": link.ID}))
+	return link, nil
 }
 
-func (uc *MasterUsecase) AccessSharedLink(ctx context.Context, linkID, userID string) (*domain.TestRequest, error) {
+// AccessSharedLink opens a shared link for a user, adding it to their inbox,
+// and returns the test with the result captured when the link was created.
+// Links created before the test had a result show its current one.
+func (uc *MasterUsecase) AccessSharedLink(ctx context.Context, linkID, userID string) (*domain.SharedTestView, error) {
 	link, err := uc.sharedLinkRepo.GetSharedLinkByID(ctx, linkID)
 	if err != nil {
 		return nil, err
 	}
+	if link.RevokedAt != nil {
+		return nil, fmt.Errorf("shared link revoked")
+	}
 	if time.Now().After(link.ExpiresAt) {
 		return nil, fmt.Errorf("shared link expired")
 	}
 	_ = uc.sharedLinkRepo.AddUsedBy(ctx, linkID, userID) // Add user to used_by (ignore error if already present)
+	if err := uc.sharedLinkRepo.RecordSharedLinkAccess(ctx, linkID); err != nil {
+		log.Printf("Failed to record access to shared link %s: %v", linkID, err)
+	}
 	test, err := uc.testRepo.GetTestRequestByID(ctx, link.TestID)
 	if err != nil {
 		return nil, err
 	}
-	return test, nil
-}
-
-func (uc *MasterUsecase) GetInbox(ctx context.Context, userID string) ([]*domain.SharedLink, error) {
-	return uc.sharedLinkRepo.GetInboxForUser(ctx, userID)
-}
-
-func (uc *MasterUsecase) MarkInboxItemRead(ctx context.Context, linkID, userID string) error {
-	return uc.sharedLinkRepo.MarkInboxItemRead(ctx, linkID, userID)
+	view := &domain.SharedTestView{TestRequest: test}
+	view.Result, view.SnapshotAt, err = uc.sharedResult(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
 }
 
 // ShareTestToUserInbox shares a test and inserts the link into the specified user's inbox.
-func (uc *MasterUsecase) ShareTestToUserInbox(ctx context.Context, testID, sharedBy, targetUserID string) (*domain.SharedLink, error) {
-	expiresAt := time.Now().Add(72 * time.Hour) // 3 days
+func (uc *MasterUsecase) ShareTestToUserInbox(ctx context.Context, testID, sharedBy, targetUserID string, ttl time.Duration) (*domain.SharedLink, error) {
+	expiresAt, err := shareLinkExpiry(ttl)
+	if err != nil {
+		return nil, err
+	}
 	link, err := uc.sharedLinkRepo.CreateSharedLink(ctx, testID, sharedBy, expiresAt)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.snapshotSharedResult(ctx, link); err != nil {
+		return nil, err
+	}
 	// Insert into target user's inbox (used_by array)
 	err = uc.sharedLinkRepo.AddUsedBy(ctx, link.ID, targetUserID)
 	if err != nil {
 		return nil, err
 	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditShareCreate, testID,
+		map[string]string{"linkId": link.ID, "sharedWith": targetUserID}))
+	uc.publishInboxUpdate(link, []string{targetUserID})
 	return link, nil
 }
 
-// cleanupStaleWorkers periodically checks for workers that haven't sent status updates
-// and marks them as offline. It also re-queue tests if they were assigned to these workers.
-func (uc *MasterUsecase) cleanupStaleWorkers(ctx context.Context) {
-	log.Println("Running stale worker cleanup...")
-	const staleThreshold = 30 * time.Second // Workers are considered stale if no update in 30 seconds
-
-	allWorkers, err := uc.workerRepo.GetAllWorkers(ctx)
+// snapshotSharedResult stores the test's current aggregated result, as its
+// owner sees it, with a new link. Tests without a result yet are skipped.
+func (uc *MasterUsecase) snapshotSharedResult(ctx context.Context, link *domain.SharedLink) error {
+	result, err := uc.GetAggregatedTestResult(ctx, link.TestID)
 	if err != nil {
-		log.Printf("Error fetching all workers for stale cleanup: %v", err)
-		return
+		if strings.Contains(err.Error(), "not found") {
+			return nil
+		}
+		return fmt.Errorf("failed to snapshot the result of test %s: %w", link.TestID, err)
 	}
+	return uc.sharedLinkRepo.SetSharedLinkSnapshot(ctx, link.ID, result)
+}
 
-	for _, worker := range allWorkers {
-		if worker.Status != "OFFLINE" && time.Since(worker.LastSeen) > staleThreshold {
-			log.Printf("Worker %s (%s) is stale. Marking offline.", worker.ID, worker.Address)
-			err := uc.MarkWorkerOffline(ctx, worker.ID)
-			if err != nil {
-				log.Printf("Failed to mark stale worker %s offline: %v", worker.ID, err)
-			}
+// sharedResult returns the result stored with a link and when it was
+// captured, or the test's current result and a nil time for links created
+// before the test had one.
+func (uc *MasterUsecase) sharedResult(ctx context.Context, link *domain.SharedLink) (*domain.TestResultAggregated, *time.Time, error) {
+	snapshot, err := uc.sharedLinkRepo.GetSharedLinkSnapshot(ctx, link.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if snapshot != nil {
+		snapshotAt := link.CreatedAt
+		return snapshot, &snapshotAt, nil
+	}
+	result, err := uc.GetAggregatedTestResult(ctx, link.TestID)
+	if err != nil {
+		return nil, nil, nil
+	}
+	return result, nil, nil
+}
 
-			// If worker was busy, re-queue the test
-			if worker.CurrentTestID != "" {
-				test, err := uc.testRepo.GetTestRequestByID(ctx, worker.CurrentTestID)
-				if err != nil {
-					log.Printf("Could not retrieve test %s for stale worker %s cleanup: %v", worker.CurrentTestID, worker.ID, err)
-					continue
-				}
-				// Only re-queue if the test is still running/pending and not fully completed/failed
-				if test.Status == "RUNNING" || test.Status == "PENDING" {
-					log.Printf("Re-queueing test %s as worker %s went offline.", test.ID, worker.ID)
-					uc.testRepo.AddFailedWorkerToTest(ctx, test.ID, worker.ID) // Mark this worker as failed for this test
-					select {
-					case uc.testQueue <- test:
-					default:
-						log.Printf("Failed to re-queue test %s, test queue full.", test.ID)
-					}
-				}
-			}
+// GetMySharedLinks lists the links a user created, with their usage and,
+// for public links, their tokens.
+func (uc *MasterUsecase) GetMySharedLinks(ctx context.Context, userID string) ([]*domain.SharedLink, error) {
+	links, err := uc.sharedLinkRepo.GetSharedLinksBySharer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.Public && uc.publicShareKey != nil {
+			link.PublicToken = uc.publicShareToken(link)
 		}
 	}
+	return links, nil
+}
+
+// RevokeSharedLink stops a link from being opened. Only the user who
+// created it, or an admin, may revoke it.
+func (uc *MasterUsecase) RevokeSharedLink(ctx context.Context, linkID string, user *domain.UserProfile) error {
+	link, err := uc.sharedLinkRepo.GetSharedLinkByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if link.SharedBy != user.ID && user.Role != "admin" {
+		return fmt.Errorf("insufficient permissions")
+	}
+	if err := uc.sharedLinkRepo.RevokeSharedLink(ctx, linkID); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditShareRevoke, link.TestID, map[string]string{"linkId": linkID}))
+	return nil
+}
+
+// requeueWorkerTest marks a worker that went offline as failed for the test
+// it was running, if any. While other workers still run the test, its worker
+// failure policy applies; otherwise the test is re-queued.
+func (uc *MasterUsecase) requeueWorkerTest(ctx context.Context, workerID, testID string) {
+	if testID == "" {
+		return
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		log.Printf("Could not retrieve test %s for offline worker %s cleanup: %v", testID, workerID, err)
+		return
+	}
+	// Other workers still running the test go on as its worker failure policy says
+	if uc.handleWorkerFailure(ctx, test, workerID) {
+		return
+	}
+	// Only re-queue if the test is still running/pending and not fully completed/failed
+	if test.Status == "RUNNING" || test.Status == "PENDING" {
+		log.Printf("Re-queueing test %s as worker %s went offline.", test.ID, workerID)
+		uc.testRepo.AddFailedWorkerToTest(ctx, test.ID, workerID) // Mark this worker as failed for this test
+		uc.testQueue.push(test)
+	}
 }
 
 // StartAggregationBackgroundJob starts a background job that periodically checks for
@@ -679,110 +859,59 @@ func (uc *MasterUsecase) assignTestToMultipleWorkers(ctx context.Context, testRe
 		testReq.ID, len(workerIDs), workerIDs, testReq.RateDistribution)
 
 	// Calculate how to distribute the load across workers based on distribution mode
-	var workerRates []uint64
-	var totalExpectedRate uint64
-
-	switch testReq.RateDistribution {
-	case "same":
-		// Each worker gets the same full rate
-		for i := 0; i < len(workerIDs); i++ {
-			workerRates = append(workerRates, testReq.RatePerSecond)
-		}
-		totalExpectedRate = testReq.RatePerSecond * uint64(len(workerIDs))
-		log.Printf("Using 'same' rate distribution: each worker gets %d req/s (total: %d req/s)",
-			testReq.RatePerSecond, totalExpectedRate)
-
-	case "weighted":
-		// Distribute rate based on provided weights
-		totalWeight := 0.0
-		for _, weight := range testReq.RateWeights {
-			totalWeight += weight
-		}
-
-		totalAssigned := uint64(0)
-		for _, weight := range testReq.RateWeights {
-			workerRate := uint64(float64(testReq.RatePerSecond) * weight / totalWeight)
-			workerRates = append(workerRates, workerRate)
-			totalAssigned += workerRate
-		}
+	workerRates, regions := uc.workerRates(ctx, testReq, workerIDs)
+	log.Printf("Using '%s' rate distribution: rates %v (total: %d req/s)",
+		testReq.RateDistribution, workerRates, sumRates(workerRates))
 
-		// Handle rounding errors by adding remainder to the first worker
-		if totalAssigned < testReq.RatePerSecond {
-			remainder := testReq.RatePerSecond - totalAssigned
-			workerRates[0] += remainder
-		}
-
-		totalExpectedRate = testReq.RatePerSecond
-		log.Printf("Using 'weighted' rate distribution: weights %v, rates %v (total: %d req/s)",
-			testReq.RateWeights, workerRates, totalExpectedRate)
-
-	case "ramped":
-		// Gradually increase rate across workers (first worker gets lower rate, last gets higher)
-		baseRate := testReq.RatePerSecond / uint64(len(workerIDs))
-		rampStep := baseRate / 2 // Ramp from 50% to 150% of base rate
-
-		for i := 0; i < len(workerIDs); i++ {
-			// Calculate ramped rate: starts at baseRate - rampStep, ends at baseRate + rampStep
-			rampFactor := float64(i) / float64(len(workerIDs)-1) // 0.0 to 1.0
-			workerRate := uint64(float64(baseRate) + (2.0*rampFactor-1.0)*float64(rampStep))
-			if workerRate < 1 {
-				workerRate = 1 // Minimum 1 req/s
-			}
-			workerRates = append(workerRates, workerRate)
-		}
+	// Partition {{seq}} values so workers never generate colliding entities
+	var seqRanges [][2]uint64
+	if testReq.TemplateTargets {
+		seqRanges = sequenceRanges(testReq.SequenceStart, testReq.DurationSeconds, workerRates, sequenceReserve(testReq))
+		log.Printf("Partitioned sequence ranges for test %s: %v", testReq.ID, seqRanges)
+	}
 
-		totalExpectedRate = 0
-		for _, rate := range workerRates {
-			totalExpectedRate += rate
+	// Record the plan so results can later be read against each worker's rate
+	plan := make([]domain.WorkerRate, len(workerIDs))
+	for i, workerID := range workerIDs {
+		plan[i] = domain.WorkerRate{WorkerID: workerID, RatePerSecond: workerRates[i]}
+		if regions != nil {
+			plan[i].Region = regions[i]
 		}
-		log.Printf("Using 'ramped' rate distribution: rates %v (total: %d req/s)",
-			workerRates, totalExpectedRate)
-
-	case "burst":
-		// Concentrate higher load on first few workers, lower on the rest
-		burstWorkers := len(workerIDs) / 2
-		if burstWorkers < 1 {
-			burstWorkers = 1
+		if seqRanges != nil {
+			plan[i].SequenceStart, plan[i].SequenceEnd = seqRanges[i][0], seqRanges[i][1]
 		}
+	}
 
-		burstRate := (testReq.RatePerSecond * 70) / (100 * uint64(burstWorkers))                 // 70% of load on burst workers
-		normalRate := (testReq.RatePerSecond * 30) / (100 * uint64(len(workerIDs)-burstWorkers)) // 30% on remaining
-
-		for i := 0; i < len(workerIDs); i++ {
-			if i < burstWorkers {
-				workerRates = append(workerRates, burstRate)
-			} else {
-				workerRates = append(workerRates, normalRate)
+	// Check each worker's share against the rate it can generate
+	var capacityWarning string
+	if over := uc.overCapacityWorkers(ctx, plan); len(over) > 0 {
+		capacityWarning = fmt.Sprintf("rate exceeds the calibrated maximum of %s", strings.Join(over, ", "))
+		if uc.rejectOverCapacity {
+			log.Printf("Refusing test %s: %s", testReq.ID, capacityWarning)
+			if err := uc.testRepo.SaveDistributionPlan(ctx, testReq.ID, plan); err != nil {
+				log.Printf("Warning: Failed to save distribution plan for test %s: %v", testReq.ID, err)
 			}
-		}
-
-		totalExpectedRate = 0
-		for _, rate := range workerRates {
-			totalExpectedRate += rate
-		}
-		log.Printf("Using 'burst' rate distribution: %d burst workers at %d req/s, %d normal workers at %d req/s (total: %d req/s)",
-			burstWorkers, burstRate, len(workerIDs)-burstWorkers, normalRate, totalExpectedRate)
-
-	default:
-		// Default "shared" - divide the rate evenly across all workers
-		baseRate := testReq.RatePerSecond / uint64(len(workerIDs))
-		remainder := testReq.RatePerSecond % uint64(len(workerIDs))
-
-		for i := 0; i < len(workerIDs); i++ {
-			workerRate := baseRate
-			if i < int(remainder) {
-				workerRate++ // Distribute remainder among first workers
+			uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "FAILED",
+				testReq.CompletedWorkers, append(testReq.FailedWorkers, "ExceedsWorkerCapacity"))
+			uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testReq.ID, Status: "FAILED",
+				Message: "Per-worker " + capacityWarning})
+			for _, workerID := range workerIDs {
+				uc.addWorkerToAvailabilityQueue(workerID)
 			}
-			workerRates = append(workerRates, workerRate)
+			return
 		}
-
-		totalExpectedRate = testReq.RatePerSecond
-		log.Printf("Using 'shared' rate distribution: rates %v (total: %d req/s)",
-			workerRates, totalExpectedRate)
+		log.Printf("Warning: Test %s may fall short of its rate: %s", testReq.ID, capacityWarning)
 	}
 
 	// Update test status to RUNNING - we'll add workers to assigned list after successful assignment
 	uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "RUNNING", nil, nil)
+	uc.guardrails.started(testReq)
+
+	// Have the workers start together rather than as each accepts
+	var startAt time.Time
+	if len(workerIDs) > 1 {
+		startAt = uc.testStartAt(time.Now())
+	}
 
 	// Initialize assignment tracking
 	uc.mu.Lock()
@@ -806,44 +935,30 @@ func (uc *MasterUsecase) assignTestToMultipleWorkers(ctx context.Context, testRe
 			// Get this worker's rate from the pre-calculated rates
 			workerRate := workerRates[workerIndex]
 
-			// Create a modified test request for this worker with its specific rate
-			workerTestReq := *testReq
-			workerTestReq.RatePerSecond = workerRate
-
 			log.Printf("Assigning test %s to worker %s with rate %d req/s (mode: %s)",
 				testReq.ID, workerID, workerRate, testReq.RateDistribution)
 
-			connVal, ok := uc.activeWorkerClients.Load(workerID)
+			client, ok := uc.workerClient(workerID)
 			if !ok {
 				log.Printf("Worker %s connection not found during multi-worker assignment for test %s", workerID, testReq.ID)
+				uc.recordTimeline(testReq.ID, domain.TimelineWorkerRejected, workerID, "Worker is not connected")
 				uc.MarkWorkerOffline(ctx, workerID)
-				uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, workerID)
 				return
 			}
 
-			conn := connVal.(*grpc.ClientConn)
-			client := pb.NewWorkerServiceClient(conn)
-
 			// Mark worker as busy
 			uc.workerRepo.UpdateWorkerStatus(ctx, workerID, "BUSY", testReq.ID,
 				fmt.Sprintf("Running test (rate: %d req/s, mode: %s)", workerRate, testReq.RateDistribution), 0, 0)
 
-			assignment := &pb.TestAssignment{
-				TestId:            testReq.ID,
-				VegetaPayloadJson: workerTestReq.VegetaPayloadJSON,
-				DurationSeconds:   workerTestReq.DurationSeconds,
-				RatePerSecond:     workerRate, // Use the distributed rate
-				TargetsBase64:     workerTestReq.TargetsBase64,
+			assignment := workerAssignment(testReq, plan[workerIndex], testReq.DurationSeconds)
+			if !startAt.IsZero() {
+				assignment.StartAtUnixNano = startAt.UnixNano()
 			}
-
-			assignmentCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-			defer cancel()
-
-			resp, err := client.AssignTest(assignmentCtx, assignment)
+			resp, err := client.AssignTest(ctx, assignment)
 			if err != nil {
 				log.Printf("Failed to assign test %s to worker %s: %v", testReq.ID, workerID, err)
+				uc.recordTimeline(testReq.ID, domain.TimelineWorkerRejected, workerID, "Assignment failed: "+err.Error())
 				uc.MarkWorkerOffline(ctx, workerID)
-				uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, workerID)
 				// Reset worker status back to READY if still reachable
 				uc.workerRepo.UpdateWorkerStatus(ctx, workerID, "READY", "", "Assignment failed", 0, 0)
 				return
@@ -851,7 +966,7 @@ func (uc *MasterUsecase) assignTestToMultipleWorkers(ctx context.Context, testRe
 
 			if !resp.Accepted {
 				log.Printf("Worker %s rejected test %s assignment: %s", workerID, testReq.ID, resp.Message)
-				uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, workerID)
+				uc.recordTimeline(testReq.ID, domain.TimelineWorkerRejected, workerID, "Rejected the assignment: "+resp.Message)
 				// Reset worker status back to READY since assignment failed
 				uc.workerRepo.UpdateWorkerStatus(ctx, workerID, "READY", "", "Assignment rejected", 0, 0)
 				// Add worker back to availability queue
@@ -861,9 +976,12 @@ func (uc *MasterUsecase) assignTestToMultipleWorkers(ctx context.Context, testRe
 
 			log.Printf("Test %s assigned successfully to worker %s (rate: %d req/s, mode: %s)",
 				testReq.ID, workerID, workerRate, testReq.RateDistribution)
+			uc.recordTimeline(testReq.ID, domain.TimelineWorkerAssigned, workerID,
+				fmt.Sprintf("Assigned at %d req/s (%s distribution)", workerRate, testReq.RateDistribution))
 
 			// Only add to assigned workers list after successful assignment
 			uc.testRepo.IncrementTestAssignedWorkers(ctx, testReq.ID, workerID)
+			plan[workerIndex].Accepted = true // Each goroutine writes only its own entry
 
 			assignmentMutex.Lock()
 			successfulAssignments++
@@ -874,21 +992,107 @@ func (uc *MasterUsecase) assignTestToMultipleWorkers(ctx context.Context, testRe
 	// Wait for all assignments to complete
 	wg.Wait()
 
+	// Hand the share of each worker that did not take the test to a spare,
+	// recording the worker as failed only when there is none
+	for i := range workerIDs {
+		if plan[i].Accepted {
+			continue
+		}
+		if spare, ok := uc.assignSpare(ctx, testReq, plan, plan[i], 0); ok {
+			uc.recordTimeline(testReq.ID, domain.TimelineWorkerAssigned, spare.WorkerID,
+				fmt.Sprintf("Assigned at %d req/s as a spare for worker %s", spare.RatePerSecond, plan[i].WorkerID))
+			plan = append(plan, spare)
+			successfulAssignments++
+			continue
+		}
+		uc.testRepo.AddFailedWorkerToTest(ctx, testReq.ID, plan[i].WorkerID)
+	}
+
 	log.Printf("Multi-worker assignment completed for test %s: %d/%d workers assigned successfully",
 		testReq.ID, successfulAssignments, len(workerIDs))
+	if err := uc.testRepo.SaveDistributionPlan(ctx, testReq.ID, plan); err != nil {
+		log.Printf("Warning: Failed to save distribution plan for test %s: %v", testReq.ID, err)
+	}
 
 	// If no workers accepted the assignment, mark test as failed
 	if successfulAssignments == 0 {
 		log.Printf("No workers accepted test %s assignment, marking as failed", testReq.ID)
 		uc.testRepo.UpdateTestStatus(ctx, testReq.ID, "FAILED",
 			testReq.CompletedWorkers, append(testReq.FailedWorkers, "AllWorkersRejected"))
+		uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testReq.ID, Status: "FAILED",
+			Message: "No workers accepted the assignment"})
+		return
+	}
+	message := fmt.Sprintf("Assigned to %d of %d workers", successfulAssignments, len(workerIDs))
+	if uint32(len(workerIDs)) < testReq.WorkerCount {
+		message += fmt.Sprintf(" (%d requested, partial policy: %s)", testReq.WorkerCount, testReq.PartialPolicy)
+	}
+	if capacityWarning != "" {
+		message += "; per-worker " + capacityWarning
+	}
+	uc.events.Publish(domain.Event{Type: domain.EventTestAssigned, TestID: testReq.ID, Status: "RUNNING", Message: message})
+}
+
+// workerAssignment builds the assignment of one worker's share of a test,
+// to run for duration.
+func workerAssignment(testReq *domain.TestRequest, share domain.WorkerRate, duration string) *pb.TestAssignment {
+	return setAssignmentApdex(&pb.TestAssignment{
+		TestId:            testReq.ID,
+		VegetaPayloadJson: testReq.VegetaPayloadJSON,
+		DurationSeconds:   duration,
+		RatePerSecond:     share.RatePerSecond, // Use the distributed rate
+		TargetsBase64:     testReq.TargetsBase64,
+		ThinkTime:         testReq.ThinkTime,
+		PacingJitter:      testReq.PacingJitter,
+		RequestIdPrefix:   testReq.RequestIDPrefix,
+		TemplateTargets:   testReq.TemplateTargets,
+		SequenceStart:     share.SequenceStart,
+		SequenceEnd:       share.SequenceEnd,
+		ScenarioJson:      testReq.ScenarioJSON,
+		Preflight:         testReq.Preflight,
+		Smoke:             testReq.Smoke,
+		InterimInterval:   testReq.InterimInterval,
+		CaptureRequests:   testReq.CaptureRequests,
+		Protocol:          testReq.Protocol,
+		Script:            testReq.Script,
+		LabelHeaders:      assignmentLabels(testReq),
+	}, testReq)
+}
+
+// assignmentLabels fills the test's placeholders into its label headers,
+// leaving domain.LabelWorkerID to each worker.
+func assignmentLabels(testReq *domain.TestRequest) map[string]string {
+	return domain.ExpandLabelHeaders(testReq.LabelHeaders, domain.LabelTestID, testReq.ID, domain.LabelTestName, testReq.Name)
+}
+
+// RecordWorkerTestError marks a worker as failed for a test after it reported
+// an execution error, so the test finishes without waiting for its result.
+// Probe results from a failed pre-flight check are stored on the test.
+func (uc *MasterUsecase) RecordWorkerTestError(ctx context.Context, testID, workerID string, probeResults []domain.ProbeResult) error {
+	if len(probeResults) > 0 {
+		if err := uc.testRepo.SaveProbeResults(ctx, testID, probeResults); err != nil {
+			log.Printf("Warning: Failed to save probe results for test %s: %v", testID, err)
+		}
+	}
+
+	if err := uc.testRepo.AddFailedWorkerToTest(ctx, testID, workerID); err != nil {
+		return fmt.Errorf("failed to mark worker %s as failed for test %s: %w", workerID, testID, err)
+	}
+	message := "Reported an execution error"
+	if len(probeResults) > 0 {
+		message = fmt.Sprintf("Failed its pre-flight check (%d targets probed)", len(probeResults))
 	}
+	uc.recordTimeline(testID, domain.TimelineWorkerFailed, workerID, message)
+	return uc.checkAndUpdateTestCompletion(ctx, testID)
 }
 
 // SaveWorkerTestResult saves a test result received from a worker to the database
 func (uc *MasterUsecase) SaveWorkerTestResult(ctx context.Context, testResult *domain.TestResult) error {
 	log.Printf("Saving test result from worker %s for test %s", testResult.WorkerID, testResult.TestID)
 
+	// Tag the results of multi-region tests with the worker's region
+	testResult.Region = uc.resultRegion(ctx, testResult)
+
 	// Save the test result to database
 	err := uc.testResultRepo.SaveTestResult(ctx, testResult)
 	if err != nil {
@@ -898,6 +1102,26 @@ func (uc *MasterUsecase) SaveWorkerTestResult(ctx context.Context, testResult *d
 
 	log.Printf("Successfully saved test result from worker %s for test %s (Total: %d, Completed: %d, Success Rate: %.2f%%)",
 		testResult.WorkerID, testResult.TestID, testResult.TotalRequests, testResult.CompletedRequests, testResult.SuccessRate*100)
+	if testResult.ResourceUsage != nil {
+		if warnings := testResult.ResourceUsage.SaturationWarnings(); len(warnings) > 0 {
+			log.Printf("Warning: worker %s was saturated during test %s, its results may be skewed: %s",
+				testResult.WorkerID, testResult.TestID, strings.Join(warnings, "; "))
+		}
+	}
+	if testResult.GeneratorOverhead != nil {
+		if warnings := testResult.GeneratorOverhead.Warnings(); len(warnings) > 0 {
+			log.Printf("Warning: worker %s fell behind during test %s, its results may be skewed: %s",
+				testResult.WorkerID, testResult.TestID, strings.Join(warnings, "; "))
+		}
+	}
+	if len(testResult.TargetResponses) > 0 {
+		if err := uc.testRepo.SaveSmokeReport(ctx, testResult.TestID, testResult.TargetResponses); err != nil {
+			log.Printf("Warning: Failed to save smoke report of test %s: %v", testResult.TestID, err)
+		}
+	}
+
+	uc.recordTimeline(testResult.TestID, domain.TimelineWorkerResult, testResult.WorkerID,
+		fmt.Sprintf("Delivered its result: %d requests, %.2f%% successful", testResult.TotalRequests, testResult.SuccessRate*100))
 
 	// Mark this worker as completed in the test record
 	err = uc.testRepo.AddCompletedWorkerToTest(ctx, testResult.TestID, testResult.WorkerID)
@@ -937,8 +1161,9 @@ func (uc *MasterUsecase) checkAndUpdateTestCompletion(ctx context.Context, testI
 		return fmt.Errorf("failed to get test %s: %w", testID, err)
 	}
 
-	// Skip if test is already marked as completed
-	if test.Status == "COMPLETED" || test.Status == "FAILED" {
+	// Skip if test is already marked as completed, or was aborted and keeps that status
+	if test.Status == "COMPLETED" || test.Status == "PARTIALLY_FAILED" || test.Status == "FAILED" ||
+		test.Status == TestStatusAbortedErrorBudget || test.Status == TestStatusAbortedWorkerLost {
 		return nil
 	}
 
@@ -967,11 +1192,19 @@ func (uc *MasterUsecase) checkAndUpdateTestCompletion(ctx context.Context, testI
 
 		// Update the test status
 		err = uc.testRepo.UpdateTestStatus(ctx, testID, newStatus, test.CompletedWorkers, test.FailedWorkers)
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			// Another result finished the test first
+			log.Printf("Test %s already finalized: %v", testID, err)
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("failed to update test %s status to %s: %w", testID, newStatus, err)
 		}
 
 		log.Printf("🎯 Updated test %s status to: %s", testID, newStatus)
+		uc.releaseTestAssignments(testID)
+		uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testID, Status: newStatus,
+			Message: fmt.Sprintf("%d of %d workers completed", totalCompleted, totalAssigned)})
 
 		// Also update worker status back to READY
 		for _, workerID := range test.AssignedWorkersIDs {
@@ -980,6 +1213,9 @@ func (uc *MasterUsecase) checkAndUpdateTestCompletion(ctx context.Context, testI
 				log.Printf("Warning: Failed to reset worker %s status to READY: %v", workerID, err)
 			}
 		}
+	} else {
+		uc.events.Publish(domain.Event{Type: domain.EventTestUpdated, TestID: testID, Status: test.Status,
+			Message: fmt.Sprintf("%d of %d workers finished", totalCompleted+totalFailed, totalAssigned)})
 	}
 
 	return nil
@@ -987,42 +1223,8 @@ func (uc *MasterUsecase) checkAndUpdateTestCompletion(ctx context.Context, testI
 
 // updateAggregatedResult recalculates and updates the aggregated result for a test
 func (uc *MasterUsecase) updateAggregatedResult(ctx context.Context, testID string) error {
-	// Get all results for this test
-	results, err := uc.testResultRepo.GetResultsByTestID(ctx, testID)
-	if err != nil {
-		return fmt.Errorf("failed to get results for test %s: %w", testID, err)
-	}
-
-	if len(results) == 0 {
-		return nil // No results to aggregate yet
-	}
-	// Calculate aggregated metrics
-	var totalRequests, totalCompleted int64
-	var totalDuration, totalLatency, totalP95 float64
-
-	for _, result := range results {
-		totalRequests += result.TotalRequests
-		totalCompleted += result.CompletedRequests
-		totalDuration += float64(result.DurationMs)
-		totalLatency += result.AverageLatencyMs
-		totalP95 += result.P95LatencyMs
-	}
-
-	numWorkers := len(results)
-	aggregatedResult := &domain.TestResultAggregated{
-		TestID:             testID,
-		TotalRequests:      totalRequests,
-		SuccessfulRequests: totalCompleted,
-		FailedRequests:     totalRequests - totalCompleted,
-		AvgLatencyMs:       totalLatency / float64(numWorkers),
-		P95LatencyMs:       totalP95 / float64(numWorkers),
-		DurationMs:         int64(totalDuration / float64(numWorkers)),
-		OverallStatus:      "Completed",
-		CompletedAt:        time.Now(),
-	}
-
-	// Save the aggregated result
-	return uc.aggregatedResultRepo.SaveAggregatedResult(ctx, aggregatedResult)
+	_, err := uc.RecomputeAggregatedResult(ctx, testID)
+	return err
 }
 
 // Analytics methods
@@ -1050,16 +1252,19 @@ func (uc *MasterUsecase) GetAnalyticsOverview(ctx context.Context, req *domain.A
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tests in range: %w", err)
 	}
+	tests = testsOfBuild(tests, req.TargetBuild)
 
 	// Get all aggregated results for these tests
 	var allResults []*domain.TestResultAggregated
 	var totalRequests, successfulRequests int64
 	var responseTimeSum float64
 	var responseTimeCount int64
-	var allP95Times []float64
+	var resultTestIDs []string
 	errorCodes := make(map[string]int64)
 	testsPerDay := make(map[string]int64)
 	requestsPerDay := make(map[string]int64)
+	dailyTrend := make(map[string]*dailyStats)
+	var apdex *domain.AnalyticsApdex
 
 	for _, test := range tests {
 		result, err := uc.aggregatedResultRepo.GetByTestID(ctx, test.ID)
@@ -1068,6 +1273,7 @@ func (uc *MasterUsecase) GetAnalyticsOverview(ctx context.Context, req *domain.A
 		}
 
 		allResults = append(allResults, result)
+		resultTestIDs = append(resultTestIDs, test.ID)
 		totalRequests += result.TotalRequests
 		successfulRequests += result.SuccessfulRequests
 
@@ -1077,20 +1283,29 @@ func (uc *MasterUsecase) GetAnalyticsOverview(ctx context.Context, req *domain.A
 			responseTimeCount += result.TotalRequests
 		}
 
-		// Collect P95 times for percentile calculation
-		if result.P95LatencyMs > 0 {
-			allP95Times = append(allP95Times, result.P95LatencyMs)
-		}
-
 		// Accumulate error codes
 		for code, count := range result.ErrorRates {
 			errorCodes[code] += int64(count)
 		}
 
+		if result.Apdex != nil {
+			if apdex == nil {
+				apdex = &domain.AnalyticsApdex{}
+			}
+			apdex.Tests++
+			apdex.Satisfied += result.Apdex.Satisfied
+			apdex.Tolerating += result.Apdex.Tolerating
+			apdex.Frustrated += result.Apdex.Frustrated
+		}
+
 		// Group by day for trends
 		dayKey := test.CreatedAt.Format("2006-01-02")
 		testsPerDay[dayKey]++
 		requestsPerDay[dayKey] += result.TotalRequests
+		if dailyTrend[dayKey] == nil {
+			dailyTrend[dayKey] = &dailyStats{date: dayKey}
+		}
+		dailyTrend[dayKey].add(test.ID, result)
 	}
 
 	// Calculate success rate
@@ -1105,21 +1320,11 @@ func (uc *MasterUsecase) GetAnalyticsOverview(ctx context.Context, req *domain.A
 		averageResponseTime = responseTimeSum / float64(responseTimeCount)
 	}
 
-	// Calculate P95 and P99 response times from collected data
-	var p95ResponseTime, p99ResponseTime float64
-	if len(allP95Times) > 0 {
-		sort.Float64s(allP95Times)
-		p95Index := int(float64(len(allP95Times)) * 0.95)
-		if p95Index >= len(allP95Times) {
-			p95Index = len(allP95Times) - 1
-		}
-		p95ResponseTime = allP95Times[p95Index]
+	latency := uc.latencyDistribution(ctx, resultTestIDs, "")
 
-		p99Index := int(float64(len(allP95Times)) * 0.99)
-		if p99Index >= len(allP95Times) {
-			p99Index = len(allP95Times) - 1
-		}
-		p99ResponseTime = allP95Times[p99Index]
+	if apdex != nil {
+		score := apdex.ApdexCounts.Score()
+		apdex.Score, apdex.Rating = math.Round(score*1000)/1000, domain.ApdexRating(score)
 	}
 
 	// Build top error codes
@@ -1160,17 +1365,30 @@ func (uc *MasterUsecase) GetAnalyticsOverview(ctx context.Context, req *domain.A
 		})
 	}
 
+	days := make([]*dailyStats, 0, len(dailyTrend))
+	for _, d := range dailyTrend {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].date < days[j].date })
+
 	return &domain.AnalyticsOverview{
 		TotalTests:          int64(len(tests)),
 		TotalRequests:       totalRequests,
 		SuccessRate:         successRate,
 		AverageResponseTime: averageResponseTime,
-		MedianResponseTime:  averageResponseTime, // Simplified - could calculate true median
-		P95ResponseTime:     p95ResponseTime,
-		P99ResponseTime:     p99ResponseTime,
+		MedianResponseTime:  latency.P50Ms,
+		P75ResponseTime:     latency.P75Ms,
+		P90ResponseTime:     latency.P90Ms,
+		P95ResponseTime:     latency.P95Ms,
+		P99ResponseTime:     latency.P99Ms,
+		P999ResponseTime:    latency.P999Ms,
+		PercentileSource:    latency.Source,
 		TopErrorCodes:       topErrorCodes,
 		TestsPerDay:         testsPerDaySlice,
 		RequestsPerDay:      requestsPerDaySlice,
+		PerformancePerDay:   performanceTrend(days),
+		Anomalies:           detectAnomalies(days),
+		Apdex:               apdex,
 	}, nil
 }
 
@@ -1197,6 +1415,7 @@ func (uc *MasterUsecase) GetTargetAnalytics(ctx context.Context, req *domain.Ana
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tests in range: %w", err)
 	}
+	tests = testsOfBuild(tests, req.TargetBuild)
 
 	// Group tests by target URL
 	targetGroups := make(map[string][]*domain.TestRequest)
@@ -1248,7 +1467,7 @@ func (uc *MasterUsecase) extractTargetsFromBase64(targetsBase64 string) []string
 		if err := json.Unmarshal([]byte(targetsContent), &jsonTargets); err == nil {
 			for _, target := range jsonTargets {
 				if url, ok := target["url"].(string); ok {
-					targets = append(targets, url)
+					targets = append(targets, graphQLTargetKey(url, target["body"]))
 				}
 			}
 			if len(targets) > 0 {
@@ -1284,12 +1503,62 @@ func (uc *MasterUsecase) extractTargetsFromBase64(targetsBase64 string) []string
 	return targets
 }
 
+// graphQLTargetKey breaks GraphQL endpoints down by operation, so each query
+// or mutation gets its own entry in target analytics ("url#OperationName").
+func graphQLTargetKey(url string, body interface{}) string {
+	encoded, ok := body.(string)
+	if !ok {
+		return url
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return url
+	}
+	if name := domain.GraphQLOperationName(decoded); name != "" {
+		return url + "#" + name
+	}
+	return url
+}
+
+// sequenceRanges partitions consecutive {{seq}} ranges starting at start, one
+// per worker, each sized to the most requests that worker can send at its rate
+// plus reserve values for its pre-flight probes.
+func sequenceRanges(start uint64, durationStr string, rates []uint64, reserve uint64) [][2]uint64 {
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		duration = 0
+	}
+	seconds := uint64((duration + time.Second - 1) / time.Second)
+
+	ranges := make([][2]uint64, len(rates))
+	next := start
+	for i, rate := range rates {
+		size := rate*seconds + 1 + reserve // vegeta may send one extra request at the boundary
+		ranges[i] = [2]uint64{next, next + size}
+		next += size
+	}
+	return ranges
+}
+
+// sequenceReserve returns how many {{seq}} values a worker's pre-flight probes
+// may take from the start of its range: one per target.
+func sequenceReserve(testReq *domain.TestRequest) uint64 {
+	if !testReq.Preflight || testReq.TargetsBase64 == "" {
+		return 0
+	}
+	targets, err := decodeTargets(testReq.TargetsBase64)
+	if err != nil {
+		return 0
+	}
+	return uint64(len(targets))
+}
+
 // Helper method to calculate analytics for a specific target
 func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target string, tests []*domain.TestRequest) domain.TargetAnalytics {
 	var totalRequests, successfulRequests int64
 	var responseTimeSum float64
 	var responseTimeCount int64
-	var allP95Times []float64
+	var resultTestIDs []string
 	errorCodes := make(map[string]int64)
 	var performanceTrend []domain.PerformancePoint
 
@@ -1299,6 +1568,7 @@ func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target st
 			continue
 		}
 
+		resultTestIDs = append(resultTestIDs, test.ID)
 		totalRequests += result.TotalRequests
 		successfulRequests += result.SuccessfulRequests
 
@@ -1307,10 +1577,6 @@ func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target st
 			responseTimeCount += result.TotalRequests
 		}
 
-		if result.P95LatencyMs > 0 {
-			allP95Times = append(allP95Times, result.P95LatencyMs)
-		}
-
 		for code, count := range result.ErrorRates {
 			errorCodes[code] += int64(count)
 		}
@@ -1340,21 +1606,7 @@ func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target st
 		averageResponseTime = responseTimeSum / float64(responseTimeCount)
 	}
 
-	var p95ResponseTime, p99ResponseTime float64
-	if len(allP95Times) > 0 {
-		sort.Float64s(allP95Times)
-		p95Index := int(float64(len(allP95Times)) * 0.95)
-		if p95Index >= len(allP95Times) {
-			p95Index = len(allP95Times) - 1
-		}
-		p95ResponseTime = allP95Times[p95Index]
-
-		p99Index := int(float64(len(allP95Times)) * 0.99)
-		if p99Index >= len(allP95Times) {
-			p99Index = len(allP95Times) - 1
-		}
-		p99ResponseTime = allP95Times[p99Index]
-	}
+	latency := uc.latencyDistribution(ctx, resultTestIDs, target)
 
 	// Build error breakdown
 	var errorBreakdown []domain.ErrorCodeStats
@@ -1378,9 +1630,13 @@ func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target st
 		TotalRequests:       totalRequests,
 		SuccessRate:         successRate,
 		AverageResponseTime: averageResponseTime,
-		MedianResponseTime:  averageResponseTime, // Simplified
-		P95ResponseTime:     p95ResponseTime,
-		P99ResponseTime:     p99ResponseTime,
+		MedianResponseTime:  latency.P50Ms,
+		P75ResponseTime:     latency.P75Ms,
+		P90ResponseTime:     latency.P90Ms,
+		P95ResponseTime:     latency.P95Ms,
+		P99ResponseTime:     latency.P99Ms,
+		P999ResponseTime:    latency.P999Ms,
+		PercentileSource:    latency.Source,
 		ErrorBreakdown:      errorBreakdown,
 		PerformanceTrend:    performanceTrend,
 	}
@@ -1390,6 +1646,9 @@ func (uc *MasterUsecase) calculateTargetAnalytics(ctx context.Context, target st
 
 // addWorkerToAvailabilityQueue adds a worker to the availability queue
 func (uc *MasterUsecase) addWorkerToAvailabilityQueue(workerID string) {
+	if uc.isDraining(workerID) {
+		return
+	}
 	uc.mu.Lock()
 	defer uc.mu.Unlock()
 
@@ -1469,6 +1728,8 @@ func (uc *MasterUsecase) fixStuckTests(ctx context.Context) {
 						log.Printf("Error updating stuck test %s: %v", test.ID, err)
 					} else {
 						log.Printf("✅ Updated stuck test %s status to %s", test.ID, newStatus)
+						uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: test.ID, Status: newStatus,
+							Message: fmt.Sprintf("Stuck waiting for %d workers", test.WorkerCount)})
 					}
 				}
 			}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// DefaultWorkerWaitTimeout is how long a test waits for its workers before
+// its partial assignment policy decides what to do with those that turned up,
+// unless configured otherwise or set on the test.
+const DefaultWorkerWaitTimeout = 30 * time.Second
+
+// SetWorkerWaitTimeout sets how long tests that set no maxWaitForWorkers wait
+// for their workers.
+func (uc *MasterUsecase) SetWorkerWaitTimeout(timeout time.Duration) error {
+	if timeout <= 0 || timeout > domain.MaxWaitForWorkersLimit {
+		return fmt.Errorf("invalid worker wait timeout %v: must be greater than 0 and at most %v", timeout, domain.MaxWaitForWorkersLimit)
+	}
+	uc.workerWaitTimeout = timeout
+	return nil
+}
+
+// WaitNeedsAdmin reports whether a test's maxWaitForWorkers is longer than
+// the master's default wait. Only admins may submit such tests: while a test
+// waits for workers, no other test is assigned any.
+func (uc *MasterUsecase) WaitNeedsAdmin(maxWaitForWorkers string) bool {
+	wait, err := time.ParseDuration(maxWaitForWorkers)
+	return err == nil && wait > uc.workerWaitFor(&domain.TestRequest{})
+}
+
+// workerWaitFor returns how long a test waits for its workers: its own
+// maxWaitForWorkers if set, else the master's default.
+func (uc *MasterUsecase) workerWaitFor(testReq *domain.TestRequest) time.Duration {
+	if wait, err := time.ParseDuration(testReq.MaxWaitForWorkers); err == nil && wait > 0 {
+		return wait
+	}
+	if uc.workerWaitTimeout > 0 {
+		return uc.workerWaitTimeout
+	}
+	return DefaultWorkerWaitTimeout
+}
+
+// gatherWorkers takes available workers that can run a test until it has as
+// many as it asked for or timeout passes, and returns those it took. Workers
+// lacking a capability the test needs, or from a region a multi-region test
+// has enough workers of, are left available for other tests.
+func (uc *MasterUsecase) gatherWorkers(testReq *domain.TestRequest, timeout time.Duration) []string {
+	var workers, unsuitable []string
+	defer func() {
+		for _, workerID := range unsuitable {
+			uc.addWorkerToAvailabilityQueue(workerID)
+		}
+	}()
+
+	payloadBytes := assignmentPayloadBytes(testReq)
+	needs := regionNeeds(testReq)
+	deadline := time.After(timeout)
+	for uint32(len(workers)) < testReq.WorkerCount {
+		select {
+		case workerID := <-uc.workerAvailability:
+			uc.removeWorkerFromAvailabilityQueue(workerID) // Remove from tracking
+			if slices.Contains(workers, workerID) || slices.Contains(unsuitable, workerID) {
+				// A worker reporting READY again while we wait is queued twice
+				continue
+			}
+			if _, connected := uc.activeWorkerClients.Load(workerID); !connected || uc.isDraining(workerID) {
+				// Went offline, or was drained for a rollout, after it became available
+				continue
+			}
+			if missing := uc.missingCapabilities(context.Background(), workerID, testReq, payloadBytes); len(missing) > 0 {
+				log.Printf("Worker %s cannot run test %s: lacks %s", workerID, testReq.ID, strings.Join(missing, ", "))
+				unsuitable = append(unsuitable, workerID)
+				continue
+			}
+			if needs != nil {
+				region := uc.workerRegion(context.Background(), workerID)
+				if needs[region] == 0 {
+					log.Printf("Worker %s (region %q) is not needed by test %s", workerID, region, testReq.ID)
+					unsuitable = append(unsuitable, workerID)
+					continue
+				}
+				needs[region]--
+			}
+			workers = append(workers, workerID)
+			log.Printf("Worker %s assigned to test %s (%d/%d workers collected)",
+				workerID, testReq.ID, len(workers), testReq.WorkerCount)
+		case <-deadline:
+			log.Printf("Timeout after %v waiting for workers for test %s. Only %d/%d workers available",
+				timeout, testReq.ID, len(workers), testReq.WorkerCount)
+			return workers
+		}
+	}
+	return workers
+}
+
+// acceptPartialAssignment applies a test's partial assignment policy when
+// fewer workers than it asked for were gathered. It returns true if the test
+// should run on workers; otherwise it has released them and put the test back
+// in the queue, or failed it.
+func (uc *MasterUsecase) acceptPartialAssignment(testReq *domain.TestRequest, workers []string) bool {
+	if len(workers) == 0 {
+		log.Printf("No workers available for test %s, re-queueing", testReq.ID)
+		uc.requeueTest(testReq)
+		return false
+	}
+
+	if testReq.PartialPolicy == domain.PartialRequireAll {
+		for _, workerID := range workers {
+			uc.addWorkerToAvailabilityQueue(workerID)
+		}
+		if capable := uc.capableWorkerCount(context.Background(), testReq); capable < int(testReq.WorkerCount) {
+			message := fmt.Sprintf("Test requires all %d workers but only %d registered workers can run it", testReq.WorkerCount, capable)
+			log.Printf("Failing test %s: %s", testReq.ID, message)
+			uc.testQueue.assigned()
+			uc.testRepo.UpdateTestStatus(context.Background(), testReq.ID, "FAILED",
+				testReq.CompletedWorkers, append(testReq.FailedWorkers, "NotEnoughWorkers"))
+			uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testReq.ID, Status: "FAILED",
+				Message: message})
+			return false
+		}
+		log.Printf("Test %s requires all %d workers, re-queueing until they are free", testReq.ID, testReq.WorkerCount)
+		uc.requeueTest(testReq)
+		return false
+	}
+
+	log.Printf("Proceeding with partial assignment for test %s using %d/%d workers (policy: %s)",
+		testReq.ID, len(workers), testReq.WorkerCount, testReq.PartialPolicy)
+	return true
+}
+
+// requeueTest puts the test being assigned back in the queue.
+func (uc *MasterUsecase) requeueTest(testReq *domain.TestRequest) {
+	uc.testQueue.assigned()
+	uc.testQueue.push(testReq)
+}
+
+// capableWorkerCount is the number of registered workers that are not
+// offline and can run a test. Workers of a multi-region test only count up to
+// the number its region needs.
+func (uc *MasterUsecase) capableWorkerCount(ctx context.Context, testReq *domain.TestRequest) int {
+	workers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to list workers: %v", err)
+		return 0
+	}
+	payloadBytes := assignmentPayloadBytes(testReq)
+	needs := regionNeeds(testReq)
+	capable := 0
+	for _, worker := range workers {
+		if worker.Status == "OFFLINE" || len(worker.MissingCapabilities(testReq, payloadBytes)) > 0 {
+			continue
+		}
+		if needs != nil {
+			if needs[worker.Region] == 0 {
+				continue
+			}
+			needs[worker.Region]--
+		}
+		capable++
+	}
+	return capable
+}
+
+// assignmentRates returns the rate of each of the n workers a test was
+// assigned. With fewer workers than the test asked for, PartialScaleRate
+// spreads the full rate over them, while PartialBestEffort keeps each worker
+// at its planned share and drops the missing workers' load.
+func assignmentRates(testReq *domain.TestRequest, n int) []uint64 {
+	requested := int(testReq.WorkerCount)
+	if n >= requested {
+		return distributeRate(testReq, n)
+	}
+	if testReq.PartialPolicy == domain.PartialScaleRate {
+		scaled := *testReq
+		if len(scaled.RateWeights) > n {
+			scaled.RateWeights = scaled.RateWeights[:n]
+		}
+		return distributeRate(&scaled, n)
+	}
+	return distributeRate(testReq, requested)[:n]
+}
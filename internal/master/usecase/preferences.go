@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetPreferencesRepository makes tests take the worker count, rate
+// distribution and duration they omit from their requester's preferences.
+func (uc *MasterUsecase) SetPreferencesRepository(repo domain.PreferencesRepository) {
+	uc.preferencesRepo = repo
+}
+
+// applyPreferences fills in the settings testReq omits from its requester's
+// preferences. A multi-region test's worker count comes from its regions,
+// and it only takes a rate distribution it can use.
+func (uc *MasterUsecase) applyPreferences(ctx context.Context, testReq *domain.TestRequest) {
+	if uc.preferencesRepo == nil || testReq.RequesterID == "" {
+		return
+	}
+	prefs, err := uc.preferencesRepo.GetUserPreferences(ctx, testReq.RequesterID)
+	if err != nil {
+		log.Printf("Warning: Failed to load the preferences of %s: %v", testReq.RequesterID, err)
+		return
+	}
+
+	multiRegion := len(testReq.Regions) > 0
+	if testReq.WorkerCount == 0 && !multiRegion {
+		testReq.WorkerCount = prefs.DefaultWorkerCount
+	}
+	if testReq.RateDistribution == "" && (!multiRegion || slices.Contains(regionalRateDistributions, prefs.DefaultRateDistribution)) {
+		testReq.RateDistribution = prefs.DefaultRateDistribution
+	}
+	if testReq.DurationSeconds == "" {
+		testReq.DurationSeconds = prefs.DefaultDuration
+	}
+}
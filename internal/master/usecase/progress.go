@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// liveProgressTTL is how long live progress is kept after its last update.
+const liveProgressTTL = 10 * time.Minute
+
+// liveTest tracks worker progress reports for one test.
+type liveTest struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	updatedAt time.Time
+	workers   map[string]*liveWorker
+
+	budget      *domain.ErrorBudget // Error budget from the test's options; nil = none
+	samples     []budgetSample      // Test-wide request deltas inside the budget window
+	abortReason string              // Why the test was aborted; workers are told to stop once set
+	rates       map[string]uint64   // New rates of workers that have not been told yet, by worker ID
+}
+
+// liveWorker holds a worker's latest report and the change since the one before.
+type liveWorker struct {
+	progress       domain.WorkerProgress
+	latencyTotalUs int64
+	deltaRequests  int64
+	deltaFailures  int64
+	deltaLatencyUs int64
+	statusCodes    map[string]int64 // Cumulative requests by status code
+	saturated      bool             // A saturation warning has been logged for this worker
+}
+
+// RecordWorkerProgress stores a status report from a worker running testID.
+// Counters in BUSY reports are cumulative and rates are derived from the
+// previous report; other statuses only update the worker's status. It returns
+// why the test was aborted when the worker should stop running it, or "".
+func (uc *MasterUsecase) RecordWorkerProgress(ctx context.Context, workerID, testID, status string, requests, expected, failures, latencyTotalUs int64, statusCodes map[string]int64, resources *domain.ResourceSample) string {
+	now := time.Now()
+	lt := uc.liveTestFor(ctx, testID, now)
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	lt.updatedAt = now
+
+	w, ok := lt.workers[workerID]
+	if !ok {
+		w = &liveWorker{progress: domain.WorkerProgress{WorkerID: workerID}}
+		lt.workers[workerID] = w
+	}
+	if status != "BUSY" {
+		w.progress.Status = status
+		w.progress.RPS = 0
+		w.deltaRequests, w.deltaFailures, w.deltaLatencyUs = 0, 0, 0 // No longer contributes to test-wide rates
+		return lt.abortReason
+	}
+	if requests < w.progress.Requests {
+		// Counters went backwards (e.g. a retried assignment); start over
+		w.progress.Requests, w.progress.Failures, w.latencyTotalUs = 0, 0, 0
+		w.statusCodes = nil
+	}
+
+	w.deltaRequests = requests - w.progress.Requests
+	w.deltaFailures = failures - w.progress.Failures
+	w.deltaLatencyUs = latencyTotalUs - w.latencyTotalUs
+	if ok {
+		if elapsed := now.Sub(w.progress.UpdatedAt).Seconds(); elapsed > 0 {
+			w.progress.RPS = float64(w.deltaRequests) / elapsed
+		}
+	}
+	if w.deltaRequests > 0 {
+		w.progress.MeanLatencyMs = float64(w.deltaLatencyUs) / float64(w.deltaRequests) / 1000
+	}
+
+	if resources != nil {
+		w.progress.Resources = resources
+		if resources.Saturated() && !w.saturated {
+			w.saturated = true
+			log.Printf("Warning: worker %s is saturated while running test %s (CPU %.0f%%, memory %.0f%%, open files %d/%d); its results may be skewed",
+				workerID, testID, resources.CPUPercent, resources.MemoryPercent, resources.OpenFiles, resources.OpenFilesLimit)
+		}
+	}
+	w.progress.Status = status
+	w.progress.Requests = requests
+	w.progress.Expected = expected
+	w.progress.Failures = failures
+	w.progress.UpdatedAt = now
+	w.latencyTotalUs = latencyTotalUs
+	deltaCodes := make(map[string]int64, len(statusCodes))
+	for code, count := range statusCodes {
+		if delta := count - w.statusCodes[code]; delta > 0 {
+			deltaCodes[code] = delta
+		}
+	}
+	w.statusCodes = statusCodes
+	uc.bufferMetricSample(domain.MetricSample{TestID: testID, WorkerID: workerID, Time: now,
+		Requests: w.deltaRequests, Failures: w.deltaFailures, LatencyTotalUs: w.deltaLatencyUs, StatusCodes: deltaCodes})
+	progress := w.progress
+	uc.events.Publish(domain.Event{Type: domain.EventTestMetrics, TestID: testID, WorkerID: workerID, Status: status, Progress: &progress})
+
+	if lt.abortReason == "" {
+		if errorRate, exhausted := lt.spendErrorBudget(now, w.deltaRequests, w.deltaFailures); exhausted {
+			lt.abortReason = "error budget exceeded"
+			go uc.abortForErrorBudget(testID, errorRate)
+		}
+	}
+
+	uc.pruneLiveProgress(now)
+	return lt.abortReason
+}
+
+// liveTestFor returns the live progress entry for testID, creating it on the
+// first report. A new entry loads the test's error budget before it is shared.
+func (uc *MasterUsecase) liveTestFor(ctx context.Context, testID string, now time.Time) *liveTest {
+	if val, ok := uc.liveProgress.Load(testID); ok {
+		return val.(*liveTest)
+	}
+
+	lt := &liveTest{startedAt: now, workers: make(map[string]*liveWorker)}
+	if test, err := uc.testRepo.GetTestRequestByID(ctx, testID); err == nil {
+		if opts, err := domain.ParseAttackOptions(test.VegetaPayloadJSON); err == nil {
+			lt.budget = opts.ErrorBudget
+		}
+	}
+	val, _ := uc.liveProgress.LoadOrStore(testID, lt)
+	return val.(*liveTest)
+}
+
+// GetTestProgress returns the live progress of a test. Tests that have not
+// started yet, or finished long ago, have no worker entries.
+func (uc *MasterUsecase) GetTestProgress(ctx context.Context, testID string) (*domain.TestProgress, error) {
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &domain.TestProgress{TestID: testID, Status: test.Status, Workers: []domain.WorkerProgress{}}
+	val, ok := uc.liveProgress.Load(testID)
+	if !ok {
+		return progress, nil
+	}
+	lt := val.(*liveTest)
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	progress.ElapsedMs = lt.updatedAt.Sub(lt.startedAt).Milliseconds()
+
+	var deltaRequests, deltaFailures, deltaLatencyUs int64
+	for _, w := range lt.workers {
+		progress.Workers = append(progress.Workers, w.progress)
+		progress.Requests += w.progress.Requests
+		progress.Failures += w.progress.Failures
+		progress.RPS += w.progress.RPS
+		deltaRequests += w.deltaRequests
+		deltaFailures += w.deltaFailures
+		deltaLatencyUs += w.deltaLatencyUs
+	}
+	if deltaRequests > 0 {
+		progress.ErrorRate = float64(deltaFailures) / float64(deltaRequests)
+		progress.MeanLatencyMs = float64(deltaLatencyUs) / float64(deltaRequests) / 1000
+	}
+	sort.Slice(progress.Workers, func(i, j int) bool {
+		return progress.Workers[i].WorkerID < progress.Workers[j].WorkerID
+	})
+	return progress, nil
+}
+
+// IsTestActive reports whether a test with the given status may still make progress.
+func IsTestActive(status string) bool {
+	switch status {
+	case "PENDING", "RUNNING", TestStatusAwaitingApproval:
+		return true
+	}
+	return false
+}
+
+// pruneLiveProgress drops live progress for tests not updated recently.
+func (uc *MasterUsecase) pruneLiveProgress(now time.Time) {
+	uc.liveProgress.Range(func(key, val interface{}) bool {
+		lt := val.(*liveTest)
+		if lt.mu.TryLock() {
+			stale := now.Sub(lt.updatedAt) > liveProgressTTL
+			lt.mu.Unlock()
+			if stale {
+				uc.liveProgress.Delete(key)
+			}
+		}
+		return true
+	})
+}
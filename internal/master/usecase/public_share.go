@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// errPublicLinkNotFound covers unknown, tampered, private, revoked and
+// expired links alike, so a token reveals nothing about why it does not work.
+var errPublicLinkNotFound = errors.New("shared link not found or expired")
+
+// SetPublicShareKey enables public shared links, whose tokens are signed
+// with key. Changing the key invalidates every public link.
+func (uc *MasterUsecase) SetPublicShareKey(key []byte) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("public-shared-links"))
+	uc.publicShareKey = mac.Sum(nil)
+}
+
+// ShareTestPublicly creates a link to a test that anyone holding its token
+// can open, read-only and without logging in, until it expires after ttl or
+// is revoked. Only the test's requester or an admin may publish it.
+func (uc *MasterUsecase) ShareTestPublicly(ctx context.Context, testID string, user *domain.UserProfile, ttl time.Duration) (*domain.SharedLink, error) {
+	if uc.publicShareKey == nil {
+		return nil, errors.New("public shared links are not enabled")
+	}
+	expiresAt, err := shareLinkExpiry(ttl)
+	if err != nil {
+		return nil, err
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil || test == nil {
+		return nil, fmt.Errorf("test not found: %s", testID)
+	}
+	if test.RequesterID != user.ID && user.Role != "admin" {
+		return nil, errors.New("insufficient permissions: only the test's requester or an admin can share it publicly")
+	}
+
+	link, err := uc.sharedLinkRepo.CreateSharedLink(ctx, testID, user.ID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.sharedLinkRepo.SetSharedLinkPublic(ctx, link.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.snapshotSharedResult(ctx, link); err != nil {
+		return nil, err
+	}
+	link.Public = true
+	link.PublicToken = uc.publicShareToken(link)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditShareCreate, testID, map[string]string{"linkId": link.ID, "public": "true"}))
+	return link, nil
+}
+
+// OpenPublicSharedLink returns the read-only view of the test a public link
+// token points to, with the result captured when the link was created, and
+// counts the view. Target URLs lose their query strings, and they and
+// errors are scrubbed like exports.
+func (uc *MasterUsecase) OpenPublicSharedLink(ctx context.Context, token string) (*domain.PublicTestView, error) {
+	linkID, ok := uc.verifyPublicShareToken(token)
+	if !ok {
+		return nil, errPublicLinkNotFound
+	}
+	link, err := uc.sharedLinkRepo.GetSharedLinkByID(ctx, linkID)
+	if err != nil || !link.Public || link.RevokedAt != nil || time.Now().After(link.ExpiresAt) ||
+		uc.publicShareToken(link) != token {
+		return nil, errPublicLinkNotFound
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, link.TestID)
+	if err != nil || test == nil {
+		return nil, errPublicLinkNotFound
+	}
+	if err := uc.sharedLinkRepo.RecordSharedLinkAccess(ctx, link.ID); err != nil {
+		log.Printf("Failed to record access to shared link %s: %v", link.ID, err)
+	}
+
+	view := &domain.PublicTestView{
+		Name:             test.Name,
+		Status:           test.Status,
+		CreatedAt:        test.CreatedAt,
+		DurationSeconds:  test.DurationSeconds,
+		RatePerSecond:    test.RatePerSecond,
+		WorkerCount:      test.WorkerCount,
+		RateDistribution: test.RateDistribution,
+		Tags:             test.Tags,
+		LinkExpiresAt:    link.ExpiresAt,
+	}
+	for _, target := range test.TargetURLs {
+		if u, err := url.Parse(target); err == nil {
+			u.RawQuery, u.Fragment, u.User = "", "", nil
+			target = u.String()
+		}
+		view.TargetURLs = append(view.TargetURLs, uc.redactor.Redact(target))
+	}
+	if result, snapshotAt, err := uc.sharedResult(ctx, link); err == nil && result != nil {
+		// The workers that ran the test stay private
+		result.ResourceWarnings, result.Environments = nil, nil
+		view.SnapshotAt = snapshotAt
+		errorRates := make(map[string]int, len(result.ErrorRates))
+		for message, count := range result.ErrorRates {
+			errorRates[uc.redactor.Redact(message)] += count
+		}
+		result.ErrorRates = errorRates
+		view.Result = result
+	}
+	return view, nil
+}
+
+// publicShareToken signs a link's ID and expiry, so the token stops working
+// when the link expires even if the database were to say otherwise.
+func (uc *MasterUsecase) publicShareToken(link *domain.SharedLink) string {
+	payload := link.ID + "." + strconv.FormatInt(link.ExpiresAt.Unix(), 10)
+	mac := hmac.New(sha256.New, uc.publicShareKey)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyPublicShareToken checks a token's signature and expiry and returns
+// the ID of its link.
+func (uc *MasterUsecase) verifyPublicShareToken(token string) (string, bool) {
+	if uc.publicShareKey == nil {
+		return "", false
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().After(time.Unix(expires, 0)) {
+		return "", false
+	}
+	mac := hmac.New(sha256.New, uc.publicShareKey)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", false
+	}
+	return parts[0], true
+}
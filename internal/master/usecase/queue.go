@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// TestStatusCancelled marks a test removed from the queue before it ran.
+const TestStatusCancelled = domain.TestStatusCancelled
+
+// overflowListLimit caps how many tests waiting in the database GetQueue
+// lists after the queue window.
+const overflowListLimit = 500
+
+// DefaultMaxResumeAge is how long ago a test may have been queued for the
+// master to still run it after a restart, unless configured otherwise.
+const DefaultMaxResumeAge = 24 * time.Hour
+
+// resumePageSize is how many queued tests resumeQueuedTests loads at a time.
+const resumePageSize = 500
+
+// SetQueueWindow sets how many queued tests are held in memory for
+// scheduling. Tests beyond it wait in the database, so none are refused.
+func (uc *MasterUsecase) SetQueueWindow(window int) error {
+	if window < 1 {
+		return fmt.Errorf("invalid queue window %d: must be at least 1", window)
+	}
+	uc.testQueue.setWindow(window)
+	return nil
+}
+
+// SetMaxResumeAge sets how long ago a test may have been queued for the
+// master to still run it after a restart. 0 resumes every queued test.
+func (uc *MasterUsecase) SetMaxResumeAge(age time.Duration) error {
+	if age < 0 {
+		return fmt.Errorf("invalid max resume age %v: must not be negative", age)
+	}
+	uc.maxResumeAge = age
+	return nil
+}
+
+// resumeQueuedTests runs once when test distribution starts. Tests still
+// PENDING from before the master restarted will be run; it logs which, and
+// cancels those queued longer ago than maxResumeAge, whose requesters have
+// likely moved on, rather than suddenly start them.
+func (uc *MasterUsecase) resumeQueuedTests(ctx context.Context) {
+	var pending []*domain.TestRequest
+	for offset := 0; ; offset += resumePageSize {
+		tests, _, err := uc.testRepo.ListTests(ctx, domain.TestListFilter{
+			Status:    domain.TestStatusPending,
+			SortBy:    domain.TestSortCreated,
+			Ascending: true,
+			Limit:     resumePageSize,
+			Offset:    offset,
+		})
+		if err != nil {
+			log.Printf("Error listing tests queued before the restart: %v", err)
+			return
+		}
+		pending = append(pending, tests...)
+		if len(tests) < resumePageSize {
+			break
+		}
+	}
+
+	var resumed []string
+	for _, test := range pending {
+		if uc.maxResumeAge == 0 || time.Since(test.CreatedAt) <= uc.maxResumeAge {
+			resumed = append(resumed, test.ID)
+			continue
+		}
+		if err := uc.testRepo.UpdateTestStatus(ctx, test.ID, TestStatusCancelled, nil, nil); err != nil {
+			log.Printf("Error cancelling stale queued test %s: %v", test.ID, err)
+			continue
+		}
+		message := fmt.Sprintf("Not resumed after a master restart: queued %s ago, longer than %s",
+			time.Since(test.CreatedAt).Truncate(time.Minute), uc.maxResumeAge)
+		log.Printf("Cancelled test %s (%s): %s", test.ID, test.Name, message)
+		uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: test.ID, Status: TestStatusCancelled, Message: message})
+	}
+	if len(resumed) > 0 {
+		log.Printf("Resuming %d tests queued before the restart: %s", len(resumed), strings.Join(resumed, ", "))
+	}
+}
+
+// refillQueue moves tests waiting in the database into the queue window as
+// it frees up, highest priority first and oldest first within a priority.
+func (uc *MasterUsecase) refillQueue(ctx context.Context) {
+	room, spills := uc.testQueue.room()
+	if room == 0 {
+		return
+	}
+	waiting, err := uc.overflowTests(ctx, room)
+	if err != nil {
+		log.Printf("Error loading queued tests waiting in the database: %v", err)
+		return // Looked for again on the next refill
+	}
+	added := 0
+	for _, tests := range waiting {
+		added += uc.testQueue.fill(tests)
+	}
+	if added > 0 {
+		log.Printf("Moved %d queued tests from the database into the queue window.", added)
+	}
+	if added < room {
+		uc.testQueue.drained(spills)
+	}
+}
+
+// overflowTests returns up to limit PENDING tests the queue does not hold,
+// grouped by priority from highest, oldest first.
+func (uc *MasterUsecase) overflowTests(ctx context.Context, limit int) ([][]*domain.TestRequest, error) {
+	var groups [][]*domain.TestRequest
+	for _, priority := range domain.Priorities {
+		// Tests in the window are PENDING too, so look past them
+		tests, _, err := uc.testRepo.ListTests(ctx, domain.TestListFilter{
+			Status:    domain.TestStatusPending,
+			Priority:  priority,
+			SortBy:    domain.TestSortCreated,
+			Ascending: true,
+			Limit:     limit + uc.testQueue.size(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		waiting := slices.DeleteFunc(tests, func(test *domain.TestRequest) bool { return uc.testQueue.contains(test.ID) })
+		waiting = waiting[:min(len(waiting), limit)]
+		groups = append(groups, waiting)
+		if limit -= len(waiting); limit == 0 {
+			break
+		}
+	}
+	return groups, nil
+}
+
+// GetQueue lists the tests waiting for workers in the order they will get
+// them, starting with the test already gathering workers and ending with up
+// to overflowListLimit tests waiting in the database for room in the queue
+// window. Tests held back by a guardrail say which. Other users' tests
+// are shown to user without their ID, name and requester unless user is an
+// admin.
+func (uc *MasterUsecase) GetQueue(ctx context.Context, user *domain.UserProfile) []domain.QueuedTest {
+	now := time.Now()
+	assigning, tests := uc.testQueue.snapshot(now)
+
+	queue := make([]domain.QueuedTest, 0, len(tests)+1)
+	add := func(test *domain.TestRequest, isAssigning bool) {
+		entry := domain.QueuedTest{
+			Position:          len(queue) + 1,
+			Priority:          test.Priority,
+			EffectivePriority: domain.Priorities[effectivePriorityRank(test, now)],
+			WorkerCount:       test.WorkerCount,
+			RatePerSecond:     test.RatePerSecond,
+			SubmittedAt:       test.CreatedAt,
+			Assigning:         isAssigning,
+		}
+		if !isAssigning {
+			entry.HeldBy = uc.guardrails.heldBy(test, now)
+		}
+		if test.RequesterID == user.ID || user.Role == "admin" {
+			entry.TestID, entry.Name, entry.RequesterID = test.ID, test.Name, test.RequesterID
+		}
+		queue = append(queue, entry)
+	}
+	if assigning != nil {
+		add(assigning, true)
+	}
+	for i := range tests {
+		add(&tests[i], false)
+	}
+	waiting, err := uc.overflowTests(ctx, overflowListLimit)
+	if err != nil {
+		log.Printf("Error listing queued tests waiting in the database: %v", err)
+	}
+	for _, tests := range waiting {
+		for _, test := range tests {
+			add(test, false)
+			queue[len(queue)-1].Overflow = true
+		}
+	}
+	return queue
+}
+
+// CancelQueuedTest removes a test from the queue so it never runs. Only the
+// user who submitted it, or an admin, may cancel it.
+func (uc *MasterUsecase) CancelQueuedTest(ctx context.Context, testID string, user *domain.UserProfile) error {
+	test, inWindow, err := uc.queuedTestFor(ctx, testID, user)
+	if err != nil {
+		return err
+	}
+	if inWindow && !uc.testQueue.remove(testID) {
+		return fmt.Errorf("test %s is not queued", testID)
+	}
+	if err := uc.testRepo.UpdateTestStatus(ctx, testID, TestStatusCancelled, nil, nil); err != nil {
+		// Put the test back rather than lose it
+		if inWindow {
+			uc.testQueue.push(&test)
+		}
+		return fmt.Errorf("failed to cancel test %s: %w", testID, err)
+	}
+	if !inWindow {
+		// A refill may have moved it into the window meanwhile
+		uc.testQueue.remove(testID)
+	}
+	log.Printf("Test %s removed from the queue by %s.", testID, user.Username)
+	uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: testID, Status: TestStatusCancelled,
+		Message: "Removed from the queue by " + user.Username})
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTestCancel, testID, nil))
+	return nil
+}
+
+// PromoteQueuedTest raises the priority of a queued test to priority, or by
+// one level when priority is empty. Only the user who submitted it, or an
+// admin, may promote it.
+func (uc *MasterUsecase) PromoteQueuedTest(ctx context.Context, testID, priority string, user *domain.UserProfile) (string, error) {
+	test, inWindow, err := uc.queuedTestFor(ctx, testID, user)
+	if err != nil {
+		return "", err
+	}
+	current := domain.PriorityRank(test.Priority)
+	if priority == "" {
+		if current == 0 {
+			return "", fmt.Errorf("invalid promotion: test %s already has %s priority", testID, test.Priority)
+		}
+		priority = domain.Priorities[current-1]
+	}
+	if !slices.Contains(domain.Priorities, priority) {
+		return "", fmt.Errorf("invalid priority %q: must be one of %v", priority, domain.Priorities)
+	}
+	if domain.PriorityRank(priority) >= current {
+		return "", fmt.Errorf("invalid promotion: %s is not above the test's %s priority", priority, test.Priority)
+	}
+
+	if err := uc.testRepo.SetTestPriority(ctx, testID, priority); err != nil {
+		return "", err
+	}
+	// Tests waiting in the database have their new priority when loaded
+	if !uc.testQueue.setPriority(testID, priority) && inWindow {
+		return "", fmt.Errorf("test %s is not queued", testID)
+	}
+	log.Printf("Test %s promoted from %s to %s priority by %s.", testID, test.Priority, priority, user.Username)
+	uc.events.Publish(domain.Event{Type: domain.EventTestUpdated, TestID: testID, Status: test.Status,
+		Message: fmt.Sprintf("Promoted to %s priority by %s", priority, user.Username)})
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTestPromote, testID,
+		map[string]string{"from": test.Priority, "to": priority}))
+	return priority, nil
+}
+
+// queuedTestFor returns a copy of a queued test that user may change, and
+// whether it is in the queue window rather than waiting in the database.
+func (uc *MasterUsecase) queuedTestFor(ctx context.Context, testID string, user *domain.UserProfile) (domain.TestRequest, bool, error) {
+	test, inWindow := uc.testQueue.find(testID)
+	if !inWindow {
+		if uc.testQueue.isAssigning(testID) {
+			return test, false, fmt.Errorf("test %s is already gathering workers", testID)
+		}
+		stored, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+		if err != nil || stored.Status != domain.TestStatusPending {
+			return test, false, fmt.Errorf("test %s not found in the queue", testID)
+		}
+		test = *stored
+	}
+	if test.RequesterID != user.ID && user.Role != "admin" {
+		return test, inWindow, fmt.Errorf("insufficient permissions")
+	}
+	return test, inWindow, nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetRateAttainmentWarning sets the rate attainment, in percent, below which
+// aggregates flag a test or worker with a WARNING.
+func (uc *MasterUsecase) SetRateAttainmentWarning(percent float64) error {
+	if percent <= 0 || percent > 100 {
+		return fmt.Errorf("invalid rate attainment warning %v: must be above 0 and at most 100", percent)
+	}
+	uc.rateAttainmentWarning = percent
+	return nil
+}
+
+// rateAttainment compares the rate test asked of each worker that reported
+// with the rate its result shows it sent at. Overall, the rate asked of the
+// whole test is compared with the sum of the workers' rates, so workers that
+// never reported count as sending nothing.
+func (uc *MasterUsecase) rateAttainment(test *domain.TestRequest, results []*domain.TestResult) *domain.RateAttainmentReport {
+	warnBelow := uc.rateAttainmentWarning
+	if warnBelow == 0 {
+		warnBelow = domain.DefaultRateAttainmentWarning
+	}
+
+	requested := make(map[string]float64)
+	for _, worker := range test.DistributionPlan {
+		if worker.Accepted {
+			requested[worker.WorkerID] += float64(worker.RatePerSecond)
+		}
+	}
+	achieved := make(map[string]float64)
+	var workerIDs []string
+	for _, res := range results {
+		if _, seen := achieved[res.WorkerID]; !seen {
+			workerIDs = append(workerIDs, res.WorkerID)
+		}
+		achieved[res.WorkerID] += achievedRate(res)
+	}
+
+	report := &domain.RateAttainmentReport{Workers: make([]domain.WorkerRateComparison, 0, len(workerIDs))}
+	var total float64
+	for _, workerID := range workerIDs {
+		workerRequested, ok := requested[workerID]
+		if !ok && test.WorkerCount > 0 {
+			workerRequested = float64(test.RatePerSecond) / float64(test.WorkerCount) // Not in the plan, e.g. assigned before plans were kept
+		}
+		total += achieved[workerID]
+		report.Workers = append(report.Workers, domain.WorkerRateComparison{
+			WorkerID:       workerID,
+			RateComparison: compareRates(workerRequested, achieved[workerID], warnBelow),
+		})
+	}
+	report.RateComparison = compareRates(requestedRate(test), total, warnBelow)
+	return report
+}
+
+// achievedRate is the rate a worker sent requests at: vegeta's rate from its
+// metrics, else its requests over its duration.
+func achievedRate(res *domain.TestResult) float64 {
+	var metric struct {
+		Rate float64 `json:"rate"`
+	}
+	if err := json.Unmarshal(res.Metric, &metric); err == nil && metric.Rate > 0 {
+		return metric.Rate
+	}
+	if res.DurationMs <= 0 {
+		return 0
+	}
+	return float64(res.TotalRequests) / (float64(res.DurationMs) / 1000)
+}
+
+// compareRates rounds the rates to hundredths and the attainment to tenths
+// of a percent. Without a requested rate there is nothing to fall short of.
+func compareRates(requested, achieved, warnBelow float64) domain.RateComparison {
+	comparison := domain.RateComparison{
+		RequestedRate: math.Round(requested*100) / 100,
+		AchievedRate:  math.Round(achieved*100) / 100,
+		Status:        domain.RateAttainmentOK,
+	}
+	if requested <= 0 {
+		return comparison
+	}
+	attainment := achieved / requested * 100
+	comparison.RateAttainment = math.Round(attainment*10) / 10
+	if attainment < warnBelow {
+		comparison.Status = domain.RateAttainmentWarning
+	}
+	return comparison
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import "github.com/pace-noge/distributed-load-tester/internal/domain"
+
+// distributeRate splits a test's rate across workerCount workers according to
+// its rate distribution mode and returns each worker's rate in req/s.
+func distributeRate(testReq *domain.TestRequest, workerCount int) []uint64 {
+	workerRates := make([]uint64, 0, workerCount)
+
+	switch testReq.RateDistribution {
+	case "same":
+		// Each worker gets the same full rate
+		for i := 0; i < workerCount; i++ {
+			workerRates = append(workerRates, testReq.RatePerSecond)
+		}
+
+	case "weighted":
+		// Distribute rate based on provided weights
+		totalWeight := 0.0
+		for _, weight := range testReq.RateWeights {
+			totalWeight += weight
+		}
+
+		totalAssigned := uint64(0)
+		for _, weight := range testReq.RateWeights {
+			workerRate := uint64(float64(testReq.RatePerSecond) * weight / totalWeight)
+			workerRates = append(workerRates, workerRate)
+			totalAssigned += workerRate
+		}
+
+		// Handle rounding errors by adding remainder to the first worker
+		if totalAssigned < testReq.RatePerSecond && len(workerRates) > 0 {
+			workerRates[0] += testReq.RatePerSecond - totalAssigned
+		}
+
+	case "ramped":
+		// Gradually increase rate across workers (first worker gets lower rate, last gets higher)
+		baseRate := testReq.RatePerSecond / uint64(workerCount)
+		rampStep := baseRate / 2 // Ramp from 50% to 150% of base rate
+
+		for i := 0; i < workerCount; i++ {
+			// Calculate ramped rate: starts at baseRate - rampStep, ends at baseRate + rampStep
+			rampFactor := 0.5 // A single worker gets the base rate
+			if workerCount > 1 {
+				rampFactor = float64(i) / float64(workerCount-1) // 0.0 to 1.0
+			}
+			workerRate := uint64(float64(baseRate) + (2.0*rampFactor-1.0)*float64(rampStep))
+			if workerRate < 1 {
+				workerRate = 1 // Minimum 1 req/s
+			}
+			workerRates = append(workerRates, workerRate)
+		}
+
+	case "burst":
+		// Concentrate higher load on first few workers, lower on the rest
+		burstWorkers := workerCount / 2
+		if burstWorkers < 1 {
+			burstWorkers = 1
+		}
+		if burstWorkers == workerCount {
+			// No remaining workers to take the other 30%
+			workerRates = append(workerRates, testReq.RatePerSecond)
+			break
+		}
+
+		burstRate := (testReq.RatePerSecond * 70) / (100 * uint64(burstWorkers))              // 70% of load on burst workers
+		normalRate := (testReq.RatePerSecond * 30) / (100 * uint64(workerCount-burstWorkers)) // 30% on remaining
+
+		for i := 0; i < workerCount; i++ {
+			if i < burstWorkers {
+				workerRates = append(workerRates, burstRate)
+			} else {
+				workerRates = append(workerRates, normalRate)
+			}
+		}
+
+	default:
+		// Default "shared" - divide the rate evenly across all workers
+		baseRate := testReq.RatePerSecond / uint64(workerCount)
+		remainder := testReq.RatePerSecond % uint64(workerCount)
+
+		for i := 0; i < workerCount; i++ {
+			workerRate := baseRate
+			if i < int(remainder) {
+				workerRate++ // Distribute remainder among first workers
+			}
+			workerRates = append(workerRates, workerRate)
+		}
+	}
+	return workerRates
+}
+
+// sumRates returns the combined rate of all workers.
+func sumRates(workerRates []uint64) uint64 {
+	var total uint64
+	for _, rate := range workerRates {
+		total += rate
+	}
+	return total
+}
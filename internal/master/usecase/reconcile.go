@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// resultMissingGrace is how long a worker may be off a test without having
+// delivered its result before the result is flagged as missing. It covers the
+// moment between an assignment and the worker's first BUSY status.
+const resultMissingGrace = time.Minute
+
+// StartResultReconciliationJob periodically flags running tests whose workers
+// moved on from the test without their result reaching the master. Workers
+// spool undelivered results and keep retrying, so a flag clears by itself once
+// the result arrives.
+func (uc *MasterUsecase) StartResultReconciliationJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Starting result reconciliation job with interval: %v", interval)
+
+	firstSeen := make(map[string]time.Time) // testID/workerID -> when the result was first found missing
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Result reconciliation job stopped due to context cancellation")
+			return
+		case <-ticker.C:
+			uc.reconcileResults(ctx, firstSeen)
+		}
+	}
+}
+
+// reconcileResults updates the missing results of every running test.
+func (uc *MasterUsecase) reconcileResults(ctx context.Context, firstSeen map[string]time.Time) {
+	workers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		log.Printf("Error fetching workers for result reconciliation: %v", err)
+		return
+	}
+	runningTest := make(map[string]string) // workerID -> test it is still running
+	for _, worker := range workers {
+		if worker.Status == "BUSY" || worker.Status == "FINISHING" {
+			runningTest[worker.ID] = worker.CurrentTestID
+		}
+	}
+
+	tests, err := uc.testRepo.GetAllTestRequests(ctx)
+	if err != nil {
+		log.Printf("Error fetching tests for result reconciliation: %v", err)
+		return
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, test := range tests {
+		if test.Status != "RUNNING" {
+			continue
+		}
+		finished := make(map[string]bool)
+		for _, workerID := range append(test.CompletedWorkers, test.FailedWorkers...) {
+			finished[workerID] = true
+		}
+
+		var missing []string
+		for _, workerID := range test.AssignedWorkersIDs {
+			if finished[workerID] || runningTest[workerID] == test.ID {
+				continue
+			}
+			key := test.ID + "/" + workerID
+			seen[key] = true
+			if _, ok := firstSeen[key]; !ok {
+				firstSeen[key] = now
+			}
+			if now.Sub(firstSeen[key]) >= resultMissingGrace {
+				missing = append(missing, workerID)
+			}
+		}
+		if sameWorkers(missing, test.MissingResults) {
+			continue
+		}
+
+		if err := uc.testRepo.SetMissingResults(ctx, test.ID, missing); err != nil {
+			log.Printf("Error flagging missing results for test %s: %v", test.ID, err)
+			continue
+		}
+		flagged := make(map[string]bool)
+		for _, workerID := range test.MissingResults {
+			flagged[workerID] = true
+		}
+		for _, workerID := range missing {
+			if flagged[workerID] {
+				continue
+			}
+			log.Printf("⚠️ Worker %s finished test %s but its result has not arrived", workerID, test.ID)
+			uc.events.Publish(domain.Event{Type: domain.EventResultMissing, TestID: test.ID, WorkerID: workerID,
+				Status: test.Status, Message: "Worker finished without delivering its result"})
+		}
+	}
+
+	// Forget workers that delivered their result or whose test is no longer running
+	for key := range firstSeen {
+		if !seen[key] {
+			delete(firstSeen, key)
+		}
+	}
+}
+
+// sameWorkers reports whether two worker lists hold the same IDs in the same order.
+func sameWorkers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
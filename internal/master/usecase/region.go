@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// regionalRateDistributions lists the rate distributions of multi-region
+// tests, which split each region's rate among that region's workers.
+var regionalRateDistributions = []string{"shared", "ramped", "burst"}
+
+// RegionSharesFromPB maps the regions of a submission payload onto domain
+// region shares.
+func RegionSharesFromPB(regions []*pb.RegionShare) []domain.RegionShare {
+	var shares []domain.RegionShare
+	for _, region := range regions {
+		shares = append(shares, domain.RegionShare{
+			Region:        region.Region,
+			RatePerSecond: region.RatePerSecond,
+			WorkerCount:   region.WorkerCount,
+		})
+	}
+	return shares
+}
+
+// validateRegions checks the regions of a multi-region test, defaulting
+// their worker counts to 1 and the test's rate and worker count to their sums.
+func validateRegions(testReq *domain.TestRequest) []domain.ValidationError {
+	if len(testReq.Regions) == 0 {
+		return nil
+	}
+	var errs []domain.ValidationError
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, domain.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var rate uint64
+	var workers uint32
+	seen := make(map[string]bool, len(testReq.Regions))
+	for i := range testReq.Regions {
+		share := &testReq.Regions[i]
+		share.Region = strings.TrimSpace(share.Region)
+		if share.Region == "" {
+			add("regions", "regions[%d]: region is required", i)
+		} else if seen[share.Region] {
+			add("regions", "regions[%d]: region %q is listed twice", i, share.Region)
+		}
+		seen[share.Region] = true
+		if share.RatePerSecond == 0 {
+			add("regions", "regions[%d]: rate_per_second must be greater than 0", i)
+		}
+		if share.WorkerCount == 0 {
+			share.WorkerCount = 1
+		}
+		rate += share.RatePerSecond
+		workers += share.WorkerCount
+	}
+
+	if testReq.RatePerSecond == 0 {
+		testReq.RatePerSecond = rate
+	} else if testReq.RatePerSecond != rate {
+		add("rate_per_second", "rate_per_second (%d) must match the sum of the regions' rates (%d)", testReq.RatePerSecond, rate)
+	}
+	if testReq.WorkerCount == 0 {
+		testReq.WorkerCount = workers
+	} else if testReq.WorkerCount != workers {
+		add("worker_count", "worker_count (%d) must match the sum of the regions' worker counts (%d)", testReq.WorkerCount, workers)
+	}
+	if testReq.RateDistribution != "" && !slices.Contains(regionalRateDistributions, testReq.RateDistribution) {
+		add("rate_distribution", "multi-region tests split each region's rate among its workers: rate_distribution must be one of %v", regionalRateDistributions)
+	}
+	return errs
+}
+
+// regionNeeds returns how many workers a multi-region test needs from each
+// region, or nil if the test can run on workers from anywhere.
+func regionNeeds(testReq *domain.TestRequest) map[string]uint32 {
+	if len(testReq.Regions) == 0 {
+		return nil
+	}
+	needs := make(map[string]uint32, len(testReq.Regions))
+	for _, share := range testReq.Regions {
+		needs[share.Region] = share.WorkerCount
+	}
+	return needs
+}
+
+// workerRegion returns the region workerID registered with.
+func (uc *MasterUsecase) workerRegion(ctx context.Context, workerID string) string {
+	worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+	if err != nil {
+		return ""
+	}
+	return worker.Region
+}
+
+// workerRates returns the rate of each of the workers a test was assigned.
+// Multi-region tests split each region's rate among that region's workers,
+// applying the partial assignment policy within each region; their workers'
+// regions are returned too.
+func (uc *MasterUsecase) workerRates(ctx context.Context, testReq *domain.TestRequest, workerIDs []string) ([]uint64, []string) {
+	if len(testReq.Regions) == 0 {
+		return assignmentRates(testReq, len(workerIDs)), nil
+	}
+
+	regions := make([]string, len(workerIDs))
+	for i, workerID := range workerIDs {
+		regions[i] = uc.workerRegion(ctx, workerID)
+	}
+	rates := make([]uint64, len(workerIDs))
+	for _, share := range testReq.Regions {
+		var workers []int
+		for i, region := range regions {
+			if region == share.Region {
+				workers = append(workers, i)
+			}
+		}
+		if len(workers) == 0 {
+			log.Printf("Warning: Test %s has no worker in region %s; its %d req/s are dropped", testReq.ID, share.Region, share.RatePerSecond)
+			continue
+		}
+		regional := *testReq
+		regional.RatePerSecond, regional.WorkerCount, regional.Regions = share.RatePerSecond, share.WorkerCount, nil
+		for j, rate := range assignmentRates(&regional, len(workers)) {
+			rates[workers[j]] = rate
+		}
+	}
+	return rates, regions
+}
+
+// resultRegion returns the region of the worker that sent result, as recorded
+// in its test's distribution plan; empty unless the test is multi-region.
+func (uc *MasterUsecase) resultRegion(ctx context.Context, result *domain.TestResult) string {
+	test, err := uc.testRepo.GetTestRequestByID(ctx, result.TestID)
+	if err != nil || len(test.Regions) == 0 {
+		return ""
+	}
+	for _, share := range test.DistributionPlan {
+		if share.WorkerID == result.WorkerID {
+			return share.Region
+		}
+	}
+	return uc.workerRegion(ctx, result.WorkerID)
+}
+
+// regionBreakdowns aggregates a test's results region by region, in the
+// order of the test's regions. Results without a region are left out.
+func regionBreakdowns(test *domain.TestRequest, results []*domain.TestResult) []domain.RegionBreakdown {
+	if len(test.Regions) == 0 {
+		return nil
+	}
+	byRegion := make(map[string][]*domain.TestResult)
+	for _, result := range results {
+		if result.Region != "" {
+			byRegion[result.Region] = append(byRegion[result.Region], result)
+		}
+	}
+
+	var breakdowns []domain.RegionBreakdown
+	for _, share := range test.Regions {
+		regional, ok := byRegion[share.Region]
+		if !ok {
+			continue
+		}
+		aggregated := aggregateResults(test.ID, regional)
+		breakdowns = append(breakdowns, domain.RegionBreakdown{
+			Region:         share.Region,
+			Workers:        len(regional),
+			TotalRequests:  aggregated.TotalRequests,
+			FailedRequests: aggregated.FailedRequests,
+			AvgLatencyMs:   aggregated.AvgLatencyMs,
+			P95LatencyMs:   aggregated.P95LatencyMs,
+			ThroughputRPS:  aggregated.ThroughputRPS,
+		})
+	}
+	return breakdowns
+}
+
+// GetRegionAnalytics compares the latency of each worker region across the
+// multi-region tests in the requested time range (the last 30 days by
+// default), busiest region first.
+func (uc *MasterUsecase) GetRegionAnalytics(ctx context.Context, req *domain.AnalyticsRequest) ([]domain.RegionAnalytics, error) {
+	var startDate, endDate time.Time
+	if req.TimeRange != nil {
+		startDate = req.TimeRange.StartDate
+		endDate = req.TimeRange.EndDate
+	} else {
+		endDate = time.Now()
+		startDate = endDate.AddDate(0, 0, -30)
+	}
+
+	var tests []*domain.TestRequest
+	var err error
+	if req.UserID != "" {
+		tests, err = uc.testRepo.GetTestsInRangeByUser(ctx, req.UserID, startDate, endDate)
+	} else {
+		tests, err = uc.testRepo.GetTestsInRange(ctx, startDate, endDate)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tests in range: %w", err)
+	}
+	tests = testsOfBuild(tests, req.TargetBuild)
+
+	type regionTotals struct {
+		tests, requests, successful int64
+		weightedLatencyMs, p95      float64
+	}
+	totals := make(map[string]*regionTotals)
+	for _, test := range tests {
+		if len(test.Regions) == 0 {
+			continue
+		}
+		results, err := uc.testResultRepo.GetResultsByTestID(ctx, test.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to get results of test %s for region analytics: %v", test.ID, err)
+			continue
+		}
+		for _, breakdown := range regionBreakdowns(test, results) {
+			t, ok := totals[breakdown.Region]
+			if !ok {
+				t = &regionTotals{}
+				totals[breakdown.Region] = t
+			}
+			t.tests++
+			t.requests += breakdown.TotalRequests
+			t.successful += breakdown.TotalRequests - breakdown.FailedRequests
+			t.weightedLatencyMs += breakdown.AvgLatencyMs * float64(breakdown.TotalRequests)
+			t.p95 = max(t.p95, breakdown.P95LatencyMs)
+		}
+	}
+
+	analytics := make([]domain.RegionAnalytics, 0, len(totals))
+	for region, t := range totals {
+		a := domain.RegionAnalytics{Region: region, TestCount: t.tests, TotalRequests: t.requests, P95ResponseTime: t.p95}
+		if t.requests > 0 {
+			a.SuccessRate = float64(t.successful) / float64(t.requests) * 100
+			a.AverageResponseTime = t.weightedLatencyMs / float64(t.requests)
+		}
+		analytics = append(analytics, a)
+	}
+	sort.Slice(analytics, func(i, j int) bool {
+		return analytics[i].TotalRequests > analytics[j].TotalRequests
+	})
+	return analytics, nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Heatmap time buckets are at least minHeatmapInterval wide, so a long test
+// cannot produce an unbounded number of cells.
+const (
+	defaultHeatmapInterval = time.Second
+	minHeatmapInterval     = 100 * time.Millisecond
+)
+
+// SetRequestRecordStore enables queries over the per-request records workers
+// write to the store.
+func (uc *MasterUsecase) SetRequestRecordStore(store domain.RequestRecordStore) {
+	uc.requestRecords = store
+}
+
+// GetLatencyHeatmap returns request counts per time and latency bucket for a
+// test. A zero interval uses one-second time buckets.
+func (uc *MasterUsecase) GetLatencyHeatmap(ctx context.Context, testID string, interval time.Duration) ([]domain.HeatmapCell, error) {
+	if err := uc.checkRequestRecords(ctx, testID); err != nil {
+		return nil, err
+	}
+	if interval == 0 {
+		interval = defaultHeatmapInterval
+	}
+	if interval < minHeatmapInterval {
+		return nil, fmt.Errorf("invalid interval %v: must be at least %v", interval, minHeatmapInterval)
+	}
+	return uc.requestRecords.LatencyHeatmap(ctx, testID, interval)
+}
+
+// GetLatencyPercentiles returns exact latency percentiles over every request
+// of a test, or of one of its targets.
+func (uc *MasterUsecase) GetLatencyPercentiles(ctx context.Context, testID, target string) (*domain.LatencyPercentiles, error) {
+	if err := uc.checkRequestRecords(ctx, testID); err != nil {
+		return nil, err
+	}
+	return uc.requestRecords.LatencyPercentiles(ctx, testID, target)
+}
+
+// checkRequestRecords fails unless request records are enabled and the test exists.
+func (uc *MasterUsecase) checkRequestRecords(ctx context.Context, testID string) error {
+	if uc.requestRecords == nil {
+		return fmt.Errorf("request records are not enabled: start the master with --clickhouse-url")
+	}
+	_, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	return err
+}
+
+// latencyDistribution returns latency percentiles over the requests of the
+// given tests, restricted to target when set. They are exact when request
+// records hold the tests. Otherwise they are estimated from the per-second
+// metrics, weighting each second's mean latency by its requests; averaging
+// hides single slow requests, so the estimated tail percentiles run low, and
+// the estimate covers every target of the tests.
+func (uc *MasterUsecase) latencyDistribution(ctx context.Context, testIDs []string, target string) *domain.LatencyDistribution {
+	if uc.requestRecords != nil && len(testIDs) > 0 {
+		url, _, _ := strings.Cut(target, "#") // Records hold the URL, not the GraphQL operation
+		distribution, err := uc.requestRecords.LatencyDistribution(ctx, testIDs, url)
+		if err != nil {
+			log.Printf("Error querying latency distribution, estimating from metrics instead: %v", err)
+		} else if distribution.Requests > 0 {
+			return distribution
+		}
+	}
+
+	var samples []latencySample
+	for _, testID := range testIDs {
+		points, err := uc.metricsRepo.GetTimeSeries(ctx, testID, time.Second)
+		if err != nil {
+			log.Printf("Error loading metrics of test %s for latency percentiles: %v", testID, err)
+			continue
+		}
+		for _, p := range points {
+			if p.Requests > 0 {
+				samples = append(samples, latencySample{latencyMs: p.MeanLatencyMs, requests: p.Requests})
+			}
+		}
+	}
+	return weightedLatencyDistribution(samples)
+}
+
+// latencySample is one second's mean latency and the requests it covers.
+type latencySample struct {
+	latencyMs float64
+	requests  int64
+}
+
+// weightedLatencyDistribution computes percentiles over samples, counting each
+// sample once per request.
+func weightedLatencyDistribution(samples []latencySample) *domain.LatencyDistribution {
+	distribution := &domain.LatencyDistribution{Source: domain.LatencySourceTimeSeries}
+	var total int64
+	for _, s := range samples {
+		total += s.requests
+	}
+	if total == 0 {
+		return distribution
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].latencyMs < samples[j].latencyMs })
+
+	percentile := func(q float64) float64 {
+		rank := q * float64(total)
+		var seen int64
+		for _, s := range samples {
+			seen += s.requests
+			if float64(seen) >= rank {
+				return s.latencyMs
+			}
+		}
+		return samples[len(samples)-1].latencyMs
+	}
+	distribution.Requests = uint64(total)
+	distribution.P50Ms = percentile(0.5)
+	distribution.P75Ms = percentile(0.75)
+	distribution.P90Ms = percentile(0.9)
+	distribution.P95Ms = percentile(0.95)
+	distribution.P99Ms = percentile(0.99)
+	distribution.P999Ms = percentile(0.999)
+	return distribution
+}
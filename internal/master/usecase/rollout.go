@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// defaultRolloutBatchTimeout is how long a batch of workers may take to
+// drain and re-register when the rollout does not say.
+const defaultRolloutBatchTimeout = 10 * time.Minute
+
+// rolloutPollInterval is how often a rollout checks on the workers of its
+// current batch.
+const rolloutPollInterval = 2 * time.Second
+
+// workerRollout holds the rolling upgrade in progress, or the last one, and
+// the workers it keeps from taking new tests. Rollouts are not persisted: a
+// restarted master forgets them and its workers take tests again.
+type workerRollout struct {
+	mu       sync.Mutex
+	current  *domain.Rollout
+	cancel   context.CancelFunc // Stops the running rollout
+	draining map[string]bool    // workerID -> takes no new tests
+}
+
+// StartRollout starts upgrading the workers that do not run the target
+// version, a batch at a time, in the background. Each batch is drained:
+// its workers finish the tests they run but take no new ones. The next
+// batch starts once every worker of the batch re-registered with the target
+// version; the rollout fails if they do not within the batch timeout.
+func (uc *MasterUsecase) StartRollout(ctx context.Context, options domain.RolloutOptions, user *domain.UserProfile) (*domain.Rollout, error) {
+	if options.TargetVersion == "" {
+		return nil, fmt.Errorf("target version is required")
+	}
+	if options.BatchSize < 0 || options.BatchTimeout < 0 {
+		return nil, fmt.Errorf("batch size and batch timeout must not be negative")
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = 1
+	}
+	if options.BatchTimeout == 0 {
+		options.BatchTimeout = defaultRolloutBatchTimeout
+	}
+
+	workers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workers: %w", err)
+	}
+	var outdated []domain.RolloutWorker
+	for _, worker := range workers {
+		if worker.Status == "OFFLINE" || worker.Version == options.TargetVersion {
+			continue
+		}
+		outdated = append(outdated, domain.RolloutWorker{
+			WorkerID:    worker.ID,
+			Phase:       domain.RolloutWorkerPending,
+			FromVersion: worker.Version,
+			Version:     worker.Version,
+		})
+	}
+	if len(outdated) == 0 {
+		return nil, fmt.Errorf("no online worker needs upgrading to version %s", options.TargetVersion)
+	}
+	slices.SortFunc(outdated, func(a, b domain.RolloutWorker) int { return strings.Compare(a.WorkerID, b.WorkerID) })
+	for i := range outdated {
+		outdated[i].Batch = i/options.BatchSize + 1
+	}
+
+	rollout := &domain.Rollout{
+		State:          domain.RolloutRunning,
+		TargetVersion:  options.TargetVersion,
+		BatchSize:      options.BatchSize,
+		BatchTimeout:   options.BatchTimeout.String(),
+		RestartWorkers: options.RestartWorkers,
+		Batches:        outdated[len(outdated)-1].Batch,
+		StartedBy:      user.Username,
+		StartedAt:      time.Now(),
+		Workers:        outdated,
+	}
+
+	uc.rollout.mu.Lock()
+	if uc.rollout.current != nil && uc.rollout.current.State == domain.RolloutRunning {
+		uc.rollout.mu.Unlock()
+		return nil, domain.ErrRolloutInProgress
+	}
+	rolloutCtx, cancel := context.WithCancel(context.Background())
+	uc.rollout.current, uc.rollout.cancel = rollout, cancel
+	uc.rollout.mu.Unlock()
+
+	log.Printf("Rollout to worker version %s started by %s: %d workers in %d batches of %d",
+		options.TargetVersion, user.Username, len(outdated), rollout.Batches, options.BatchSize)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditRolloutStart, options.TargetVersion, map[string]interface{}{
+		"batchSize": options.BatchSize, "batchTimeout": rollout.BatchTimeout, "restartWorkers": options.RestartWorkers,
+	}))
+	go uc.runRollout(rolloutCtx, rollout.Batches, options.BatchTimeout)
+	return uc.GetRollout(), nil
+}
+
+// GetRollout returns a copy of the rollout in progress, or of the last one,
+// or nil if there has been none since the master started.
+func (uc *MasterUsecase) GetRollout() *domain.Rollout {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	if uc.rollout.current == nil {
+		return nil
+	}
+	rollout := *uc.rollout.current
+	rollout.Workers = slices.Clone(rollout.Workers)
+	return &rollout
+}
+
+// CancelRollout stops the rollout in progress. Workers of the current batch
+// take tests again, whatever version they run.
+func (uc *MasterUsecase) CancelRollout(ctx context.Context, user *domain.UserProfile) error {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	if uc.rollout.current == nil || uc.rollout.current.State != domain.RolloutRunning {
+		return fmt.Errorf("no rollout in progress")
+	}
+	uc.rollout.current.Message = "Cancelled by " + user.Username
+	uc.rollout.cancel()
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditRolloutCancel, uc.rollout.current.TargetVersion, nil))
+	return nil
+}
+
+// isDraining reports whether a rollout keeps a worker from taking new tests.
+func (uc *MasterUsecase) isDraining(workerID string) bool {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	return uc.rollout.draining[workerID]
+}
+
+// WorkerShouldRestart reports whether a worker was drained by a rollout that
+// restarts workers, and is idle, so it should exit and be restarted by its
+// supervisor on the target version.
+func (uc *MasterUsecase) WorkerShouldRestart(workerID string) bool {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	rollout := uc.rollout.current
+	if rollout == nil || rollout.State != domain.RolloutRunning || !rollout.RestartWorkers {
+		return false
+	}
+	for _, worker := range rollout.Workers {
+		if worker.WorkerID == workerID {
+			return worker.Phase == domain.RolloutWorkerUpgrading && worker.Version != rollout.TargetVersion
+		}
+	}
+	return false
+}
+
+// noteRolloutRegistration records the version a worker registered with, so
+// a worker that came back upgraded is not asked to restart again before the
+// rollout next checks on it.
+func (uc *MasterUsecase) noteRolloutRegistration(workerID, version string) {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	if uc.rollout.current == nil || uc.rollout.current.State != domain.RolloutRunning {
+		return
+	}
+	for i := range uc.rollout.current.Workers {
+		if worker := &uc.rollout.current.Workers[i]; worker.WorkerID == workerID {
+			worker.Version = version
+		}
+	}
+}
+
+// runRollout upgrades the batches of the current rollout one after another.
+func (uc *MasterUsecase) runRollout(ctx context.Context, batches int, timeout time.Duration) {
+	for batch := 1; batch <= batches; batch++ {
+		workerIDs := uc.startRolloutBatch(batch)
+		log.Printf("Rollout batch %d/%d: draining %v", batch, batches, workerIDs)
+		if err := uc.upgradeRolloutBatch(ctx, workerIDs, timeout); err != nil {
+			uc.finishRollout(err)
+			return
+		}
+		log.Printf("Rollout batch %d/%d upgraded", batch, batches)
+	}
+	uc.finishRollout(nil)
+}
+
+// startRolloutBatch drains the workers of a batch and returns their IDs.
+func (uc *MasterUsecase) startRolloutBatch(batch int) []string {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	if uc.rollout.draining == nil {
+		uc.rollout.draining = make(map[string]bool)
+	}
+	rollout := uc.rollout.current
+	rollout.Batch = batch
+	var workerIDs []string
+	for i := range rollout.Workers {
+		if worker := &rollout.Workers[i]; worker.Batch == batch {
+			worker.Phase = domain.RolloutWorkerDraining
+			uc.rollout.draining[worker.WorkerID] = true
+			workerIDs = append(workerIDs, worker.WorkerID)
+		}
+	}
+	return workerIDs
+}
+
+// upgradeRolloutBatch waits until every worker of a batch re-registered with
+// the target version, the timeout passes or the rollout is cancelled.
+func (uc *MasterUsecase) upgradeRolloutBatch(ctx context.Context, workerIDs []string, timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+	for {
+		if uc.advanceRolloutWorkers(ctx, workerIDs) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("workers did not re-register with the target version within %v", timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// advanceRolloutWorkers moves the workers of a batch to their next phase: a
+// draining worker that is idle or offline is ready to be upgraded, and a
+// worker that registered with the target version takes tests again. It
+// returns true once every worker of the batch is upgraded.
+func (uc *MasterUsecase) advanceRolloutWorkers(ctx context.Context, workerIDs []string) bool {
+	workers := make(map[string]*domain.Worker, len(workerIDs))
+	for _, workerID := range workerIDs {
+		worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+		if err != nil || worker == nil {
+			log.Printf("Warning: Failed to look up worker %s for the rollout: %v", workerID, err)
+			continue
+		}
+		workers[workerID] = worker
+	}
+
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	rollout := uc.rollout.current
+	upgraded := true
+	for i := range rollout.Workers {
+		entry := &rollout.Workers[i]
+		if entry.Batch != rollout.Batch {
+			continue
+		}
+		worker, ok := workers[entry.WorkerID]
+		if !ok {
+			upgraded = upgraded && entry.Phase == domain.RolloutWorkerUpgraded
+			continue
+		}
+		entry.Version = worker.Version
+		switch {
+		case entry.Phase == domain.RolloutWorkerUpgraded:
+		case worker.Version == rollout.TargetVersion && worker.Status != "OFFLINE":
+			// Its next READY heartbeat puts it back in the availability queue
+			entry.Phase = domain.RolloutWorkerUpgraded
+			delete(uc.rollout.draining, entry.WorkerID)
+			log.Printf("Rollout: worker %s re-registered with version %s", entry.WorkerID, worker.Version)
+		case entry.Phase == domain.RolloutWorkerDraining && (worker.Status == "OFFLINE" || worker.Status == "READY" && worker.CurrentTestID == ""):
+			entry.Phase = domain.RolloutWorkerUpgrading
+			log.Printf("Rollout: worker %s drained and ready to be upgraded", entry.WorkerID)
+		}
+		upgraded = upgraded && entry.Phase == domain.RolloutWorkerUpgraded
+	}
+	return upgraded
+}
+
+// finishRollout records the outcome of the current rollout and lets the
+// workers it drained take tests again.
+func (uc *MasterUsecase) finishRollout(err error) {
+	uc.rollout.mu.Lock()
+	defer uc.rollout.mu.Unlock()
+	rollout := uc.rollout.current
+	now := time.Now()
+	rollout.FinishedAt = &now
+	switch {
+	case err == nil:
+		rollout.State = domain.RolloutCompleted
+	case errors.Is(err, context.Canceled):
+		rollout.State = domain.RolloutCancelled
+	default:
+		rollout.State = domain.RolloutFailed
+		rollout.Message = err.Error()
+		for i := range rollout.Workers {
+			if worker := &rollout.Workers[i]; worker.Batch == rollout.Batch && worker.Phase != domain.RolloutWorkerUpgraded {
+				worker.Phase = domain.RolloutWorkerFailed
+			}
+		}
+	}
+	uc.rollout.draining = nil
+	uc.rollout.cancel()
+	log.Printf("Rollout to worker version %s %s. %s", rollout.TargetVersion, rollout.State, rollout.Message)
+}
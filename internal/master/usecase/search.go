@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SearchTests finds tests whose name or target URLs contain query. Tests of
+// every user are searched when userID is empty.
+func (uc *MasterUsecase) SearchTests(ctx context.Context, query, userID string, limit int) ([]domain.SearchResult, error) {
+	tests, err := uc.testRepo.SearchTests(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tests: %w", err)
+	}
+
+	lowerQuery := strings.ToLower(query)
+	results := make([]domain.SearchResult, 0, len(tests))
+	for _, test := range tests {
+		result := domain.SearchResult{
+			Type:      domain.SearchResultTest,
+			ID:        test.ID,
+			Title:     test.Name,
+			Subtitle:  test.Status,
+			Matched:   "name",
+			CreatedAt: test.CreatedAt,
+		}
+		if !strings.Contains(strings.ToLower(test.Name), lowerQuery) {
+			result.Matched = "target"
+			for _, target := range test.TargetURLs {
+				if strings.Contains(strings.ToLower(target), lowerQuery) {
+					result.Subtitle = test.Status + " · " + target
+					break
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// testTargetURLs lists the endpoints a test hits: its scenario steps, or the
+// targets in its targets file.
+func (uc *MasterUsecase) testTargetURLs(test *domain.TestRequest) []string {
+	if test.ScenarioJSON == "" {
+		return uc.extractTargetsFromBase64(test.TargetsBase64)
+	}
+	scenario, err := domain.ParseScenario(test.ScenarioJSON)
+	if err != nil {
+		return nil
+	}
+	targets := make([]string, 0, len(scenario.Steps))
+	for _, step := range scenario.Steps {
+		targets = append(targets, step.Method+" "+step.URL)
+	}
+	return targets
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+func TestSequenceRangesAreContiguousAndDisjoint(t *testing.T) {
+	rates := []uint64{10, 25, 1, 7}
+	ranges := sequenceRanges(1000, "30s", rates, 0)
+	if len(ranges) != len(rates) {
+		t.Fatalf("got %d ranges, want %d", len(ranges), len(rates))
+	}
+
+	next := uint64(1000)
+	for i, r := range ranges {
+		if r[0] != next {
+			t.Errorf("range %d starts at %d, want %d (ranges must neither overlap nor leave gaps)", i, r[0], next)
+		}
+		// Each worker may send rate*seconds requests plus one at the boundary
+		if want := rates[i]*30 + 1; r[1]-r[0] != want {
+			t.Errorf("range %d holds %d values, want %d", i, r[1]-r[0], want)
+		}
+		next = r[1]
+	}
+}
+
+func TestSequenceRangesRoundPartialSecondsUp(t *testing.T) {
+	ranges := sequenceRanges(0, "1500ms", []uint64{10, 10}, 0)
+	want := [][2]uint64{{0, 21}, {21, 42}}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Errorf("range %d = %v, want %v", i, ranges[i], want[i])
+		}
+	}
+}
+
+func TestSequenceRangesSingleWorkerStartsAtSequenceStart(t *testing.T) {
+	ranges := sequenceRanges(42, "1m", []uint64{5}, 0)
+	if want := [2]uint64{42, 42 + 5*60 + 1}; ranges[0] != want {
+		t.Errorf("range = %v, want %v", ranges[0], want)
+	}
+}
+
+func TestSequenceRangesInvalidDuration(t *testing.T) {
+	// An unparsable duration still yields disjoint ranges of one value each
+	ranges := sequenceRanges(7, "soon", []uint64{100, 100}, 0)
+	want := [][2]uint64{{7, 8}, {8, 9}}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Errorf("range %d = %v, want %v", i, ranges[i], want[i])
+		}
+	}
+}
+
+func TestSequenceRangesReserveProbeValues(t *testing.T) {
+	// Pre-flight probes take up to 3 values before each worker's attack
+	ranges := sequenceRanges(0, "2s", []uint64{10, 10}, 3)
+	want := [][2]uint64{{0, 24}, {24, 48}}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Errorf("range %d = %v, want %v", i, ranges[i], want[i])
+		}
+	}
+}
+
+func TestSpareSequenceStartSkipsValuesTheLostWorkerUsed(t *testing.T) {
+	lost := domain.WorkerRate{RatePerSecond: 10, SequenceStart: 100, SequenceEnd: 401}
+	cases := []struct {
+		elapsed time.Duration
+		want    uint64
+	}{
+		{0, 100},
+		{time.Second, 110},
+		{2700 * time.Millisecond, 130}, // Up to 27 values may be used by 2.7s
+		{time.Hour, 401},               // Never past the lost worker's range
+	}
+	for _, c := range cases {
+		if got := spareSequenceStart(lost, c.elapsed, 0); got != c.want {
+			t.Errorf("spareSequenceStart(%v) = %d, want %d", c.elapsed, got, c.want)
+		}
+	}
+	// Values the lost worker's pre-flight probes took are skipped too
+	if got := spareSequenceStart(lost, time.Second, 2); got != 112 {
+		t.Errorf("spareSequenceStart with reserve = %d, want 112", got)
+	}
+}
+
+func TestRedistributeRejectedWithTemplateTargets(t *testing.T) {
+	uc := &MasterUsecase{}
+	testReq := &domain.TestRequest{
+		Name:            "templated",
+		DurationSeconds: "30s",
+		RatePerSecond:   10,
+		WorkerCount:     2,
+		TargetsBase64:   "R0VUIGh0dHA6Ly9sb2NhbGhvc3QvdXNlcnMve3tzZXF9fQ==", // GET http://localhost/users/{{seq}}
+		TemplateTargets: true,
+		OnWorkerFailure: domain.WorkerFailureRedistribute,
+	}
+	errs := uc.ValidateTestRequest(context.Background(), testReq)
+	if !slices.ContainsFunc(errs, func(e domain.ValidationError) bool { return e.Field == "on_worker_failure" }) {
+		t.Errorf("redistribute with template_targets was accepted: %v", errs)
+	}
+
+	testReq.OnWorkerFailure = domain.WorkerFailureContinue
+	for _, e := range uc.ValidateTestRequest(context.Background(), testReq) {
+		if e.Field == "on_worker_failure" {
+			t.Errorf("continue with template_targets was rejected: %s", e.Message)
+		}
+	}
+}
+
+func TestSpareSkipsPreflightWithTemplateTargets(t *testing.T) {
+	// The spare's range only holds the lost worker's remaining requests
+	share := domain.WorkerRate{RatePerSecond: 10, SequenceStart: 112, SequenceEnd: 303}
+	testReq := &domain.TestRequest{Preflight: true, TemplateTargets: true}
+	if spareAssignment(testReq, share, 19*time.Second).Preflight {
+		t.Error("spare of a templated test runs a pre-flight check")
+	}
+
+	testReq.TemplateTargets = false
+	if !spareAssignment(testReq, share, 19*time.Second).Preflight {
+		t.Error("spare of a plain test skips its pre-flight check")
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// spareCutoff is the share of a test's duration after which a lost worker is
+// no longer replaced by a spare: late in the test, a spare would only add a
+// short burst at a different point of the run.
+const spareCutoff = 0.5
+
+// spareSequenceStart returns the first {{seq}} value of a spare taking over
+// from lost elapsed into the test, past every value lost may already have
+// used: the reserve its pre-flight probes took, and its rate over the elapsed
+// time rounded up to whole seconds.
+func spareSequenceStart(lost domain.WorkerRate, elapsed time.Duration, reserve uint64) uint64 {
+	seconds := uint64((elapsed + time.Second - 1) / time.Second)
+	return min(lost.SequenceStart+reserve+lost.RatePerSecond*seconds, lost.SequenceEnd)
+}
+
+// assignSpare hands the share of lost, a worker that failed to take a test or
+// went offline elapsed into it, to a READY worker from the availability
+// queue. The spare runs at the lost worker's rate for the rest of the test,
+// and comes from the same region in multi-region tests. Workers in plan are
+// never taken as spares. It returns the spare's share,
+// to be added to the test's distribution plan.
+func (uc *MasterUsecase) assignSpare(ctx context.Context, testReq *domain.TestRequest, plan []domain.WorkerRate, lost domain.WorkerRate, elapsed time.Duration) (domain.WorkerRate, bool) {
+	duration, err := time.ParseDuration(testReq.DurationSeconds)
+	if err != nil || lost.RatePerSecond == 0 || elapsed > time.Duration(float64(duration)*spareCutoff) {
+		return domain.WorkerRate{}, false
+	}
+	remaining := (duration - elapsed).Truncate(time.Second)
+
+	share := domain.WorkerRate{RatePerSecond: lost.RatePerSecond, SpareOf: lost.WorkerID, Region: lost.Region,
+		SequenceStart: lost.SequenceStart, SequenceEnd: lost.SequenceEnd}
+	if testReq.TemplateTargets {
+		share.SequenceStart = spareSequenceStart(lost, elapsed, sequenceReserve(testReq))
+	}
+
+	exclude := make([]string, 0, len(plan))
+	for _, r := range plan {
+		exclude = append(exclude, r.WorkerID)
+	}
+	for {
+		workerID, ok := uc.takeSpareWorker(testReq, lost.Region, exclude)
+		if !ok {
+			log.Printf("No spare worker to take over the %d req/s of worker %s in test %s", lost.RatePerSecond, lost.WorkerID, testReq.ID)
+			return domain.WorkerRate{}, false
+		}
+		exclude = append(exclude, workerID)
+
+		client, ok := uc.workerClient(workerID)
+		if !ok {
+			continue
+		}
+		uc.workerRepo.UpdateWorkerStatus(ctx, workerID, "BUSY", testReq.ID,
+			fmt.Sprintf("Running test as a spare for %s (rate: %d req/s)", lost.WorkerID, lost.RatePerSecond), 0, 0)
+		resp, err := client.AssignTest(ctx, spareAssignment(testReq, share, remaining))
+		if err != nil {
+			log.Printf("Failed to assign test %s to spare worker %s: %v", testReq.ID, workerID, err)
+			uc.MarkWorkerOffline(ctx, workerID)
+			continue
+		}
+		if !resp.Accepted {
+			log.Printf("Spare worker %s rejected test %s assignment: %s", workerID, testReq.ID, resp.Message)
+			uc.workerRepo.UpdateWorkerStatus(ctx, workerID, "READY", "", "Assignment rejected", 0, 0)
+			uc.addWorkerToAvailabilityQueue(workerID)
+			continue
+		}
+
+		log.Printf("Spare worker %s took over the %d req/s of worker %s in test %s for the remaining %v",
+			workerID, lost.RatePerSecond, lost.WorkerID, testReq.ID, remaining)
+		uc.testRepo.IncrementTestAssignedWorkers(ctx, testReq.ID, workerID)
+		share.WorkerID, share.Accepted = workerID, true
+		return share, true
+	}
+}
+
+// spareAssignment is the assignment of a spare taking over share for the
+// remaining time. With template targets, the spare runs no pre-flight check:
+// its probes would take {{seq}} values from the range it continues, which
+// only holds enough for the lost worker's remaining requests.
+func spareAssignment(testReq *domain.TestRequest, share domain.WorkerRate, remaining time.Duration) *pb.TestAssignment {
+	assignment := workerAssignment(testReq, share, remaining.String())
+	if testReq.TemplateTargets {
+		assignment.Preflight = false
+	}
+	return assignment
+}
+
+// takeSpareWorker takes the first worker in the availability queue that can
+// run a test, is in region unless it is empty, and is not in exclude, without
+// waiting for one to turn up. Workers passed over are left available for
+// other tests.
+func (uc *MasterUsecase) takeSpareWorker(testReq *domain.TestRequest, region string, exclude []string) (string, bool) {
+	var passed []string
+	defer func() {
+		for _, workerID := range passed {
+			uc.addWorkerToAvailabilityQueue(workerID)
+		}
+	}()
+
+	payloadBytes := assignmentPayloadBytes(testReq)
+	for {
+		select {
+		case workerID := <-uc.workerAvailability:
+			uc.removeWorkerFromAvailabilityQueue(workerID) // Remove from tracking
+			if slices.Contains(passed, workerID) {
+				continue // Queued twice
+			}
+			if _, connected := uc.activeWorkerClients.Load(workerID); !connected || uc.isDraining(workerID) {
+				continue
+			}
+			if slices.Contains(exclude, workerID) || (region != "" && uc.workerRegion(context.Background(), workerID) != region) {
+				passed = append(passed, workerID)
+				continue
+			}
+			if missing := uc.missingCapabilities(context.Background(), workerID, testReq, payloadBytes); len(missing) > 0 {
+				log.Printf("Worker %s cannot be a spare for test %s: lacks %s", workerID, testReq.ID, strings.Join(missing, ", "))
+				passed = append(passed, workerID)
+				continue
+			}
+			return workerID, true
+		default:
+			return "", false
+		}
+	}
+}
+
+// replaceWithSpare hands the share of workerID, which went offline while
+// running test, to a spare worker. It returns a summary of the change.
+func (uc *MasterUsecase) replaceWithSpare(ctx context.Context, test *domain.TestRequest, workerID string) (string, bool) {
+	plan := test.DistributionPlan
+	lost := slices.IndexFunc(plan, func(r domain.WorkerRate) bool { return r.WorkerID == workerID })
+	if lost < 0 {
+		return "", false
+	}
+
+	spare, ok := uc.assignSpare(ctx, test, plan, plan[lost], uc.testElapsed(test.ID))
+	if !ok {
+		return "", false
+	}
+	if err := uc.testRepo.SaveDistributionPlan(ctx, test.ID, append(plan, spare)); err != nil {
+		log.Printf("Warning: Failed to save distribution plan for test %s: %v", test.ID, err)
+	}
+	return fmt.Sprintf("Worker %s went offline; spare worker %s took over its %d req/s", workerID, spare.WorkerID, spare.RatePerSecond), true
+}
+
+// testElapsed is how long workers have been reporting progress on a test;
+// zero before the first report.
+func (uc *MasterUsecase) testElapsed(testID string) time.Duration {
+	val, ok := uc.liveProgress.Load(testID)
+	if !ok {
+		return 0
+	}
+	lt := val.(*liveTest)
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	return time.Since(lt.startedAt)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultStartDelay is how long after its workers are assigned a test starts
+// on all of them at once, leaving time for every assignment to arrive.
+const DefaultStartDelay = 2 * time.Second
+
+// maxStartDelay matches the longest a worker waits for a synchronized start.
+const maxStartDelay = time.Minute
+
+// workerClock is a worker's measurement of the master's clock minus its own,
+// with the round trip of the status exchange it was measured by.
+type workerClock struct {
+	offset time.Duration
+	rtt    time.Duration
+}
+
+// SetStartDelay changes how long after its workers are assigned a test
+// starts. Zero has each worker start as soon as it accepts its assignment.
+func (uc *MasterUsecase) SetStartDelay(delay time.Duration) error {
+	if delay < 0 || delay > maxStartDelay {
+		return fmt.Errorf("invalid start delay %v: must be between 0 and %v", delay, maxStartDelay)
+	}
+	uc.startDelay = delay
+	return nil
+}
+
+// testStartAt returns when a test assigned at now starts on its workers, on
+// the master's clock, or zero if workers start as soon as they accept.
+func (uc *MasterUsecase) testStartAt(now time.Time) time.Time {
+	if uc.startDelay == 0 {
+		return time.Time{}
+	}
+	return now.Add(uc.startDelay)
+}
+
+// RecordClockOffset stores the offset of the master's clock from a worker's,
+// as the worker measured it on its status stream.
+func (uc *MasterUsecase) RecordClockOffset(workerID string, offset, rtt time.Duration) {
+	uc.workerClocks.Store(workerID, workerClock{offset: offset, rtt: rtt})
+}
+
+// workerClockOffset describes a worker's clock offset for admins, as the
+// offset and its error bound; empty until the worker has measured it.
+func (uc *MasterUsecase) workerClockOffset(workerID string) string {
+	val, ok := uc.workerClocks.Load(workerID)
+	if !ok {
+		return ""
+	}
+	clock := val.(workerClock)
+	return fmt.Sprintf("%v ±%v", clock.offset, clock.rtt/2)
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// maxTeamNameLength matches the teams.name column.
+const maxTeamNameLength = 255
+
+// SetTeamRepository enables teams, which admins manage and users share tests with.
+func (uc *MasterUsecase) SetTeamRepository(repo domain.TeamRepository) {
+	uc.teamRepo = repo
+}
+
+// GetTeams lists every team, by name.
+func (uc *MasterUsecase) GetTeams(ctx context.Context) ([]*domain.Team, error) {
+	if uc.teamRepo == nil {
+		return nil, fmt.Errorf("teams are not enabled")
+	}
+	return uc.teamRepo.GetAllTeams(ctx)
+}
+
+// GetTeam returns a team with its members.
+func (uc *MasterUsecase) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	if uc.teamRepo == nil {
+		return nil, fmt.Errorf("teams are not enabled")
+	}
+	return uc.teamRepo.GetTeamByID(ctx, teamID)
+}
+
+// CreateTeam creates a team. Team names are unique, ignoring case.
+func (uc *MasterUsecase) CreateTeam(ctx context.Context, req domain.TeamRequest, createdBy string) (*domain.Team, error) {
+	if uc.teamRepo == nil {
+		return nil, fmt.Errorf("teams are not enabled")
+	}
+	name, members, err := uc.validateTeamRequest(ctx, req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	team := &domain.Team{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: req.Description,
+		MemberIDs:   members,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.teamRepo.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTeamCreate, team.ID, req))
+	return team, nil
+}
+
+// UpdateTeam replaces a team's name, description and members.
+func (uc *MasterUsecase) UpdateTeam(ctx context.Context, teamID string, req domain.TeamRequest) (*domain.Team, error) {
+	if uc.teamRepo == nil {
+		return nil, fmt.Errorf("teams are not enabled")
+	}
+	team, err := uc.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	name, members, err := uc.validateTeamRequest(ctx, req, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Name = name
+	team.Description = req.Description
+	team.MemberIDs = members
+	team.UpdatedAt = time.Now()
+	if err := uc.teamRepo.UpdateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTeamUpdate, teamID, req))
+	return team, nil
+}
+
+// DeleteTeam removes a team. Tests already shared with it stay in its
+// former members' inboxes.
+func (uc *MasterUsecase) DeleteTeam(ctx context.Context, teamID string) error {
+	if uc.teamRepo == nil {
+		return fmt.Errorf("teams are not enabled")
+	}
+	if err := uc.teamRepo.DeleteTeam(ctx, teamID); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTeamDelete, teamID, nil))
+	return nil
+}
+
+// validateTeamRequest checks a team's name against the other teams and
+// returns it trimmed, along with the members without duplicates.
+func (uc *MasterUsecase) validateTeamRequest(ctx context.Context, req domain.TeamRequest, teamID string) (string, []string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", nil, fmt.Errorf("invalid team: name is required")
+	}
+	if len(name) > maxTeamNameLength {
+		return "", nil, fmt.Errorf("invalid team: name is longer than %d characters", maxTeamNameLength)
+	}
+
+	teams, err := uc.teamRepo.GetAllTeams(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, other := range teams {
+		if other.ID != teamID && strings.EqualFold(other.Name, name) {
+			return "", nil, fmt.Errorf("team name already exists")
+		}
+	}
+
+	members := []string{}
+	for _, id := range req.MemberIDs {
+		if id != "" && !slices.Contains(members, id) {
+			members = append(members, id)
+		}
+	}
+	return name, members, nil
+}
+
+// ShareTestToTeam shares a test and puts the link in the inbox of every
+// current member of a team. Users who join the team later do not receive it.
+func (uc *MasterUsecase) ShareTestToTeam(ctx context.Context, testID, sharedBy, teamID string, ttl time.Duration) (*domain.SharedLink, error) {
+	if uc.teamRepo == nil {
+		return nil, fmt.Errorf("teams are not enabled")
+	}
+	team, err := uc.teamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := shareLinkExpiry(ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := uc.sharedLinkRepo.CreateSharedLink(ctx, testID, sharedBy, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.sharedLinkRepo.SetSharedLinkTeam(ctx, link.ID, team.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.snapshotSharedResult(ctx, link); err != nil {
+		return nil, err
+	}
+	link.TeamID = team.ID
+	for _, userID := range team.MemberIDs {
+		if err := uc.sharedLinkRepo.AddUsedBy(ctx, link.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+	link.UsedBy = team.MemberIDs
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditShareCreate, testID,
+		map[string]string{"linkId": link.ID, "sharedWithTeam": team.ID}))
+	uc.publishInboxUpdate(link, team.MemberIDs)
+	return link, nil
+}
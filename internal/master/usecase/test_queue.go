@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// DefaultQueueWindow is how many queued tests are held in memory for
+// scheduling unless configured otherwise. Tests submitted while it is full
+// wait in the database and enter it as it frees up.
+const DefaultQueueWindow = 100
+
+// priorityAgingInterval is how long a test waits before it is scheduled as if
+// it had the next higher priority, so a steady stream of high-priority tests
+// cannot starve low-priority ones forever.
+const priorityAgingInterval = 10 * time.Minute
+
+// testQueue holds the window of tests waiting for workers. Tests leave it
+// highest priority first and, within a priority, in the order they were
+// submitted. Queued tests beyond the window stay PENDING in the database
+// until it has room.
+type testQueue struct {
+	mu        sync.Mutex
+	tests     []*domain.TestRequest
+	assigning *domain.TestRequest // Last test popped, until its workers are assigned
+	window    int                 // Most newly queued tests held; re-queued tests may go past it
+	overflow  bool                // Queued tests may be waiting in the database
+	spills    uint64              // Times a test was left in the database, to tell refills apart
+	ready     chan struct{}       // Holds a value while the queue has tests or overflow
+}
+
+func newTestQueue() *testQueue {
+	// Tests queued before the master restarted wait in the database and are
+	// resumed on purpose: a restart should not drop the queue. Those queued
+	// too long ago are cancelled first by resumeQueuedTests.
+	q := &testQueue{window: DefaultQueueWindow, overflow: true, ready: make(chan struct{}, 1)}
+	q.signal()
+	return q
+}
+
+// setWindow changes how many newly queued tests the queue holds.
+func (q *testQueue) setWindow(window int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.window = window
+	q.overflow = true // A larger window has room for tests in the database
+	q.signal()
+}
+
+// admit adds a newly queued test if the window has room and no older tests
+// wait in the database, returning false if the test is left to wait there.
+func (q *testQueue) admit(test *domain.TestRequest) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.overflow || len(q.tests) >= q.window {
+		q.overflow = true
+		q.spills++
+		q.signal()
+		return false
+	}
+	q.tests = append(q.tests, test)
+	q.signal()
+	return true
+}
+
+// push puts a test that was already queued back in the queue. It never
+// fails: the test was counted against the window when it was first queued.
+func (q *testQueue) push(test *domain.TestRequest) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tests = append(q.tests, test)
+	q.signal()
+}
+
+// room returns how many tests waiting in the database the window can take,
+// and the spill count to pass to drained.
+func (q *testQueue) room() (int, uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.overflow {
+		return 0, q.spills
+	}
+	return max(q.window-len(q.tests), 0), q.spills
+}
+
+// size returns how many tests the queue holds, counting the one gathering
+// workers.
+func (q *testQueue) size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.assigning != nil {
+		return len(q.tests) + 1
+	}
+	return len(q.tests)
+}
+
+// fill adds tests loaded from the database that the queue does not hold yet,
+// while the window has room, and returns how many it added.
+func (q *testQueue) fill(tests []*domain.TestRequest) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	added := 0
+	for _, test := range tests {
+		if len(q.tests) >= q.window {
+			break
+		}
+		if q.holds(test.ID) {
+			continue
+		}
+		q.tests = append(q.tests, test)
+		added++
+	}
+	if added > 0 {
+		q.signal()
+	}
+	return added
+}
+
+// drained records that no queued tests are left in the database, unless
+// another was left there since room returned spills.
+func (q *testQueue) drained(spills uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.spills == spills {
+		q.overflow = false
+	}
+}
+
+// contains reports whether the queue holds the test with the given ID,
+// including as the test gathering workers.
+func (q *testQueue) contains(testID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.holds(testID)
+}
+
+// holds is contains for callers holding q.mu.
+func (q *testQueue) holds(testID string) bool {
+	if q.assigning != nil && q.assigning.ID == testID {
+		return true
+	}
+	for _, test := range q.tests {
+		if test.ID == testID {
+			return true
+		}
+	}
+	return false
+}
+
+// pop removes and returns the test to assign next among those mayStart
+// allows, or nil if there is none.
+func (q *testQueue) pop(now time.Time, mayStart func(*domain.TestRequest) bool) *domain.TestRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	next := -1
+	for i, test := range q.tests {
+		if (next < 0 || scheduledBefore(test, q.tests[next], now)) && mayStart(test) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return nil
+	}
+	test := q.tests[next]
+	q.tests = append(q.tests[:next], q.tests[next+1:]...)
+	q.assigning = test
+	if len(q.tests) > 0 || q.overflow {
+		q.signal()
+	}
+	return test
+}
+
+// assigned records that the test returned by pop has left the queue for good,
+// whether it was assigned workers or pushed back.
+func (q *testQueue) assigned() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.assigning = nil
+}
+
+// snapshot returns copies of the test gathering workers, if any, and of the
+// queued tests in the order they will be assigned.
+func (q *testQueue) snapshot(now time.Time) (*domain.TestRequest, []domain.TestRequest) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	sort.SliceStable(q.tests, func(i, j int) bool { return scheduledBefore(q.tests[i], q.tests[j], now) })
+	tests := make([]domain.TestRequest, len(q.tests))
+	for i, test := range q.tests {
+		tests[i] = *test
+	}
+	var assigning *domain.TestRequest
+	if q.assigning != nil {
+		copied := *q.assigning
+		assigning = &copied
+	}
+	return assigning, tests
+}
+
+// find returns a copy of the queued test with the given ID.
+func (q *testQueue) find(testID string) (domain.TestRequest, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, test := range q.tests {
+		if test.ID == testID {
+			return *test, true
+		}
+	}
+	return domain.TestRequest{}, false
+}
+
+// isAssigning reports whether the test gathering workers has the given ID.
+func (q *testQueue) isAssigning(testID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.assigning != nil && q.assigning.ID == testID
+}
+
+// remove takes a test out of the queue, returning false if it is not queued.
+func (q *testQueue) remove(testID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, test := range q.tests {
+		if test.ID == testID {
+			q.tests = append(q.tests[:i], q.tests[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// setPriority changes the priority of a queued test, returning false if it is
+// not queued.
+func (q *testQueue) setPriority(testID, priority string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, test := range q.tests {
+		if test.ID == testID {
+			test.Priority = priority
+			return true
+		}
+	}
+	return false
+}
+
+// wake makes the distribution routine look at the queue again, such as after
+// a guardrail holding tests back was lifted.
+func (q *testQueue) wake() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.tests) > 0 || q.overflow {
+		q.signal()
+	}
+}
+
+// signal wakes the distribution routine. The caller must hold q.mu.
+func (q *testQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// scheduledBefore reports whether a should get workers before b.
+func scheduledBefore(a, b *domain.TestRequest, now time.Time) bool {
+	rankA, rankB := effectivePriorityRank(a, now), effectivePriorityRank(b, now)
+	if rankA != rankB {
+		return rankA < rankB
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// effectivePriorityRank is a queued test's priority rank (0 = high) after it
+// gains one level for every priorityAgingInterval since it was submitted.
+func effectivePriorityRank(test *domain.TestRequest, now time.Time) int {
+	rank := domain.PriorityRank(test.Priority) - int(now.Sub(test.CreatedAt)/priorityAgingInterval)
+	return max(rank, 0)
+}
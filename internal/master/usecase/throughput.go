@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// throughputResolutions are the bucket widths GetTestThroughput accepts.
+var throughputResolutions = map[string]time.Duration{
+	"1s":  time.Second,
+	"10s": 10 * time.Second,
+	"1m":  time.Minute,
+}
+
+// GetTestThroughput returns the rate a test sent and completed requests at
+// over time, with the rate it asked for, in buckets of resolution: "1s",
+// "10s" (the default) or "1m".
+//
+// Only completions are stored, so sends are reconstructed by moving each
+// bucket's completions back by their mean latency, assuming they were spread
+// evenly over it.
+func (uc *MasterUsecase) GetTestThroughput(ctx context.Context, testID, resolution string) (*domain.TestThroughput, error) {
+	if resolution == "" {
+		resolution = "10s"
+	}
+	interval, ok := throughputResolutions[resolution]
+	if !ok {
+		return nil, fmt.Errorf("invalid resolution %q: must be 1s, 10s or 1m", resolution)
+	}
+	test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := uc.metricsRepo.GetTimeSeries(ctx, testID, interval)
+	if err != nil {
+		return nil, err
+	}
+	throughput := &domain.TestThroughput{
+		TestID:       testID,
+		IntervalMs:   interval.Milliseconds(),
+		RequestedRPS: requestedRate(test),
+		Points:       []domain.ThroughputPoint{},
+	}
+	if len(series) == 0 {
+		return throughput, nil
+	}
+
+	// Sends can start up to a bucket's latency before its first completion
+	start := series[0].Time
+	for _, point := range series {
+		if sentFrom := point.Time.Add(-latencyOf(point)).Truncate(interval); sentFrom.Before(start) {
+			start = sentFrom
+		}
+	}
+	end := series[len(series)-1].Time
+	buckets := int(end.Sub(start)/interval) + 1
+	sent := make([]float64, buckets)
+	completed := make([]int64, buckets)
+	for _, point := range series {
+		completed[int(point.Time.Sub(start)/interval)] = point.Requests
+		spreadSends(sent, start, interval, point.Time.Add(-latencyOf(point)), float64(point.Requests))
+	}
+
+	for i := range sent {
+		if len(throughput.Points) == 0 && completed[i] == 0 && math.Round(sent[i]*10) == 0 {
+			continue // Only a sliver of a send moved back into it
+		}
+		point := domain.ThroughputPoint{
+			Time:          start.Add(time.Duration(i) * interval),
+			Sent:          math.Round(sent[i]*10) / 10,
+			Completed:     completed[i],
+			SendRPS:       math.Round(sent[i]/interval.Seconds()*100) / 100,
+			CompletionRPS: math.Round(float64(completed[i])/interval.Seconds()*100) / 100,
+		}
+		if throughput.RequestedRPS > 0 {
+			point.Achieved = math.Round(sent[i]/interval.Seconds()/throughput.RequestedRPS*1000) / 1000
+		}
+		throughput.Points = append(throughput.Points, point)
+	}
+	return throughput, nil
+}
+
+// requestedRate is the rate a test asked for: that of the workers which
+// accepted it, which partial assignment may have scaled, else the submitted one.
+func requestedRate(test *domain.TestRequest) float64 {
+	var rate float64
+	for _, worker := range test.DistributionPlan {
+		if worker.Accepted {
+			rate += float64(worker.RatePerSecond)
+		}
+	}
+	if rate == 0 {
+		return float64(test.RatePerSecond)
+	}
+	return rate
+}
+
+func latencyOf(point domain.TimeSeriesPoint) time.Duration {
+	return time.Duration(point.MeanLatencyMs * float64(time.Millisecond))
+}
+
+// spreadSends adds requests sent evenly over the bucket-wide span from
+// sentFrom to the buckets of sent it overlaps. Buckets start at start.
+func spreadSends(sent []float64, start time.Time, interval time.Duration, sentFrom time.Time, requests float64) {
+	from := sentFrom.Sub(start)
+	to := from + interval
+	for i := int(from / interval); i < len(sent) && time.Duration(i)*interval < to; i++ {
+		lo := max(from, time.Duration(i)*interval)
+		hi := min(to, time.Duration(i+1)*interval)
+		if hi > lo {
+			sent[i] += requests * float64(hi-lo) / float64(interval)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// timelineWriteTimeout bounds saving one timeline entry.
+const timelineWriteTimeout = 5 * time.Second
+
+// SetTimelineRepository records the lifecycle of every test in repo: the
+// test events published on the event bus, except metrics, and the steps of
+// queueing, assigning and aggregating it.
+func (uc *MasterUsecase) SetTimelineRepository(repo domain.TimelineRepository) {
+	uc.timelineRepo = repo
+	uc.events.Subscribe("timeline", func(event domain.Event) {
+		if event.TestID == "" || event.Type == domain.EventTestMetrics {
+			return
+		}
+		uc.saveTimelineEntry(&domain.TestTimelineEntry{
+			TestID:   event.TestID,
+			Type:     event.Type,
+			WorkerID: event.WorkerID,
+			Status:   event.Status,
+			Message:  event.Message,
+			Time:     event.Time,
+		})
+	})
+}
+
+// recordTimeline adds a step to a test's timeline when timelines are enabled.
+func (uc *MasterUsecase) recordTimeline(testID, entryType, workerID, message string) {
+	if uc.timelineRepo == nil {
+		return
+	}
+	uc.saveTimelineEntry(&domain.TestTimelineEntry{
+		TestID:   testID,
+		Type:     entryType,
+		WorkerID: workerID,
+		Message:  message,
+		Time:     time.Now(),
+	})
+}
+
+// saveTimelineEntry saves an entry. Failures are logged rather than failing
+// the step that was already taken.
+func (uc *MasterUsecase) saveTimelineEntry(entry *domain.TestTimelineEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), timelineWriteTimeout)
+	defer cancel()
+	if err := uc.timelineRepo.SaveTimelineEntry(ctx, entry); err != nil {
+		log.Printf("Warning: Failed to record %s in the timeline of test %s: %v", entry.Type, entry.TestID, err)
+	}
+}
+
+// GetTestTimeline returns every recorded step of a test, oldest first.
+func (uc *MasterUsecase) GetTestTimeline(ctx context.Context, testID string) ([]*domain.TestTimelineEntry, error) {
+	if uc.timelineRepo == nil {
+		return nil, fmt.Errorf("test timelines are not enabled")
+	}
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+	return uc.timelineRepo.GetTestTimeline(ctx, testID)
+}
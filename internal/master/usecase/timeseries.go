@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// maxPendingMetrics caps the samples held while the database is unreachable;
+// the oldest are dropped beyond it.
+const maxPendingMetrics = 100000
+
+// Time series buckets are at least minTimeSeriesInterval wide, the rate at
+// which workers report progress.
+const (
+	defaultTimeSeriesInterval = 10 * time.Second
+	minTimeSeriesInterval     = time.Second
+)
+
+// bufferMetricSample queues a progress sample for the metrics flush job.
+func (uc *MasterUsecase) bufferMetricSample(sample domain.MetricSample) {
+	if sample.Requests <= 0 {
+		return
+	}
+	uc.metricsMu.Lock()
+	defer uc.metricsMu.Unlock()
+	if len(uc.pendingMetrics) >= maxPendingMetrics {
+		uc.pendingMetrics = uc.pendingMetrics[1:]
+	}
+	uc.pendingMetrics = append(uc.pendingMetrics, sample)
+}
+
+// StartMetricsFlushJob periodically writes buffered progress samples to the
+// metrics repository.
+func (uc *MasterUsecase) StartMetricsFlushJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Starting metrics flush job with interval: %v", interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.flushMetrics(context.Background())
+			log.Println("Metrics flush job stopped due to context cancellation")
+			return
+		case <-ticker.C:
+			uc.flushMetrics(ctx)
+		}
+	}
+}
+
+// flushMetrics writes the buffered samples, putting them back if that fails.
+func (uc *MasterUsecase) flushMetrics(ctx context.Context) {
+	uc.metricsMu.Lock()
+	samples := uc.pendingMetrics
+	uc.pendingMetrics = nil
+	uc.metricsMu.Unlock()
+	if len(samples) == 0 {
+		return
+	}
+
+	if err := uc.metricsRepo.SaveMetricSamples(ctx, samples); err != nil {
+		log.Printf("Error saving %d metric samples, will retry: %v", len(samples), err)
+		uc.metricsMu.Lock()
+		uc.pendingMetrics = append(samples, uc.pendingMetrics...)
+		if excess := len(uc.pendingMetrics) - maxPendingMetrics; excess > 0 {
+			uc.pendingMetrics = uc.pendingMetrics[excess:]
+		}
+		uc.metricsMu.Unlock()
+	}
+}
+
+// GetTestTimeSeries returns a test's throughput, error rate and latency over
+// time. A zero interval uses ten-second buckets.
+func (uc *MasterUsecase) GetTestTimeSeries(ctx context.Context, testID string, interval time.Duration) (*domain.TestTimeSeries, error) {
+	interval, err := timeSeriesInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+
+	points, err := uc.metricsRepo.GetTimeSeries(ctx, testID, interval)
+	if err != nil {
+		return nil, err
+	}
+	if points == nil {
+		points = []domain.TimeSeriesPoint{}
+	}
+	return &domain.TestTimeSeries{TestID: testID, IntervalMs: interval.Milliseconds(), Points: points}, nil
+}
+
+// GetTestStatusTimeline returns how many responses of each status code a test
+// received over time, and when the first 5xx arrived. A zero interval uses
+// ten-second buckets.
+func (uc *MasterUsecase) GetTestStatusTimeline(ctx context.Context, testID string, interval time.Duration) (*domain.TestStatusTimeline, error) {
+	interval, err := timeSeriesInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.testRepo.GetTestRequestByID(ctx, testID); err != nil {
+		return nil, err
+	}
+
+	points, err := uc.metricsRepo.GetStatusTimeline(ctx, testID, interval)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &domain.TestStatusTimeline{
+		TestID:     testID,
+		IntervalMs: interval.Milliseconds(),
+		Points:     points,
+		Totals:     make(map[string]int64),
+	}
+	if timeline.Points == nil {
+		timeline.Points = []domain.StatusCodePoint{}
+	}
+	for i, point := range timeline.Points {
+		for code, count := range point.Codes {
+			timeline.Totals[code] += count
+			if timeline.FirstServerErrorAt == nil && strings.HasPrefix(code, "5") && len(code) == 3 {
+				timeline.FirstServerErrorAt = &timeline.Points[i].Time
+			}
+		}
+	}
+	return timeline, nil
+}
+
+// timeSeriesInterval applies the default bucket width to a zero interval and
+// rejects widths that are not a whole number of seconds.
+func timeSeriesInterval(interval time.Duration) (time.Duration, error) {
+	if interval == 0 {
+		interval = defaultTimeSeriesInterval
+	}
+	if interval < minTimeSeriesInterval || interval%time.Second != 0 {
+		return 0, fmt.Errorf("invalid interval %v: must be a whole number of seconds", interval)
+	}
+	return interval, nil
+}
+
+// testRunLookback is how long before a time range a test may have been
+// submitted and still have been running inside it. testQueueAllowance is
+// added to a test's duration when judging whether it ran in a range, since it
+// may have waited for workers before starting.
+const (
+	testRunLookback    = 24 * time.Hour
+	testQueueAllowance = 10 * time.Minute
+)
+
+// GetTestsRunBetween returns the tests that may have been running between
+// from and to, judged by their submission time and duration. Only userID's
+// tests are included unless userID is empty.
+func (uc *MasterUsecase) GetTestsRunBetween(ctx context.Context, userID string, from, to time.Time) ([]*domain.TestRequest, error) {
+	var tests []*domain.TestRequest
+	var err error
+	if userID != "" {
+		tests, err = uc.testRepo.GetTestsInRangeByUser(ctx, userID, from.Add(-testRunLookback), to)
+	} else {
+		tests, err = uc.testRepo.GetTestsInRange(ctx, from.Add(-testRunLookback), to)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tests in range: %w", err)
+	}
+
+	running := make([]*domain.TestRequest, 0, len(tests))
+	for _, test := range tests {
+		duration, _ := time.ParseDuration(test.DurationSeconds)
+		if test.CreatedAt.After(to) || test.CreatedAt.Add(duration+testQueueAllowance).Before(from) {
+			continue
+		}
+		running = append(running, test)
+	}
+	return running, nil
+}
+
+// GetCombinedTimeSeries sums the time series of every test that ran between
+// from and to into one, keeping only the buckets inside that range. Only
+// userID's tests are included unless userID is empty.
+func (uc *MasterUsecase) GetCombinedTimeSeries(ctx context.Context, userID string, from, to time.Time, interval time.Duration) ([]domain.TimeSeriesPoint, error) {
+	interval, err := timeSeriesInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	tests, err := uc.GetTestsRunBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	combined := make(map[time.Time]*domain.TimeSeriesPoint)
+	latencyTotals := make(map[time.Time]float64)
+	for _, test := range tests {
+		points, err := uc.metricsRepo.GetTimeSeries(ctx, test.ID, interval)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range points {
+			if point.Time.Before(from) || point.Time.After(to) {
+				continue
+			}
+			sum, ok := combined[point.Time]
+			if !ok {
+				sum = &domain.TimeSeriesPoint{Time: point.Time}
+				combined[point.Time] = sum
+			}
+			sum.Requests += point.Requests
+			sum.Failures += point.Failures
+			latencyTotals[point.Time] += point.MeanLatencyMs * float64(point.Requests)
+		}
+	}
+
+	series := make([]domain.TimeSeriesPoint, 0, len(combined))
+	for t, point := range combined {
+		point.RPS = float64(point.Requests) / interval.Seconds()
+		if point.Requests > 0 {
+			point.ErrorRate = float64(point.Failures) / float64(point.Requests)
+			point.MeanLatencyMs = latencyTotals[t] / float64(point.Requests)
+		}
+		series = append(series, *point)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+	return series, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// TransferTests makes req.ToUserID the owner of the listed tests, or of every
+// test of req.FromUserID, so they stay reachable after their owner leaves.
+// Tests still queued or running keep their owner until they finish and are
+// reported as skipped, as are unknown tests. The caller checks that the new
+// owner exists.
+func (uc *MasterUsecase) TransferTests(ctx context.Context, req domain.TestTransferRequest) (*domain.TestTransferResult, error) {
+	if req.ToUserID == "" {
+		return nil, errors.New("toUserId is required")
+	}
+	if (req.FromUserID == "") == (len(req.TestIDs) == 0) {
+		return nil, errors.New("give either fromUserId or testIds")
+	}
+
+	var tests []*domain.TestRequest
+	skipped := map[string]string{}
+	if req.FromUserID != "" {
+		var err error
+		if tests, err = uc.testRepo.GetTestRequestsByUser(ctx, req.FromUserID); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, testID := range req.TestIDs {
+			test, err := uc.testRepo.GetTestRequestByID(ctx, testID)
+			if err != nil || test == nil {
+				skipped[testID] = "test not found"
+				continue
+			}
+			tests = append(tests, test)
+		}
+	}
+
+	result := &domain.TestTransferResult{Transferred: []string{}, Skipped: skipped}
+	for _, test := range tests {
+		switch {
+		case test.RequesterID == req.ToUserID:
+			skipped[test.ID] = "already owned by the new owner"
+		case !domain.IsFinalTestStatus(test.Status):
+			skipped[test.ID] = "test is " + test.Status + "; transfer it once it has finished"
+		default:
+			if err := uc.testRepo.SetTestRequester(ctx, test.ID, req.ToUserID); err != nil {
+				return result, err
+			}
+			uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTestTransfer, test.ID, map[string]string{"from": test.RequesterID, "to": req.ToUserID}))
+			result.Transferred = append(result.Transferred, test.ID)
+		}
+	}
+	return result, nil
+}
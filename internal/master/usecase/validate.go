@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// maxTargetErrors caps how many invalid targets are reported individually.
+const maxTargetErrors = 10
+
+// ValidateTestRequest checks a test definition the same way SubmitTest does,
+// without saving or queueing anything, and returns every problem found.
+// Omitted defaults are filled in, from the requester's preferences where
+// they have any, and GraphQL operations are expanded into
+// TargetsBase64, as they would be on submission.
+func (uc *MasterUsecase) ValidateTestRequest(ctx context.Context, testReq *domain.TestRequest) []domain.ValidationError {
+	var errs []domain.ValidationError
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, domain.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Omitted settings come from the requester's preferences first
+	uc.applyPreferences(ctx, testReq)
+
+	// A smoke run replaces the rate, duration and workers of the test
+	testReq.ApplySmoke()
+
+	// A multi-region test's rate and worker count default to its regions' sums
+	errs = append(errs, validateRegions(testReq)...)
+
+	// Set default worker count and rate distribution if not specified
+	if testReq.WorkerCount == 0 {
+		testReq.WorkerCount = 1
+	}
+	if testReq.RateDistribution == "" {
+		testReq.RateDistribution = "shared"
+	}
+	if testReq.Priority == "" {
+		testReq.Priority = domain.PriorityNormal
+	}
+	if testReq.PartialPolicy == "" {
+		testReq.PartialPolicy = domain.PartialBestEffort
+	}
+	if testReq.OnWorkerFailure == "" {
+		testReq.OnWorkerFailure = domain.WorkerFailureContinue
+	}
+	testReq.Protocol = strings.ToLower(strings.TrimSpace(testReq.Protocol))
+	testReq.TargetBuild = strings.TrimSpace(testReq.TargetBuild)
+	for i, tag := range testReq.Tags {
+		testReq.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+
+	if duration, err := time.ParseDuration(testReq.DurationSeconds); err != nil {
+		add("duration_seconds", "invalid duration_seconds %q: must be a duration such as \"30s\" or \"5m\"", testReq.DurationSeconds)
+	} else if duration <= 0 {
+		add("duration_seconds", "duration_seconds must be greater than 0")
+	}
+	if testReq.RatePerSecond == 0 {
+		add("rate_per_second", "rate_per_second must be greater than 0")
+	}
+	if testReq.InterimInterval != "" {
+		if interval, err := time.ParseDuration(testReq.InterimInterval); err != nil {
+			add("interim_interval", "invalid interim_interval %q: must be a duration such as \"5m\"", testReq.InterimInterval)
+		} else if interval < domain.MinInterimInterval {
+			add("interim_interval", "interim_interval must be at least %v", domain.MinInterimInterval)
+		}
+	}
+	if testReq.MaxWaitForWorkers != "" {
+		if wait, err := time.ParseDuration(testReq.MaxWaitForWorkers); err != nil {
+			add("max_wait_for_workers", "invalid max_wait_for_workers %q: must be a duration such as \"10m\"", testReq.MaxWaitForWorkers)
+		} else if wait <= 0 || wait > domain.MaxWaitForWorkersLimit {
+			add("max_wait_for_workers", "max_wait_for_workers must be greater than 0 and at most %v", domain.MaxWaitForWorkersLimit)
+		}
+	}
+	if len(testReq.TargetBuild) > domain.MaxTargetBuildLength {
+		add("target_build", "target_build must be at most %d characters", domain.MaxTargetBuildLength)
+	}
+	if err := domain.ValidateTags(testReq.Tags); err != nil {
+		add("tags", "%v", err)
+	}
+	if err := domain.ValidateLabelHeaders(testReq.LabelHeaders); err != nil {
+		add("label_headers", "%v", err)
+	}
+	if testReq.Apdex != nil {
+		if err := testReq.Apdex.Validate(); err != nil {
+			add("apdex", "%v", err)
+		}
+	}
+
+	// Validate rate distribution mode and weights
+	isValid := false
+	for _, mode := range domain.RateDistributions {
+		if testReq.RateDistribution == mode {
+			isValid = true
+			break
+		}
+	}
+	if !isValid {
+		add("rate_distribution", "invalid rate_distribution: must be one of %v", domain.RateDistributions)
+	}
+	if testReq.RateDistribution == "weighted" {
+		if len(testReq.RateWeights) == 0 {
+			add("rate_weights", "rate_weights must be provided for weighted distribution")
+		} else if len(testReq.RateWeights) != int(testReq.WorkerCount) {
+			add("rate_weights", "rate_weights length (%d) must match worker_count (%d)", len(testReq.RateWeights), testReq.WorkerCount)
+		}
+		for i, weight := range testReq.RateWeights {
+			if weight <= 0 {
+				add("rate_weights", "rate_weights[%d] must be positive, got %f", i, weight)
+			}
+		}
+	}
+
+	if !slices.Contains(domain.Priorities, testReq.Priority) {
+		add("priority", "invalid priority %q: must be one of %v", testReq.Priority, domain.Priorities)
+	}
+	if !slices.Contains(domain.PartialPolicies, testReq.PartialPolicy) {
+		add("partial_policy", "invalid partial_policy %q: must be one of %v", testReq.PartialPolicy, domain.PartialPolicies)
+	}
+	if !slices.Contains(domain.WorkerFailurePolicies, testReq.OnWorkerFailure) {
+		add("on_worker_failure", "invalid on_worker_failure %q: must be one of %v", testReq.OnWorkerFailure, domain.WorkerFailurePolicies)
+	}
+	// Each worker's {{seq}} range is sized to its planned rate, so a raised rate would use it up early
+	if testReq.OnWorkerFailure == domain.WorkerFailureRedistribute && testReq.TemplateTargets {
+		add("on_worker_failure", "on_worker_failure %q cannot be combined with template_targets", domain.WorkerFailureRedistribute)
+	}
+	// Other executors are registered by workers, so any well-formed name is accepted here
+	// and the test waits for a worker that has it
+	httpExecutor := domain.ExecutorName(testReq.Protocol) == domain.ExecutorVegetaHTTP
+	if testReq.Protocol != "" {
+		if err := domain.ValidateExecutorName(testReq.Protocol); err != nil {
+			add("protocol", "%v", err)
+		}
+	}
+	if !httpExecutor && testReq.ScenarioJSON != "" {
+		add("protocol", "scenario_json is only supported by the %s executor", domain.ExecutorVegetaHTTP)
+	}
+	if !httpExecutor && testReq.Preflight {
+		add("protocol", "preflight is only supported by the %s executor", domain.ExecutorVegetaHTTP)
+	}
+	if !httpExecutor && testReq.Smoke {
+		add("protocol", "smoke is only supported by the %s executor", domain.ExecutorVegetaHTTP)
+	}
+	scriptExecutor := testReq.Protocol == domain.ExecutorLua
+	if testReq.Script != "" && !scriptExecutor {
+		add("script", "script requires protocol %q", domain.ExecutorLua)
+	}
+	if scriptExecutor && testReq.ThinkTime != "" {
+		add("think_time", "think_time is not supported by the %s executor: call sleep() in the script instead", domain.ExecutorLua)
+	}
+
+	// Validate think time and pacing jitter
+	if _, _, err := domain.ParseThinkTime(testReq.ThinkTime); err != nil {
+		add("think_time", "%v", err)
+	}
+	if testReq.PacingJitter < 0 || testReq.PacingJitter > 1 {
+		add("pacing_jitter", "pacing_jitter must be between 0 and 1, got %f", testReq.PacingJitter)
+	}
+
+	// Expand GraphQL operations into JSON POST targets
+	if testReq.GraphQLJSON != "" {
+		if testReq.TargetsBase64 != "" || testReq.ScenarioJSON != "" {
+			add("graphql_json", "graphql_json cannot be combined with targets_base64 or scenario_json")
+		} else if graphQL, err := domain.ParseGraphQLTarget(testReq.GraphQLJSON); err != nil {
+			add("graphql_json", "%v", err)
+		} else if testReq.TargetsBase64, err = graphQL.TargetsBase64(); err != nil {
+			add("graphql_json", "%v", err)
+		}
+	}
+
+	// Validate the targets, or the scenario or script that replaces them
+	if scriptExecutor {
+		if testReq.TargetsBase64 != "" {
+			add("targets_base64", "targets_base64 cannot be combined with a script")
+		}
+		if err := domain.ValidateScript(testReq.Script); err != nil {
+			add("script", "%v", err)
+		}
+	} else if testReq.ScenarioJSON != "" {
+		if _, err := domain.ParseScenario(testReq.ScenarioJSON); err != nil {
+			add("scenario_json", "%v", err)
+		}
+	} else if testReq.TargetsBase64 == "" {
+		if testReq.GraphQLJSON == "" {
+			add("targets_base64", "targets_base64 is required unless scenario_json or graphql_json is set")
+		}
+	} else {
+		errs = append(errs, validateTargets(testReq.TargetsBase64, httpExecutor)...)
+		if err := uc.validateAttachmentRefs(ctx, testReq.TargetsBase64); err != nil {
+			add("targets_base64", "%v", err)
+		}
+	}
+
+	// Validate vegeta attacker options
+	attackOptions, err := domain.ParseAttackOptions(testReq.VegetaPayloadJSON)
+	if err != nil {
+		add("vegeta_payload_json", "%v", err)
+	} else if attackOptions.Cookies && testReq.ScenarioJSON == "" {
+		// Vegeta's attacker shares one client across requests, so sessions only exist within scenarios
+		add("vegeta_payload_json", "cookies require a scenario: plain attacks are stateless")
+	}
+	// Scenario steps depend on values extracted from earlier steps, so they can't be probed on their own
+	if testReq.Preflight && testReq.ScenarioJSON != "" {
+		add("preflight", "preflight is not supported for scenario tests")
+	}
+	return errs
+}
+
+// validateTargets decodes a targets file and checks that every target has an
+// absolute URL, with an http or https scheme if httpOnly is set.
+func validateTargets(targetsBase64 string, httpOnly bool) []domain.ValidationError {
+	targets, err := decodeTargets(targetsBase64)
+	if err != nil {
+		return []domain.ValidationError{{Field: "targets_base64", Message: err.Error()}}
+	}
+
+	var errs []domain.ValidationError
+	invalid := 0
+	for i, target := range targets {
+		u, err := url.Parse(target.URL)
+		var problem string
+		switch {
+		case err != nil:
+			problem = err.Error()
+		case httpOnly && u.Scheme != "http" && u.Scheme != "https":
+			problem = "scheme must be http or https"
+		case u.Scheme == "":
+			problem = "missing scheme"
+		case u.Host == "":
+			problem = "missing host"
+		default:
+			continue
+		}
+		invalid++
+		if invalid <= maxTargetErrors {
+			errs = append(errs, domain.ValidationError{
+				Field:   "targets_base64",
+				Message: fmt.Sprintf("target %d: invalid URL %q: %s", i+1, target.URL, problem),
+			})
+		}
+	}
+	if invalid > maxTargetErrors {
+		errs = append(errs, domain.ValidationError{
+			Field:   "targets_base64",
+			Message: fmt.Sprintf("%d more targets have invalid URLs", invalid-maxTargetErrors),
+		})
+	}
+	return errs
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// ListWorkers returns every registered worker, including offline ones, with
+// the master's connection to it, ordered by ID.
+func (uc *MasterUsecase) ListWorkers(ctx context.Context) ([]domain.WorkerDetail, error) {
+	workers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workers: %w", err)
+	}
+	now := time.Now()
+	details := make([]domain.WorkerDetail, 0, len(workers))
+	for _, worker := range workers {
+		details = append(details, domain.WorkerDetail{
+			Worker:            *worker,
+			ConnectionState:   uc.workerConnectionState(worker.ID),
+			Draining:          uc.isDraining(worker.ID),
+			HeartbeatInterval: uc.heartbeatInterval(worker.ID).String(),
+			MissedHeartbeats:  uc.missedHeartbeats(worker, now),
+			ClockOffset:       uc.workerClockOffset(worker.ID),
+		})
+	}
+	slices.SortFunc(details, func(a, b domain.WorkerDetail) int { return strings.Compare(a.ID, b.ID) })
+	return details, nil
+}
+
+// ForceWorkerOffline marks a worker offline and closes the master's
+// connection to it, re-queueing the test it was running. A worker that is
+// still alive comes back with its next heartbeat.
+func (uc *MasterUsecase) ForceWorkerOffline(ctx context.Context, workerID string, user *domain.UserProfile) error {
+	if worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID); err != nil || worker == nil {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+	log.Printf("Worker %s forced offline by %s.", workerID, user.Username)
+	uc.takeWorkerOffline(ctx, workerID)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditWorkerOffline, workerID, nil))
+	return nil
+}
+
+// RemoveWorker deletes the registration of an offline worker, such as one
+// that will never come back. The worker registers again if it does.
+func (uc *MasterUsecase) RemoveWorker(ctx context.Context, workerID string, user *domain.UserProfile) error {
+	worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+	if err != nil || worker == nil {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+	if worker.Status != "OFFLINE" {
+		return fmt.Errorf("worker %s is %s: force it offline before removing it", workerID, worker.Status)
+	}
+	if err := uc.workerRepo.DeleteWorker(ctx, workerID); err != nil {
+		return fmt.Errorf("failed to remove worker %s: %w", workerID, err)
+	}
+	uc.removeWorkerFromAvailabilityQueue(workerID)
+	uc.workerHeartbeats.Delete(workerID)
+	uc.workerClocks.Delete(workerID)
+	log.Printf("Worker %s removed by %s.", workerID, user.Username)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditWorkerRemove, workerID, nil))
+	return nil
+}
+
+// PingWorker checks that a worker answers at its registered address. The
+// ping is sent once, with the worker RPC deadline and no retries.
+func (uc *MasterUsecase) PingWorker(ctx context.Context, workerID string) (*domain.WorkerPing, error) {
+	if _, err := uc.workerRepo.GetWorkerByID(ctx, workerID); err != nil {
+		return nil, fmt.Errorf("worker %s not found", workerID)
+	}
+	client, ok := uc.workerClient(workerID)
+	if !ok {
+		return nil, fmt.Errorf("worker %s is not connected", workerID)
+	}
+	start := time.Now()
+	resp, err := client.Ping(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("worker %s did not answer: %w", workerID, err)
+	}
+	if resp.WorkerId != workerID {
+		return nil, fmt.Errorf("worker %s did not answer: its address is now served by worker %s", workerID, resp.WorkerId)
+	}
+	return &domain.WorkerPing{
+		WorkerID:      resp.WorkerId,
+		Version:       resp.Version,
+		CurrentTestID: resp.CurrentTestId,
+		LatencyMs:     float64(time.Since(start).Microseconds()) / 1000,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// missingCapabilities describes what a worker lacks to run a test whose
+// assignment is payloadBytes long. Unknown workers are assumed capable.
+func (uc *MasterUsecase) missingCapabilities(ctx context.Context, workerID string, testReq *domain.TestRequest, payloadBytes int) []string {
+	worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+	if err != nil || worker == nil {
+		return nil
+	}
+	return worker.MissingCapabilities(testReq, payloadBytes)
+}
+
+// assignmentPayloadBytes is the size of a test's assignment message, leaving
+// out the few bytes of per-worker rate and sequence range.
+func assignmentPayloadBytes(testReq *domain.TestRequest) int {
+	return proto.Size(setAssignmentApdex(&pb.TestAssignment{
+		TestId:            testReq.ID,
+		VegetaPayloadJson: testReq.VegetaPayloadJSON,
+		DurationSeconds:   testReq.DurationSeconds,
+		TargetsBase64:     testReq.TargetsBase64,
+		ThinkTime:         testReq.ThinkTime,
+		RequestIdPrefix:   testReq.RequestIDPrefix,
+		ScenarioJson:      testReq.ScenarioJSON,
+		Script:            testReq.Script,
+		LabelHeaders:      assignmentLabels(testReq),
+	}, testReq))
+}
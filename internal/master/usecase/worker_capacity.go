@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetRejectOverCapacity makes tests fail at assignment when a worker's share
+// of the rate exceeds the maximum it calibrated. By default such tests run
+// with a warning, since the worker may fall short of its rate.
+func (uc *MasterUsecase) SetRejectOverCapacity(reject bool) {
+	uc.rejectOverCapacity = reject
+}
+
+// overCapacityWorkers records each planned worker's calibrated maximum rate
+// in plan and describes the workers whose share exceeds it. Workers that did
+// not calibrate are assumed to keep up.
+func (uc *MasterUsecase) overCapacityWorkers(ctx context.Context, plan []domain.WorkerRate) []string {
+	var over []string
+	for i := range plan {
+		worker, err := uc.workerRepo.GetWorkerByID(ctx, plan[i].WorkerID)
+		if err != nil || worker == nil {
+			log.Printf("Warning: Failed to look up worker %s for its calibrated rate: %v", plan[i].WorkerID, err)
+			continue
+		}
+		plan[i].MaxRate = worker.MaxRate
+		if worker.MaxRate > 0 && plan[i].RatePerSecond > worker.MaxRate {
+			over = append(over, fmt.Sprintf("%s (%d req/s, max %d)", plan[i].WorkerID, plan[i].RatePerSecond, worker.MaxRate))
+		}
+	}
+	return over
+}
+
+// Fleet capacity policies: what SubmitTest does with a test whose rate is
+// more than the registered workers advertise they can generate together.
+const (
+	FleetCapacityWarn   = "warn"   // Accept it and record a capacity warning on it
+	FleetCapacityReject = "reject" // Refuse it with domain.ErrExceedsFleetCapacity
+	FleetCapacityAdjust = "adjust" // Raise its worker count until the fleet can carry it, or else warn
+)
+
+// SetFleetCapacityPolicy chooses how submitted tests are checked against the
+// fleet's calibrated capacity. The default is FleetCapacityWarn.
+func (uc *MasterUsecase) SetFleetCapacityPolicy(policy string) error {
+	switch policy {
+	case FleetCapacityWarn, FleetCapacityReject, FleetCapacityAdjust:
+		uc.fleetCapacityPolicy = policy
+		return nil
+	}
+	return fmt.Errorf("invalid fleet capacity policy %q: must be %s, %s or %s",
+		policy, FleetCapacityWarn, FleetCapacityReject, FleetCapacityAdjust)
+}
+
+// fleetMaxRates returns the calibrated maximum rates of the workers that are
+// not offline, highest first. It returns false when there are none or any of
+// them did not calibrate, since the fleet's capacity is then unknown.
+func (uc *MasterUsecase) fleetMaxRates(ctx context.Context) ([]uint64, bool) {
+	workers, err := uc.workerRepo.GetAllWorkers(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to list workers for the fleet capacity check: %v", err)
+		return nil, false
+	}
+	var maxRates []uint64
+	for _, worker := range workers {
+		if worker.Status == "OFFLINE" {
+			continue
+		}
+		if worker.MaxRate == 0 {
+			return nil, false
+		}
+		maxRates = append(maxRates, worker.MaxRate)
+	}
+	sort.Slice(maxRates, func(i, j int) bool { return maxRates[i] > maxRates[j] })
+	return maxRates, len(maxRates) > 0
+}
+
+// fastestCapacity is the rate the n fastest workers can generate together.
+func fastestCapacity(maxRates []uint64, n int) uint64 {
+	return sumRates(maxRates[:min(n, len(maxRates))])
+}
+
+// checkFleetCapacity compares a test's combined rate with what the fastest
+// workers of the fleet can generate, and applies the fleet capacity policy
+// when it is more. It may raise the test's worker count or set its capacity
+// warning.
+func (uc *MasterUsecase) checkFleetCapacity(ctx context.Context, testReq *domain.TestRequest) error {
+	maxRates, ok := uc.fleetMaxRates(ctx)
+	if !ok {
+		return nil
+	}
+	workerCount := int(testReq.WorkerCount)
+	requested := sumRates(distributeRate(testReq, workerCount))
+	capacity := fastestCapacity(maxRates, workerCount)
+	if requested <= capacity {
+		return nil
+	}
+	shortfall := fmt.Sprintf("%d req/s requested but %d of the %d workers can generate at most %d req/s",
+		requested, min(workerCount, len(maxRates)), len(maxRates), capacity)
+
+	switch uc.fleetCapacityPolicy {
+	case FleetCapacityReject:
+		return fmt.Errorf("%w: %s", domain.ErrExceedsFleetCapacity, shortfall)
+	case FleetCapacityAdjust:
+		if count, ok := workerCountForRate(testReq, maxRates); ok {
+			testReq.CapacityWarning = fmt.Sprintf("worker count raised from %d to %d: %s", testReq.WorkerCount, count, shortfall)
+			testReq.WorkerCount = count
+			log.Printf("Test %s: %s", testReq.ID, testReq.CapacityWarning)
+			return nil
+		}
+		shortfall += "; adding workers would not help"
+	}
+	testReq.CapacityWarning = shortfall
+	log.Printf("Warning: Test %s exceeds fleet capacity: %s", testReq.ID, shortfall)
+	return nil
+}
+
+// workerCountForRate finds the fewest workers above the test's count whose
+// fastest members can carry its combined rate. Only distributions that split
+// a fixed rate can gain from more workers: "same" sends the full rate from
+// each one, and "weighted" has a weight per worker.
+func workerCountForRate(testReq *domain.TestRequest, maxRates []uint64) (uint32, bool) {
+	if testReq.RateDistribution == "same" || testReq.RateDistribution == "weighted" {
+		return 0, false
+	}
+	for n := int(testReq.WorkerCount) + 1; n <= len(maxRates); n++ {
+		if sumRates(distributeRate(testReq, n)) <= fastestCapacity(maxRates, n) {
+			return uint32(n), true
+		}
+	}
+	return 0, false
+}
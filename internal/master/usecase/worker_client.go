@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// WorkerRPCPolicy sets the deadlines and retries of the master's RPCs to
+// workers, and how quickly dropped worker connections are reopened.
+type WorkerRPCPolicy struct {
+	Timeout        time.Duration // Deadline of each attempt
+	MaxAttempts    int           // Attempts per call, including the first
+	InitialBackoff time.Duration // Wait before the first retry or reconnect; grows exponentially
+	MaxBackoff     time.Duration // Longest wait between retries or reconnects
+}
+
+// DefaultWorkerRPCPolicy is the policy of a master that does not set one.
+var DefaultWorkerRPCPolicy = WorkerRPCPolicy{
+	Timeout:        15 * time.Second,
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+// SetWorkerRPCPolicy changes the policy of RPCs to workers. Connections to
+// workers that are already registered keep their reconnect backoff.
+func (uc *MasterUsecase) SetWorkerRPCPolicy(policy WorkerRPCPolicy) error {
+	if policy.Timeout <= 0 || policy.MaxAttempts < 1 || policy.InitialBackoff <= 0 || policy.MaxBackoff < policy.InitialBackoff {
+		return fmt.Errorf("invalid worker RPC policy %+v: timeout and backoff must be positive, attempts at least 1, and the maximum backoff no less than the initial one", policy)
+	}
+	uc.rpcPolicy = policy
+	return nil
+}
+
+// dialOptions returns the options of a connection to a worker, reconnecting
+// with exponential backoff when it drops.
+func (p WorkerRPCPolicy) dialOptions() []grpc.DialOption {
+	reconnect := backoff.DefaultConfig
+	reconnect.BaseDelay = p.InitialBackoff
+	reconnect.MaxDelay = p.MaxBackoff
+	return []grpc.DialOption{grpc.WithConnectParams(grpc.ConnectParams{Backoff: reconnect, MinConnectTimeout: p.Timeout})}
+}
+
+// workerClient calls a worker's RPCs under the master's worker RPC policy.
+type workerClient struct {
+	workerID string
+	client   pb.WorkerServiceClient
+	policy   WorkerRPCPolicy
+}
+
+// workerClient returns a client for a registered worker, or false if the
+// master has no connection to it.
+func (uc *MasterUsecase) workerClient(workerID string) (*workerClient, bool) {
+	connVal, ok := uc.activeWorkerClients.Load(workerID)
+	if !ok {
+		return nil, false
+	}
+	return &workerClient{workerID: workerID, client: pb.NewWorkerServiceClient(connVal.(*grpc.ClientConn)), policy: uc.rpcPolicy}, true
+}
+
+// AssignTest sends a test assignment to the worker. Workers accept a repeated
+// assignment of a test they are running, so retrying one whose response was
+// lost does not start the test twice.
+func (c *workerClient) AssignTest(ctx context.Context, assignment *pb.TestAssignment) (*pb.AssignmentResponse, error) {
+	var resp *pb.AssignmentResponse
+	err := c.call(ctx, "AssignTest", func(ctx context.Context) (err error) {
+		resp, err = c.client.AssignTest(ctx, assignment)
+		return err
+	})
+	return resp, err
+}
+
+// Ping checks that the worker answers. It is sent once, so it reports
+// whether the worker answers now rather than after a reconnect.
+func (c *workerClient) Ping(ctx context.Context) (*pb.PingResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.policy.Timeout)
+	defer cancel()
+	return c.client.Ping(ctx, &pb.PingRequest{})
+}
+
+// call runs rpc with the policy's deadline, retrying failures that may be
+// transient with exponential backoff until the attempts run out or ctx ends.
+func (c *workerClient) call(ctx context.Context, method string, rpc func(ctx context.Context) error) error {
+	wait := c.policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, c.policy.Timeout)
+		err := rpc(attemptCtx)
+		cancel()
+		if err == nil || attempt >= c.policy.MaxAttempts || !retryableRPCError(err) {
+			return err
+		}
+		log.Printf("%s to worker %s failed (attempt %d/%d), retrying in %v: %v",
+			method, c.workerID, attempt, c.policy.MaxAttempts, wait, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, c.policy.MaxBackoff)
+	}
+}
+
+// retryableRPCError reports whether an RPC error may go away on its own, such
+// as while a worker's connection is being reopened.
+func retryableRPCError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
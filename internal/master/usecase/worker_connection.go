@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// reconnectWorker reopens the connection to a worker that was marked offline,
+// such as after it missed heartbeats, when it reports again without
+// registering. Workers that are connected are left alone.
+func (uc *MasterUsecase) reconnectWorker(ctx context.Context, workerID string) {
+	if _, ok := uc.activeWorkerClients.Load(workerID); ok {
+		return
+	}
+	worker, err := uc.workerRepo.GetWorkerByID(ctx, workerID)
+	if err != nil || worker == nil {
+		return
+	}
+	options := append(uc.rpcPolicy.dialOptions(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(worker.Address, options...)
+	if err != nil {
+		log.Printf("Warning: Failed to reconnect to worker %s at %q: %v", workerID, worker.Address, err)
+		return
+	}
+	if _, loaded := uc.activeWorkerClients.LoadOrStore(workerID, conn); loaded {
+		conn.Close() // Another report reconnected it first
+		return
+	}
+	log.Printf("Worker %s is reporting again; reconnecting", workerID)
+	go uc.watchWorkerConnection(workerID, conn)
+}
+
+// watchWorkerConnection connects to a worker in the background and records
+// the connection's state as it changes, until the connection is closed or
+// replaced by a newer registration.
+func (uc *MasterUsecase) watchWorkerConnection(workerID string, conn *grpc.ClientConn) {
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Shutdown; state = conn.GetState() {
+		if current, ok := uc.activeWorkerClients.Load(workerID); !ok || current != conn {
+			return
+		}
+		uc.workerConnStates.Store(workerID, state)
+		switch state {
+		case connectivity.TransientFailure:
+			log.Printf("Warning: Connection to worker %s failed, retrying in the background", workerID)
+		case connectivity.Ready:
+			log.Printf("Connected to worker %s", workerID)
+		case connectivity.Idle:
+			// Reconnect right away so the state keeps showing whether the worker is reachable
+			conn.Connect()
+		}
+		conn.WaitForStateChange(context.Background(), state)
+	}
+}
+
+// workerConnectionState describes the master's connection to a worker.
+func (uc *MasterUsecase) workerConnectionState(workerID string) string {
+	state, ok := uc.workerConnStates.Load(workerID)
+	if !ok {
+		return "DISCONNECTED"
+	}
+	return state.(connectivity.State).String()
+}
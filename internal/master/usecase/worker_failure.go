@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// TestStatusAbortedWorkerLost marks a test stopped because one of its workers
+// went offline and its worker failure policy is WorkerFailureAbort.
+const TestStatusAbortedWorkerLost = domain.TestStatusAbortedWorkerLost
+
+// handleWorkerFailure hands the share of workerID, which went offline while
+// running test, to a spare worker or, failing that, applies the test's worker
+// failure policy. Tests that abort on a lost worker get no spare. It returns
+// false when no other worker is still running the test, leaving the caller
+// to re-queue it.
+func (uc *MasterUsecase) handleWorkerFailure(ctx context.Context, test *domain.TestRequest, workerID string) bool {
+	if test.Status != domain.TestStatusRunning {
+		return false
+	}
+	var survivors []string
+	for _, id := range test.AssignedWorkersIDs {
+		if id != workerID && !slices.Contains(test.CompletedWorkers, id) && !slices.Contains(test.FailedWorkers, id) {
+			survivors = append(survivors, id)
+		}
+	}
+	if len(survivors) == 0 {
+		return false
+	}
+
+	if err := uc.testRepo.AddFailedWorkerToTest(ctx, test.ID, workerID); err != nil {
+		log.Printf("Error marking offline worker %s as failed for test %s: %v", workerID, test.ID, err)
+	}
+
+	if test.OnWorkerFailure == domain.WorkerFailureAbort {
+		uc.abortForWorkerLost(ctx, test, workerID)
+		return true
+	}
+	message, replaced := uc.replaceWithSpare(ctx, test, workerID)
+	if !replaced && test.OnWorkerFailure == domain.WorkerFailureRedistribute {
+		message = uc.redistributeWorkerRate(ctx, test, workerID, survivors)
+	} else if !replaced {
+		message = fmt.Sprintf("Worker %s went offline; %d workers continue at their planned rates", workerID, len(survivors))
+	}
+	log.Printf("Test %s: %s", test.ID, message)
+	uc.events.Publish(domain.Event{Type: domain.EventTestUpdated, TestID: test.ID, WorkerID: workerID, Status: test.Status, Message: message})
+	return true
+}
+
+// redistributeWorkerRate spreads the planned rate of workerID evenly over the
+// survivors, recording their new rates in the test's distribution plan. In
+// multi-region tests, only survivors in the lost worker's region take it on.
+// Each survivor is told its new rate in the acknowledgement of its next
+// progress report. It returns a summary of the change.
+func (uc *MasterUsecase) redistributeWorkerRate(ctx context.Context, test *domain.TestRequest, workerID string, survivors []string) string {
+	plan := test.DistributionPlan
+	lost := slices.IndexFunc(plan, func(r domain.WorkerRate) bool { return r.WorkerID == workerID })
+	if lost < 0 || plan[lost].RatePerSecond == 0 {
+		return fmt.Sprintf("Worker %s went offline; its rate is unknown, so %d workers continue at their planned rates", workerID, len(survivors))
+	}
+
+	if region := plan[lost].Region; region != "" {
+		survivors = slices.DeleteFunc(slices.Clone(survivors), func(id string) bool {
+			i := slices.IndexFunc(plan, func(r domain.WorkerRate) bool { return r.WorkerID == id })
+			return i < 0 || plan[i].Region != region
+		})
+		if len(survivors) == 0 {
+			return fmt.Sprintf("Worker %s went offline; no other worker in region %s is left to take its rate", workerID, region)
+		}
+	}
+
+	lostRate := plan[lost].RatePerSecond
+	share, remainder := lostRate/uint64(len(survivors)), lostRate%uint64(len(survivors))
+	changes := make(map[string]uint64, len(survivors))
+	for i := range plan {
+		if !slices.Contains(survivors, plan[i].WorkerID) {
+			continue
+		}
+		plan[i].RatePerSecond += share
+		if remainder > 0 {
+			plan[i].RatePerSecond++
+			remainder--
+		}
+		changes[plan[i].WorkerID] = plan[i].RatePerSecond
+	}
+	plan[lost].RatePerSecond = 0
+	if err := uc.testRepo.SaveDistributionPlan(ctx, test.ID, plan); err != nil {
+		log.Printf("Warning: Failed to save distribution plan for test %s: %v", test.ID, err)
+	}
+
+	lt := uc.liveTestFor(ctx, test.ID, time.Now())
+	lt.mu.Lock()
+	if lt.rates == nil {
+		lt.rates = make(map[string]uint64)
+	}
+	for id, rate := range changes {
+		lt.rates[id] = rate
+	}
+	lt.mu.Unlock()
+
+	return fmt.Sprintf("Worker %s went offline; its %d req/s were spread over the %d remaining", workerID, lostRate, len(changes))
+}
+
+// abortForWorkerLost marks a running test as aborted after workerID went
+// offline. Its other workers are told to stop in the acknowledgement of their
+// next progress report.
+func (uc *MasterUsecase) abortForWorkerLost(ctx context.Context, test *domain.TestRequest, workerID string) {
+	lt := uc.liveTestFor(ctx, test.ID, time.Now())
+	lt.mu.Lock()
+	if lt.abortReason == "" {
+		lt.abortReason = fmt.Sprintf("worker %s went offline", workerID)
+	}
+	lt.mu.Unlock()
+
+	log.Printf("🛑 Worker %s of test %s went offline; aborting the test", workerID, test.ID)
+	if err := uc.testRepo.UpdateTestStatus(ctx, test.ID, TestStatusAbortedWorkerLost, test.CompletedWorkers, append(test.FailedWorkers, workerID)); errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return // Finished or aborted by another update in the meantime
+	} else if err != nil {
+		log.Printf("Error marking test %s as %s: %v", test.ID, TestStatusAbortedWorkerLost, err)
+		return
+	}
+
+	uc.events.Publish(domain.Event{Type: domain.EventTestCompleted, TestID: test.ID, WorkerID: workerID, Status: TestStatusAbortedWorkerLost,
+		Message: fmt.Sprintf("Worker %s went offline", workerID)})
+}
+
+// TakeRateChange returns the new rate of workerID for testID after a lost
+// worker's rate was spread over it. A change is only returned once.
+func (uc *MasterUsecase) TakeRateChange(testID, workerID string) (uint64, bool) {
+	val, ok := uc.liveProgress.Load(testID)
+	if !ok {
+		return 0, false
+	}
+	lt := val.(*liveTest)
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	rate, ok := lt.rates[workerID]
+	delete(lt.rates, workerID)
+	return rate, ok
+}
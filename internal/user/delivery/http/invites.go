@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// handleInvites lists invites or invites a new user (admin only). The
+// response carries the signup link only if it could not be emailed.
+func (h *UserHandler) handleInvites(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		invites, err := h.userUsecase.GetInvites(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"invites": invites})
+	case http.MethodPost:
+		user := getUserProfileFromContext(r)
+		if user == nil {
+			http.Error(w, "User not found in context", http.StatusInternalServerError)
+			return
+		}
+		var req domain.CreateInviteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		invite, link, err := h.userUsecase.CreateInvite(r.Context(), user.ID, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(inviteResponse(invite, link))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleInviteByID resends (POST .../{id}/resend) or revokes (DELETE
+// .../{id}) an invite (admin only).
+func (h *UserHandler) handleInviteByID(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/users/invites/")
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/resend"):
+		invite, link, err := h.userUsecase.ResendInvite(r.Context(), strings.TrimSuffix(path, "/resend"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(inviteResponse(invite, link))
+	case r.Method == http.MethodDelete:
+		if err := h.userUsecase.RevokeInvite(r.Context(), path); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// inviteResponse describes an invite sent to its recipient, or handed to
+// the admin as link when it could not be emailed.
+func inviteResponse(invite *domain.Invite, link string) map[string]interface{} {
+	response := map[string]interface{}{"invite": invite, "emailed": link == ""}
+	if link != "" {
+		response["signupUrl"] = link
+	}
+	return response
+}
+
+// handleInviteByToken shows whom an unused signup link is for, so the
+// signup page can show the email address and role.
+func (h *UserHandler) handleInviteByToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	invite, err := h.userUsecase.GetInviteByToken(r.Context(), strings.TrimPrefix(r.URL.Path, "/api/auth/invites/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"email":     invite.Email,
+		"role":      invite.Role,
+		"expiresAt": invite.ExpiresAt,
+	})
+}
+
+// handleSignup creates the user of an invite and logs them in.
+func (h *UserHandler) handleSignup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.SignupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	authResponse, err := h.userUsecase.Signup(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(authResponse)
+}
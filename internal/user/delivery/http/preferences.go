@@ -0,0 +1,42 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// handlePreferences returns or replaces the caller's preferences.
+func (h *UserHandler) handlePreferences(w http.ResponseWriter, r *http.Request) {
+	user := getUserProfileFromContext(r)
+	if user == nil {
+		http.Error(w, "User not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var prefs *domain.UserPreferences
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		if prefs, err = h.userUsecase.GetPreferences(r.Context(), user.ID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPut:
+		var req domain.UserPreferences
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if prefs, err = h.userUsecase.UpdatePreferences(r.Context(), user.ID, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prefs)
+}
@@ -2,7 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
@@ -27,11 +30,18 @@ func (h *UserHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/auth/login", h.handleCORS(h.handleLogin))
 	mux.HandleFunc("/api/auth/profile", h.handleCORS(h.requireAuth(h.handleGetProfile)))
 	mux.HandleFunc("/api/auth/change-password", h.handleCORS(h.requireAuth(h.handleChangePassword)))
+	mux.HandleFunc("/api/auth/preferences", h.handleCORS(h.requireAuth(h.handlePreferences)))
+	mux.HandleFunc("/api/auth/api-keys", h.handleCORS(h.requireAuth(h.handleAPIKeys)))
+	mux.HandleFunc("/api/auth/api-keys/", h.handleCORS(h.requireAuth(h.handleRevokeAPIKey)))
+	mux.HandleFunc("/api/auth/invites/", h.handleCORS(h.handleInviteByToken))
+	mux.HandleFunc("/api/auth/signup", h.handleCORS(h.handleSignup))
 
 	// User management routes (admin only)
 	mux.HandleFunc("/api/users", h.handleCORS(h.requireAuth(h.requireAdmin(h.handleUsers))))
 	mux.HandleFunc("/api/users/create", h.handleCORS(h.requireAuth(h.requireAdmin(h.handleCreateUser))))
 	mux.HandleFunc("/api/users/", h.handleCORS(h.requireAuth(h.requireAdmin(h.handleUserByID))))
+	mux.HandleFunc("/api/users/invites", h.handleCORS(h.requireAuth(h.requireAdmin(h.handleInvites))))
+	mux.HandleFunc("/api/users/invites/", h.handleCORS(h.requireAuth(h.requireAdmin(h.handleInviteByID))))
 }
 
 // handleLogin handles user authentication
@@ -52,6 +62,12 @@ func (h *UserHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	authResponse, err := h.userUsecase.AuthenticateUser(r.Context(), req.Username, req.Password)
+	var locked *domain.LoginLockedError
+	if errors.As(err, &locked) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -74,18 +90,26 @@ func (h *UserHandler) handleGetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	prefs, err := h.userUsecase.GetPreferences(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	// Return user without password hash
 	response := map[string]interface{}{
-		"id":          user.ID,
-		"username":    user.Username,
-		"email":       user.Email,
-		"firstName":   user.FirstName,
-		"lastName":    user.LastName,
-		"role":        user.Role,
-		"isActive":    user.IsActive,
-		"createdAt":   user.CreatedAt,
-		"updatedAt":   user.UpdatedAt,
-		"lastLoginAt": user.LastLoginAt,
+		"id":                 user.ID,
+		"username":           user.Username,
+		"email":              user.Email,
+		"firstName":          user.FirstName,
+		"lastName":           user.LastName,
+		"role":               user.Role,
+		"isActive":           user.IsActive,
+		"createdAt":          user.CreatedAt,
+		"updatedAt":          user.UpdatedAt,
+		"lastLoginAt":        user.LastLoginAt,
+		"mustChangePassword": user.MustChangePassword,
+		"preferences":        prefs,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -145,16 +169,17 @@ func (h *UserHandler) handleGetAllUsers(w http.ResponseWriter, r *http.Request)
 	var response []map[string]interface{}
 	for _, user := range users {
 		response = append(response, map[string]interface{}{
-			"id":          user.ID,
-			"username":    user.Username,
-			"email":       user.Email,
-			"firstName":   user.FirstName,
-			"lastName":    user.LastName,
-			"role":        user.Role,
-			"isActive":    user.IsActive,
-			"createdAt":   user.CreatedAt,
-			"updatedAt":   user.UpdatedAt,
-			"lastLoginAt": user.LastLoginAt,
+			"id":                 user.ID,
+			"username":           user.Username,
+			"email":              user.Email,
+			"firstName":          user.FirstName,
+			"lastName":           user.LastName,
+			"role":               user.Role,
+			"isActive":           user.IsActive,
+			"createdAt":          user.CreatedAt,
+			"updatedAt":          user.UpdatedAt,
+			"lastLoginAt":        user.LastLoginAt,
+			"mustChangePassword": user.MustChangePassword,
 		})
 	}
 
@@ -227,6 +252,8 @@ func (h *UserHandler) handleCreateUser(w http.ResponseWriter, r *http.Request) {
 		"role":      user.Role,
 		"isActive":  user.IsActive,
 		"createdAt": user.CreatedAt,
+
+		"mustChangePassword": user.MustChangePassword,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -253,6 +280,8 @@ func (h *UserHandler) handleUserByID(w http.ResponseWriter, r *http.Request) {
 			h.handleActivateUser(w, r, userID)
 		} else if strings.HasSuffix(r.URL.Path, "/deactivate") {
 			h.handleDeactivateUser(w, r, userID)
+		} else if strings.HasSuffix(r.URL.Path, "/unlock") {
+			h.handleUnlockUser(w, r, userID)
 		} else {
 			http.Error(w, "Invalid operation", http.StatusBadRequest)
 		}
@@ -271,16 +300,17 @@ func (h *UserHandler) handleGetUserByID(w http.ResponseWriter, r *http.Request,
 
 	// Return user without password hash
 	response := map[string]interface{}{
-		"id":          user.ID,
-		"username":    user.Username,
-		"email":       user.Email,
-		"firstName":   user.FirstName,
-		"lastName":    user.LastName,
-		"role":        user.Role,
-		"isActive":    user.IsActive,
-		"createdAt":   user.CreatedAt,
-		"updatedAt":   user.UpdatedAt,
-		"lastLoginAt": user.LastLoginAt,
+		"id":                 user.ID,
+		"username":           user.Username,
+		"email":              user.Email,
+		"firstName":          user.FirstName,
+		"lastName":           user.LastName,
+		"role":               user.Role,
+		"isActive":           user.IsActive,
+		"createdAt":          user.CreatedAt,
+		"updatedAt":          user.UpdatedAt,
+		"lastLoginAt":        user.LastLoginAt,
+		"mustChangePassword": user.MustChangePassword,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -311,6 +341,18 @@ func (h *UserHandler) handleDeactivateUser(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(map[string]string{"message": "User deactivated successfully"})
 }
 
+// handleUnlockUser lifts a user's login lockout
+func (h *UserHandler) handleUnlockUser(w http.ResponseWriter, r *http.Request, userID string) {
+	err := h.userUsecase.UnlockUser(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "User unlocked successfully"})
+}
+
 // requireAuth middleware checks if user is authenticated
 func (h *UserHandler) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -332,8 +374,15 @@ func (h *UserHandler) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Until they change their password, users can only do that and see their profile
+		if user.MustChangePassword && r.URL.Path != "/api/auth/change-password" && r.URL.Path != "/api/auth/profile" {
+			http.Error(w, "Password change required: set a new password with POST /api/auth/change-password", http.StatusForbidden)
+			return
+		}
+
 		// Add user to request context
 		ctx := setUserProfileInContext(r.Context(), user)
+		ctx = domain.WithAuditActor(ctx, user.ID, user.Username)
 		next(w, r.WithContext(ctx))
 	}
 }
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+const (
+	apiKeyHintLength    = 8           // Characters of a key after its prefix kept as its hint
+	apiKeyTouchInterval = time.Minute // How stale a key's last use may get
+)
+
+// SetAPIKeyRepository lets users create API keys and sign in with them.
+func (uc *UserUsecase) SetAPIKeyRepository(repo domain.APIKeyRepository) {
+	uc.apiKeyRepo = repo
+}
+
+// CreateAPIKey issues a new API key for a user. The returned key is the
+// only copy; just its hash is stored.
+func (uc *UserUsecase) CreateAPIKey(ctx context.Context, userID, name string) (*domain.APIKey, string, error) {
+	if uc.apiKeyRepo == nil {
+		return nil, "", errors.New("API keys are not enabled")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, "", errors.New("API key name must be 1 to 100 characters")
+	}
+	existing, err := uc.apiKeyRepo.GetAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(existing) >= domain.MaxAPIKeysPerUser {
+		return nil, "", fmt.Errorf("at most %d API keys are allowed; revoke one first", domain.MaxAPIKeysPerUser)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := domain.APIKeyPrefix + hex.EncodeToString(secret)
+	apiKey := &domain.APIKey{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Hint:      key[:len(domain.APIKeyPrefix)+apiKeyHintLength],
+		KeyHash:   hashToken(key),
+		CreatedAt: time.Now(),
+	}
+	if err := uc.apiKeyRepo.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditAPIKeyCreate, apiKey.ID, map[string]string{"name": name}))
+	return apiKey, key, nil
+}
+
+// GetAPIKeys lists a user's API keys, newest first.
+func (uc *UserUsecase) GetAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	if uc.apiKeyRepo == nil {
+		return []*domain.APIKey{}, nil
+	}
+	return uc.apiKeyRepo.GetAPIKeysByUser(ctx, userID)
+}
+
+// RevokeAPIKey deletes one of a user's API keys, which stops working at once.
+func (uc *UserUsecase) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	if uc.apiKeyRepo == nil {
+		return errors.New("API key not found")
+	}
+	if err := uc.apiKeyRepo.DeleteAPIKey(ctx, keyID, userID); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditAPIKeyRevoke, keyID, nil))
+	return nil
+}
+
+// apiKeyUserID returns the ID of the user an API key belongs to.
+func (uc *UserUsecase) apiKeyUserID(ctx context.Context, key string) (string, error) {
+	if uc.apiKeyRepo == nil {
+		return "", errors.New("invalid API key")
+	}
+	apiKey, err := uc.apiKeyRepo.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to check API key: %w", err)
+	}
+	if apiKey == nil {
+		return "", errors.New("invalid API key")
+	}
+	// Record use at most once a minute, rather than write on every request
+	if now := time.Now(); apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval {
+		if err := uc.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return apiKey.UserID, nil
+}
+
+// hashToken is how API keys and invite tokens are stored. Both are random,
+// so an unsalted hash is enough.
+func hashToken(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// InviteTTL is how long a signup link stays valid after it is sent.
+const InviteTTL = 7 * 24 * time.Hour
+
+// errInviteNotFound covers unknown, used, revoked and expired invites alike,
+// so a signup token reveals nothing about why it does not work.
+var errInviteNotFound = errors.New("invite not found or expired")
+
+// inviteSettings configure invitations.
+type inviteSettings struct {
+	repo      domain.InviteRepository // nil unless invitations are enabled
+	mailer    domain.Mailer           // nil = signup links are handed to the admin instead
+	signupURL string                  // Page the link opens; the token is added as a query parameter
+}
+
+// SetInvites lets admins invite users, who sign up at signupURL with the
+// token they receive. Without a mailer the signup link is returned to the
+// admin to pass on.
+func (uc *UserUsecase) SetInvites(repo domain.InviteRepository, mailer domain.Mailer, signupURL string) {
+	uc.invites = inviteSettings{repo: repo, mailer: mailer, signupURL: signupURL}
+}
+
+// CreateInvite invites someone to sign up with email and role, and sends
+// them the signup link. The link is returned, for the admin to pass on,
+// only if it could not be emailed.
+func (uc *UserUsecase) CreateInvite(ctx context.Context, invitedBy string, req *domain.CreateInviteRequest) (*domain.Invite, string, error) {
+	if uc.invites.repo == nil {
+		return nil, "", errors.New("invitations are not enabled")
+	}
+	address, err := mail.ParseAddress(req.Email)
+	if err != nil || address.Name != "" {
+		return nil, "", fmt.Errorf("invalid email address %q", req.Email)
+	}
+	role := req.Role
+	if role == "" {
+		role = "user"
+	}
+	if role != "user" && role != "admin" {
+		return nil, "", fmt.Errorf("invalid role %q: must be user or admin", role)
+	}
+	if _, err := uc.userRepo.GetUserByEmail(ctx, address.Address); err == nil {
+		return nil, "", fmt.Errorf("email already exists")
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	invite := &domain.Invite{
+		ID:        uuid.New().String(),
+		Email:     address.Address,
+		Role:      role,
+		TokenHash: tokenHash,
+		InvitedBy: invitedBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(InviteTTL),
+		SentAt:    now,
+	}
+	if err := uc.invites.repo.CreateInvite(ctx, invite); err != nil {
+		return nil, "", err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditInviteCreate, invite.ID, map[string]string{"email": invite.Email, "role": role}))
+	return invite, uc.sendInvite(ctx, invite, token), nil
+}
+
+// ResendInvite issues a new signup link for an unused invite, valid for
+// another InviteTTL, and sends it. The previous link stops working.
+func (uc *UserUsecase) ResendInvite(ctx context.Context, inviteID string) (*domain.Invite, string, error) {
+	invite, err := uc.getInvite(ctx, inviteID)
+	if err != nil {
+		return nil, "", err
+	}
+	if invite.AcceptedAt != nil || invite.RevokedAt != nil {
+		return nil, "", errors.New("invite was already used or revoked")
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if err := uc.invites.repo.ReissueInvite(ctx, invite.ID, tokenHash, now, now.Add(InviteTTL)); err != nil {
+		return nil, "", err
+	}
+	invite.TokenHash, invite.SentAt, invite.ExpiresAt = tokenHash, now, now.Add(InviteTTL)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditInviteResend, invite.ID, nil))
+	return invite, uc.sendInvite(ctx, invite, token), nil
+}
+
+// GetInvites lists every invite, newest first.
+func (uc *UserUsecase) GetInvites(ctx context.Context) ([]*domain.Invite, error) {
+	if uc.invites.repo == nil {
+		return []*domain.Invite{}, nil
+	}
+	return uc.invites.repo.GetInvites(ctx)
+}
+
+// RevokeInvite stops an unused invite from being used.
+func (uc *UserUsecase) RevokeInvite(ctx context.Context, inviteID string) error {
+	invite, err := uc.getInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if err := uc.invites.repo.RevokeInvite(ctx, invite.ID, time.Now()); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditInviteRevoke, invite.ID, nil))
+	return nil
+}
+
+// GetInviteByToken returns the usable invite a signup token belongs to.
+func (uc *UserUsecase) GetInviteByToken(ctx context.Context, token string) (*domain.Invite, error) {
+	if uc.invites.repo == nil || token == "" {
+		return nil, errInviteNotFound
+	}
+	invite, err := uc.invites.repo.GetInviteByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil || !invite.Usable(time.Now()) {
+		return nil, errInviteNotFound
+	}
+	return invite, nil
+}
+
+// Signup creates the user an invite was for, with the username and
+// password they chose, and logs them in.
+func (uc *UserUsecase) Signup(ctx context.Context, req *domain.SignupRequest) (*domain.AuthResponse, error) {
+	invite, err := uc.GetInviteByToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	username := strings.TrimSpace(req.Username)
+	if len(username) < 3 || len(username) > 50 {
+		return nil, errors.New("username must be 3 to 50 characters")
+	}
+
+	user, err := uc.CreateUser(ctx, &domain.CreateUserRequest{
+		Username:  username,
+		Email:     invite.Email,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Role:      invite.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.invites.repo.AcceptInvite(ctx, invite.ID, user.ID, time.Now()); err != nil {
+		// Another signup used the invite first
+		if err := uc.userRepo.DeactivateUser(ctx, user.ID); err != nil {
+			log.Printf("Warning: Failed to deactivate %s after a duplicate signup: %v", username, err)
+		}
+		return nil, errInviteNotFound
+	}
+	actx := domain.WithAuditActor(ctx, user.ID, user.Username)
+	uc.audit(actx, domain.NewAuditEntry(actx, domain.AuditInviteAccept, invite.ID, nil))
+	return uc.AuthenticateUser(ctx, username, req.Password)
+}
+
+// getInvite returns the invite with id, failing if there is none.
+func (uc *UserUsecase) getInvite(ctx context.Context, inviteID string) (*domain.Invite, error) {
+	if uc.invites.repo == nil {
+		return nil, errors.New("invite not found")
+	}
+	invite, err := uc.invites.repo.GetInvite(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil {
+		return nil, errors.New("invite not found")
+	}
+	return invite, nil
+}
+
+// sendInvite emails the signup link of invite. It returns the link if it
+// was not sent, so the admin can pass it on themselves.
+func (uc *UserUsecase) sendInvite(ctx context.Context, invite *domain.Invite, token string) string {
+	link := uc.invites.signupURL + "?token=" + url.QueryEscape(token)
+	if uc.invites.mailer == nil {
+		return link
+	}
+	body := fmt.Sprintf("You have been invited to the distributed load tester.\r\n\r\n"+
+		"Choose a username and password to sign up:\r\n\r\n%s\r\n\r\n"+
+		"The link can be used once and expires on %s.\r\n",
+		link, invite.ExpiresAt.UTC().Format(time.RFC1123))
+	if err := uc.invites.mailer.SendMail(ctx, invite.Email, "Your load tester invitation", body); err != nil {
+		log.Printf("Warning: Failed to email invite %s to %s: %v", invite.ID, invite.Email, err)
+		return link
+	}
+	return ""
+}
+
+// newInviteToken returns a random signup token and how it is stored.
+func newInviteToken() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	token := hex.EncodeToString(secret)
+	return token, hashToken(token), nil
+}
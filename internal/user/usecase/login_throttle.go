@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Defaults of the login lockout.
+const (
+	DefaultLoginMaxFailures   = 5           // Failures of one username before it is locked out
+	DefaultLoginIPMaxFailures = 20          // Failures from one address before it is locked out
+	DefaultLoginLockout       = time.Minute // First lockout; each further one doubles it
+)
+
+const (
+	maxLoginLockout    = time.Hour        // Longest a lockout gets
+	loginFailureWindow = 15 * time.Minute // Quiet time after which failures are forgotten
+	loginPruneSize     = 1024             // Entries kept before forgotten ones are pruned
+)
+
+// loginThrottle locks usernames and client addresses out of logging in
+// after repeated failures. Each lockout in a row lasts twice as long as the
+// last, up to maxLoginLockout. State is kept in memory, so a restarted
+// master forgets it.
+type loginThrottle struct {
+	mu            sync.Mutex
+	maxFailures   int           // per username; 0 = not checked
+	ipMaxFailures int           // per address; 0 = not checked
+	lockout       time.Duration // 0 = lockout disabled
+	users         map[string]*loginFailures
+	ips           map[string]*loginFailures
+}
+
+// loginFailures counts the failed logins of one username or address.
+type loginFailures struct {
+	count       int // Failures since the last lockout
+	lockouts    int // Lockouts in a row
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// SetLoginLockout locks a username out of logging in after maxFailures
+// failed attempts, and a client address after ipMaxFailures, for lockout,
+// doubling with each further lockout up to an hour. A lockout is lifted by
+// waiting it out or by an admin unlocking the user. Zero disables a check.
+func (uc *UserUsecase) SetLoginLockout(maxFailures, ipMaxFailures int, lockout time.Duration) {
+	uc.throttle.mu.Lock()
+	defer uc.throttle.mu.Unlock()
+	uc.throttle.maxFailures = maxFailures
+	uc.throttle.ipMaxFailures = ipMaxFailures
+	uc.throttle.lockout = lockout
+}
+
+// checkLoginAllowed refuses a login attempt for username from the address
+// in ctx while either is locked out.
+func (uc *UserUsecase) checkLoginAllowed(ctx context.Context, username string) error {
+	if wait := uc.throttle.locked(username, domain.AuditSourceFrom(ctx).IP, time.Now()); wait > 0 {
+		return &domain.LoginLockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// loginFailed counts a failed login and records any lockout it starts.
+func (uc *UserUsecase) loginFailed(ctx context.Context, userID, username string) {
+	ip := domain.AuditSourceFrom(ctx).IP
+	userLockout, ipLockout := uc.throttle.failed(username, ip, time.Now())
+	if userLockout > 0 {
+		uc.auditLockout(ctx, userID, username, "username", userLockout)
+	}
+	if ipLockout > 0 {
+		uc.auditLockout(ctx, userID, username, "address", ipLockout)
+	}
+}
+
+func (uc *UserUsecase) auditLockout(ctx context.Context, userID, username, scope string, lockout time.Duration) {
+	ctx = domain.WithAuditActor(ctx, userID, username)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditLoginLockout, userID, map[string]string{
+		"scope":    scope,
+		"duration": lockout.String(),
+	}))
+}
+
+// UnlockUser lifts a lockout of a user's username, so they can log in again
+// at once.
+func (uc *UserUsecase) UnlockUser(ctx context.Context, userID string) error {
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	uc.throttle.unlock(user.Username)
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUserUnlock, userID, nil))
+	return nil
+}
+
+// locked returns how long username or ip stay locked out at now, or zero.
+func (t *loginThrottle) locked(username, ip string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var wait time.Duration
+	if f := t.users[username]; f != nil {
+		wait = max(wait, f.lockedUntil.Sub(now))
+	}
+	if f := t.ips[ip]; f != nil && ip != "" {
+		wait = max(wait, f.lockedUntil.Sub(now))
+	}
+	return wait
+}
+
+// failed counts a failed login for username from ip at now, and returns
+// how long each is locked out for if this failure locked it out.
+func (t *loginThrottle) failed(username, ip string, now time.Time) (userLockout, ipLockout time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lockout <= 0 {
+		return 0, 0
+	}
+	if t.maxFailures > 0 {
+		t.users = t.count(t.users, username, t.maxFailures, now, &userLockout)
+	}
+	if t.ipMaxFailures > 0 && ip != "" {
+		t.ips = t.count(t.ips, ip, t.ipMaxFailures, now, &ipLockout)
+	}
+	return userLockout, ipLockout
+}
+
+// count adds a failure for key to failures, setting lockout if it reaches
+// maxFailures, and returns the map, created or pruned as needed.
+func (t *loginThrottle) count(failures map[string]*loginFailures, key string, maxFailures int, now time.Time, lockout *time.Duration) map[string]*loginFailures {
+	if failures == nil {
+		failures = make(map[string]*loginFailures)
+	}
+	if len(failures) >= loginPruneSize {
+		for k, f := range failures {
+			if f.forgotten(now) {
+				delete(failures, k)
+			}
+		}
+	}
+	f := failures[key]
+	if f == nil || f.forgotten(now) {
+		f = &loginFailures{}
+		failures[key] = f
+	}
+	f.count++
+	f.lastFailure = now
+	if f.count >= maxFailures {
+		*lockout = min(t.lockout<<min(f.lockouts, 16), maxLoginLockout)
+		f.count = 0
+		f.lockouts++
+		f.lockedUntil = now.Add(*lockout)
+	}
+	return failures
+}
+
+// forgotten reports whether the failures are old enough at now to start
+// counting afresh.
+func (f *loginFailures) forgotten(now time.Time) bool {
+	last := f.lastFailure
+	if f.lockedUntil.After(last) {
+		last = f.lockedUntil
+	}
+	return now.Sub(last) > loginFailureWindow
+}
+
+// succeeded clears the failures of username after it logged in. Those of
+// the address stay, so one good account cannot reset them.
+func (t *loginThrottle) succeeded(username string) {
+	t.unlock(username)
+}
+
+// unlock forgets the failures and lockout of username.
+func (t *loginThrottle) unlock(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.users, username)
+}
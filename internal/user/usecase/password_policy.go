@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// Defaults of the password policy.
+const (
+	DefaultPasswordMinLength = 8
+	DefaultPasswordHistory   = 1 // The current password cannot be set again
+)
+
+// defaultAdminPassword is the password of the admin account created when
+// there is none. It must be changed at the first login.
+const defaultAdminPassword = "admin123"
+
+// SetPasswordPolicy makes passwords set from now on satisfy policy, and
+// makes users whose password is older than policy.MaxAge change it before
+// doing anything else.
+func (uc *UserUsecase) SetPasswordPolicy(policy domain.PasswordPolicy) {
+	uc.passwordPolicy = policy
+}
+
+// checkNewPassword checks password against the policy and, for an existing
+// user, against their current and most recently replaced passwords.
+func (uc *UserUsecase) checkNewPassword(ctx context.Context, user *domain.User, password string) error {
+	policy := uc.passwordPolicy
+	if err := policy.Check(password); err != nil {
+		return err
+	}
+	if user == nil || policy.History <= 0 {
+		return nil
+	}
+	hashes := []string{user.Password}
+	if policy.History > 1 {
+		replaced, err := uc.userRepo.GetPasswordHistory(ctx, user.ID, policy.History-1)
+		if err != nil {
+			return fmt.Errorf("failed to check password history: %w", err)
+		}
+		hashes = append(hashes, replaced...)
+	}
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return fmt.Errorf("%w: must not repeat any of the last %d passwords", domain.ErrWeakPassword, policy.History)
+		}
+	}
+	return nil
+}
+
+// mustChangePassword reports whether user must change their password at
+// now, because it was set for them or is older than the policy allows. A
+// password never changed is as old as the account.
+func (uc *UserUsecase) mustChangePassword(user *domain.User, now time.Time) bool {
+	if user.MustChangePassword {
+		return true
+	}
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return uc.passwordPolicy.Expired(changedAt, now)
+}
+
+// flagDefaultPassword makes an admin still using the default password change
+// it at their next login, for databases set up before passwords could be
+// required to change.
+func (uc *UserUsecase) flagDefaultPassword(ctx context.Context, user *domain.User) {
+	if user.MustChangePassword || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(defaultAdminPassword)) != nil {
+		return
+	}
+	if err := uc.userRepo.SetMustChangePassword(ctx, user.ID, true); err != nil {
+		log.Printf("Warning: Failed to require %s to change the default password: %v", user.Username, err)
+		return
+	}
+	log.Printf("WARNING: %s still has the default password; it must be changed at the next login", user.Username)
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// SetPreferencesRepository lets users save preferences.
+func (uc *UserUsecase) SetPreferencesRepository(repo domain.PreferencesRepository) {
+	uc.prefsRepo = repo
+}
+
+// GetPreferences returns a user's preferences, empty if they saved none.
+func (uc *UserUsecase) GetPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	if uc.prefsRepo == nil {
+		return &domain.UserPreferences{}, nil
+	}
+	return uc.prefsRepo.GetUserPreferences(ctx, userID)
+}
+
+// UpdatePreferences replaces a user's preferences.
+func (uc *UserUsecase) UpdatePreferences(ctx context.Context, userID string, prefs *domain.UserPreferences) (*domain.UserPreferences, error) {
+	if uc.prefsRepo == nil {
+		return nil, errors.New("preferences are not enabled")
+	}
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.prefsRepo.SaveUserPreferences(ctx, userID, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// TokenTTL is how long a token issued at login stays valid.
+const TokenTTL = 24 * time.Hour
+
+// tokenClaims are the claims of every token the master issues. The subject
+// is the user ID; user_id repeats it for clients that read it from there.
+type tokenClaims struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// tokenService issues and verifies the master's HS256 JWTs. It only checks
+// a token itself; UserUsecase.ValidateToken also checks the user is active.
+type tokenService struct {
+	secret []byte
+}
+
+// issue returns a token for user and when it expires.
+func (s *tokenService) issue(user *domain.User, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(TokenTTL)
+	claims := tokenClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// verify checks the signature and expiry of tokenString and returns the ID
+// of the user it was issued to.
+func (s *tokenService) verify(tokenString string) (string, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	userID := claims.Subject
+	if userID == "" {
+		// Tokens issued before the subject was set carry only user_id
+		userID = claims.UserID
+	}
+	if userID == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return userID, nil
+}
@@ -3,9 +3,10 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
@@ -14,55 +15,153 @@ import (
 
 // UserUsecase implements domain.UserUsecase
 type UserUsecase struct {
-	userRepo  domain.UserRepository
-	jwtSecret string
+	userRepo   domain.UserRepository
+	tokens     *tokenService
+	auditRepo  domain.AuditRepository       // nil unless the audit log is enabled
+	apiKeyRepo domain.APIKeyRepository      // nil unless API keys are enabled
+	prefsRepo  domain.PreferencesRepository // nil unless users can save preferences
+	throttle   loginThrottle
+
+	passwordPolicy domain.PasswordPolicy
+	invites        inviteSettings
 }
 
 // NewUserUsecase creates a new user usecase
 func NewUserUsecase(userRepo domain.UserRepository, jwtSecret string) *UserUsecase {
 	return &UserUsecase{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo: userRepo,
+		tokens:   &tokenService{secret: []byte(jwtSecret)},
+		throttle: loginThrottle{
+			maxFailures:   DefaultLoginMaxFailures,
+			ipMaxFailures: DefaultLoginIPMaxFailures,
+			lockout:       DefaultLoginLockout,
+		},
+		passwordPolicy: domain.PasswordPolicy{
+			MinLength: DefaultPasswordMinLength,
+			History:   DefaultPasswordHistory,
+		},
+	}
+}
+
+// SetAuditRepository records logins and user management actions in repo.
+func (uc *UserUsecase) SetAuditRepository(repo domain.AuditRepository) {
+	uc.auditRepo = repo
+}
+
+// audit records entry in the audit log when it is enabled. Failures are
+// logged rather than failing the action that was already taken.
+func (uc *UserUsecase) audit(ctx context.Context, entry *domain.AuditEntry) {
+	if uc.auditRepo == nil {
+		return
+	}
+	if err := uc.auditRepo.SaveAuditEntry(ctx, entry); err != nil {
+		log.Printf("Warning: Failed to record %s in audit log: %v", entry.Action, err)
 	}
 }
 
 // Login authenticates a user and returns a JWT token
 func (uc *UserUsecase) Login(ctx context.Context, username, password string) (*domain.User, string, error) {
+	user, token, _, err := uc.login(ctx, username, password)
+	return user, token, err
+}
+
+// login authenticates a user and returns a JWT token and when it expires.
+func (uc *UserUsecase) login(ctx context.Context, username, password string) (*domain.User, string, time.Time, error) {
+	user, err := uc.checkCredentials(ctx, username, password)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	uc.auditLogin(ctx, domain.AuditLogin, user.ID, username, "")
+
+	// Update last login
+	uc.userRepo.UpdateLastLogin(ctx, user.ID)
+
+	// Generate JWT token
+	token, expiresAt, err := uc.tokens.issue(user, time.Now())
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	// Don't return password hash
+	user.Password = ""
+
+	return user, token, expiresAt, nil
+}
+
+// checkCredentials returns the active user with username and password,
+// recording failed attempts in the audit log. Attempts are refused while the
+// username or client address is locked out after repeated failures.
+func (uc *UserUsecase) checkCredentials(ctx context.Context, username, password string) (*domain.User, error) {
+	if err := uc.checkLoginAllowed(ctx, username); err != nil {
+		uc.auditLogin(ctx, domain.AuditLoginFailed, "", username, "locked out")
+		return nil, err
+	}
+
 	// Get user by username
 	user, err := uc.userRepo.GetUserByUsername(ctx, username)
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid credentials")
+		uc.auditLogin(ctx, domain.AuditLoginFailed, "", username, "unknown user")
+		uc.loginFailed(ctx, "", username)
+		return nil, fmt.Errorf("invalid credentials")
 	}
 
 	// Check if user is active
 	if !user.IsActive {
-		return nil, "", fmt.Errorf("user account is disabled")
+		uc.auditLogin(ctx, domain.AuditLoginFailed, user.ID, username, "account disabled")
+		return nil, fmt.Errorf("user account is disabled")
 	}
 
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid credentials")
+		uc.auditLogin(ctx, domain.AuditLoginFailed, user.ID, username, "wrong password")
+		uc.loginFailed(ctx, user.ID, username)
+		return nil, fmt.Errorf("invalid credentials")
 	}
+	uc.throttle.succeeded(username)
+	return user, nil
+}
 
-	// Update last login
-	uc.userRepo.UpdateLastLogin(ctx, user.ID)
-
-	// Generate JWT token
-	token, err := uc.generateJWT(user)
+// VerifyCredentials authenticates a request carrying a username and password
+// instead of a token, such as HTTP basic auth from a Grafana data source.
+// Unlike Login it issues no token and, since such clients send the password
+// with every request, records only failed attempts.
+func (uc *UserUsecase) VerifyCredentials(ctx context.Context, username, password string) (*domain.UserProfile, error) {
+	user, err := uc.checkCredentials(ctx, username, password)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate token: %w", err)
+		return nil, err
 	}
+	if uc.mustChangePassword(user, time.Now()) {
+		return nil, domain.ErrPasswordChangeRequired
+	}
+	return &domain.UserProfile{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        user.Role,
+		IsActive:    user.IsActive,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		LastLoginAt: user.LastLoginAt,
+	}, nil
+}
 
-	// Don't return password hash
-	user.Password = ""
-
-	return user, token, nil
+// auditLogin records a login attempt. The actor is the account logged into,
+// which the request context does not know yet.
+func (uc *UserUsecase) auditLogin(ctx context.Context, action, userID, username, reason string) {
+	ctx = domain.WithAuditActor(ctx, userID, username)
+	var payload interface{}
+	if reason != "" {
+		payload = map[string]string{"reason": reason}
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, action, userID, payload))
 }
 
 // AuthenticateUser authenticates a user and returns an auth response
 func (uc *UserUsecase) AuthenticateUser(ctx context.Context, username, password string) (*domain.AuthResponse, error) {
-	user, token, err := uc.Login(ctx, username, password)
+	user, token, expiresAt, err := uc.login(ctx, username, password)
 	if err != nil {
 		return nil, err
 	}
@@ -78,49 +177,48 @@ func (uc *UserUsecase) AuthenticateUser(ctx context.Context, username, password
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
 		LastLoginAt: user.LastLoginAt,
+
+		MustChangePassword: uc.mustChangePassword(user, time.Now()),
 	}
 
 	return &domain.AuthResponse{
 		Token:     token,
 		User:      profile,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
-// ValidateToken validates a JWT token and returns the user
+// ValidateToken validates a JWT token or an API key and returns the user.
+// It is the one check of every token, whether it comes from the HTTP API, a
+// WebSocket or a gRPC call: the token must be valid and its user must still
+// exist and be active. The user's MustChangePassword is set if they must
+// change their password first; API keys are not held back by it.
 func (uc *UserUsecase) ValidateToken(ctx context.Context, tokenString string) (*domain.User, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(uc.jwtSecret), nil
-	})
-
+	var userID string
+	var err error
+	apiKey := strings.HasPrefix(tokenString, domain.APIKeyPrefix)
+	if apiKey {
+		userID, err = uc.apiKeyUserID(ctx, tokenString)
+	} else {
+		userID, err = uc.tokens.verify(tokenString)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+		return nil, err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			return nil, fmt.Errorf("invalid token claims")
-		}
-
-		user, err := uc.userRepo.GetUserByID(ctx, userID)
-		if err != nil {
-			return nil, fmt.Errorf("user not found")
-		}
-
-		if !user.IsActive {
-			return nil, fmt.Errorf("user account is disabled")
-		}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
 
-		// Don't return password hash
-		user.Password = ""
-		return user, nil
+	if !user.IsActive {
+		return nil, fmt.Errorf("user account is disabled")
 	}
+	user.MustChangePassword = !apiKey && uc.mustChangePassword(user, time.Now())
 
-	return nil, fmt.Errorf("invalid token")
+	// Don't return password hash
+	user.Password = ""
+	return user, nil
 }
 
 // ValidateJWTToken validates a JWT token and returns the user profile
@@ -141,6 +239,8 @@ func (uc *UserUsecase) ValidateJWTToken(ctx context.Context, tokenString string)
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
 		LastLoginAt: user.LastLoginAt,
+
+		MustChangePassword: user.MustChangePassword,
 	}, nil
 }
 
@@ -158,6 +258,10 @@ func (uc *UserUsecase) CreateUser(ctx context.Context, req *domain.CreateUserReq
 		return nil, fmt.Errorf("email already exists")
 	}
 
+	if err := uc.checkNewPassword(ctx, nil, req.Password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -182,12 +286,17 @@ func (uc *UserUsecase) CreateUser(ctx context.Context, req *domain.CreateUserReq
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+
+		MustChangePassword: req.MustChangePassword,
 	}
 
 	err = uc.userRepo.CreateUser(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	audited := *req
+	audited.Password = ""
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUserCreate, user.ID, audited))
 
 	// Don't return password hash
 	user.Password = ""
@@ -212,6 +321,8 @@ func (uc *UserUsecase) GetUserProfile(ctx context.Context, userID string) (*doma
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
 		LastLoginAt: user.LastLoginAt,
+
+		MustChangePassword: uc.mustChangePassword(user, time.Now()),
 	}, nil
 }
 
@@ -229,6 +340,7 @@ func (uc *UserUsecase) UpdateUserProfile(ctx context.Context, userID string, req
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUserUpdate, userID, req))
 
 	return &domain.UserProfile{
 		ID:          updatedUser.ID,
@@ -258,6 +370,10 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, userID string, req *d
 		return fmt.Errorf("current password is incorrect")
 	}
 
+	if err := uc.checkNewPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -265,10 +381,11 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, userID string, req *d
 	}
 
 	// Update password
-	err = uc.userRepo.UpdateUserPassword(ctx, userID, string(newPasswordHash))
+	err = uc.userRepo.UpdateUserPassword(ctx, userID, string(newPasswordHash), false)
 	if err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditPasswordChange, userID, nil))
 
 	return nil
 }
@@ -280,6 +397,7 @@ func (uc *UserUsecase) GetAllUsers(ctx context.Context) ([]*domain.UserProfile,
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 
+	now := time.Now()
 	profiles := make([]*domain.UserProfile, len(users))
 	for i, user := range users {
 		profiles[i] = &domain.UserProfile{
@@ -293,20 +411,51 @@ func (uc *UserUsecase) GetAllUsers(ctx context.Context) ([]*domain.UserProfile,
 			CreatedAt:   user.CreatedAt,
 			UpdatedAt:   user.UpdatedAt,
 			LastLoginAt: user.LastLoginAt,
+
+			MustChangePassword: uc.mustChangePassword(user, now),
 		}
 	}
 
 	return profiles, nil
 }
 
+// SearchUsers finds users by username, email or name (admin only)
+func (uc *UserUsecase) SearchUsers(ctx context.Context, query string, limit int) ([]domain.SearchResult, error) {
+	users, err := uc.userRepo.SearchUsers(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	results := make([]domain.SearchResult, len(users))
+	for i, user := range users {
+		results[i] = domain.SearchResult{
+			Type:      domain.SearchResultUser,
+			ID:        user.ID,
+			Title:     user.Username,
+			Subtitle:  fmt.Sprintf("%s %s <%s>", user.FirstName, user.LastName, user.Email),
+			Matched:   "user",
+			CreatedAt: user.CreatedAt,
+		}
+	}
+	return results, nil
+}
+
 // ActivateUser activates a user account
 func (uc *UserUsecase) ActivateUser(ctx context.Context, userID string) error {
-	return uc.userRepo.ActivateUser(ctx, userID)
+	if err := uc.userRepo.ActivateUser(ctx, userID); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUserActivate, userID, nil))
+	return nil
 }
 
 // DeactivateUser deactivates a user account
 func (uc *UserUsecase) DeactivateUser(ctx context.Context, userID string) error {
-	return uc.userRepo.DeactivateUser(ctx, userID)
+	if err := uc.userRepo.DeactivateUser(ctx, userID); err != nil {
+		return err
+	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUserDeactivate, userID, nil))
+	return nil
 }
 
 // ResetUserPassword resets a user's password (admin only)
@@ -321,6 +470,12 @@ func (uc *UserUsecase) ResetUserPassword(ctx context.Context, adminUserID, targe
 		return fmt.Errorf("insufficient permissions")
 	}
 
+	// The user chooses their own password at their next login, so the
+	// history does not apply to this one
+	if err := uc.checkNewPassword(ctx, nil, newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -328,10 +483,11 @@ func (uc *UserUsecase) ResetUserPassword(ctx context.Context, adminUserID, targe
 	}
 
 	// Update password
-	err = uc.userRepo.UpdateUserPassword(ctx, targetUserID, string(newPasswordHash))
+	err = uc.userRepo.UpdateUserPassword(ctx, targetUserID, string(newPasswordHash), true)
 	if err != nil {
 		return fmt.Errorf("failed to reset password: %w", err)
 	}
+	uc.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditPasswordReset, targetUserID, nil))
 
 	return nil
 }
@@ -349,13 +505,16 @@ func (uc *UserUsecase) EnsureDefaultUser(ctx context.Context) error {
 	for _, user := range users {
 		if user.Role == "admin" && user.IsActive {
 			hasAdmin = true
-			break
+		}
+		if user.Username == "admin" {
+			uc.flagDefaultPassword(ctx, user)
 		}
 	}
 
-	// If no admin exists, create default admin
+	// If no admin exists, create default admin, who must choose a password
+	// at the first login
 	if !hasAdmin {
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(defaultAdminPassword), bcrypt.DefaultCost)
 		if err != nil {
 			return fmt.Errorf("failed to hash default password: %w", err)
 		}
@@ -371,6 +530,8 @@ func (uc *UserUsecase) EnsureDefaultUser(ctx context.Context) error {
 			IsActive:  true,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
+
+			MustChangePassword: true,
 		}
 
 		err = uc.userRepo.CreateUser(ctx, defaultAdmin)
@@ -381,17 +542,3 @@ func (uc *UserUsecase) EnsureDefaultUser(ctx context.Context) error {
 
 	return nil
 }
-
-// generateJWT generates a JWT token for a user
-func (uc *UserUsecase) generateJWT(user *domain.User) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":  user.ID,
-		"username": user.Username,
-		"role":     user.Role,
-		"exp":      time.Now().Add(24 * time.Hour).Unix(), // 24 hours
-		"iat":      time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(uc.jwtSecret))
-}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// RedactionRules configure how test definitions and results are scrubbed
+// before being shared outside the organization.
+type RedactionRules struct {
+	InternalHostSuffixes []string `json:"internalHostSuffixes"` // Hostnames ending with any of these are redacted
+	InternalHostPatterns []string `json:"internalHostPatterns"` // Regexes; matching hostnames are redacted
+	RedactAllHosts       bool     `json:"redactAllHosts"`       // Redact every hostname that appears in a URL
+	RedactPrivateIPs     bool     `json:"redactPrivateIPs"`     // Redact loopback, private and link-local IPv4 addresses
+	RedactAllIPs         bool     `json:"redactAllIPs"`         // Redact every IPv4 address
+	Headers              []string `json:"headers"`              // Header values to replace (case-insensitive names)
+	Patterns             []string `json:"patterns"`             // Extra regexes whose matches are replaced
+}
+
+// DefaultRedactionRules returns rules covering common internal hostnames,
+// private IPs and authentication headers.
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		InternalHostSuffixes: []string{".internal", ".local", ".localdomain", ".corp", ".lan", ".intranet", ".svc", ".cluster.local"},
+		RedactPrivateIPs:     true,
+		Headers:              []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token", "X-Approval-Secret"},
+	}
+}
+
+// LoadRedactionRules reads rules from a JSON file. Fields missing from the
+// file keep their default values.
+func LoadRedactionRules(path string) (RedactionRules, error) {
+	rules := DefaultRedactionRules()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse redaction rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Redactor scrubs hostnames, IPs, auth headers and custom patterns from text
+// such as JSON documents or exported scripts. The same input value always
+// maps to the same placeholder, so redacted output stays comparable.
+type Redactor struct {
+	rules        RedactionRules
+	hostPatterns []*regexp.Regexp
+	headerRes    []*regexp.Regexp
+	patterns     []*regexp.Regexp
+}
+
+var (
+	hostnameRe = regexp.MustCompile(`\b(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z][A-Za-z0-9-]*\b|\blocalhost\b`)
+	urlHostRe  = regexp.MustCompile(`(://(?:[^@/\s"']*@)?)([A-Za-z0-9.-]+)`)
+	ipv4Re     = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// NewRedactor compiles the given rules.
+func NewRedactor(rules RedactionRules) (*Redactor, error) {
+	r := &Redactor{rules: rules}
+	for _, p := range rules.InternalHostPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid internal host pattern %q: %w", p, err)
+		}
+		r.hostPatterns = append(r.hostPatterns, re)
+	}
+	for _, h := range rules.Headers {
+		name := regexp.QuoteMeta(h)
+		// Matches JSON header entries in both map[string][]string and map[string]string form
+		r.headerRes = append(r.headerRes, regexp.MustCompile(`(?i)(()"`+name+`"\s*:\s*)(\[[^\]]*\]|"(?:[^"\\]|\\.)*")`))
+		// Same, for entries inside a JSON-encoded string where quotes are escaped
+		r.headerRes = append(r.headerRes, regexp.MustCompile(`(?i)((\\)"`+name+`\\"\s*:\s*)(\[[^\]]*\]|\\"(?:[^"\\]|\\\\(?:\\["\\]|[^"\\])|\\[^"\\])*\\")`))
+	}
+	for _, p := range rules.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns s with all configured values replaced.
+func (r *Redactor) Redact(s string) string {
+	for _, re := range r.headerRes {
+		s = re.ReplaceAllStringFunc(s, func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			q := sub[2] + `"`
+			if strings.HasPrefix(sub[3], "[") {
+				return sub[1] + "[" + q + "[REDACTED]" + q + "]"
+			}
+			return sub[1] + q + "[REDACTED]" + q
+		})
+	}
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, "[REDACTED]")
+	}
+
+	if r.rules.RedactAllHosts {
+		s = urlHostRe.ReplaceAllStringFunc(s, func(m string) string {
+			sub := urlHostRe.FindStringSubmatch(m)
+			if net.ParseIP(sub[2]) != nil {
+				return m // IPs follow the IP rules below
+			}
+			return sub[1] + placeholder("host", sub[2])
+		})
+	}
+	s = hostnameRe.ReplaceAllStringFunc(s, func(host string) string {
+		if r.isInternalHost(host) {
+			return placeholder("host", host)
+		}
+		return host
+	})
+	s = ipv4Re.ReplaceAllStringFunc(s, func(addr string) string {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return addr
+		}
+		if r.rules.RedactAllIPs || (r.rules.RedactPrivateIPs && (ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast())) {
+			return placeholder("ip", addr)
+		}
+		return addr
+	})
+	return s
+}
+
+// RedactJSON marshals v, redacts the encoded document and returns it.
+func (r *Redactor) RedactJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r.Redact(string(b))), nil
+}
+
+func (r *Redactor) isInternalHost(host string) bool {
+	lower := strings.ToLower(host)
+	if lower == "localhost" {
+		return true
+	}
+	for _, suffix := range r.rules.InternalHostSuffixes {
+		if strings.HasSuffix(lower, strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	for _, re := range r.hostPatterns {
+		if re.MatchString(host) {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholder derives a stable replacement for value.
+func placeholder(kind, value string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(value)))
+	return fmt.Sprintf("redacted-%s-%s", kind, hex.EncodeToString(sum[:4]))
+}
@@ -4,6 +4,8 @@ package grpc
 import (
 	"context"
 	"log"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,7 +19,8 @@ import (
 // This server receives test assignments from the Master.
 type GRPCServer struct {
 	pb.UnimplementedWorkerServiceServer
-	usecase *workerUsecase.WorkerUsecase
+	usecase  *workerUsecase.WorkerUsecase
+	accepted sync.Map // Map[string]struct{} // IDs of the tests being executed
 }
 
 // NewGRPCServer creates a new GRPCServer instance for the worker.
@@ -28,8 +31,14 @@ func NewGRPCServer(uc *workerUsecase.WorkerUsecase) *GRPCServer {
 }
 
 // AssignTest receives a test assignment from the Master and triggers the execution.
+// The master retries assignments whose response was lost, so an assignment of
+// a test that is already executing is accepted without starting it again.
 func (s *GRPCServer) AssignTest(ctx context.Context, req *pb.TestAssignment) (*pb.AssignmentResponse, error) {
 	log.Printf("Worker received test assignment for Test ID: %s", req.TestId)
+	if _, running := s.accepted.LoadOrStore(req.TestId, struct{}{}); running {
+		log.Printf("Test %s is already executing, ignoring repeated assignment", req.TestId)
+		return &pb.AssignmentResponse{Accepted: true, Message: "Test assignment already accepted."}, nil
+	}
 
 	testAssignment := &domain.TestAssignment{
 		TestID:            req.TestId,
@@ -37,10 +46,31 @@ func (s *GRPCServer) AssignTest(ctx context.Context, req *pb.TestAssignment) (*p
 		DurationSeconds:   req.DurationSeconds,
 		RatePerSecond:     req.RatePerSecond,
 		TargetsBase64:     req.TargetsBase64,
+		ThinkTime:         req.ThinkTime,
+		PacingJitter:      req.PacingJitter,
+		RequestIDPrefix:   req.RequestIdPrefix,
+		TemplateTargets:   req.TemplateTargets,
+		SequenceStart:     req.SequenceStart,
+		SequenceEnd:       req.SequenceEnd,
+		ScenarioJSON:      req.ScenarioJson,
+		Preflight:         req.Preflight,
+		Smoke:             req.Smoke,
+		InterimInterval:   req.InterimInterval,
+		CaptureRequests:   req.CaptureRequests,
+		Protocol:          req.Protocol,
+		Script:            req.Script,
+		LabelHeaders:      req.LabelHeaders,
+	}
+	if req.StartAtUnixNano != 0 {
+		testAssignment.StartAt = time.Unix(0, req.StartAtUnixNano)
+	}
+	if req.ApdexThreshold != "" || len(req.ApdexTargets) > 0 {
+		testAssignment.Apdex = &domain.ApdexConfig{Threshold: req.ApdexThreshold, Targets: req.ApdexTargets}
 	}
 
 	// Execute test asynchronously to avoid blocking the assignment RPC
 	go func() {
+		defer s.accepted.Delete(req.TestId)
 		err := s.usecase.ExecuteTest(context.Background(), testAssignment)
 		if err != nil {
 			log.Printf("Worker failed to execute test %s: %v", req.TestId, err)
@@ -62,3 +92,10 @@ func (s *GRPCServer) RegisterWorker(ctx context.Context, req *pb.WorkerInfo) (*p
 func (s *GRPCServer) StreamWorkerStatus(stream pb.WorkerService_StreamWorkerStatusServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamWorkerStatus not implemented by worker's server (worker is a client for this RPC, not a server)")
 }
+
+// Ping tells the master which worker answers at this address and whether it
+// is running a test.
+func (s *GRPCServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	workerID, version, currentTestID := s.usecase.Identity()
+	return &pb.PingResponse{WorkerId: workerID, Version: version, CurrentTestId: currentTestID}, nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// maxCachedAttachmentBytes bounds the attachments a worker keeps between
+// tests. The least recently used ones are dropped first.
+const maxCachedAttachmentBytes = 256 << 20
+
+// cachedAttachment is an attachment already downloaded from the master.
+type cachedAttachment struct {
+	attachment *domain.Attachment
+	data       []byte
+}
+
+// attachmentCache keeps downloaded attachments up to maxCachedAttachmentBytes
+// in total, evicting the least recently used. The zero value is ready to use.
+type attachmentCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element // attachmentID -> element holding a *cachedAttachment
+	order   list.List                // Most recently used first
+	size    int
+}
+
+// get returns a cached attachment and marks it as recently used.
+func (c *attachmentCache) get(id string) (*cachedAttachment, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cachedAttachment), true
+}
+
+// put caches an attachment, evicting others until the cache fits its limit.
+// Attachments larger than the whole limit are not cached.
+func (c *attachmentCache) put(id string, cached *cachedAttachment) {
+	if len(cached.data) > maxCachedAttachmentBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+	}
+	if _, ok := c.entries[id]; ok {
+		return
+	}
+	c.entries[id] = c.order.PushFront(cached)
+	c.size += len(cached.data)
+	for c.size > maxCachedAttachmentBytes {
+		oldest := c.order.Back()
+		evicted := c.order.Remove(oldest).(*cachedAttachment)
+		delete(c.entries, evicted.attachment.ID)
+		c.size -= len(evicted.data)
+	}
+}
+
+// resolveAttachments inlines attachment references in the assignment's
+// targets. Attachments are immutable, so downloads are cached for later tests.
+func (uc *WorkerUsecase) resolveAttachments(ctx context.Context, assignment *domain.TestAssignment) error {
+	resolved, err := domain.ResolveTargetAttachments(assignment.TargetsBase64, func(id string) (*domain.Attachment, []byte, error) {
+		if cached, ok := uc.attachments.get(id); ok {
+			return cached.attachment, cached.data, nil
+		}
+		attachment, data, err := uc.fetchAttachment(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		uc.attachments.put(id, &cachedAttachment{attachment: attachment, data: data})
+		return attachment, data, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve attachments: %w", err)
+	}
+	assignment.TargetsBase64 = resolved
+	return nil
+}
+
+// fetchAttachment downloads an attachment from the master and verifies its checksum.
+func (uc *WorkerUsecase) fetchAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, []byte, error) {
+	stream, err := uc.masterClient.GetAttachment(ctx, &pb.AttachmentRequest{AttachmentId: attachmentID, WorkerId: uc.workerID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch attachment %s: %w", attachmentID, err)
+	}
+
+	var attachment *domain.Attachment
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch attachment %s: %w", attachmentID, err)
+		}
+		if attachment == nil {
+			attachment = &domain.Attachment{
+				ID:          attachmentID,
+				Name:        chunk.Name,
+				ContentType: chunk.ContentType,
+				Size:        chunk.Size,
+				SHA256:      chunk.Sha256,
+			}
+			buf.Grow(int(chunk.Size))
+		}
+		buf.Write(chunk.Data)
+	}
+	if attachment == nil {
+		return nil, nil, fmt.Errorf("attachment %s: empty response from master", attachmentID)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(sum[:]) != attachment.SHA256 {
+		return nil, nil, fmt.Errorf("attachment %s: checksum mismatch", attachmentID)
+	}
+	return attachment, buf.Bytes(), nil
+}
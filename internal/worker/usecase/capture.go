@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// captureChunkSize is the size of each uploaded request capture chunk.
+const captureChunkSize = 1 << 20
+
+// captureUploadTimeout bounds the upload of a test's captured requests.
+const captureUploadTimeout = 5 * time.Minute
+
+var errCaptureFull = fmt.Errorf("request capture reached its limit of %d bytes", domain.MaxRequestCaptureBytes)
+
+// captureFile keeps the requests a test captures gzipped in a temporary file
+// until they are uploaded. Each Write is one request.
+type captureFile struct {
+	file      *os.File
+	zw        *gzip.Writer
+	written   int64 // Uncompressed bytes
+	requests  int64
+	truncated bool
+}
+
+func newCaptureFile() (*captureFile, error) {
+	file, err := os.CreateTemp("", "capture-*.ndjson.gz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request capture file: %w", err)
+	}
+	return &captureFile{file: file, zw: gzip.NewWriter(file)}, nil
+}
+
+// Write implements io.Writer, refusing requests past domain.MaxRequestCaptureBytes.
+func (c *captureFile) Write(p []byte) (int, error) {
+	if c.written+int64(len(p)) > domain.MaxRequestCaptureBytes {
+		c.truncated = true
+		return 0, errCaptureFull
+	}
+	n, err := c.zw.Write(p)
+	c.written += int64(n)
+	if err == nil {
+		c.requests++
+	}
+	return n, err
+}
+
+// remove deletes the file; c may be nil.
+func (c *captureFile) remove() {
+	if c == nil {
+		return
+	}
+	c.file.Close()
+	os.Remove(c.file.Name())
+}
+
+// uploadCapture streams the requests a test captured to the master.
+func (uc *WorkerUsecase) uploadCapture(ctx context.Context, testID string, capture *captureFile) error {
+	if err := capture.zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish request capture: %w", err)
+	}
+	if _, err := capture.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read request capture: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, captureUploadTimeout)
+	defer cancel()
+	stream, err := uc.masterClient.UploadRequestCapture(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upload request capture: %w", err)
+	}
+
+	chunk := &pb.RequestCaptureChunk{TestId: testID, WorkerId: uc.workerID, Requests: capture.requests, Truncated: capture.truncated}
+	buf := make([]byte, captureChunkSize)
+	for {
+		n, readErr := io.ReadFull(capture.file, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return fmt.Errorf("failed to read request capture: %w", readErr)
+		}
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return fmt.Errorf("failed to upload request capture: %w", err)
+			}
+			chunk = &pb.RequestCaptureChunk{}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to upload request capture: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("master rejected request capture: %s", resp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// clockSamples is how many of the latest status exchanges the clock offset
+// is estimated from.
+const clockSamples = 8
+
+// maxStartWait caps how long a worker waits for a synchronized start, so a
+// wildly wrong clock cannot hold a test up.
+const maxStartWait = time.Minute
+
+// clockSync estimates the offset of the master's clock from the worker's,
+// NTP-style, from the timestamps exchanged with each status and its ack.
+// The exchange with the shortest round trip bounds the error best, so the
+// estimate is the offset measured by the fastest of the latest exchanges.
+type clockSync struct {
+	mu      sync.Mutex
+	samples []clockSample // Latest exchanges, oldest first
+}
+
+// clockSample is the clock offset measured by one status exchange.
+type clockSample struct {
+	offset time.Duration // Master's clock minus the worker's
+	rtt    time.Duration // Round trip, less the master's processing time
+}
+
+// observe records the exchange an ack received at receivedAt completes.
+// Acks of statuses sent without a timestamp, or from masters that do not
+// stamp them, are ignored.
+func (c *clockSync) observe(ack *pb.WorkerStatusAck, receivedAt time.Time) {
+	if ack.StatusSentAtUnixNano == 0 || ack.ReceivedAtUnixNano == 0 || ack.SentAtUnixNano == 0 {
+		return
+	}
+	sent := ack.StatusSentAtUnixNano
+	received := receivedAt.UnixNano()
+	sample := clockSample{
+		offset: time.Duration(((ack.ReceivedAtUnixNano - sent) + (ack.SentAtUnixNano - received)) / 2),
+		rtt:    time.Duration((received - sent) - (ack.SentAtUnixNano - ack.ReceivedAtUnixNano)),
+	}
+	if sample.rtt < 0 {
+		return // The worker's clock was stepped mid-exchange
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.samples = append(c.samples, sample)
+	if len(c.samples) > clockSamples {
+		c.samples = c.samples[1:]
+	}
+}
+
+// estimate returns the current estimate of the master's clock minus the
+// worker's, with the round trip it was measured by; false until measured.
+func (c *clockSync) estimate() (clockSample, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.samples) == 0 {
+		return clockSample{}, false
+	}
+	best := c.samples[0]
+	for _, sample := range c.samples[1:] {
+		if sample.rtt < best.rtt {
+			best = sample
+		}
+	}
+	return best, true
+}
+
+// stamp sets the send time of statusMsg, and the latest clock estimate, just
+// before it goes out on the status stream.
+func (c *clockSync) stamp(statusMsg *pb.WorkerStatus) {
+	if sample, ok := c.estimate(); ok {
+		statusMsg.ClockOffsetUs, statusMsg.ClockRttUs = sample.offset.Microseconds(), max(sample.rtt.Microseconds(), 1)
+	}
+	statusMsg.SentAtUnixNano = time.Now().UnixNano()
+}
+
+// waitForStart holds a test until its synchronized start, converted from the
+// master's clock to the worker's. A worker that is already late starts at
+// once; an abort cancels the wait.
+func (uc *WorkerUsecase) waitForStart(ctx context.Context, assignment *domain.TestAssignment) error {
+	startAt := assignment.StartAt
+	sample, measured := uc.clock.estimate()
+	if measured {
+		startAt = startAt.Add(-sample.offset)
+	} else {
+		log.Printf("Warning: Worker %s has not measured its clock offset from the master; test %s may start out of step", uc.workerID, assignment.TestID)
+	}
+
+	wait := time.Until(startAt)
+	if wait <= 0 {
+		log.Printf("Worker %s is %v late for the start of test %s; starting now", uc.workerID, -wait, assignment.TestID)
+		return nil
+	}
+	if wait > maxStartWait {
+		log.Printf("Warning: Start of test %s is %v away; waiting %v", assignment.TestID, wait, maxStartWait)
+		wait = maxStartWait
+	}
+	log.Printf("Worker %s starting test %s in %v (clock offset from master: %v ±%v)",
+		uc.workerID, assignment.TestID, wait, sample.offset, sample.rtt/2)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
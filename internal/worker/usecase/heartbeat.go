@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// defaultHeartbeatInterval is used with masters that do not negotiate one.
+const defaultHeartbeatInterval = 5 * time.Second
+
+// Backoff between attempts to reach the master. Waits are jittered so that
+// workers cut off together do not all reconnect at the same moment.
+const (
+	reconnectBaseDelay = 500 * time.Millisecond
+	reconnectMaxDelay  = 30 * time.Second
+)
+
+// SetHeartbeatInterval asks the master for heartbeats at interval rather
+// than at its default. The master may clamp it. It must be called before
+// StartWorkerLifecycle.
+func (uc *WorkerUsecase) SetHeartbeatInterval(interval time.Duration) {
+	uc.requestedHeartbeat = interval
+}
+
+// acceptHeartbeat records the heartbeat interval the master negotiated.
+func (uc *WorkerUsecase) acceptHeartbeat(resp *pb.RegisterResponse) {
+	uc.heartbeatInterval = time.Duration(resp.HeartbeatIntervalMs) * time.Millisecond
+	if uc.heartbeatInterval == 0 {
+		uc.heartbeatInterval = defaultHeartbeatInterval
+	}
+	log.Printf("Worker %s sends heartbeats every %v (master allows %d missed)",
+		uc.workerID, uc.heartbeatInterval, resp.MaxMissedHeartbeats)
+}
+
+// reconnectDelay is the wait before retry attempt (from 0) to reach the
+// master: an exponential backoff, of which a random half is waited.
+func reconnectDelay(attempt int) time.Duration {
+	backoff := reconnectMaxDelay
+	if attempt < 16 {
+		backoff = min(reconnectBaseDelay<<attempt, reconnectMaxDelay)
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// Stop tells the master the worker is shutting down, so it is marked offline
+// at once instead of after missed heartbeats, and closes the status stream.
+func (uc *WorkerUsecase) Stop() {
+	uc.stopped.Store(true)
+	if uc.statusSenderCancel != nil {
+		uc.statusSenderCancel()
+	}
+	uc.statusStreamMu.Lock()
+	defer uc.statusStreamMu.Unlock()
+	if uc.statusStreamClient == nil {
+		return
+	}
+	err := uc.statusStreamClient.Send(&pb.WorkerStatus{
+		WorkerId: uc.workerID,
+		Status:   pb.StatusType_READY,
+		Message:  "Worker shutting down",
+		TestId:   uc.currentTestID,
+		Fin:      true,
+	})
+	if err != nil {
+		log.Printf("Worker %s failed to tell master it is shutting down: %v", uc.workerID, err)
+	}
+	uc.statusStreamClient.CloseSend()
+	if uc.statusStreamCancel != nil {
+		uc.statusStreamCancel()
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// interimSubmitTimeout bounds each interim result submission.
+const interimSubmitTimeout = 30 * time.Second
+
+// interimReporter sends the interim results of a running test to the master,
+// numbering them from 1. Interim results are not spooled: a flush the master
+// misses is superseded by the next one, which is cumulative.
+type interimReporter struct {
+	uc       *WorkerUsecase
+	testID   string
+	sequence int32
+}
+
+// ReportInterim submits result in the background, so the attack is not held
+// up by a slow master.
+func (r *interimReporter) ReportInterim(result *domain.TestResult) {
+	r.sequence++
+	result.TestID, result.WorkerID = r.testID, r.uc.workerID
+	submission := resultSubmission(result)
+	submission.InterimSequence = r.sequence
+	log.Printf("Worker %s flushing interim result %d for test %s (%d requests so far)",
+		r.uc.workerID, r.sequence, r.testID, result.TotalRequests)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), interimSubmitTimeout)
+		defer cancel()
+		resp, err := r.uc.masterClient.SubmitInterimResult(ctx, submission)
+		if err != nil {
+			log.Printf("Worker %s failed to submit interim result %d of test %s: %v", r.uc.workerID, submission.InterimSequence, r.testID, err)
+		} else if !resp.Success {
+			log.Printf("Master rejected interim result %d of test %s: %s", submission.InterimSequence, r.testID, resp.Message)
+		}
+	}()
+}
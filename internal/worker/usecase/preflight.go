@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+)
+
+// runPreflight probes every target once before the attack and returns a
+// *domain.PreflightError if any of them is unhealthy.
+func (uc *WorkerUsecase) runPreflight(ctx context.Context, assignment *domain.TestAssignment) error {
+	log.Printf("Worker %s running pre-flight check for test %s", uc.workerID, assignment.TestID)
+	results, err := uc.targetProber.Probe(ctx, assignment)
+	if err != nil {
+		return fmt.Errorf("pre-flight check failed: %w", err)
+	}
+	return domain.CheckProbeResults(results)
+}
+
+// probeResultsJSON returns the probe results carried by a pre-flight error as
+// JSON, or "" for any other error.
+func probeResultsJSON(err error) string {
+	var preflightErr *domain.PreflightError
+	if !errors.As(err, &preflightErr) {
+		return ""
+	}
+	data, marshalErr := json.Marshal(preflightErr.Results)
+	if marshalErr != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pace-noge/distributed-load-tester/internal/domain"
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// progressInterval is how often live progress is reported while a test runs.
+const progressInterval = time.Second
+
+// resourceTracker accumulates the resource samples taken during one test.
+type resourceTracker struct {
+	mu    sync.Mutex
+	usage domain.ResourceUsage
+}
+
+func (t *resourceTracker) add(sample domain.ResourceSample) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage.Add(sample)
+}
+
+// summary returns the usage so far, or nil if nothing was sampled.
+func (t *resourceTracker) summary() *domain.ResourceUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.usage.Samples == 0 {
+		return nil
+	}
+	usage := t.usage
+	return &usage
+}
+
+// reportProgress sends the running test's live request counts, and the
+// worker's resource use when a sampler is set, to the master every
+// progressInterval until ctx is done. While it runs it replaces the periodic
+// heartbeat.
+func (uc *WorkerUsecase) reportProgress(ctx context.Context, assignment *domain.TestAssignment, resources *resourceTracker) {
+	expected := expectedRequests(assignment)
+	began := time.Now()
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	if uc.sampler != nil {
+		uc.sampler.Sample() // Start the first interval now rather than at the previous test
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshot := assignment.Progress.Snapshot()
+			var resourcesJSON string
+			if uc.sampler != nil {
+				if sample, ok := uc.sampler.Sample(); ok {
+					resources.add(sample)
+					if data, err := json.Marshal(sample); err == nil {
+						resourcesJSON = string(data)
+					}
+				}
+			}
+			err := uc.sendStatus(&pb.WorkerStatus{
+				WorkerId:          uc.workerID,
+				Status:            pb.StatusType_BUSY,
+				Message:           fmt.Sprintf("Running test %s", assignment.TestID),
+				TestId:            assignment.TestID,
+				TotalRequests:     expected,
+				CompletedRequests: snapshot.Requests,
+				DurationMs:        time.Since(began).Milliseconds(),
+				FailedRequests:    snapshot.Failures,
+				LatencyTotalUs:    snapshot.LatencyMicros,
+				StatusCodes:       snapshot.StatusCodes,
+				ResourcesJson:     resourcesJSON,
+			})
+			if err != nil {
+				log.Printf("Worker %s failed to send progress for test %s: %v", uc.workerID, assignment.TestID, err)
+			}
+		}
+	}
+}
+
+// expectedRequests estimates how many requests the assignment sends in total.
+func expectedRequests(assignment *domain.TestAssignment) int64 {
+	duration, err := time.ParseDuration(assignment.DurationSeconds)
+	if err != nil {
+		return 0
+	}
+	expected := int64(float64(assignment.RatePerSecond) * duration.Seconds())
+	if assignment.ScenarioJSON != "" {
+		if scenario, err := domain.ParseScenario(assignment.ScenarioJSON); err == nil {
+			expected *= int64(len(scenario.Steps)) // Rate counts iterations, not requests
+		}
+	}
+	return expected
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pace-noge/distributed-load-tester/proto"
+)
+
+// Spooled results are retried with exponential backoff between these bounds,
+// and dropped once they are older than resultSpoolMaxAge.
+const (
+	resultRetryMinBackoff = 5 * time.Second
+	resultRetryMaxBackoff = 5 * time.Minute
+	resultSpoolMaxAge     = 24 * time.Hour
+)
+
+// resultSpoolExt is the file extension of spooled result submissions.
+const resultSpoolExt = ".result"
+
+// resultSpool keeps result submissions that could not be delivered to the
+// master on disk, so they survive a worker restart until they are delivered.
+type resultSpool struct {
+	dir  string
+	wake chan struct{} // Signals the delivery loop that a result was spooled
+}
+
+// EnableResultSpool stores results the master could not accept in dir and
+// retries delivering them in the background until ctx is cancelled. Results
+// left in dir by an earlier run are delivered too.
+func (uc *WorkerUsecase) EnableResultSpool(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create result spool directory %s: %w", dir, err)
+	}
+	uc.spool = &resultSpool{dir: dir, wake: make(chan struct{}, 1)}
+	go uc.deliverSpooledResults(ctx)
+	return nil
+}
+
+// spoolResult saves a submission for later delivery.
+func (uc *WorkerUsecase) spoolResult(submission *pb.TestResultSubmission) error {
+	if uc.spool == nil {
+		return fmt.Errorf("result spool is disabled")
+	}
+	data, err := proto.Marshal(submission)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial result behind
+	path := filepath.Join(uc.spool.dir, url.PathEscape(submission.WorkerId+"_"+submission.TestId)+resultSpoolExt)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write spooled result: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write spooled result: %w", err)
+	}
+
+	select {
+	case uc.spool.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// deliverSpooledResults retries spooled results, backing off while the master
+// keeps failing and waiting for new results once the spool is empty.
+func (uc *WorkerUsecase) deliverSpooledResults(ctx context.Context) {
+	backoff := resultRetryMinBackoff
+	for {
+		var wait <-chan time.Time
+		if err := uc.flushResultSpool(ctx); err != nil {
+			log.Printf("Worker %s could not deliver spooled results, retrying in %v: %v", uc.workerID, backoff, err)
+			wait = time.After(backoff)
+			backoff *= 2
+			if backoff > resultRetryMaxBackoff {
+				backoff = resultRetryMaxBackoff
+			}
+		} else {
+			backoff = resultRetryMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-uc.spool.wake:
+		case <-wait:
+		}
+	}
+}
+
+// flushResultSpool submits spooled results oldest first and removes each one
+// the master accepts. It stops at the first failure.
+func (uc *WorkerUsecase) flushResultSpool(ctx context.Context) error {
+	paths, err := filepath.Glob(filepath.Join(uc.spool.dir, "*"+resultSpoolExt))
+	if err != nil {
+		return fmt.Errorf("failed to list spooled results: %w", err)
+	}
+	submissions := make(map[string]*pb.TestResultSubmission, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read spooled result %s: %w", path, err)
+		}
+		submission := &pb.TestResultSubmission{}
+		if err := proto.Unmarshal(data, submission); err != nil {
+			log.Printf("Discarding unreadable spooled result %s: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+		submissions[path] = submission
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return submissions[paths[i]].GetTimestamp() < submissions[paths[j]].GetTimestamp()
+	})
+
+	for _, path := range paths {
+		submission, ok := submissions[path]
+		if !ok {
+			continue
+		}
+		if age := time.Since(time.Unix(submission.Timestamp, 0)); age > resultSpoolMaxAge {
+			log.Printf("Discarding result for test %s: undelivered for %v", submission.TestId, age.Round(time.Minute))
+			os.Remove(path)
+			continue
+		}
+		if err := uc.submitResult(ctx, submission); err != nil {
+			return err
+		}
+		log.Printf("Worker %s delivered spooled result for test %s", uc.workerID, submission.TestId)
+		if err := os.Remove(path); err != nil {
+			log.Printf("Warning: Failed to remove delivered result %s: %v", path, err)
+		}
+	}
+	return nil
+}
@@ -3,11 +3,14 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io" // For io.EOF
 	"log"
 	"net"
+	"strings"
 	"sync" // For sync.Once and mutex
+	"sync/atomic"
 	"time"
 
 	"github.com/pace-noge/distributed-load-tester/internal/domain"
@@ -17,19 +20,42 @@ import (
 
 // WorkerUsecase handles the business logic for the worker service.
 type WorkerUsecase struct {
-	workerID       string
-	masterClient   pb.WorkerServiceClient
-	vegetaExecutor domain.VegetaExecutor
-	currentTestID  string // Tracks the ID of the test currently being executed
+	workerID      string
+	masterClient  pb.WorkerServiceClient
+	executors     *domain.ExecutorRegistry // Runs assignments with the executor their Protocol selects
+	targetProber  domain.TargetProber
+	currentTestID string                    // Tracks the ID of the test currently being executed
+	attachments   attachmentCache           // Recently downloaded attachments
+	spool         *resultSpool              // Undelivered results; nil unless EnableResultSpool was called
+	recorder      domain.RequestRecorder    // Receives every request sent; nil unless SetRequestRecorder was called
+	sampler       domain.ResourceSampler    // Measures the worker's resource use during tests; nil unless SetResourceSampler was called
+	maxRate       uint64                    // Calibrated maximum rate advertised at registration; 0 = not calibrated
+	region        string                    // Region advertised at registration; empty = none
+	capabilities  domain.WorkerCapabilities // Version and executor capabilities advertised at registration
+	environment   domain.RunEnvironment     // Recorded with every result; set by SetEnvironment
+
+	requestedHeartbeat time.Duration // Heartbeat interval asked of the master; 0 = its default
+	heartbeatInterval  time.Duration // Heartbeat interval negotiated at registration
+	stopped            atomic.Bool   // Set by Stop; the status stream is not re-established after it
+	clock              clockSync     // Offset of the master's clock, measured on the status stream
 
 	statusStreamClient pb.WorkerService_StreamWorkerStatusClient
 	statusStreamCancel context.CancelFunc // To cancel the status stream context
+	statusSenderCancel context.CancelFunc // To stop the periodic status sender
+	streamFailures     int                // Consecutive stream receive errors, for the reconnect backoff
 	statusStreamOnce   sync.Once          // Ensures stream is established only once
 	statusStreamMu     sync.Mutex         // Protects sending on the stream
+
+	abortMu   sync.Mutex
+	abortTest context.CancelFunc // Stops the running test when the master aborts it
+	rates     chan uint64        // Passes rate changes from the master to the running test
+
+	shutdown     chan struct{} // Closed when the master asks the worker to exit
+	shutdownOnce sync.Once
 }
 
 // NewWorkerUsecase creates a new WorkerUsecase instance without database dependency.
-func NewWorkerUsecase(workerID string, vegetaExecutor domain.VegetaExecutor, masterClient pb.WorkerServiceClient) *WorkerUsecase {
+func NewWorkerUsecase(workerID string, executors *domain.ExecutorRegistry, targetProber domain.TargetProber, masterClient pb.WorkerServiceClient) *WorkerUsecase {
 	// Generate a memorable worker name if not provided or if it's generic
 	if workerID == "" || workerID == "worker-1" || workerID == "worker-2" {
 		workerID = utils.GenerateWorkerName()
@@ -37,17 +63,27 @@ func NewWorkerUsecase(workerID string, vegetaExecutor domain.VegetaExecutor, mas
 	}
 
 	return &WorkerUsecase{
-		workerID:       workerID,
-		masterClient:   masterClient,
-		vegetaExecutor: vegetaExecutor,
+		workerID:     workerID,
+		masterClient: masterClient,
+		executors:    executors,
+		targetProber: targetProber,
+		shutdown:     make(chan struct{}),
 	}
 }
 
 // StartWorkerLifecycle registers the worker with the master and starts the bidirectional status stream.
 func (uc *WorkerUsecase) StartWorkerLifecycle(ctx context.Context, workerGRPCPort int) error {
 	workerInfo := &pb.WorkerInfo{
-		Id:      uc.workerID,
-		Address: getWorkerAddress(workerGRPCPort),
+		Id:                  uc.workerID,
+		Address:             getWorkerAddress(workerGRPCPort),
+		MaxRate:             uc.maxRate,
+		Region:              uc.region,
+		Version:             uc.capabilities.Version,
+		Protocols:           uc.capabilities.Protocols,
+		Features:            uc.capabilities.Features,
+		Executors:           uc.executors.Names(),
+		MaxPayloadBytes:     uc.capabilities.MaxPayloadBytes,
+		HeartbeatIntervalMs: uint32(uc.requestedHeartbeat.Milliseconds()),
 	}
 	log.Printf("Attempting to register worker %s with master at %s", uc.workerID, workerInfo.Address)
 
@@ -58,16 +94,19 @@ func (uc *WorkerUsecase) StartWorkerLifecycle(ctx context.Context, workerGRPCPor
 	for i := 0; i < 5; i++ { // Retry 5 times
 		regResp, regErr = uc.masterClient.RegisterWorker(ctx, workerInfo)
 		if regErr != nil {
-			log.Printf("Attempt %d: Failed to register worker %s with master: %v. Retrying in 5s...", i+1, uc.workerID, regErr)
-			time.Sleep(5 * time.Second)
+			wait := reconnectDelay(i)
+			log.Printf("Attempt %d: Failed to register worker %s with master: %v. Retrying in %v...", i+1, uc.workerID, regErr, wait)
+			time.Sleep(wait)
 			continue
 		}
 		if !regResp.Success {
-			log.Printf("Attempt %d: Master rejected worker %s registration: %s. Retrying in 5s...", i+1, uc.workerID, regResp.Message)
-			time.Sleep(5 * time.Second)
+			wait := reconnectDelay(i)
+			log.Printf("Attempt %d: Master rejected worker %s registration: %s. Retrying in %v...", i+1, uc.workerID, regResp.Message, wait)
+			time.Sleep(wait)
 			continue
 		}
 		log.Printf("Worker %s registered successfully with master.", uc.workerID)
+		uc.acceptHeartbeat(regResp)
 		break
 	}
 
@@ -87,11 +126,14 @@ func (uc *WorkerUsecase) StartWorkerLifecycle(ctx context.Context, workerGRPCPor
 		uc.statusStreamClient = stream
 		log.Printf("Worker %s established bidirectional status stream to master.", uc.workerID)
 
-		// Goroutine to send periodic heartbeats/status updates
-		go uc.sendPeriodicStatusUpdates(streamCtx)
+		// Goroutine to send periodic heartbeats/status updates. It outlives
+		// any one stream, so it gets its own context.
+		senderCtx, senderCancel := context.WithCancel(context.Background())
+		uc.statusSenderCancel = senderCancel
+		go uc.sendPeriodicStatusUpdates(senderCtx)
 
 		// Goroutine to receive acknowledgments/commands from master
-		go uc.receiveStreamResponses(streamCtx)
+		go uc.receiveStreamResponses(streamCtx, stream)
 	})
 
 	// Send initial READY status through the newly established stream
@@ -117,10 +159,7 @@ func getWorkerAddress(workerGRPCPort int) string {
 // sendStatusToMaster sends a WorkerStatus message over the bidirectional stream.
 // It tries to re-establish the stream if it's broken.
 func (uc *WorkerUsecase) sendStatusToMaster(statusType pb.StatusType, message, testID string, totalReq, completedReq, durationMs int64) error {
-	uc.statusStreamMu.Lock()
-	defer uc.statusStreamMu.Unlock()
-
-	statusMsg := &pb.WorkerStatus{
+	return uc.sendStatus(&pb.WorkerStatus{
 		WorkerId:          uc.workerID,
 		Status:            statusType,
 		Message:           message,
@@ -128,19 +167,32 @@ func (uc *WorkerUsecase) sendStatusToMaster(statusType pb.StatusType, message, t
 		TotalRequests:     totalReq,
 		CompletedRequests: completedReq,
 		DurationMs:        durationMs,
-	}
+	})
+}
+
+// sendStatus sends a prepared WorkerStatus message, re-establishing the
+// stream if it's broken.
+func (uc *WorkerUsecase) sendStatus(statusMsg *pb.WorkerStatus) error {
+	uc.statusStreamMu.Lock()
+	defer uc.statusStreamMu.Unlock()
+
+	statusType, testID := statusMsg.Status, statusMsg.TestId
 
 	// Retry sending status in case of stream issues
 	for i := 0; i < 3; i++ {
+		if uc.stopped.Load() {
+			return fmt.Errorf("worker is shutting down")
+		}
 		if uc.statusStreamClient == nil {
 			log.Printf("Status stream client is nil. Attempting to re-establish. Attempt %d...", i+1)
 			uc.reestablishStatusStream()
 			if uc.statusStreamClient == nil {
-				time.Sleep(time.Second)
+				time.Sleep(reconnectDelay(i))
 				continue
 			}
 		}
 
+		uc.clock.stamp(statusMsg)
 		err := uc.statusStreamClient.Send(statusMsg)
 		if err == nil {
 			log.Printf("Worker %s sent status: %s (Test: %s)", uc.workerID, statusType.String(), testID)
@@ -150,7 +202,7 @@ func (uc *WorkerUsecase) sendStatusToMaster(statusType pb.StatusType, message, t
 		log.Printf("Worker %s failed to send status update (attempt %d): %v. Re-establishing stream...", uc.workerID, i+1, err)
 		uc.statusStreamClient.CloseSend() // Close the current broken stream
 		uc.statusStreamClient = nil       // Mark for re-establishment
-		time.Sleep(time.Second)           // Small backoff before retrying
+		time.Sleep(reconnectDelay(i))     // Jittered backoff before retrying
 		uc.reestablishStatusStream()      // Attempt to re-establish
 	}
 	return fmt.Errorf("failed to send status after multiple retries")
@@ -160,8 +212,13 @@ func (uc *WorkerUsecase) sendStatusToMaster(statusType pb.StatusType, message, t
 func (uc *WorkerUsecase) reestablishStatusStream() {
 	// This function should ideally be called under a lock if used concurrently,
 	// but here it's called within `sendStatusToMaster` which already holds a lock.
-	if uc.statusStreamClient != nil {
-		return // Stream is already active or being re-established by another call
+	if uc.statusStreamClient != nil || uc.stopped.Load() {
+		return // Stream is already active, being re-established by another call, or shut down
+	}
+
+	// Release the broken stream before replacing it, so it can't leak
+	if uc.statusStreamCancel != nil {
+		uc.statusStreamCancel()
 	}
 
 	// Create a new context for the new stream
@@ -177,13 +234,14 @@ func (uc *WorkerUsecase) reestablishStatusStream() {
 	uc.statusStreamClient = newStream
 	log.Printf("Worker %s successfully re-established status stream.", uc.workerID)
 
-	// Restart receive goroutine for the new stream
-	go uc.receiveStreamResponses(streamCtx)
+	// Each stream gets its own receiver, which exits when the stream breaks
+	go uc.receiveStreamResponses(streamCtx, newStream)
 }
 
-// sendPeriodicStatusUpdates sends a "READY" heartbeat or current test progress periodically.
+// sendPeriodicStatusUpdates sends a "READY" heartbeat at the negotiated
+// interval while the worker is idle.
 func (uc *WorkerUsecase) sendPeriodicStatusUpdates(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(uc.heartbeatInterval)
 	defer ticker.Stop()
 
 	for {
@@ -192,25 +250,11 @@ func (uc *WorkerUsecase) sendPeriodicStatusUpdates(ctx context.Context) {
 			log.Printf("Worker %s periodic status sender stopped.", uc.workerID)
 			return
 		case <-ticker.C:
-			var statusType pb.StatusType
-			var message string
-			var testID string
-			var completedReqs, totalReqs int64
-
 			if uc.currentTestID != "" {
-				statusType = pb.StatusType_BUSY
-				message = fmt.Sprintf("Running test %s", uc.currentTestID)
-				testID = uc.currentTestID
-				// In a real scenario, you'd store and update actual progress during test execution.
-				// For now, these are placeholders unless updated by ExecuteTest.
-				// completedReqs = uc.currentTestProgress.completed
-				// totalReqs = uc.currentTestProgress.total
-			} else {
-				statusType = pb.StatusType_READY
-				message = "Ready for new tests"
+				continue // reportProgress sends live progress while a test runs
 			}
 
-			err := uc.sendStatusToMaster(statusType, message, testID, totalReqs, completedReqs, 0)
+			err := uc.sendStatusToMaster(pb.StatusType_READY, "Ready for new tests", "", 0, 0, 0)
 			if err != nil {
 				log.Printf("Worker %s failed to send periodic status: %v", uc.workerID, err)
 				// Error handling for persistent stream failures would go here (e.g., exponential backoff)
@@ -219,44 +263,85 @@ func (uc *WorkerUsecase) sendPeriodicStatusUpdates(ctx context.Context) {
 	}
 }
 
-// receiveStreamResponses listens for messages from the Master on the bidirectional stream.
-func (uc *WorkerUsecase) receiveStreamResponses(ctx context.Context) {
+// receiveStreamResponses listens for messages from the Master on one
+// bidirectional stream. When the stream breaks it re-establishes it, if no
+// one else has yet, and exits; the new stream gets a receiver of its own.
+func (uc *WorkerUsecase) receiveStreamResponses(ctx context.Context, stream pb.WorkerService_StreamWorkerStatusClient) {
 	for {
-		select {
-		case <-ctx.Done():
+		ack, err := stream.Recv()
+		receivedAt := time.Now()
+		if uc.stopped.Load() || ctx.Err() != nil {
 			log.Printf("Worker %s stream receiver stopped.", uc.workerID)
 			return
-		default:
-			if uc.statusStreamClient == nil {
-				time.Sleep(time.Second) // Wait for stream to be re-established
-				continue
-			}
-			ack, err := uc.statusStreamClient.Recv()
+		}
+		if err != nil {
 			if err == io.EOF {
 				log.Printf("Master closed status stream to worker %s. Attempting to re-establish.", uc.workerID)
-				uc.statusStreamClient = nil // Mark for re-establishment
-				uc.reestablishStatusStream()
-				time.Sleep(time.Second) // Small backoff
-				continue
-			}
-			if err != nil {
+			} else {
 				log.Printf("Error receiving from master on status stream for worker %s: %v. Attempting to re-establish.", uc.workerID, err)
-				uc.statusStreamClient = nil // Mark for re-establishment
-				uc.reestablishStatusStream()
-				time.Sleep(time.Second) // Small backoff
-				continue
 			}
-			log.Printf("Received ACK from Master for worker %s: Success=%t, Message=%s", uc.workerID, ack.Accepted, ack.Message)
-			// Handle any specific commands/acks from master here
+			uc.restartStatusStream(stream)
+			return
+		}
+
+		uc.statusStreamMu.Lock()
+		uc.streamFailures = 0
+		uc.statusStreamMu.Unlock()
+		uc.clock.observe(ack, receivedAt)
+		log.Printf("Received ACK from Master for worker %s: Success=%t, Message=%s", uc.workerID, ack.Accepted, ack.Message)
+		// Handle any specific commands/acks from master here
+		if ack.AbortTestId != "" {
+			uc.AbortTest(ack.AbortTestId, ack.Message)
+		}
+		if ack.RateTestId != "" {
+			uc.AdjustRate(ack.RateTestId, ack.RatePerSecond)
+		}
+		if ack.Shutdown && uc.currentTestID == "" {
+			log.Printf("Worker %s asked to shut down by master: %s", uc.workerID, ack.Message)
+			uc.shutdownOnce.Do(func() { close(uc.shutdown) })
 		}
 	}
 }
 
+// restartStatusStream replaces a broken stream after a jittered backoff,
+// unless a sender already replaced it. If reconnecting fails, the next
+// status send retries.
+func (uc *WorkerUsecase) restartStatusStream(broken pb.WorkerService_StreamWorkerStatusClient) {
+	uc.statusStreamMu.Lock()
+	failures := uc.streamFailures
+	uc.streamFailures++
+	uc.statusStreamMu.Unlock()
+	time.Sleep(reconnectDelay(failures))
+
+	uc.statusStreamMu.Lock()
+	defer uc.statusStreamMu.Unlock()
+	if uc.statusStreamClient == broken {
+		uc.statusStreamClient = nil // Mark for re-establishment
+	}
+	uc.reestablishStatusStream()
+}
+
 // ExecuteTest takes a test assignment and runs the Vegeta load test.
 func (uc *WorkerUsecase) ExecuteTest(ctx context.Context, assignment *domain.TestAssignment) error {
 	uc.currentTestID = assignment.TestID // Set current test ID
 
+	// Let the master stop the test early (e.g. its error budget ran out) or
+	// change its rate (e.g. to take over the share of a lost worker)
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+	rates := make(chan uint64, 1)
+	assignment.Rates = rates
+	uc.abortMu.Lock()
+	uc.abortTest, uc.rates = abort, rates
+	uc.abortMu.Unlock()
+	defer func() {
+		uc.abortMu.Lock()
+		uc.abortTest, uc.rates = nil, nil
+		uc.abortMu.Unlock()
+	}()
+
 	log.Printf("Worker %s starting test %s...", uc.workerID, assignment.TestID)
+	assignment.LabelHeaders = domain.ExpandLabelHeaders(assignment.LabelHeaders, domain.LabelWorkerID, uc.workerID)
 
 	// Inform master that worker is busy
 	err := uc.sendStatusToMaster(
@@ -269,16 +354,56 @@ func (uc *WorkerUsecase) ExecuteTest(ctx context.Context, assignment *domain.Tes
 		// Proceed with test, but master might not know worker is busy
 	}
 
-	// Execute Vegeta attack
-	result, err := uc.vegetaExecutor.Attack(ctx, assignment.VegetaPayloadJSON, assignment.DurationSeconds, assignment.RatePerSecond, assignment.TargetsBase64)
+	// Report live progress while the test runs
+	assignment.Progress = &domain.ProgressCounter{}
+	if uc.recorder != nil {
+		assignment.Recorder = testRecorder{next: uc.recorder, testID: assignment.TestID, workerID: uc.workerID}
+	}
+	if assignment.InterimInterval != "" {
+		assignment.Interim = &interimReporter{uc: uc, testID: assignment.TestID}
+	}
+	var capture *captureFile
+	if assignment.CaptureRequests {
+		var captureErr error
+		if capture, captureErr = newCaptureFile(); captureErr != nil {
+			log.Printf("Warning: Worker %s cannot capture the requests of test %s: %v", uc.workerID, assignment.TestID, captureErr)
+		} else {
+			assignment.Capture = capture
+			defer capture.remove()
+		}
+	}
+	progressCtx, stopProgress := context.WithCancel(ctx)
+	resources := &resourceTracker{}
+	go uc.reportProgress(progressCtx, assignment, resources)
+
+	// Run the assignment with the executor its protocol selects.
+	// Attachment and pre-flight failures are reported like any other execution failure.
+	var result *domain.TestResult
+	err = uc.resolveAttachments(ctx, assignment)
+	if err == nil && assignment.Preflight {
+		err = uc.runPreflight(ctx, assignment)
+	}
+	if err == nil && !assignment.StartAt.IsZero() {
+		// Start together with the test's other workers
+		err = uc.waitForStart(ctx, assignment)
+	}
+	if err == nil {
+		var executor domain.Executor
+		if executor, err = uc.executors.Get(assignment.Protocol); err == nil {
+			result, err = executor.Execute(ctx, assignment)
+		}
+	}
+	stopProgress()
 	if err != nil {
-		log.Printf("Worker %s failed to execute Vegeta attack for test %s: %v", uc.workerID, assignment.TestID, err)
-		// Send ERROR status to master
-		sendErr := uc.sendStatusToMaster(
-			pb.StatusType_ERROR,
-			fmt.Sprintf("Test failed: %v", err),
-			assignment.TestID, 0, 0, 0,
-		)
+		log.Printf("Worker %s failed to execute test %s: %v", uc.workerID, assignment.TestID, err)
+		// Send ERROR status to master, with the probe results if the pre-flight check failed
+		sendErr := uc.sendStatus(&pb.WorkerStatus{
+			WorkerId:         uc.workerID,
+			Status:           pb.StatusType_ERROR,
+			Message:          fmt.Sprintf("Test failed: %v", err),
+			TestId:           assignment.TestID,
+			ProbeResultsJson: probeResultsJSON(err),
+		})
 		if sendErr != nil {
 			log.Printf("Warning: Could not send error status to master: %v", sendErr)
 		}
@@ -288,60 +413,73 @@ func (uc *WorkerUsecase) ExecuteTest(ctx context.Context, assignment *domain.Tes
 
 	result.TestID = assignment.TestID
 	result.WorkerID = uc.workerID
+	environment := uc.environment
+	environment.Executor = domain.ExecutorName(assignment.Protocol)
+	result.Environment = &environment
 
 	// Send test result to master via gRPC instead of saving to database directly
 	log.Printf("Worker %s sending test result to master for test %s", uc.workerID, assignment.TestID)
-	submitCtx, submitCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer submitCancel()
 
-	// Create the test result submission request
-	submitRequest := &pb.TestResultSubmission{
-		TestId:              assignment.TestID,
-		WorkerId:            uc.workerID,
-		TotalRequests:       result.TotalRequests,
-		CompletedRequests:   result.CompletedRequests,
-		SuccessRate:         result.SuccessRate,
-		AverageLatencyMs:    result.AverageLatencyMs,
-		P95LatencyMs:        result.P95LatencyMs,
-		DurationMs:          result.DurationMs,
-		VegetaMetricsBase64: string(result.Metric), // Base64 encoded Vegeta results as string
-		Timestamp:           time.Now().Unix(),
+	// Upload the captured requests first, so they are in place once the test completes
+	if capture != nil {
+		if err := uc.uploadCapture(context.Background(), assignment.TestID, capture); err != nil {
+			log.Printf("Warning: Worker %s could not upload the requests captured in test %s: %v", uc.workerID, assignment.TestID, err)
+		} else {
+			log.Printf("Worker %s uploaded %d captured requests of test %s", uc.workerID, capture.requests, assignment.TestID)
+		}
 	}
 
-	// Send result to master
-	submitResponse, err := uc.masterClient.SubmitTestResult(submitCtx, submitRequest)
-	if err != nil {
-		log.Printf("Worker %s failed to submit test result to master for test %s: %v", uc.workerID, assignment.TestID, err)
-		// Send ERROR status to master
-		sendErr := uc.sendStatusToMaster(
-			pb.StatusType_ERROR,
-			fmt.Sprintf("Failed to submit test result: %v", err),
-			assignment.TestID, result.TotalRequests, result.CompletedRequests, result.DurationMs,
-		)
-		if sendErr != nil {
-			log.Printf("Warning: Could not send error status to master: %v", sendErr)
+	// Create the test result submission request
+	submitRequest := resultSubmission(result)
+	if usage := resources.summary(); usage != nil {
+		if warnings := usage.SaturationWarnings(); len(warnings) > 0 {
+			log.Printf("Warning: worker %s was saturated during test %s, results may be skewed: %s",
+				uc.workerID, assignment.TestID, strings.Join(warnings, "; "))
+		}
+		if data, err := json.Marshal(usage); err == nil {
+			submitRequest.ResourceUsageJson = string(data)
 		}
-		uc.currentTestID = "" // Clear current test
-		return fmt.Errorf("failed to submit test result: %w", err)
 	}
-
-	if !submitResponse.Success {
-		log.Printf("Worker %s: Master rejected test result submission for test %s: %s", uc.workerID, assignment.TestID, submitResponse.Message)
-		// Send ERROR status to master
-		sendErr := uc.sendStatusToMaster(
-			pb.StatusType_ERROR,
-			fmt.Sprintf("Master rejected result submission: %s", submitResponse.Message),
-			assignment.TestID, result.TotalRequests, result.CompletedRequests, result.DurationMs,
-		)
-		if sendErr != nil {
-			log.Printf("Warning: Could not send error status to master: %v", sendErr)
+	if overhead := result.GeneratorOverhead; overhead != nil {
+		if warnings := overhead.Warnings(); len(warnings) > 0 {
+			log.Printf("Warning: worker %s fell behind during test %s, results may be skewed: %s",
+				uc.workerID, assignment.TestID, strings.Join(warnings, "; "))
+		}
+		if data, err := json.Marshal(overhead); err == nil {
+			submitRequest.GeneratorOverheadJson = string(data)
+		}
+	}
+	if data, err := json.Marshal(result.Environment); err == nil {
+		submitRequest.EnvironmentJson = string(data)
+	}
+	if len(result.TargetResponses) > 0 {
+		if data, err := json.Marshal(result.TargetResponses); err == nil {
+			submitRequest.TargetResponsesJson = string(data)
 		}
-		uc.currentTestID = "" // Clear current test
-		return fmt.Errorf("master rejected test result: %s", submitResponse.Message)
 	}
 
-	log.Printf("Worker %s completed test %s and submitted results successfully: TotalRequests=%d, CompletedRequests=%d, DurationMs=%d, SuccessRate=%.2f",
-		uc.workerID, assignment.TestID, result.TotalRequests, result.CompletedRequests, result.DurationMs, result.SuccessRate)
+	// Send result to master, keeping it for a later retry if that fails
+	if err := uc.submitResult(context.Background(), submitRequest); err != nil {
+		log.Printf("Worker %s failed to submit test result to master for test %s: %v", uc.workerID, assignment.TestID, err)
+		if spoolErr := uc.spoolResult(submitRequest); spoolErr != nil {
+			log.Printf("Worker %s could not spool result for test %s: %v", uc.workerID, assignment.TestID, spoolErr)
+			// Send ERROR status to master
+			sendErr := uc.sendStatusToMaster(
+				pb.StatusType_ERROR,
+				fmt.Sprintf("Failed to submit test result: %v", err),
+				assignment.TestID, result.TotalRequests, result.CompletedRequests, result.DurationMs,
+			)
+			if sendErr != nil {
+				log.Printf("Warning: Could not send error status to master: %v", sendErr)
+			}
+			uc.currentTestID = "" // Clear current test
+			return err
+		}
+		log.Printf("Worker %s spooled result for test %s; it will be retried until the master accepts it", uc.workerID, assignment.TestID)
+	} else {
+		log.Printf("Worker %s completed test %s and submitted results successfully: TotalRequests=%d, CompletedRequests=%d, DurationMs=%d, SuccessRate=%.2f",
+			uc.workerID, assignment.TestID, result.TotalRequests, result.CompletedRequests, result.DurationMs, result.SuccessRate)
+	}
 
 	// Update worker status back to READY (non-blocking)
 	go func() {
@@ -360,3 +498,136 @@ func (uc *WorkerUsecase) ExecuteTest(ctx context.Context, assignment *domain.Tes
 
 	return nil
 }
+
+// SetResourceSampler samples the worker's own resource use every second
+// while it runs a test, reporting it with progress and in the test result.
+func (uc *WorkerUsecase) SetResourceSampler(sampler domain.ResourceSampler) {
+	uc.sampler = sampler
+}
+
+// SetMaxRate advertises rate, in requests per second, to the master as the
+// most this worker can generate. It must be called before StartWorkerLifecycle.
+func (uc *WorkerUsecase) SetMaxRate(rate uint64) {
+	uc.maxRate = rate
+}
+
+// SetRegion advertises the region the worker runs in to the master, which
+// gives it the region's share of multi-region tests. It must be called before
+// StartWorkerLifecycle.
+func (uc *WorkerUsecase) SetRegion(region string) {
+	uc.region = region
+}
+
+// SetCapabilities advertises the worker's version and what its executor can
+// run to the master. It must be called before StartWorkerLifecycle.
+func (uc *WorkerUsecase) SetCapabilities(capabilities domain.WorkerCapabilities) {
+	uc.capabilities = capabilities
+}
+
+// SetEnvironment sets what the worker records with its results as having run
+// them with; the executor is filled in for each test.
+func (uc *WorkerUsecase) SetEnvironment(environment domain.RunEnvironment) {
+	uc.environment = environment
+}
+
+// Identity returns the worker's ID, its version and the test it is running,
+// or an empty test ID while it is idle.
+func (uc *WorkerUsecase) Identity() (workerID, version, currentTestID string) {
+	return uc.workerID, uc.capabilities.Version, uc.currentTestID
+}
+
+// SetRequestRecorder sends a record of every request this worker's attacks
+// send to recorder.
+func (uc *WorkerUsecase) SetRequestRecorder(recorder domain.RequestRecorder) {
+	uc.recorder = recorder
+}
+
+// testRecorder stamps request records with the test and worker they belong to.
+type testRecorder struct {
+	next     domain.RequestRecorder
+	testID   string
+	workerID string
+}
+
+// Record implements domain.RequestRecorder.
+func (r testRecorder) Record(record domain.RequestRecord) {
+	record.TestID, record.WorkerID = r.testID, r.workerID
+	r.next.Record(record)
+}
+
+// resultSubmission builds the submission of a result to the master.
+func resultSubmission(result *domain.TestResult) *pb.TestResultSubmission {
+	submission := &pb.TestResultSubmission{
+		TestId:              result.TestID,
+		WorkerId:            result.WorkerID,
+		TotalRequests:       result.TotalRequests,
+		CompletedRequests:   result.CompletedRequests,
+		SuccessRate:         result.SuccessRate,
+		SuccessfulRequests:  result.SuccessfulRequests,
+		FailedRequests:      result.FailedRequests,
+		AverageLatencyMs:    result.AverageLatencyMs,
+		P95LatencyMs:        result.P95LatencyMs,
+		DurationMs:          result.DurationMs,
+		VegetaMetricsBase64: string(result.Metric), // Base64 encoded Vegeta results as string
+		Timestamp:           time.Now().Unix(),
+	}
+	if result.Apdex != nil {
+		if data, err := json.Marshal(result.Apdex); err == nil {
+			submission.ApdexJson = string(data)
+		}
+	}
+	return submission
+}
+
+// submitResult sends a test result to the master and fails if the master does
+// not accept it.
+func (uc *WorkerUsecase) submitResult(ctx context.Context, submission *pb.TestResultSubmission) error {
+	submitCtx, submitCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer submitCancel()
+
+	submitResponse, err := uc.masterClient.SubmitTestResult(submitCtx, submission)
+	if err != nil {
+		return fmt.Errorf("failed to submit test result: %w", err)
+	}
+	if !submitResponse.Success {
+		return fmt.Errorf("master rejected test result: %s", submitResponse.Message)
+	}
+	return nil
+}
+
+// ShutdownRequested is closed when the master asks the worker to exit, such
+// as after draining it for a rollout, so its supervisor can restart it on a
+// new version.
+func (uc *WorkerUsecase) ShutdownRequested() <-chan struct{} {
+	return uc.shutdown
+}
+
+// AbortTest stops testID if it is the test currently running. The attack
+// ends early and the requests sent so far are reported as its result.
+func (uc *WorkerUsecase) AbortTest(testID, reason string) {
+	uc.abortMu.Lock()
+	defer uc.abortMu.Unlock()
+
+	if uc.abortTest == nil || uc.currentTestID != testID {
+		return
+	}
+	log.Printf("Worker %s aborting test %s: %s", uc.workerID, testID, reason)
+	uc.abortTest()
+}
+
+// AdjustRate changes the rate of testID if it is the test currently running.
+// The new rate applies from the attack's next request on.
+func (uc *WorkerUsecase) AdjustRate(testID string, ratePerSecond uint64) {
+	uc.abortMu.Lock()
+	defer uc.abortMu.Unlock()
+
+	if uc.rates == nil || uc.currentTestID != testID || ratePerSecond == 0 {
+		return
+	}
+	log.Printf("Worker %s changing the rate of test %s to %d req/s", uc.workerID, testID, ratePerSecond)
+	select {
+	case <-uc.rates: // Replaced before the attack picked it up
+	default:
+	}
+	uc.rates <- ratePerSecond
+}
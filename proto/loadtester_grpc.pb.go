@@ -29,6 +29,14 @@ type WorkerServiceClient interface {
 	AssignTest(ctx context.Context, in *TestAssignment, opts ...grpc.CallOption) (*AssignmentResponse, error)
 	// New RPC for workers to submit test results to master
 	SubmitTestResult(ctx context.Context, in *TestResultSubmission, opts ...grpc.CallOption) (*TestResultResponse, error)
+	// Workers running long tests flush their cumulative result so far at the test's interim interval
+	SubmitInterimResult(ctx context.Context, in *TestResultSubmission, opts ...grpc.CallOption) (*TestResultResponse, error)
+	// Workers fetch attachments referenced by targets, streamed in chunks
+	GetAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (WorkerService_GetAttachmentClient, error)
+	// Workers upload the requests they captured in a test, streamed in chunks, before its result
+	UploadRequestCapture(ctx context.Context, opts ...grpc.CallOption) (WorkerService_UploadRequestCaptureClient, error)
+	// The master pings a worker on demand to check that it answers
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
 
 type workerServiceClient struct {
@@ -97,6 +105,90 @@ func (c *workerServiceClient) SubmitTestResult(ctx context.Context, in *TestResu
 	return out, nil
 }
 
+func (c *workerServiceClient) SubmitInterimResult(ctx context.Context, in *TestResultSubmission, opts ...grpc.CallOption) (*TestResultResponse, error) {
+	out := new(TestResultResponse)
+	err := c.cc.Invoke(ctx, "/loadtester.WorkerService/SubmitInterimResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) GetAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (WorkerService_GetAttachmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WorkerService_ServiceDesc.Streams[1], "/loadtester.WorkerService/GetAttachment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerServiceGetAttachmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkerService_GetAttachmentClient interface {
+	Recv() (*AttachmentChunk, error)
+	grpc.ClientStream
+}
+
+type workerServiceGetAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerServiceGetAttachmentClient) Recv() (*AttachmentChunk, error) {
+	m := new(AttachmentChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *workerServiceClient) UploadRequestCapture(ctx context.Context, opts ...grpc.CallOption) (WorkerService_UploadRequestCaptureClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WorkerService_ServiceDesc.Streams[2], "/loadtester.WorkerService/UploadRequestCapture", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerServiceUploadRequestCaptureClient{stream}
+	return x, nil
+}
+
+type WorkerService_UploadRequestCaptureClient interface {
+	Send(*RequestCaptureChunk) error
+	CloseAndRecv() (*TestResultResponse, error)
+	grpc.ClientStream
+}
+
+type workerServiceUploadRequestCaptureClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerServiceUploadRequestCaptureClient) Send(m *RequestCaptureChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *workerServiceUploadRequestCaptureClient) CloseAndRecv() (*TestResultResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TestResultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *workerServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/loadtester.WorkerService/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerServiceServer is the server API for WorkerService service.
 // All implementations must embed UnimplementedWorkerServiceServer
 // for forward compatibility
@@ -108,6 +200,14 @@ type WorkerServiceServer interface {
 	AssignTest(context.Context, *TestAssignment) (*AssignmentResponse, error)
 	// New RPC for workers to submit test results to master
 	SubmitTestResult(context.Context, *TestResultSubmission) (*TestResultResponse, error)
+	// Workers running long tests flush their cumulative result so far at the test's interim interval
+	SubmitInterimResult(context.Context, *TestResultSubmission) (*TestResultResponse, error)
+	// Workers fetch attachments referenced by targets, streamed in chunks
+	GetAttachment(*AttachmentRequest, WorkerService_GetAttachmentServer) error
+	// Workers upload the requests they captured in a test, streamed in chunks, before its result
+	UploadRequestCapture(WorkerService_UploadRequestCaptureServer) error
+	// The master pings a worker on demand to check that it answers
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	mustEmbedUnimplementedWorkerServiceServer()
 }
 
@@ -127,6 +227,18 @@ func (UnimplementedWorkerServiceServer) AssignTest(context.Context, *TestAssignm
 func (UnimplementedWorkerServiceServer) SubmitTestResult(context.Context, *TestResultSubmission) (*TestResultResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitTestResult not implemented")
 }
+func (UnimplementedWorkerServiceServer) SubmitInterimResult(context.Context, *TestResultSubmission) (*TestResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitInterimResult not implemented")
+}
+func (UnimplementedWorkerServiceServer) GetAttachment(*AttachmentRequest, WorkerService_GetAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedWorkerServiceServer) UploadRequestCapture(WorkerService_UploadRequestCaptureServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadRequestCapture not implemented")
+}
+func (UnimplementedWorkerServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
 func (UnimplementedWorkerServiceServer) mustEmbedUnimplementedWorkerServiceServer() {}
 
 // UnsafeWorkerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -220,6 +332,89 @@ func _WorkerService_SubmitTestResult_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerService_SubmitInterimResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestResultSubmission)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).SubmitInterimResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.WorkerService/SubmitInterimResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).SubmitInterimResult(ctx, req.(*TestResultSubmission))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_GetAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkerServiceServer).GetAttachment(m, &workerServiceGetAttachmentServer{stream})
+}
+
+type WorkerService_GetAttachmentServer interface {
+	Send(*AttachmentChunk) error
+	grpc.ServerStream
+}
+
+type workerServiceGetAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerServiceGetAttachmentServer) Send(m *AttachmentChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WorkerService_UploadRequestCapture_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkerServiceServer).UploadRequestCapture(&workerServiceUploadRequestCaptureServer{stream})
+}
+
+type WorkerService_UploadRequestCaptureServer interface {
+	SendAndClose(*TestResultResponse) error
+	Recv() (*RequestCaptureChunk, error)
+	grpc.ServerStream
+}
+
+type workerServiceUploadRequestCaptureServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerServiceUploadRequestCaptureServer) SendAndClose(m *TestResultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *workerServiceUploadRequestCaptureServer) Recv() (*RequestCaptureChunk, error) {
+	m := new(RequestCaptureChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WorkerService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.WorkerService/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkerService_ServiceDesc is the grpc.ServiceDesc for WorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +434,14 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitTestResult",
 			Handler:    _WorkerService_SubmitTestResult_Handler,
 		},
+		{
+			MethodName: "SubmitInterimResult",
+			Handler:    _WorkerService_SubmitInterimResult_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _WorkerService_Ping_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -247,6 +450,16 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "GetAttachment",
+			Handler:       _WorkerService_GetAttachment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadRequestCapture",
+			Handler:       _WorkerService_UploadRequestCapture_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "proto/loadtester.proto",
 }
@@ -257,6 +470,18 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 type MasterServiceClient interface {
 	SubmitTest(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (*TestSubmissionResponse, error)
 	GetDashboardStatus(ctx context.Context, in *DashboardRequest, opts ...grpc.CallOption) (*DashboardStatus, error)
+	// Streams live progress for a test until it finishes
+	WatchTest(ctx context.Context, in *WatchTestRequest, opts ...grpc.CallOption) (MasterService_WatchTestClient, error)
+	// Lists a requester's tests, filtered, sorted and paginated
+	ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error)
+	// Returns each worker's raw result of a test, in the shape workers submit them
+	GetTestResults(ctx context.Context, in *TestResultsRequest, opts ...grpc.CallOption) (*TestResultsResponse, error)
+	// Returns the aggregated result of a finished test
+	GetAggregatedResult(ctx context.Context, in *AggregatedResultRequest, opts ...grpc.CallOption) (*AggregatedResult, error)
+	// Removes a queued test of the requester so it never runs
+	CancelTest(ctx context.Context, in *CancelTestRequest, opts ...grpc.CallOption) (*CancelTestResponse, error)
+	// Streams a test's events (test.assigned, test.metrics, test.completed, ...) as they happen
+	StreamTestEvents(ctx context.Context, in *TestEventsRequest, opts ...grpc.CallOption) (MasterService_StreamTestEventsClient, error)
 }
 
 type masterServiceClient struct {
@@ -285,12 +510,124 @@ func (c *masterServiceClient) GetDashboardStatus(ctx context.Context, in *Dashbo
 	return out, nil
 }
 
+func (c *masterServiceClient) WatchTest(ctx context.Context, in *WatchTestRequest, opts ...grpc.CallOption) (MasterService_WatchTestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MasterService_ServiceDesc.Streams[0], "/loadtester.MasterService/WatchTest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &masterServiceWatchTestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MasterService_WatchTestClient interface {
+	Recv() (*TestProgress, error)
+	grpc.ClientStream
+}
+
+type masterServiceWatchTestClient struct {
+	grpc.ClientStream
+}
+
+func (x *masterServiceWatchTestClient) Recv() (*TestProgress, error) {
+	m := new(TestProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *masterServiceClient) ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error) {
+	out := new(ListTestsResponse)
+	err := c.cc.Invoke(ctx, "/loadtester.MasterService/ListTests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterServiceClient) GetTestResults(ctx context.Context, in *TestResultsRequest, opts ...grpc.CallOption) (*TestResultsResponse, error) {
+	out := new(TestResultsResponse)
+	err := c.cc.Invoke(ctx, "/loadtester.MasterService/GetTestResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterServiceClient) GetAggregatedResult(ctx context.Context, in *AggregatedResultRequest, opts ...grpc.CallOption) (*AggregatedResult, error) {
+	out := new(AggregatedResult)
+	err := c.cc.Invoke(ctx, "/loadtester.MasterService/GetAggregatedResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterServiceClient) CancelTest(ctx context.Context, in *CancelTestRequest, opts ...grpc.CallOption) (*CancelTestResponse, error) {
+	out := new(CancelTestResponse)
+	err := c.cc.Invoke(ctx, "/loadtester.MasterService/CancelTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterServiceClient) StreamTestEvents(ctx context.Context, in *TestEventsRequest, opts ...grpc.CallOption) (MasterService_StreamTestEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MasterService_ServiceDesc.Streams[1], "/loadtester.MasterService/StreamTestEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &masterServiceStreamTestEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MasterService_StreamTestEventsClient interface {
+	Recv() (*TestEvent, error)
+	grpc.ClientStream
+}
+
+type masterServiceStreamTestEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *masterServiceStreamTestEventsClient) Recv() (*TestEvent, error) {
+	m := new(TestEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MasterServiceServer is the server API for MasterService service.
 // All implementations must embed UnimplementedMasterServiceServer
 // for forward compatibility
 type MasterServiceServer interface {
 	SubmitTest(context.Context, *TestRequest) (*TestSubmissionResponse, error)
 	GetDashboardStatus(context.Context, *DashboardRequest) (*DashboardStatus, error)
+	// Streams live progress for a test until it finishes
+	WatchTest(*WatchTestRequest, MasterService_WatchTestServer) error
+	// Lists a requester's tests, filtered, sorted and paginated
+	ListTests(context.Context, *ListTestsRequest) (*ListTestsResponse, error)
+	// Returns each worker's raw result of a test, in the shape workers submit them
+	GetTestResults(context.Context, *TestResultsRequest) (*TestResultsResponse, error)
+	// Returns the aggregated result of a finished test
+	GetAggregatedResult(context.Context, *AggregatedResultRequest) (*AggregatedResult, error)
+	// Removes a queued test of the requester so it never runs
+	CancelTest(context.Context, *CancelTestRequest) (*CancelTestResponse, error)
+	// Streams a test's events (test.assigned, test.metrics, test.completed, ...) as they happen
+	StreamTestEvents(*TestEventsRequest, MasterService_StreamTestEventsServer) error
 	mustEmbedUnimplementedMasterServiceServer()
 }
 
@@ -304,6 +641,24 @@ func (UnimplementedMasterServiceServer) SubmitTest(context.Context, *TestRequest
 func (UnimplementedMasterServiceServer) GetDashboardStatus(context.Context, *DashboardRequest) (*DashboardStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDashboardStatus not implemented")
 }
+func (UnimplementedMasterServiceServer) WatchTest(*WatchTestRequest, MasterService_WatchTestServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTest not implemented")
+}
+func (UnimplementedMasterServiceServer) ListTests(context.Context, *ListTestsRequest) (*ListTestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTests not implemented")
+}
+func (UnimplementedMasterServiceServer) GetTestResults(context.Context, *TestResultsRequest) (*TestResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTestResults not implemented")
+}
+func (UnimplementedMasterServiceServer) GetAggregatedResult(context.Context, *AggregatedResultRequest) (*AggregatedResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregatedResult not implemented")
+}
+func (UnimplementedMasterServiceServer) CancelTest(context.Context, *CancelTestRequest) (*CancelTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTest not implemented")
+}
+func (UnimplementedMasterServiceServer) StreamTestEvents(*TestEventsRequest, MasterService_StreamTestEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTestEvents not implemented")
+}
 func (UnimplementedMasterServiceServer) mustEmbedUnimplementedMasterServiceServer() {}
 
 // UnsafeMasterServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -353,6 +708,120 @@ func _MasterService_GetDashboardStatus_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MasterService_WatchTest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MasterServiceServer).WatchTest(m, &masterServiceWatchTestServer{stream})
+}
+
+type MasterService_WatchTestServer interface {
+	Send(*TestProgress) error
+	grpc.ServerStream
+}
+
+type masterServiceWatchTestServer struct {
+	grpc.ServerStream
+}
+
+func (x *masterServiceWatchTestServer) Send(m *TestProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MasterService_ListTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServiceServer).ListTests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.MasterService/ListTests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServiceServer).ListTests(ctx, req.(*ListTestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterService_GetTestResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServiceServer).GetTestResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.MasterService/GetTestResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServiceServer).GetTestResults(ctx, req.(*TestResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterService_GetAggregatedResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregatedResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServiceServer).GetAggregatedResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.MasterService/GetAggregatedResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServiceServer).GetAggregatedResult(ctx, req.(*AggregatedResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterService_CancelTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServiceServer).CancelTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/loadtester.MasterService/CancelTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServiceServer).CancelTest(ctx, req.(*CancelTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterService_StreamTestEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TestEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MasterServiceServer).StreamTestEvents(m, &masterServiceStreamTestEventsServer{stream})
+}
+
+type MasterService_StreamTestEventsServer interface {
+	Send(*TestEvent) error
+	grpc.ServerStream
+}
+
+type masterServiceStreamTestEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *masterServiceStreamTestEventsServer) Send(m *TestEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // MasterService_ServiceDesc is the grpc.ServiceDesc for MasterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -368,7 +837,34 @@ var MasterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDashboardStatus",
 			Handler:    _MasterService_GetDashboardStatus_Handler,
 		},
+		{
+			MethodName: "ListTests",
+			Handler:    _MasterService_ListTests_Handler,
+		},
+		{
+			MethodName: "GetTestResults",
+			Handler:    _MasterService_GetTestResults_Handler,
+		},
+		{
+			MethodName: "GetAggregatedResult",
+			Handler:    _MasterService_GetAggregatedResult_Handler,
+		},
+		{
+			MethodName: "CancelTest",
+			Handler:    _MasterService_CancelTest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTest",
+			Handler:       _MasterService_WatchTest_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTestEvents",
+			Handler:       _MasterService_StreamTestEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/loadtester.proto",
 }